@@ -2,9 +2,13 @@ package langfuse
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"runtime/debug"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/jdziat/langfuse-go/pkg/builders"
@@ -49,6 +53,7 @@ type endConfig struct {
 	endTime             time.Time
 	hasEndTime          bool
 	observationError    error
+	hasError            bool
 }
 
 // EndOption configures how an observation is ended.
@@ -139,7 +144,9 @@ func WithEndTime(t time.Time) EndOption {
 }
 
 // WithError marks the observation as having an error.
-// This sets the level to ERROR and includes the error message.
+// This includes the error message and sets the level given by the client's
+// observation level mapping (ERROR by default), unless WithEndLevel is also
+// used.
 //
 // Example:
 //
@@ -147,14 +154,62 @@ func WithEndTime(t time.Time) EndOption {
 func WithError(err error) EndOption {
 	return func(c *endConfig) {
 		c.observationError = err
-		c.level = ObservationLevelError
-		c.hasLevel = true
+		c.hasError = true
 		if err != nil {
 			c.statusMessage = err.Error()
 		}
 	}
 }
 
+// DefaultLevelMapping returns the observation level mapping used when none
+// is configured: nil maps to DEFAULT, a *ValidationError to WARNING, and any
+// other error to ERROR.
+func DefaultLevelMapping() func(error) ObservationLevel {
+	return func(err error) ObservationLevel {
+		if err == nil {
+			return ObservationLevelDefault
+		}
+		if _, ok := AsValidationError(err); ok {
+			return ObservationLevelWarning
+		}
+		return ObservationLevelError
+	}
+}
+
+// StrictLevelMapping returns an observation level mapping that records every
+// non-nil error as ERROR.
+func StrictLevelMapping() func(error) ObservationLevel {
+	return func(err error) ObservationLevel {
+		if err == nil {
+			return ObservationLevelDefault
+		}
+		return ObservationLevelError
+	}
+}
+
+// LenientLevelMapping returns an observation level mapping that records
+// retryable errors (see IsRetryable) as WARNING and other errors as ERROR.
+func LenientLevelMapping() func(error) ObservationLevel {
+	return func(err error) ObservationLevel {
+		if err == nil {
+			return ObservationLevelDefault
+		}
+		if IsRetryable(err) {
+			return ObservationLevelWarning
+		}
+		return ObservationLevelError
+	}
+}
+
+// observationLevelFor returns the observation level the client's mapping
+// assigns to err.
+func (c *Client) observationLevelFor(err error) ObservationLevel {
+	if mapping := c.rootConfig.ObservationLevelMapping; mapping != nil {
+		return mapping(err)
+	}
+	return DefaultLevelMapping()(err)
+}
+
 // ============================================================================
 // Helper Builders - Re-exported from pkg/builders
 // ============================================================================
@@ -461,6 +516,8 @@ const (
 	MaxNameLength = builders.MaxNameLength
 	MaxTagLength  = builders.MaxTagLength
 	MaxTagCount   = builders.MaxTagCount
+
+	MaxReleaseLength = builders.MaxReleaseLength
 )
 
 // ============================================================================
@@ -488,12 +545,26 @@ func (r BuildResult[T]) Must() T {
 	return r.value
 }
 
+// OK returns true if there was no error.
+func (r BuildResult[T]) OK() bool {
+	return r.err == nil
+}
+
+// Error returns the error, if any.
+func (r BuildResult[T]) Error() error {
+	return r.err
+}
+
 // Ok returns true if there was no error.
+//
+// Deprecated: Use OK.
 func (r BuildResult[T]) Ok() bool {
-	return r.err == nil
+	return r.OK()
 }
 
 // Err returns the error, if any.
+//
+// Deprecated: Use Error.
 func (r BuildResult[T]) Err() error {
 	return r.err
 }
@@ -1006,10 +1077,70 @@ func DefaultStrictValidationConfig() StrictValidationConfig {
 // Trace Builders (from trace.go)
 // ============================================================================
 
-// traceContextKey is the context key for TraceContext.
-type traceContextKey struct{}
+// observationContextKey is the context key for the current trace and span.
+type observationContextKey struct{}
+
+// currentObservation is the context value holding the current trace and
+// span. Either may be nil.
+type currentObservation struct {
+	trace *TraceContext
+	span  *SpanContext
+}
+
+// observationFromContext returns the current trace and span stored in ctx.
+func observationFromContext(ctx context.Context) currentObservation {
+	obs, _ := ctx.Value(observationContextKey{}).(currentObservation)
+	return obs
+}
+
+// CurrentTrace returns the trace stored in ctx by ContextWithTrace or a
+// ChildContext method, if present.
+//
+// Example:
+//
+//	func handleRequest(ctx context.Context) {
+//	    if trace, ok := langfuse.CurrentTrace(ctx); ok {
+//	        trace.Event(ctx, "cache-miss")
+//	    }
+//	}
+func CurrentTrace(ctx context.Context) (*TraceContext, bool) {
+	tc := observationFromContext(ctx).trace
+	return tc, tc != nil
+}
+
+// CurrentSpan returns the span stored in ctx by ContextWithSpan or
+// SpanContext.ChildContext, if present. It returns false after
+// TraceContext.ChildContext, which makes the trace itself the current
+// observation.
+//
+// Example:
+//
+//	func processItem(ctx context.Context) {
+//	    if span, ok := langfuse.CurrentSpan(ctx); ok {
+//	        child, _ := span.Span(ctx, "item-processing")
+//	        defer child.End(ctx)
+//	    }
+//	}
+func CurrentSpan(ctx context.Context) (*SpanContext, bool) {
+	sc := observationFromContext(ctx).span
+	return sc, sc != nil
+}
+
+// ChildContext returns a context with t as the current trace and no current
+// span, replacing any span stored in ctx. Retrieve the trace with
+// CurrentTrace.
+//
+// Example:
+//
+//	trace, _ := client.NewTrace().Name("request").Create(ctx)
+//	ctx = trace.ChildContext(ctx)
+//	handle(ctx) // langfuse.CurrentTrace(ctx) returns trace
+func (t *TraceContext) ChildContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, observationContextKey{}, currentObservation{trace: t})
+}
 
-// TraceFromContext returns the TraceContext from ctx, if present.
+// TraceFromContext returns the TraceContext from ctx, if present. It is
+// equivalent to CurrentTrace.
 // This allows retrieving a trace that was previously stored in the context
 // for use in nested function calls without explicitly passing the trace.
 //
@@ -1023,13 +1154,13 @@ type traceContextKey struct{}
 //	    }
 //	}
 func TraceFromContext(ctx context.Context) (*TraceContext, bool) {
-	tc, ok := ctx.Value(traceContextKey{}).(*TraceContext)
-	return tc, ok
+	return CurrentTrace(ctx)
 }
 
 // ContextWithTrace returns a new context with the TraceContext stored.
 // This allows passing trace context through function calls without
-// explicitly threading the trace through all parameters.
+// explicitly threading the trace through all parameters. A span stored in
+// ctx is kept; use TraceContext.ChildContext to clear it.
 //
 // Example:
 //
@@ -1037,7 +1168,9 @@ func TraceFromContext(ctx context.Context) (*TraceContext, bool) {
 //	ctx = langfuse.ContextWithTrace(ctx, trace)
 //	processRequest(ctx) // Can retrieve trace via TraceFromContext
 func ContextWithTrace(ctx context.Context, tc *TraceContext) context.Context {
-	return context.WithValue(ctx, traceContextKey{}, tc)
+	obs := observationFromContext(ctx)
+	obs.trace = tc
+	return context.WithValue(ctx, observationContextKey{}, obs)
 }
 
 // MustTraceFromContext returns the TraceContext from ctx.
@@ -1069,9 +1202,26 @@ func MustTraceFromContext(ctx context.Context) *TraceContext {
 //	    UserID("user-123").
 //	    Create()
 type TraceBuilder struct {
-	client    *Client
-	trace     *createTraceEvent
-	validator Validator
+	client        *Client
+	trace         *createTraceEvent
+	validator     Validator
+	parentTraceID string
+
+	// Fields set explicitly rather than defaulted by NewTrace, used by Upsert
+	idSet      bool
+	releaseSet bool
+
+	// continued is set by FromContext; Create then behaves like Upsert
+	continued bool
+
+	// inputSchemaVersion is set by VersionedInput
+	inputSchemaVersion *int
+
+	// autoEnd is the timeout set by AutoEnd
+	autoEnd time.Duration
+
+	// replayable is set by Replayable
+	replayable bool
 }
 
 // NewTrace creates a new trace builder.
@@ -1081,6 +1231,7 @@ func (c *Client) NewTrace() *TraceBuilder {
 		trace: &createTraceEvent{
 			ID:        generateID(),
 			Timestamp: TimeNow(),
+			Release:   c.Release(),
 		},
 	}
 }
@@ -1088,6 +1239,7 @@ func (c *Client) NewTrace() *TraceBuilder {
 // ID sets the trace ID.
 func (b *TraceBuilder) ID(id string) *TraceBuilder {
 	b.trace.ID = id
+	b.idSet = true
 	return b
 }
 
@@ -1147,6 +1299,7 @@ func (b *TraceBuilder) Tags(tags []string) *TraceBuilder {
 // Release sets the release version.
 func (b *TraceBuilder) Release(release string) *TraceBuilder {
 	b.trace.Release = release
+	b.releaseSet = true
 	return b
 }
 
@@ -1168,6 +1321,173 @@ func (b *TraceBuilder) Environment(env string) *TraceBuilder {
 	return b
 }
 
+const (
+	// ParentTraceIDMetadataKey is the trace metadata key that links a trace
+	// to the trace that caused it, typically in another service.
+	ParentTraceIDMetadataKey = "parent_trace_id"
+
+	// ParentTraceTag is the tag added to traces that have a parent trace.
+	ParentTraceTag = "has_parent_trace:true"
+
+	// maxTraceChainHops bounds GetTraceChain to prevent following cycles.
+	maxTraceChainHops = 10
+)
+
+// ParentTraceID links the trace to a parent trace, for correlating traces
+// across service boundaries. Langfuse has no native parent trace, so the ID
+// is stored in the trace metadata under "parent_trace_id" and the trace is
+// tagged "has_parent_trace:true". Use Client.GetTraceChain to follow the links.
+//
+// Example:
+//
+//	trace, _ := client.NewTrace().
+//	    Name("billing-worker").
+//	    ParentTraceID(req.Header.Get("X-Langfuse-Trace-Id")).
+//	    Create(ctx)
+func (b *TraceBuilder) ParentTraceID(id string) *TraceBuilder {
+	if id == "" {
+		b.validator.AddFieldError("parent_trace_id", "cannot be empty")
+		return b
+	}
+	b.parentTraceID = id
+	return b
+}
+
+// InputSchemaVersionMetadataKey is the trace metadata key under which
+// TraceBuilder.VersionedInput records the input schema version.
+const InputSchemaVersionMetadataKey = "input_schema_version"
+
+// VersionedInput sets the trace input to {"schema_version": schemaVersion,
+// "data": input} and records schemaVersion in the trace metadata under
+// InputSchemaVersionMetadataKey, so evaluation results can be compared
+// across deployments that changed the input schema. List traces of one
+// schema version with TracesListParams.InputSchemaVersion.
+//
+// Example:
+//
+//	trace, _ := client.NewTrace().
+//	    Name("rag-query").
+//	    VersionedInput(2, evaluation.RAGInput{Query: query, Context: docs}).
+//	    Create(ctx)
+func (b *TraceBuilder) VersionedInput(schemaVersion int, input any) *TraceBuilder {
+	b.trace.Input = map[string]any{
+		"schema_version": schemaVersion,
+		"data":           input,
+	}
+	b.inputSchemaVersion = &schemaVersion
+	return b
+}
+
+// AutoEndStatusMessage is the status message recorded on traces ended by
+// the TraceBuilder.AutoEnd timeout.
+const AutoEndStatusMessage = "auto-ended after timeout"
+
+// StatusMessageMetadataKey is the trace metadata key under which
+// AutoEndStatusMessage is recorded.
+const StatusMessageMetadataKey = "status_message"
+
+// AutoEnd ends the trace if it is not updated with an output within d of
+// Create, for long-running processes that may forget to end their traces.
+// The trace is then updated with AutoEndStatusMessage in its metadata under
+// StatusMessageMetadataKey. Updating the trace with a non-nil output, or
+// calling TraceContext.CancelAutoEnd, cancels the timeout. Pending
+// timeouts are cancelled when the client shuts down, including by
+// IdleTimeout.
+//
+// Example:
+//
+//	trace, _ := client.NewTrace().Name("nightly-sync").AutoEnd(30*time.Minute).Create(ctx)
+//	defer trace.Update().Output(result).Apply(ctx)
+func (b *TraceBuilder) AutoEnd(d time.Duration) *TraceBuilder {
+	b.autoEnd = d
+	return b
+}
+
+// Replayable makes the trace keep the name, input and metadata it is created
+// with, and the settings of its generations, so it can be re-run with
+// TraceContext.Replay. Traces are not replayable by default, since this
+// state stays in memory for as long as the TraceContext is referenced.
+//
+// Example:
+//
+//	trace, _ := client.NewTrace().Name("qa").Input(query).Replayable().Create(ctx)
+//	replay, _ := trace.Replay(ctx, langfuse.WithReplayGenerations("gpt-4o"))
+func (b *TraceBuilder) Replayable() *TraceBuilder {
+	b.replayable = true
+	return b
+}
+
+// applyInputSchemaVersion records the input schema version in the trace
+// metadata. Like applyParentTraceID it runs at Create time.
+func (b *TraceBuilder) applyInputSchemaVersion() {
+	if b.inputSchemaVersion == nil {
+		return
+	}
+
+	metadata := make(Metadata, len(b.trace.Metadata)+1)
+	for k, v := range b.trace.Metadata {
+		metadata[k] = v
+	}
+	metadata[InputSchemaVersionMetadataKey] = *b.inputSchemaVersion
+	b.trace.Metadata = metadata
+}
+
+// FromContext continues the trace stored in ctx with ContextWithTrace.
+// The builder takes over the existing trace's ID, user ID, session ID, tags
+// and environment, and Create behaves like Upsert: it emits a trace-create
+// event with the existing ID and without the default timestamp and release,
+// which Langfuse merges into that trace without moving its start time. If
+// ctx holds no trace, the builder is returned unchanged.
+//
+// Example:
+//
+//	func authMiddleware(ctx context.Context, user string) {
+//	    client.NewTrace().FromContext(ctx).
+//	        Metadata(langfuse.Metadata{"authenticated_as": user}).
+//	        Create(ctx)
+//	}
+func (b *TraceBuilder) FromContext(ctx context.Context) *TraceBuilder {
+	tc, ok := TraceFromContext(ctx)
+	if !ok || tc == nil {
+		return b
+	}
+
+	b.trace.ID = tc.traceID
+	b.idSet = true
+	b.continued = true
+	b.trace.UserID = tc.userID
+	b.trace.SessionID = tc.sessionID
+	b.trace.Environment = tc.environment
+	if tc.tags != nil {
+		b.trace.Tags = make([]string, len(tc.tags))
+		copy(b.trace.Tags, tc.tags)
+	}
+	return b
+}
+
+// applyParentTraceID records the parent trace ID in the trace metadata and
+// tags. It runs at Create time so it is not lost if Metadata or Tags are
+// set after ParentTraceID.
+func (b *TraceBuilder) applyParentTraceID() {
+	if b.parentTraceID == "" {
+		return
+	}
+
+	metadata := make(Metadata, len(b.trace.Metadata)+1)
+	for k, v := range b.trace.Metadata {
+		metadata[k] = v
+	}
+	metadata[ParentTraceIDMetadataKey] = b.parentTraceID
+	b.trace.Metadata = metadata
+
+	for _, tag := range b.trace.Tags {
+		if tag == ParentTraceTag {
+			return
+		}
+	}
+	b.trace.Tags = append(b.trace.Tags[:len(b.trace.Tags):len(b.trace.Tags)], ParentTraceTag)
+}
+
 // Clone creates a deep copy of the TraceBuilder with a new ID and timestamp.
 // This is useful for creating multiple similar traces from a template.
 //
@@ -1198,7 +1518,10 @@ func (b *TraceBuilder) Clone() *TraceBuilder {
 	}
 
 	return &TraceBuilder{
-		client: b.client,
+		client:             b.client,
+		parentTraceID:      b.parentTraceID,
+		inputSchemaVersion: b.inputSchemaVersion,
+		autoEnd:            b.autoEnd,
 		trace: &createTraceEvent{
 			ID:          generateID(), // New ID for the clone
 			Timestamp:   TimeNow(),    // Fresh timestamp
@@ -1244,25 +1567,99 @@ func (b *TraceBuilder) Validate() error {
 
 // Create creates the trace and returns a TraceContext for adding observations.
 func (b *TraceBuilder) Create(ctx context.Context) (*TraceContext, error) {
+	if b.continued {
+		return b.Upsert(ctx)
+	}
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	b.applyParentTraceID()
+	b.applyInputSchemaVersion()
+
+	return b.queue(ctx, eventTypeTraceCreate, b.trace)
+}
+
+// TryCreate is like Create but returns the trace and error as a single
+// BuildResult.
+func (b *TraceBuilder) TryCreate(ctx context.Context) BuildResult[*TraceContext] {
+	return NewBuildResult(b.Create(ctx))
+}
+
+// IsUpsert reports whether Upsert will update an existing trace, which is
+// the case when an ID was set with ID. Otherwise Upsert creates a new trace.
+func (b *TraceBuilder) IsUpsert() bool {
+	return b.idSet
+}
+
+// Upsert updates the trace with the ID set by ID, such as one created by
+// another service, instead of creating a duplicate. It sends a trace-create
+// event with that ID carrying only the fields set on the builder, so
+// Langfuse merges them into the existing trace; the default timestamp and
+// client release are omitted. If no ID was set,
+// Upsert behaves like Create.
+//
+// Example:
+//
+//	trace, err := client.NewTrace().
+//	    ID(r.Header.Get("X-Trace-Id")).
+//	    Metadata(langfuse.Metadata{"region": "eu"}).
+//	    Upsert(ctx)
+func (b *TraceBuilder) Upsert(ctx context.Context) (*TraceContext, error) {
+	if !b.idSet {
+		return b.Create(ctx)
+	}
+
 	if err := b.Validate(); err != nil {
 		return nil, err
 	}
 
+	b.applyParentTraceID()
+	b.applyInputSchemaVersion()
+
+	update := *b.trace
+	update.Timestamp = nil
+	if !b.releaseSet {
+		update.Release = ""
+	}
+
+	return b.queue(ctx, eventTypeTraceCreate, &update)
+}
+
+// queue sends body as an event of eventType and returns its TraceContext.
+func (b *TraceBuilder) queue(ctx context.Context, eventType string, body *createTraceEvent) (*TraceContext, error) {
 	event := ingestionEvent{
 		ID:        generateID(),
-		Type:      eventTypeTraceCreate,
+		Type:      eventType,
 		Timestamp: Now(),
-		Body:      b.trace,
+		Body:      body,
 	}
 
 	if err := b.client.queueEvent(ctx, event); err != nil {
 		return nil, err
 	}
 
-	return &TraceContext{
-		client:  b.client,
-		traceID: b.trace.ID,
-	}, nil
+	trace := &TraceContext{
+		client:        b.client,
+		traceID:       b.trace.ID,
+		parentTraceID: b.parentTraceID,
+		userID:        b.trace.UserID,
+		sessionID:     b.trace.SessionID,
+		tags:          b.trace.Tags,
+		environment:   b.trace.Environment,
+	}
+	trace.inputSchemaVersion, _ = b.trace.Metadata[InputSchemaVersionMetadataKey].(int)
+	if b.replayable {
+		trace.replay = &replayState{
+			name:     b.trace.Name,
+			input:    b.trace.Input,
+			metadata: b.trace.Metadata,
+		}
+	}
+	if b.autoEnd > 0 {
+		b.client.startAutoEnd(trace, b.autoEnd)
+	}
+	return trace, nil
 }
 
 // TraceContext provides context for a trace and allows adding observations.
@@ -1272,8 +1669,32 @@ func (b *TraceBuilder) Create(ctx context.Context) (*TraceContext, error) {
 // concurrently. However, individual builders created from TraceContext
 // (via Span(), Generation(), etc.) are NOT safe for concurrent use.
 type TraceContext struct {
-	client  *Client
-	traceID string
+	client        *Client
+	traceID       string
+	parentTraceID string
+
+	// Settings captured at Create, used by TraceBuilder.FromContext
+	userID      string
+	sessionID   string
+	tags        []string
+	environment string
+
+	// inputSchemaVersion is the version set by TraceBuilder.VersionedInput
+	inputSchemaVersion int
+
+	// replay holds the state used by Replay; nil unless the trace was
+	// created with TraceBuilder.Replayable
+	replay *replayState
+
+	genMu sync.Mutex
+	chain []*GenerationContext // generations linked by ChainTo
+
+	// Trace-level baggage, replaced on every SetBaggage
+	baggageMu sync.RWMutex
+	baggage   *Baggage
+
+	// autoEnd is the pending TraceBuilder.AutoEnd timeout, if any
+	autoEnd *autoEndTimer
 }
 
 // ID returns the trace ID.
@@ -1281,12 +1702,29 @@ func (t *TraceContext) ID() string {
 	return t.traceID
 }
 
+// InputSchemaVersion returns the input schema version set with
+// TraceBuilder.VersionedInput, or 0 if the input is not versioned.
+func (t *TraceContext) InputSchemaVersion() int {
+	return t.inputSchemaVersion
+}
+
+// CancelAutoEnd cancels the timeout set with TraceBuilder.AutoEnd, if any.
+func (t *TraceContext) CancelAutoEnd() {
+	t.autoEnd.cancel()
+}
+
 // TraceID returns the trace ID. This method satisfies the Observer interface.
 // For TraceContext, this returns the same value as ID().
 func (t *TraceContext) TraceID() string {
 	return t.traceID
 }
 
+// ParentTraceID returns the parent trace ID set with TraceBuilder.ParentTraceID,
+// or an empty string if the trace has no parent.
+func (t *TraceContext) ParentTraceID() string {
+	return t.parentTraceID
+}
+
 // Update updates the trace.
 func (t *TraceContext) Update() *TraceUpdateBuilder {
 	return &TraceUpdateBuilder{
@@ -1310,6 +1748,184 @@ func (t *TraceContext) NewSpan() *SpanBuilder {
 	}
 }
 
+// NewOrphanSpan creates a span builder that is not bound to a TraceContext,
+// for adding a span to a trace created elsewhere, such as by an upstream
+// service that propagated only its trace ID. The trace ID must be set with
+// TraceID before Create.
+//
+// Example:
+//
+//	span, err := client.NewOrphanSpan().
+//	    TraceID(r.Header.Get("X-Trace-Id")).
+//	    Name("charge-card").
+//	    Create(ctx)
+func (c *Client) NewOrphanSpan() *SpanBuilder {
+	return &SpanBuilder{
+		ctx: &TraceContext{client: c},
+		span: &createSpanEvent{
+			ID:        generateID(),
+			StartTime: TimeNow(),
+		},
+	}
+}
+
+// RootGenerationBuilder builds a generation together with an implicit parent
+// trace, for cases such as simple completion logging that don't need a
+// trace hierarchy. The trace is named after the generation and carries the
+// UserID, SessionID, Tags and Environment set on the builder.
+type RootGenerationBuilder struct {
+	trace *TraceBuilder
+	gen   *GenerationBuilder
+}
+
+// NewRootGeneration creates a builder for a generation whose parent trace is
+// created implicitly.
+//
+// Example:
+//
+//	trace, gen, err := client.NewRootGeneration().
+//	    Name("summarize").
+//	    Model("gpt-4").
+//	    Input(prompt).
+//	    UserID("user-123").
+//	    Create(ctx)
+func (c *Client) NewRootGeneration() *RootGenerationBuilder {
+	return &RootGenerationBuilder{
+		trace: c.NewTrace(),
+		gen: &GenerationBuilder{
+			gen: &createGenerationEvent{
+				ID:        generateID(),
+				StartTime: TimeNow(),
+			},
+		},
+	}
+}
+
+// Name sets the generation name, which is also used as the trace name.
+func (b *RootGenerationBuilder) Name(name string) *RootGenerationBuilder {
+	b.gen.Name(name)
+	return b
+}
+
+// Model sets the model name.
+func (b *RootGenerationBuilder) Model(model string) *RootGenerationBuilder {
+	b.gen.Model(model)
+	return b
+}
+
+// ModelParameters sets the model parameters.
+func (b *RootGenerationBuilder) ModelParameters(params Metadata) *RootGenerationBuilder {
+	b.gen.ModelParameters(params)
+	return b
+}
+
+// Input sets the generation input.
+func (b *RootGenerationBuilder) Input(input any) *RootGenerationBuilder {
+	b.gen.Input(input)
+	return b
+}
+
+// Output sets the generation output.
+func (b *RootGenerationBuilder) Output(output any) *RootGenerationBuilder {
+	b.gen.Output(output)
+	return b
+}
+
+// Metadata sets the generation metadata.
+func (b *RootGenerationBuilder) Metadata(metadata Metadata) *RootGenerationBuilder {
+	b.gen.Metadata(metadata)
+	return b
+}
+
+// Level sets the generation level.
+func (b *RootGenerationBuilder) Level(level ObservationLevel) *RootGenerationBuilder {
+	b.gen.Level(level)
+	return b
+}
+
+// StartTime sets the generation start time.
+func (b *RootGenerationBuilder) StartTime(t time.Time) *RootGenerationBuilder {
+	b.gen.StartTime(t)
+	return b
+}
+
+// EndTime sets the generation end time.
+func (b *RootGenerationBuilder) EndTime(t time.Time) *RootGenerationBuilder {
+	b.gen.EndTime(t)
+	return b
+}
+
+// Usage sets the token usage.
+func (b *RootGenerationBuilder) Usage(usage *Usage) *RootGenerationBuilder {
+	b.gen.Usage(usage)
+	return b
+}
+
+// UsageTokens sets token counts.
+func (b *RootGenerationBuilder) UsageTokens(input, output int) *RootGenerationBuilder {
+	b.gen.UsageTokens(input, output)
+	return b
+}
+
+// PromptName sets the prompt name.
+func (b *RootGenerationBuilder) PromptName(name string) *RootGenerationBuilder {
+	b.gen.PromptName(name)
+	return b
+}
+
+// PromptVersion sets the prompt version.
+func (b *RootGenerationBuilder) PromptVersion(version int) *RootGenerationBuilder {
+	b.gen.PromptVersion(version)
+	return b
+}
+
+// UserID sets the user ID on the implicit trace.
+func (b *RootGenerationBuilder) UserID(userID string) *RootGenerationBuilder {
+	b.trace.UserID(userID)
+	return b
+}
+
+// SessionID sets the session ID on the implicit trace.
+func (b *RootGenerationBuilder) SessionID(sessionID string) *RootGenerationBuilder {
+	b.trace.SessionID(sessionID)
+	return b
+}
+
+// Tags sets the tags on the implicit trace.
+func (b *RootGenerationBuilder) Tags(tags []string) *RootGenerationBuilder {
+	b.trace.Tags(tags)
+	return b
+}
+
+// Environment sets the environment on both the trace and the generation.
+func (b *RootGenerationBuilder) Environment(env string) *RootGenerationBuilder {
+	b.trace.Environment(env)
+	b.gen.Environment(env)
+	return b
+}
+
+// Create creates the trace and then the generation as its first child.
+// If the generation cannot be queued, the trace has already been queued and
+// is returned alongside the error.
+func (b *RootGenerationBuilder) Create(ctx context.Context) (*TraceContext, *GenerationContext, error) {
+	if b.trace.trace.Name == "" {
+		b.trace.Name(b.gen.gen.Name)
+	}
+
+	trace, err := b.trace.Create(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	b.gen.ctx = trace
+	b.gen.gen.TraceID = trace.ID()
+	gen, err := b.gen.Create(ctx)
+	if err != nil {
+		return trace, nil, err
+	}
+	return trace, gen, nil
+}
+
 // NewGeneration creates a new generation builder in this trace (Advanced API).
 // For the Simple API, use Generation(ctx, name, ...opts).
 func (t *TraceContext) NewGeneration() *GenerationBuilder {
@@ -1377,12 +1993,50 @@ func (t *TraceContext) ScoreBoolean(ctx context.Context, name string, value bool
 	return t.NewScore().Name(name).BooleanValue(value).Create(ctx)
 }
 
-// TraceUpdateBuilder provides a fluent interface for updating traces.
+// SetRelease updates only the release of the trace. This is useful when the
+// release is not known until after the trace was created, for example when
+// it depends on a feature flag evaluation during a canary deployment.
 //
-// TraceUpdateBuilder is NOT safe for concurrent use. Each builder instance
-// should be created, configured, and used within a single goroutine.
-type TraceUpdateBuilder struct {
-	ctx    *TraceContext
+// Example:
+//
+//	trace.SetRelease(ctx, "v2.3.0-canary")
+func (t *TraceContext) SetRelease(ctx context.Context, release string) error {
+	if err := validateReleaseField("release", release); err != nil {
+		return err
+	}
+	return t.Update().Release(release).Apply(ctx)
+}
+
+// SetVersion updates only the version of the trace.
+//
+// Example:
+//
+//	trace.SetVersion(ctx, "2")
+func (t *TraceContext) SetVersion(ctx context.Context, version string) error {
+	if err := validateReleaseField("version", version); err != nil {
+		return err
+	}
+	return t.Update().Version(version).Apply(ctx)
+}
+
+// validateReleaseField checks that a release or version value is non-empty
+// and at most MaxReleaseLength characters.
+func validateReleaseField(field, value string) error {
+	if value == "" {
+		return NewValidationError(field, "cannot be empty")
+	}
+	if len(value) > MaxReleaseLength {
+		return NewValidationError(field, fmt.Sprintf("exceeds maximum length of %d characters", MaxReleaseLength))
+	}
+	return nil
+}
+
+// TraceUpdateBuilder provides a fluent interface for updating traces.
+//
+// TraceUpdateBuilder is NOT safe for concurrent use. Each builder instance
+// should be created, configured, and used within a single goroutine.
+type TraceUpdateBuilder struct {
+	ctx    *TraceContext
 	update *updateTraceEvent
 }
 
@@ -1434,6 +2088,18 @@ func (b *TraceUpdateBuilder) Public(public bool) *TraceUpdateBuilder {
 	return b
 }
 
+// Release sets the release version.
+func (b *TraceUpdateBuilder) Release(release string) *TraceUpdateBuilder {
+	b.update.Release = release
+	return b
+}
+
+// Version sets the version.
+func (b *TraceUpdateBuilder) Version(version string) *TraceUpdateBuilder {
+	b.update.Version = version
+	return b
+}
+
 // Apply applies the update.
 //
 // Langfuse's ingestion API has no distinct "trace-update" event type — traces
@@ -1447,17 +2113,21 @@ func (b *TraceUpdateBuilder) Apply(ctx context.Context) error {
 		Body:      b.update,
 	}
 
-	return b.ctx.client.queueEvent(ctx, event)
+	if err := b.ctx.client.queueEvent(ctx, event); err != nil {
+		return err
+	}
+	if b.update.Output != nil {
+		b.ctx.CancelAutoEnd()
+	}
+	return nil
 }
 
 // ============================================================================
 // Span Builders (from span.go)
 // ============================================================================
 
-// spanContextKey is the context key for SpanContext.
-type spanContextKey struct{}
-
-// SpanFromContext returns the SpanContext from ctx, if present.
+// SpanFromContext returns the SpanContext from ctx, if present. It is
+// equivalent to CurrentSpan.
 // This allows retrieving a span that was previously stored in the context
 // for use in nested function calls without explicitly passing the span.
 //
@@ -1471,13 +2141,14 @@ type spanContextKey struct{}
 //	    }
 //	}
 func SpanFromContext(ctx context.Context) (*SpanContext, bool) {
-	sc, ok := ctx.Value(spanContextKey{}).(*SpanContext)
-	return sc, ok
+	return CurrentSpan(ctx)
 }
 
 // ContextWithSpan returns a new context with the SpanContext stored.
 // This allows passing span context through function calls without
-// explicitly threading the span through all parameters.
+// explicitly threading the span through all parameters. The current trace
+// is left unchanged; use SpanContext.ChildContext to store the span's trace
+// as well.
 //
 // Example:
 //
@@ -1485,7 +2156,23 @@ func SpanFromContext(ctx context.Context) (*SpanContext, bool) {
 //	ctx = langfuse.ContextWithSpan(ctx, span)
 //	doWork(ctx) // Can retrieve span via SpanFromContext
 func ContextWithSpan(ctx context.Context, sc *SpanContext) context.Context {
-	return context.WithValue(ctx, spanContextKey{}, sc)
+	obs := observationFromContext(ctx)
+	obs.span = sc
+	return context.WithValue(ctx, observationContextKey{}, obs)
+}
+
+// ChildContext returns a context with s as the current span and its trace
+// as the current trace, so both can be retrieved with CurrentSpan and
+// CurrentTrace.
+//
+// Example:
+//
+//	span, _ := trace.Span(ctx, "retrieve")
+//	defer span.End(ctx)
+//	ctx = span.ChildContext(ctx)
+//	search(ctx) // langfuse.CurrentSpan(ctx) returns span
+func (s *SpanContext) ChildContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, observationContextKey{}, currentObservation{trace: s.TraceContext, span: s})
 }
 
 // MustSpanFromContext returns the SpanContext from ctx.
@@ -1513,8 +2200,9 @@ func MustSpanFromContext(ctx context.Context) *SpanContext {
 //	    Input(data).
 //	    Create()
 type SpanBuilder struct {
-	ctx  *TraceContext
-	span *createSpanEvent
+	ctx     *TraceContext
+	span    *createSpanEvent
+	baggage *Baggage // inherited from the parent span
 }
 
 // ID sets the span ID.
@@ -1571,6 +2259,17 @@ func (b *SpanBuilder) StatusMessage(msg string) *SpanBuilder {
 	return b
 }
 
+// TraceID attaches the span to the trace with the given ID instead of the
+// trace the builder was created from. The returned SpanContext, and any
+// observations created from it, belong to that trace.
+func (b *SpanBuilder) TraceID(id string) *SpanBuilder {
+	b.span.TraceID = id
+	if b.ctx.traceID != id {
+		b.ctx = &TraceContext{client: b.ctx.client, traceID: id}
+	}
+	return b
+}
+
 // ParentObservationID sets the parent observation ID.
 func (b *SpanBuilder) ParentObservationID(id string) *SpanBuilder {
 	b.span.ParentObservationID = id
@@ -1617,7 +2316,8 @@ func (b *SpanBuilder) Clone() *SpanBuilder {
 	}
 
 	return &SpanBuilder{
-		ctx: b.ctx,
+		ctx:     b.ctx,
+		baggage: b.baggage,
 		span: &createSpanEvent{
 			ID:                  generateID(), // New ID for the clone
 			TraceID:             b.span.TraceID,
@@ -1653,6 +2353,8 @@ func (b *SpanBuilder) Create(ctx context.Context) (*SpanContext, error) {
 		return nil, err
 	}
 
+	b.span.Metadata = withBaggage(b.span.Metadata, b.ctx.observationBaggage(b.baggage))
+
 	event := ingestionEvent{
 		ID:        generateID(),
 		Type:      eventTypeSpanCreate,
@@ -1664,12 +2366,33 @@ func (b *SpanBuilder) Create(ctx context.Context) (*SpanContext, error) {
 		return nil, err
 	}
 
+	startTime := time.Now()
+	if b.span.StartTime != nil {
+		startTime = b.span.StartTime.Time
+	}
+
 	return &SpanContext{
 		TraceContext: b.ctx,
 		spanID:       b.span.ID,
+		startTime:    startTime,
+		baggage:      b.baggage,
 	}, nil
 }
 
+// TryCreate is like Create but returns the span and error as a single
+// BuildResult.
+//
+// Example:
+//
+//	result := trace.NewSpan().Name("retrieve").TryCreate(ctx)
+//	if result.OK() {
+//	    span := result.Value()
+//	    defer span.End(ctx)
+//	}
+func (b *SpanBuilder) TryCreate(ctx context.Context) BuildResult[*SpanContext] {
+	return NewBuildResult(b.Create(ctx))
+}
+
 // SpanContext provides context for a span.
 //
 // SpanContext is safe for concurrent use. You can create child spans,
@@ -1677,7 +2400,15 @@ func (b *SpanBuilder) Create(ctx context.Context) (*SpanContext, error) {
 // created from SpanContext are NOT safe for concurrent use.
 type SpanContext struct {
 	*TraceContext
-	spanID string
+	spanID    string
+	startTime time.Time
+
+	timerMu sync.Mutex
+	timer   *SpanTimer
+
+	// Baggage set on this span or inherited from ancestor spans
+	baggageMu sync.RWMutex
+	baggage   *Baggage
 }
 
 // SpanID returns the span ID.
@@ -1703,12 +2434,20 @@ func (s *SpanContext) Update() *SpanUpdateBuilder {
 
 // End ends the span with the current time.
 func (s *SpanContext) End(ctx context.Context) error {
-	return s.Update().EndTime(time.Now()).Apply(ctx)
+	update := s.Update().EndTime(time.Now())
+	if timing := s.timingMetadata(); timing != nil {
+		update.Metadata(Metadata{"timing": timing})
+	}
+	return update.Apply(ctx)
 }
 
 // EndWithOutput ends the span with output and the current time.
 func (s *SpanContext) EndWithOutput(ctx context.Context, output any) error {
-	return s.Update().Output(output).EndTime(time.Now()).Apply(ctx)
+	update := s.Update().Output(output).EndTime(time.Now())
+	if timing := s.timingMetadata(); timing != nil {
+		update.Metadata(Metadata{"timing": timing})
+	}
+	return update.Apply(ctx)
 }
 
 // EndWith ends the span with the provided options.
@@ -1744,11 +2483,20 @@ func (s *SpanContext) EndWith(ctx context.Context, opts ...EndOption) EndResult
 	if cfg.output != nil {
 		update.Output(cfg.output)
 	}
-	if cfg.metadata != nil {
+	if timing := s.timingMetadata(); timing != nil {
+		metadata := make(Metadata, len(cfg.metadata)+1)
+		for k, v := range cfg.metadata {
+			metadata[k] = v
+		}
+		metadata["timing"] = timing
+		update.Metadata(metadata)
+	} else if cfg.metadata != nil {
 		update.Metadata(cfg.metadata)
 	}
 	if cfg.hasLevel {
 		update.Level(cfg.level)
+	} else if cfg.hasError {
+		update.Level(s.client.observationLevelFor(cfg.observationError))
 	}
 	if cfg.statusMessage != "" {
 		update.StatusMessage(cfg.statusMessage)
@@ -1761,11 +2509,103 @@ func (s *SpanContext) EndWith(ctx context.Context, opts ...EndOption) EndResult
 	}
 }
 
+// SetStatusError marks the span as failed by setting its status message to
+// err and its level to the one given by the client's observation level
+// mapping (ERROR by default). It is a no-op for nil errors.
+func (s *SpanContext) SetStatusError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	return s.Update().Level(s.client.observationLevelFor(err)).StatusMessage(err.Error()).Apply(ctx)
+}
+
+// RecordPanic records a recovered panic on the span and ends it. The panic
+// value becomes the status message, through SetStatusError, and the stack
+// trace of the panicking goroutine is stored in the span metadata under
+// "panic_stack". If recovered is an error it is kept in the chain, so the
+// observation level mapping sees it. RecordPanic must be called from the
+// deferred function that recovered the panic for the stack to include the
+// panic site.
+//
+// Example:
+//
+//	defer func() {
+//	    if r := recover(); r != nil {
+//	        span.RecordPanic(ctx, r)
+//	    }
+//	}()
+func (s *SpanContext) RecordPanic(ctx context.Context, recovered any) error {
+	if recovered == nil {
+		return nil
+	}
+
+	err := panicError(recovered)
+	stack := string(debug.Stack())
+
+	if statusErr := s.SetStatusError(ctx, err); statusErr != nil {
+		return statusErr
+	}
+
+	metadata := Metadata{"panic_stack": stack}
+	if timing := s.timingMetadata(); timing != nil {
+		metadata["timing"] = timing
+	}
+	return s.Update().Metadata(metadata).EndTime(time.Now()).Apply(ctx)
+}
+
+// panicError converts a recovered panic value to an error, keeping it in the
+// chain if it is one.
+func panicError(recovered any) error {
+	if e, ok := recovered.(error); ok {
+		return fmt.Errorf("panic: %w", e)
+	}
+	return fmt.Errorf("panic: %v", recovered)
+}
+
+// EnrichFromError records err on the span with structured fields. Like
+// SetStatusError it sets the level from the observation level mapping and
+// the status message; in addition, it records ErrorCodeOf(err) under
+// "error_code" in the span metadata and, for a LangfuseError, adds
+// "request_id" and "retryable", plus "status_code" for an *APIError. The
+// trace tags are left alone, since an update would replace tags set
+// elsewhere. It is a no-op for nil errors.
+//
+// Example:
+//
+//	if _, err := client.Prompts().Get(ctx, name, nil); err != nil {
+//	    span.EnrichFromError(ctx, err)
+//	}
+func (s *SpanContext) EnrichFromError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	update := s.Update().Level(s.client.observationLevelFor(err)).StatusMessage(err.Error())
+	metadata := Metadata{}
+	if code := ErrorCodeOf(err); code != "" {
+		metadata["error_code"] = string(code)
+	}
+	var lfErr LangfuseError
+	if errors.As(err, &lfErr) {
+		metadata["request_id"] = lfErr.GetRequestID()
+		metadata["retryable"] = lfErr.IsRetryable()
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			metadata["status_code"] = apiErr.StatusCode
+		}
+	}
+	if len(metadata) > 0 {
+		update.Metadata(metadata)
+	}
+	return update.Apply(ctx)
+}
+
 // NewSpan creates a child span builder (Advanced API).
 // For the Simple API, use Span(ctx, name, ...opts).
 func (s *SpanContext) NewSpan() *SpanBuilder {
 	builder := s.TraceContext.NewSpan()
 	builder.span.ParentObservationID = s.spanID
+	builder.baggage = s.spanBaggage()
 	return builder
 }
 
@@ -1774,6 +2614,7 @@ func (s *SpanContext) NewSpan() *SpanBuilder {
 func (s *SpanContext) NewGeneration() *GenerationBuilder {
 	builder := s.TraceContext.NewGeneration()
 	builder.gen.ParentObservationID = s.spanID
+	builder.baggage = s.spanBaggage()
 	return builder
 }
 
@@ -1782,6 +2623,7 @@ func (s *SpanContext) NewGeneration() *GenerationBuilder {
 func (s *SpanContext) NewEvent() *EventBuilder {
 	builder := s.TraceContext.NewEvent()
 	builder.event.ParentObservationID = s.spanID
+	builder.baggage = s.spanBaggage()
 	return builder
 }
 
@@ -1811,6 +2653,105 @@ func (s *SpanContext) ScoreBoolean(ctx context.Context, name string, value bool)
 	return s.NewScore().Name(name).BooleanValue(value).Create(ctx)
 }
 
+// Timer returns the span's timer, started at the span's start time. Every
+// call returns the same timer. When the span is ended with End, EndWithOutput,
+// or EndWith, recorded laps are added to the span metadata under "timing" as
+// milliseconds.
+//
+// Example:
+//
+//	timer := span.Timer()
+//	docs := retrieve(ctx, query)
+//	timer.Lap("retrieve")
+//	answer := generate(ctx, docs)
+//	timer.Lap("generate")
+//	span.End(ctx) // metadata: {"timing": {"retrieve": 120.5, "generate": 830.2}}
+func (s *SpanContext) Timer() *SpanTimer {
+	s.timerMu.Lock()
+	defer s.timerMu.Unlock()
+	if s.timer == nil {
+		start := s.startTime
+		if start.IsZero() {
+			start = time.Now()
+		}
+		s.timer = newSpanTimer(start)
+	}
+	return s.timer
+}
+
+// timingMetadata returns the timer laps in milliseconds, or nil if no laps
+// were recorded.
+func (s *SpanContext) timingMetadata() map[string]float64 {
+	s.timerMu.Lock()
+	timer := s.timer
+	s.timerMu.Unlock()
+	if timer == nil {
+		return nil
+	}
+
+	laps := timer.Finish()
+	if len(laps) == 0 {
+		return nil
+	}
+	timing := make(map[string]float64, len(laps))
+	for name, d := range laps {
+		timing[name] = float64(d) / float64(time.Millisecond)
+	}
+	return timing
+}
+
+// SpanTimer records named lap durations within a span.
+//
+// SpanTimer is safe for concurrent use.
+type SpanTimer struct {
+	mu      sync.Mutex
+	start   time.Time
+	lastLap time.Time
+	laps    map[string]time.Duration
+}
+
+// newSpanTimer creates a timer started at start.
+func newSpanTimer(start time.Time) *SpanTimer {
+	return &SpanTimer{
+		start:   start,
+		lastLap: start,
+		laps:    make(map[string]time.Duration),
+	}
+}
+
+// Lap records the time elapsed since the previous lap, or since the start
+// for the first lap, under name and returns it. Laps recorded under the same
+// name are summed.
+func (t *SpanTimer) Lap(name string) time.Duration {
+	now := time.Now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	d := now.Sub(t.lastLap)
+	if d < 0 {
+		d = 0
+	}
+	t.lastLap = now
+	t.laps[name] += d
+	return d
+}
+
+// Elapsed returns the time since the timer started.
+func (t *SpanTimer) Elapsed() time.Duration {
+	return time.Since(t.start)
+}
+
+// Finish returns a copy of all recorded laps keyed by name.
+func (t *SpanTimer) Finish() map[string]time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	laps := make(map[string]time.Duration, len(t.laps))
+	for name, d := range t.laps {
+		laps[name] = d
+	}
+	return laps
+}
+
 // SpanUpdateBuilder provides a fluent interface for updating spans.
 //
 // SpanUpdateBuilder is NOT safe for concurrent use. Each builder instance
@@ -1880,6 +2821,142 @@ func (b *SpanUpdateBuilder) Apply(ctx context.Context) error {
 	return b.ctx.client.queueEvent(ctx, event)
 }
 
+// BaggageMetadataKey is the metadata key under which baggage entries are
+// recorded on observations.
+const BaggageMetadataKey = "_baggage"
+
+// Baggage is an immutable set of key-value pairs propagated to descendant
+// observations. Methods that change it return a new Baggage, so a Baggage
+// can be shared between goroutines and copied freely. The nil *Baggage is
+// empty.
+type Baggage struct {
+	entries map[string]string
+}
+
+// Get returns the value for key, or an empty string if it is not set.
+func (b *Baggage) Get(key string) string {
+	if b == nil {
+		return ""
+	}
+	return b.entries[key]
+}
+
+// Len returns the number of entries.
+func (b *Baggage) Len() int {
+	if b == nil {
+		return 0
+	}
+	return len(b.entries)
+}
+
+// Entries returns a copy of the entries.
+func (b *Baggage) Entries() map[string]string {
+	entries := make(map[string]string, b.Len())
+	if b != nil {
+		for k, v := range b.entries {
+			entries[k] = v
+		}
+	}
+	return entries
+}
+
+// With returns a copy of b with key set to value.
+func (b *Baggage) With(key, value string) *Baggage {
+	entries := b.Entries()
+	entries[key] = value
+	return &Baggage{entries: entries}
+}
+
+// merge returns b with the entries of other added, other taking precedence.
+func (b *Baggage) merge(other *Baggage) *Baggage {
+	if other.Len() == 0 {
+		return b
+	}
+	if b.Len() == 0 {
+		return other
+	}
+	entries := b.Entries()
+	for k, v := range other.entries {
+		entries[k] = v
+	}
+	return &Baggage{entries: entries}
+}
+
+// withBaggage returns metadata with the baggage entries recorded under
+// BaggageMetadataKey. The caller's metadata map is not modified.
+func withBaggage(metadata Metadata, baggage *Baggage) Metadata {
+	if baggage.Len() == 0 {
+		return metadata
+	}
+	merged := make(Metadata, len(metadata)+1)
+	for k, v := range metadata {
+		merged[k] = v
+	}
+	merged[BaggageMetadataKey] = baggage.Entries()
+	return merged
+}
+
+// SetBaggage sets a baggage entry that is recorded in the metadata of every
+// observation subsequently created in this trace.
+func (t *TraceContext) SetBaggage(key, value string) {
+	t.baggageMu.Lock()
+	t.baggage = t.baggage.With(key, value)
+	t.baggageMu.Unlock()
+}
+
+// GetBaggage returns the trace-level baggage value for key.
+func (t *TraceContext) GetBaggage(key string) string {
+	return t.Baggage().Get(key)
+}
+
+// Baggage returns a snapshot of the trace-level baggage.
+func (t *TraceContext) Baggage() *Baggage {
+	t.baggageMu.RLock()
+	defer t.baggageMu.RUnlock()
+	return t.baggage
+}
+
+// observationBaggage returns the trace baggage overlaid with inherited.
+func (t *TraceContext) observationBaggage(inherited *Baggage) *Baggage {
+	return t.Baggage().merge(inherited)
+}
+
+// SetBaggage sets a baggage entry that is recorded in the metadata of every
+// descendant observation subsequently created from this span. It does not
+// affect the span itself or its siblings.
+//
+// Example:
+//
+//	span.SetBaggage("tenant", "acme")
+//	gen, _ := span.NewGeneration().Name("llm").Create(ctx)
+//	// gen metadata: {"_baggage": {"tenant": "acme"}}
+func (s *SpanContext) SetBaggage(key, value string) {
+	s.baggageMu.Lock()
+	s.baggage = s.baggage.With(key, value)
+	s.baggageMu.Unlock()
+}
+
+// GetBaggage returns the baggage value for key, looking at the span's own
+// and inherited entries before the trace-level baggage.
+func (s *SpanContext) GetBaggage(key string) string {
+	return s.Baggage().Get(key)
+}
+
+// Baggage returns a snapshot of the baggage visible to this span's
+// descendants: the trace-level baggage overlaid with the span's own and
+// inherited entries.
+func (s *SpanContext) Baggage() *Baggage {
+	return s.TraceContext.observationBaggage(s.spanBaggage())
+}
+
+// spanBaggage returns the span's own and inherited baggage, excluding
+// trace-level entries.
+func (s *SpanContext) spanBaggage() *Baggage {
+	s.baggageMu.RLock()
+	defer s.baggageMu.RUnlock()
+	return s.baggage
+}
+
 // ============================================================================
 // Event Builder
 // ============================================================================
@@ -1898,8 +2975,9 @@ func (b *SpanUpdateBuilder) Apply(ctx context.Context) error {
 //	    Input(actionData).
 //	    Create()
 type EventBuilder struct {
-	ctx   *TraceContext
-	event *createEventEvent
+	ctx     *TraceContext
+	event   *createEventEvent
+	baggage *Baggage // inherited from the parent span
 }
 
 // ID sets the event ID.
@@ -1991,6 +3069,8 @@ func (b *EventBuilder) Create(ctx context.Context) error {
 		return err
 	}
 
+	b.event.Metadata = withBaggage(b.event.Metadata, b.ctx.observationBaggage(b.baggage))
+
 	event := ingestionEvent{
 		ID:        generateID(),
 		Type:      eventTypeEventCreate,
@@ -2020,8 +3100,11 @@ func (b *EventBuilder) Create(ctx context.Context) error {
 //	    Input(prompt).
 //	    Create()
 type GenerationBuilder struct {
-	ctx *TraceContext
-	gen *createGenerationEvent
+	ctx      *TraceContext
+	gen      *createGenerationEvent
+	tools    []ToolDefinition
+	provider string
+	baggage  *Baggage // inherited from the parent span
 }
 
 // ID sets the generation ID.
@@ -2117,6 +3200,7 @@ func (b *GenerationBuilder) ModelParameters(params Metadata) *GenerationBuilder
 // Usage sets the token usage.
 func (b *GenerationBuilder) Usage(usage *Usage) *GenerationBuilder {
 	b.gen.Usage = usage
+	b.gen.UsageDetails = usage.Details()
 	return b
 }
 
@@ -2148,6 +3232,198 @@ func (b *GenerationBuilder) Environment(env string) *GenerationBuilder {
 	return b
 }
 
+// openAIChatRequest is the subset of an OpenAI chat completion request
+// extracted by GenerationBuilder.OpenAIRequest.
+type openAIChatRequest struct {
+	Model               string   `json:"model"`
+	Messages            any      `json:"messages"`
+	MaxTokens           *int     `json:"max_tokens"`
+	MaxCompletionTokens *int     `json:"max_completion_tokens"`
+	Temperature         *float64 `json:"temperature"`
+}
+
+// openAIChatResponse is the subset of an OpenAI chat completion response
+// extracted by GenerationContext.OpenAIResponse.
+type openAIChatResponse struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
+}
+
+// OpenAIRequest populates the generation from an OpenAI chat completion
+// request. req can be any value whose JSON form has the OpenAI request shape,
+// such as openai.ChatCompletionNewParams or a map; the OpenAI SDK is not
+// required. The model, messages (as input), max_tokens, and temperature are
+// extracted. Fields that are absent, or a req that cannot be marshalled to
+// JSON, leave the builder unchanged.
+//
+// Example:
+//
+//	params := openai.ChatCompletionNewParams{Model: openai.ChatModelGPT4o, Messages: msgs}
+//	gen, _ := trace.NewGeneration().Name("chat").OpenAIRequest(params).Create(ctx)
+func (b *GenerationBuilder) OpenAIRequest(req any) *GenerationBuilder {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return b
+	}
+	var parsed openAIChatRequest
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return b
+	}
+
+	if parsed.Model != "" {
+		b.Model(parsed.Model)
+	}
+	if parsed.Messages != nil {
+		b.Input(parsed.Messages)
+	}
+
+	params := Metadata{}
+	if parsed.MaxTokens != nil {
+		params["max_tokens"] = *parsed.MaxTokens
+	} else if parsed.MaxCompletionTokens != nil {
+		params["max_tokens"] = *parsed.MaxCompletionTokens
+	}
+	if parsed.Temperature != nil {
+		params["temperature"] = *parsed.Temperature
+	}
+	if len(params) > 0 {
+		if b.gen.ModelParameters == nil {
+			b.gen.ModelParameters = Metadata{}
+		}
+		for k, v := range params {
+			b.gen.ModelParameters[k] = v
+		}
+	}
+
+	return b
+}
+
+// ToolsMetadataKey is the generation metadata key under which
+// GenerationBuilder.ExpectedTools records the available tools.
+const ToolsMetadataKey = "tools"
+
+// LLMProviderMetadataKey is the generation metadata key under which
+// GenerationBuilder.LLMProvider records the provider.
+const LLMProviderMetadataKey = "llm_provider"
+
+// LLM providers for GenerationBuilder.LLMProvider. Any other provider name
+// may be used as well.
+const (
+	ProviderOpenAI    = "openai"
+	ProviderAnthropic = "anthropic"
+	ProviderCohere    = "cohere"
+	ProviderGoogle    = "google"
+	ProviderMistral   = "mistral"
+)
+
+// LLMProvider records the LLM provider serving the generation, such as
+// ProviderOpenAI, under LLMProviderMetadataKey in the generation metadata,
+// so usage and cost can be analyzed per provider. Filter generations by
+// provider with ObservationsListParams.LLMProvider.
+//
+// Example:
+//
+//	gen, _ := trace.NewGeneration().
+//	    Name("chat").
+//	    Model("claude-sonnet-4").
+//	    LLMProvider(langfuse.ProviderAnthropic).
+//	    Create(ctx)
+func (b *GenerationBuilder) LLMProvider(provider string) *GenerationBuilder {
+	b.provider = provider
+	return b
+}
+
+// applyLLMProvider records the provider in the generation metadata. Like
+// applyExpectedTools it runs at Create time.
+func (b *GenerationBuilder) applyLLMProvider() {
+	if b.provider == "" {
+		return
+	}
+
+	metadata := make(Metadata, len(b.gen.Metadata)+1)
+	for k, v := range b.gen.Metadata {
+		metadata[k] = v
+	}
+	metadata[LLMProviderMetadataKey] = b.provider
+	b.gen.Metadata = metadata
+}
+
+// ToolDefinition describes a tool or function made available to the model.
+type ToolDefinition struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Parameters  map[string]any `json:"parameters,omitempty"` // JSON Schema
+}
+
+// ToolCall is a tool or function call returned by the model.
+type ToolCall struct {
+	ID        string `json:"id,omitempty"`
+	Name      string `json:"name"`
+	Arguments string `json:"arguments,omitempty"` // JSON-encoded arguments
+	Result    string `json:"result,omitempty"`    // JSON-encoded result
+}
+
+// ParseArguments unmarshals the JSON-encoded arguments into v.
+//
+// Example:
+//
+//	var args struct{ City string `json:"city"` }
+//	if err := call.ParseArguments(&args); err != nil {
+//	    return err
+//	}
+func (c ToolCall) ParseArguments(v any) error {
+	if err := json.Unmarshal([]byte(c.Arguments), v); err != nil {
+		return fmt.Errorf("langfuse: failed to parse arguments of tool call %q: %w", c.Name, err)
+	}
+	return nil
+}
+
+// toolCallsOutput is the generation output recorded by EndWithToolCalls.
+type toolCallsOutput struct {
+	ToolCalls []ToolCall `json:"tool_calls"`
+	Text      string     `json:"text"`
+}
+
+// ExpectedTools records the tools made available to the model. They are
+// stored in the generation metadata under "tools" when the generation is
+// created.
+//
+// Example:
+//
+//	gen, _ := trace.NewGeneration().
+//	    Name("agent-step").
+//	    Model("gpt-4o").
+//	    ExpectedTools([]langfuse.ToolDefinition{
+//	        {Name: "get_weather", Description: "Current weather for a city"},
+//	    }).
+//	    Create(ctx)
+func (b *GenerationBuilder) ExpectedTools(tools []ToolDefinition) *GenerationBuilder {
+	b.tools = tools
+	return b
+}
+
+// applyExpectedTools records the expected tools in the generation metadata.
+// It runs at Create time so it is not lost if Metadata is set afterwards.
+func (b *GenerationBuilder) applyExpectedTools() {
+	if len(b.tools) == 0 {
+		return
+	}
+
+	metadata := make(Metadata, len(b.gen.Metadata)+1)
+	for k, v := range b.gen.Metadata {
+		metadata[k] = v
+	}
+	metadata[ToolsMetadataKey] = b.tools
+	b.gen.Metadata = metadata
+}
+
 // Clone creates a deep copy of the GenerationBuilder with a new ID and timestamp.
 // This is useful for creating multiple similar generations from a template.
 //
@@ -2190,11 +3466,17 @@ func (b *GenerationBuilder) Clone() *GenerationBuilder {
 			InputCost:  b.gen.Usage.InputCost,
 			OutputCost: b.gen.Usage.OutputCost,
 			TotalCost:  b.gen.Usage.TotalCost,
+
+			ReasoningTokens:   b.gen.Usage.ReasoningTokens,
+			CachedInputTokens: b.gen.Usage.CachedInputTokens,
 		}
 	}
 
 	return &GenerationBuilder{
-		ctx: b.ctx,
+		ctx:      b.ctx,
+		tools:    b.tools,
+		provider: b.provider,
+		baggage:  b.baggage,
 		gen: &createGenerationEvent{
 			ID:                  generateID(), // New ID for the clone
 			TraceID:             b.gen.TraceID,
@@ -2213,6 +3495,7 @@ func (b *GenerationBuilder) Clone() *GenerationBuilder {
 			Model:               b.gen.Model,
 			ModelParameters:     modelParams,
 			Usage:               usage,
+			UsageDetails:        usage.Details(),
 			PromptName:          b.gen.PromptName,
 			PromptVersion:       b.gen.PromptVersion,
 		},
@@ -2236,6 +3519,10 @@ func (b *GenerationBuilder) Create(ctx context.Context) (*GenerationContext, err
 		return nil, err
 	}
 
+	b.applyExpectedTools()
+	b.applyLLMProvider()
+	b.gen.Metadata = withBaggage(b.gen.Metadata, b.ctx.observationBaggage(b.baggage))
+
 	event := ingestionEvent{
 		ID:        generateID(),
 		Type:      eventTypeGenerationCreate,
@@ -2246,11 +3533,25 @@ func (b *GenerationBuilder) Create(ctx context.Context) (*GenerationContext, err
 	if err := b.ctx.client.queueEvent(ctx, event); err != nil {
 		return nil, err
 	}
+	b.ctx.recordGeneration(b.gen)
+
+	g := &GenerationContext{
+		TraceContext:  b.ctx,
+		genID:         b.gen.ID,
+		name:          b.gen.Name,
+		parentID:      b.gen.ParentObservationID,
+		model:         b.gen.Model,
+		promptName:    b.gen.PromptName,
+		promptVersion: b.gen.PromptVersion,
+	}
+	g.record(b.gen)
+	return g, nil
+}
 
-	return &GenerationContext{
-		TraceContext: b.ctx,
-		genID:        b.gen.ID,
-	}, nil
+// TryCreate is like Create but returns the generation and error as a single
+// BuildResult.
+func (b *GenerationBuilder) TryCreate(ctx context.Context) BuildResult[*GenerationContext] {
+	return NewBuildResult(b.Create(ctx))
 }
 
 // GenerationContext provides context for a generation.
@@ -2261,6 +3562,19 @@ func (b *GenerationBuilder) Create(ctx context.Context) (*GenerationContext, err
 type GenerationContext struct {
 	*TraceContext
 	genID string
+
+	// Creation-time settings retained so sibling generations can be forked
+	// with the same configuration.
+	name          string
+	parentID      string
+	model         string
+	promptName    string
+	promptVersion int
+
+	mu      sync.Mutex
+	usage   *Usage    // last usage reported for this generation
+	output  any       // last output reported for this generation
+	endTime time.Time // end time reported for this generation, if any
 }
 
 // GenerationID returns the generation ID.
@@ -2303,6 +3617,50 @@ func (g *GenerationContext) EndWithUsage(ctx context.Context, output any, inputT
 		Apply(ctx)
 }
 
+// EndWithExtendedUsage ends the generation with output, usage including
+// reasoning and cached input tokens, and the current time. Reasoning tokens
+// are reported by models such as OpenAI o1 and Anthropic Claude with
+// extended thinking; they are recorded in usageDetails separately from
+// outputTokens. cachedInputTokens are the inputTokens served from the
+// prompt cache.
+//
+// Example:
+//
+//	gen.EndWithExtendedUsage(ctx, resp.Text,
+//	    resp.Usage.PromptTokens,
+//	    resp.Usage.CompletionTokens,
+//	    resp.Usage.CompletionTokensDetails.ReasoningTokens,
+//	    resp.Usage.PromptTokensDetails.CachedTokens,
+//	)
+func (g *GenerationContext) EndWithExtendedUsage(ctx context.Context, output string, inputTokens, outputTokens, reasoningTokens, cachedInputTokens int) error {
+	return g.Update().
+		Output(output).
+		Usage(&Usage{
+			Input:             inputTokens,
+			Output:            outputTokens,
+			Total:             inputTokens + outputTokens,
+			ReasoningTokens:   reasoningTokens,
+			CachedInputTokens: cachedInputTokens,
+		}).
+		EndTime(time.Now()).
+		Apply(ctx)
+}
+
+// EndWithToolCalls ends the generation with the tool calls returned by the
+// model, usage, and the current time. The output is recorded as
+// {"tool_calls": [...], "text": ""}.
+//
+// Example:
+//
+//	calls := []langfuse.ToolCall{{ID: "call_1", Name: "get_weather", Arguments: `{"city":"Paris"}`}}
+//	gen.EndWithToolCalls(ctx, calls, resp.Usage.PromptTokens, resp.Usage.CompletionTokens)
+func (g *GenerationContext) EndWithToolCalls(ctx context.Context, toolCalls []ToolCall, inputTokens, outputTokens int) error {
+	if toolCalls == nil {
+		toolCalls = []ToolCall{}
+	}
+	return g.EndWithUsage(ctx, &toolCallsOutput{ToolCalls: toolCalls}, inputTokens, outputTokens)
+}
+
 // EndWith ends the generation with the provided options.
 // This provides a consistent, flexible API for ending observations.
 //
@@ -2345,6 +3703,8 @@ func (g *GenerationContext) EndWith(ctx context.Context, opts ...EndOption) EndR
 	}
 	if cfg.hasLevel {
 		update.Level(cfg.level)
+	} else if cfg.hasError {
+		update.Level(g.client.observationLevelFor(cfg.observationError))
 	}
 	if cfg.statusMessage != "" {
 		update.StatusMessage(cfg.statusMessage)
@@ -2360,6 +3720,37 @@ func (g *GenerationContext) EndWith(ctx context.Context, opts ...EndOption) EndR
 	}
 }
 
+// OpenAIResponse ends the generation from an OpenAI chat completion
+// response. resp can be any value whose JSON form has the OpenAI response
+// shape, such as *openai.ChatCompletion; the OpenAI SDK is not required.
+// The content of the first choice becomes the output, and
+// usage.prompt_tokens and usage.completion_tokens become the token usage.
+//
+// Example:
+//
+//	completion, err := oai.Chat.Completions.New(ctx, params)
+//	if err != nil {
+//	    return err
+//	}
+//	gen.OpenAIResponse(ctx, completion)
+func (g *GenerationContext) OpenAIResponse(ctx context.Context, resp any) error {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("langfuse: failed to marshal OpenAI response: %w", err)
+	}
+	var parsed openAIChatResponse
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return fmt.Errorf("langfuse: failed to parse OpenAI response: %w", err)
+	}
+
+	var output string
+	if len(parsed.Choices) > 0 {
+		output = parsed.Choices[0].Message.Content
+	}
+
+	return g.EndWithUsage(ctx, output, parsed.Usage.PromptTokens, parsed.Usage.CompletionTokens)
+}
+
 // NewScore creates a score builder for this generation (Advanced API).
 // For the Simple API, use Score(ctx, name, value, ...opts).
 func (g *GenerationContext) NewScore() *ScoreBuilder {
@@ -2410,6 +3801,427 @@ func (g *GenerationContext) NewEvent() *EventBuilder {
 	return builder
 }
 
+// record stores the token usage, output and end time reported by a
+// generation create or update event.
+func (g *GenerationContext) record(event *observationEvent) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if event.Usage != nil {
+		u := *event.Usage
+		g.usage = &u
+	}
+	if event.Output != nil {
+		g.output = event.Output
+	}
+	if event.EndTime != nil {
+		g.endTime = event.EndTime.Time
+	}
+}
+
+// TrackedUsage returns the last token usage reported for this generation
+// through the builder or update API, or nil if none was reported.
+func (g *GenerationContext) TrackedUsage() *Usage {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.usage == nil {
+		return nil
+	}
+	u := *g.usage
+	return &u
+}
+
+// ForkForParallelCalls creates n sibling generations that share this
+// generation's parent, model, and prompt link. Use it to track parallel LLM
+// calls (for example, parallel tool calls) as independent generations. Each
+// returned generation must be ended by the caller.
+//
+// Example:
+//
+//	forks, err := gen.ForkForParallelCalls(ctx, len(toolCalls))
+//	for i, call := range toolCalls {
+//	    go func(g *langfuse.GenerationContext, call ToolCall) {
+//	        out, usage := run(call)
+//	        g.EndWithUsage(ctx, out, usage.Input, usage.Output)
+//	    }(forks[i], call)
+//	}
+func (g *GenerationContext) ForkForParallelCalls(ctx context.Context, n int) ([]*GenerationContext, error) {
+	if n <= 0 {
+		return nil, NewValidationError("n", "fork count must be positive")
+	}
+
+	forks := make([]*GenerationContext, 0, n)
+	for i := 0; i < n; i++ {
+		builder := g.TraceContext.NewGeneration().
+			ParentObservationID(g.parentID).
+			Model(g.model).
+			PromptName(g.promptName).
+			PromptVersion(g.promptVersion)
+		if g.name != "" {
+			builder.Name(fmt.Sprintf("%s-%d", g.name, i))
+		}
+
+		fork, err := builder.Create(ctx)
+		if err != nil {
+			return forks, fmt.Errorf("langfuse: fork %d of %d: %w", i, n, err)
+		}
+		forks = append(forks, fork)
+	}
+	return forks, nil
+}
+
+// ChainTo creates the next generation in a multi-model chain, such as a
+// router model handing off to a specialist model. The new generation is a
+// sibling of this one (it shares this generation's parent) and uses
+// nextModel as its model and name. This generation's output becomes its
+// input unless opts set one. Its start time is never before this
+// generation's end time, so chain members have sequential start times.
+//
+// ChainTo must be called after this generation has ended.
+//
+// Example:
+//
+//	router.EndWithOutput(ctx, route)
+//	specialist, err := router.ChainTo(ctx, "gpt-4o")
+//	...
+//	for _, gen := range trace.GenerationChain() {
+//	    fmt.Println(gen.GenerationID())
+//	}
+func (g *GenerationContext) ChainTo(ctx context.Context, nextModel string, opts ...GenerationOption) (*GenerationContext, error) {
+	if nextModel == "" {
+		return nil, NewValidationError("nextModel", "model cannot be empty")
+	}
+
+	g.mu.Lock()
+	output, endTime := g.output, g.endTime
+	g.mu.Unlock()
+	if endTime.IsZero() {
+		return nil, NewValidationError("generation", "ChainTo must be called after the generation has ended")
+	}
+
+	cfg := &generationConfig{}
+	for _, opt := range opts {
+		opt.apply2(cfg)
+	}
+
+	startTime := time.Now()
+	if startTime.Before(endTime) {
+		startTime = endTime
+	}
+
+	builder := g.TraceContext.NewGeneration().
+		Name(nextModel).
+		ParentObservationID(g.parentID).
+		Input(output).
+		StartTime(startTime)
+	cfg.applyTo(builder)
+	builder.Model(nextModel)
+
+	next, err := builder.Create(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	t := g.TraceContext
+	t.genMu.Lock()
+	if !slices.Contains(t.chain, g) {
+		t.chain = append(t.chain, g)
+	}
+	t.chain = append(t.chain, next)
+	t.genMu.Unlock()
+	return next, nil
+}
+
+// GenerationChain returns the generations linked by ChainTo, in the order
+// they were chained. It returns nil if ChainTo has not been called.
+func (t *TraceContext) GenerationChain() []*GenerationContext {
+	t.genMu.Lock()
+	defer t.genMu.Unlock()
+	if len(t.chain) == 0 {
+		return nil
+	}
+	chain := make([]*GenerationContext, len(t.chain))
+	copy(chain, t.chain)
+	return chain
+}
+
+// MergeGenerations aggregates the tracked token usage of gens and records
+// combinedOutput as the trace output. The summed usage is attached to the
+// trace metadata under "merged_usage".
+//
+// All generations must belong to this trace.
+func (t *TraceContext) MergeGenerations(ctx context.Context, gens []*GenerationContext, combinedOutput string) error {
+	total := Usage{}
+	for i, g := range gens {
+		if g == nil {
+			return NewValidationError("gens", fmt.Sprintf("generation %d is nil", i))
+		}
+		if g.traceID != t.traceID {
+			return NewValidationError("gens", fmt.Sprintf("generation %s belongs to trace %s, not %s", g.genID, g.traceID, t.traceID))
+		}
+		if u := g.TrackedUsage(); u != nil {
+			total.Input += u.Input
+			total.Output += u.Output
+			total.Total += u.Total
+			total.InputCost += u.InputCost
+			total.OutputCost += u.OutputCost
+			total.TotalCost += u.TotalCost
+			total.ReasoningTokens += u.ReasoningTokens
+			total.CachedInputTokens += u.CachedInputTokens
+			if total.Unit == "" {
+				total.Unit = u.Unit
+			}
+		}
+	}
+
+	return t.Update().
+		Output(combinedOutput).
+		Metadata(Metadata{
+			"merged_generations": len(gens),
+			"merged_usage":       total,
+		}).
+		Apply(ctx)
+}
+
+// replayableGeneration is the creation-time configuration of a generation,
+// kept so Replay can re-create it.
+type replayableGeneration struct {
+	name            string
+	input           any
+	metadata        Metadata
+	model           string
+	modelParameters Metadata
+	promptName      string
+	promptVersion   int
+	environment     string
+}
+
+// replayState is the creation-time state of a replayable trace.
+type replayState struct {
+	name     string
+	input    any
+	metadata Metadata
+
+	mu          sync.Mutex
+	generations []replayableGeneration
+}
+
+// recordGeneration remembers gen's configuration for Replay if the trace is
+// replayable.
+func (t *TraceContext) recordGeneration(gen *createGenerationEvent) {
+	if t.replay == nil {
+		return
+	}
+	t.replay.mu.Lock()
+	defer t.replay.mu.Unlock()
+	t.replay.generations = append(t.replay.generations, replayableGeneration{
+		name:            gen.Name,
+		input:           gen.Input,
+		metadata:        gen.Metadata,
+		model:           gen.Model,
+		modelParameters: gen.ModelParameters,
+		promptName:      gen.PromptName,
+		promptVersion:   gen.PromptVersion,
+		environment:     gen.Environment,
+	})
+}
+
+// ReplayMetadataKey is the trace metadata key that links a replayed trace
+// to the trace it was replayed from.
+const ReplayMetadataKey = "replay_of_trace_id"
+
+// ErrTraceNotReplayable is returned by TraceContext.Replay for a trace that
+// was not created with TraceBuilder.Replayable.
+var ErrTraceNotReplayable = errors.New("langfuse: trace was not created with TraceBuilder.Replayable")
+
+type replayConfig struct {
+	replayGenerations bool
+	model             string
+	tags              []string
+	userID            string
+}
+
+// ReplayOption configures TraceContext.Replay and Client.Replay.
+type ReplayOption func(*replayConfig)
+
+// WithReplayGenerations makes Replay also re-create the trace's generations
+// with the given model and without their outputs, ready to be filled in by
+// the new run. An empty model keeps each generation's original model.
+//
+// Example:
+//
+//	replay, _ := trace.Replay(ctx, langfuse.WithReplayGenerations("gpt-4o"))
+func WithReplayGenerations(model string) ReplayOption {
+	return func(c *replayConfig) {
+		c.replayGenerations = true
+		c.model = model
+	}
+}
+
+// WithReplayTags replaces the tags of the replayed trace, for example to
+// mark it for a different evaluator.
+//
+// Example:
+//
+//	replay, _ := client.Replay(ctx, traceID, langfuse.WithReplayTags("eval-v2"))
+func WithReplayTags(tags ...string) ReplayOption {
+	return func(c *replayConfig) {
+		c.tags = tags
+	}
+}
+
+// WithReplayUserID replaces the user ID of the replayed trace.
+func WithReplayUserID(userID string) ReplayOption {
+	return func(c *replayConfig) {
+		c.userID = userID
+	}
+}
+
+// apply sets the tag and user ID overrides on builder.
+func (c *replayConfig) apply(builder *TraceBuilder) {
+	if c.tags != nil {
+		builder.Tags(append([]string(nil), c.tags...))
+	}
+	if c.userID != "" {
+		builder.UserID(c.userID)
+	}
+}
+
+// Replay creates a new trace with the same name, input, user ID, session ID,
+// tags and metadata this trace was created with, for re-running an input
+// through a different model. The new trace has a new ID and start time, and
+// its metadata records this trace's ID under ReplayMetadataKey. The trace
+// must have been created with TraceBuilder.Replayable; otherwise Replay
+// returns ErrTraceNotReplayable.
+//
+// Only creation-time settings are replayed; later updates are not. Replayed
+// generations are attached directly to the new trace, since spans are not
+// replayed.
+//
+// Example:
+//
+//	replay, err := trace.Replay(ctx, langfuse.WithReplayGenerations("gpt-4o"))
+func (t *TraceContext) Replay(ctx context.Context, replayOpts ...ReplayOption) (*TraceContext, error) {
+	state := t.replay
+	if state == nil {
+		return nil, ErrTraceNotReplayable
+	}
+
+	cfg := &replayConfig{}
+	for _, opt := range replayOpts {
+		opt(cfg)
+	}
+
+	metadata := make(Metadata, len(state.metadata)+1)
+	for k, v := range state.metadata {
+		metadata[k] = v
+	}
+	metadata[ReplayMetadataKey] = t.traceID
+
+	builder := t.client.NewTrace().
+		Name(state.name).
+		Input(state.input).
+		UserID(t.userID).
+		SessionID(t.sessionID).
+		Environment(t.environment).
+		Metadata(metadata)
+	if len(t.tags) > 0 {
+		builder.Tags(append([]string(nil), t.tags...))
+	}
+	cfg.apply(builder)
+
+	replay, err := builder.Create(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !cfg.replayGenerations {
+		return replay, nil
+	}
+
+	state.mu.Lock()
+	gens := append([]replayableGeneration(nil), state.generations...)
+	state.mu.Unlock()
+
+	for _, gen := range gens {
+		model := cfg.model
+		if model == "" {
+			model = gen.model
+		}
+		_, err := replay.NewGeneration().
+			Name(gen.name).
+			Model(model).
+			ModelParameters(gen.modelParameters).
+			Input(gen.input).
+			Metadata(gen.metadata).
+			PromptName(gen.promptName).
+			PromptVersion(gen.promptVersion).
+			Environment(gen.environment).
+			Create(ctx)
+		if err != nil {
+			return replay, err
+		}
+	}
+	return replay, nil
+}
+
+// Replay fetches a stored trace with TracesClient.Get and ingests a copy of
+// it as a new trace, so different evaluators can be applied to it. The copy
+// has a new ID and start time, the stored trace's name, input, output, user
+// ID, session ID, tags, metadata, release, version, environment and
+// visibility, and records the stored trace's ID under ReplayMetadataKey.
+// Attach scores to the returned TraceContext.
+//
+// Child observations and scores of the stored trace are not replayed, and
+// WithReplayGenerations has no effect.
+//
+// Example:
+//
+//	replay, err := client.Replay(ctx, traceID, langfuse.WithReplayTags("eval-v2"))
+//	if err != nil {
+//	    return err
+//	}
+//	replay.ScoreNumeric(ctx, "faithfulness", 0.8)
+func (c *Client) Replay(ctx context.Context, traceID string, replayOpts ...ReplayOption) (*TraceContext, error) {
+	if traceID == "" {
+		return nil, NewValidationError("traceID", "trace ID is required")
+	}
+	cfg := &replayConfig{}
+	for _, opt := range replayOpts {
+		opt(cfg)
+	}
+
+	stored, err := c.Traces().Get(ctx, traceID)
+	if err != nil {
+		return nil, fmt.Errorf("langfuse: get trace to replay: %w", err)
+	}
+
+	metadata := make(Metadata, len(stored.Metadata)+1)
+	for k, v := range stored.Metadata {
+		metadata[k] = v
+	}
+	metadata[ReplayMetadataKey] = stored.ID
+
+	builder := c.NewTrace().
+		Name(stored.Name).
+		Input(stored.Input).
+		Output(stored.Output).
+		UserID(stored.UserID).
+		SessionID(stored.SessionID).
+		Version(stored.Version).
+		Environment(stored.Environment).
+		Public(stored.Public).
+		Metadata(metadata)
+	if stored.Release != "" {
+		builder.Release(stored.Release)
+	}
+	if len(stored.Tags) > 0 {
+		builder.Tags(append([]string(nil), stored.Tags...))
+	}
+	cfg.apply(builder)
+
+	return builder.Create(ctx)
+}
+
 // GenerationUpdateBuilder provides a fluent interface for updating generations.
 //
 // GenerationUpdateBuilder is NOT safe for concurrent use. Each builder
@@ -2482,6 +4294,7 @@ func (b *GenerationUpdateBuilder) ModelParameters(params Metadata) *GenerationUp
 // Usage sets the token usage.
 func (b *GenerationUpdateBuilder) Usage(usage *Usage) *GenerationUpdateBuilder {
 	b.update.Usage = usage
+	b.update.UsageDetails = usage.Details()
 	return b
 }
 
@@ -2504,5 +4317,143 @@ func (b *GenerationUpdateBuilder) Apply(ctx context.Context) error {
 		Body:      b.update,
 	}
 
-	return b.ctx.client.queueEvent(ctx, event)
+	if err := b.ctx.client.queueEvent(ctx, event); err != nil {
+		return err
+	}
+	b.ctx.record(b.update)
+	return nil
+}
+
+// ============================================================================
+// Checkpoint Traces
+// ============================================================================
+
+// CheckpointSummary describes a checkpoint recorded by a CheckpointTrace.
+type CheckpointSummary struct {
+	// Stage is the checkpoint name
+	Stage string
+
+	// Elapsed is the time from the start of the trace to the checkpoint
+	Elapsed time.Duration
+
+	// Duration is the duration of the stage's span for checkpoints recorded
+	// by CheckpointSpan, and zero for those recorded by Checkpoint
+	Duration time.Duration
+}
+
+// CheckpointTraceBuilder creates a CheckpointTrace. It is returned by
+// TraceBuilder.WithCheckpoints and accepts the same settings; set them
+// before calling WithCheckpoints.
+type CheckpointTraceBuilder struct {
+	*TraceBuilder
+}
+
+// WithCheckpoints returns a builder for a trace that records pipeline stage
+// latencies as checkpoint events.
+//
+// Example:
+//
+//	trace, _ := client.NewTrace().Name("ingest").WithCheckpoints().Create(ctx)
+//	parse(doc)
+//	trace.Checkpoint(ctx, "parsed")
+//	span, done := trace.CheckpointSpan(ctx, "embed")
+//	embed(ctx, span, doc)
+//	done()
+//	for _, cp := range trace.Summary() {
+//	    log.Printf("%s at %v", cp.Stage, cp.Elapsed)
+//	}
+func (b *TraceBuilder) WithCheckpoints() *CheckpointTraceBuilder {
+	return &CheckpointTraceBuilder{TraceBuilder: b}
+}
+
+// Create creates the trace. Checkpoint times are measured from the trace
+// timestamp.
+func (b *CheckpointTraceBuilder) Create(ctx context.Context) (*CheckpointTrace, error) {
+	trace, err := b.TraceBuilder.Create(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	if b.trace.Timestamp != nil && !b.trace.Timestamp.IsZero() {
+		start = b.trace.Timestamp.Time
+	}
+	return &CheckpointTrace{TraceContext: trace, start: start}, nil
+}
+
+// CheckpointTrace is a trace that records checkpoint events at the end of
+// pipeline stages.
+//
+// CheckpointTrace is safe for concurrent use.
+type CheckpointTrace struct {
+	*TraceContext
+	start time.Time
+
+	mu          sync.Mutex
+	checkpoints []CheckpointSummary
+}
+
+// Checkpoint records an event named stage with metadata
+// {"stage": stage, "elapsed_ms": N}, where N is the time since the trace
+// started.
+func (t *CheckpointTrace) Checkpoint(ctx context.Context, stage string) error {
+	return t.checkpoint(ctx, stage, 0)
+}
+
+// CheckpointSpan creates a span named stage and returns a function that ends
+// it and records a checkpoint event like Checkpoint, with the span duration
+// added to the metadata as "duration_ms". The function may be called more
+// than once; only the first call has an effect. Errors from ending the span
+// or recording the checkpoint are reported to the client's error handler.
+//
+// If the span cannot be created, the error is reported to the client's
+// error handler, and CheckpointSpan returns a nil span and a function that
+// does nothing.
+func (t *CheckpointTrace) CheckpointSpan(ctx context.Context, stage string) (*SpanContext, func()) {
+	span, err := t.NewSpan().Name(stage).Create(ctx)
+	if err != nil {
+		t.client.handleRootError(err)
+		return nil, func() {}
+	}
+
+	started := time.Now()
+	var once sync.Once
+	return span, func() {
+		once.Do(func() {
+			if err := span.End(ctx); err != nil {
+				t.client.handleRootError(err)
+			}
+			if err := t.checkpoint(ctx, stage, time.Since(started)); err != nil {
+				t.client.handleRootError(err)
+			}
+		})
+	}
+}
+
+// Summary returns the recorded checkpoints in the order they were recorded.
+func (t *CheckpointTrace) Summary() []CheckpointSummary {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]CheckpointSummary(nil), t.checkpoints...)
+}
+
+// checkpoint records a checkpoint event and adds it to the summary.
+func (t *CheckpointTrace) checkpoint(ctx context.Context, stage string, duration time.Duration) error {
+	elapsed := time.Since(t.start)
+	metadata := Metadata{
+		"stage":      stage,
+		"elapsed_ms": elapsed.Milliseconds(),
+	}
+	if duration > 0 {
+		metadata["duration_ms"] = duration.Milliseconds()
+	}
+
+	if err := t.NewEvent().Name(stage).Metadata(metadata).Create(ctx); err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	t.checkpoints = append(t.checkpoints, CheckpointSummary{Stage: stage, Elapsed: elapsed, Duration: duration})
+	t.mu.Unlock()
+	return nil
 }
@@ -1,12 +1,19 @@
 package langfuse
 
 import (
+	"container/list"
 	"context"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"net/url"
 	"os"
+	"os/exec"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	pkgclient "github.com/jdziat/langfuse-go/pkg/client"
@@ -35,6 +42,30 @@ type Client struct {
 	// Root-specific config (extends pkg/client.Config with evaluation, etc.)
 	rootConfig *Config
 
+	// release is the default release for new traces (string)
+	release atomic.Value
+
+	// statsHistory records periodic Stats snapshots; nil unless enabled
+	statsHistory *statsHistory
+
+	// lastTraceID is the ID of the most recently queued trace (string),
+	// checked by FlushAndWait
+	lastTraceID atomic.Value
+
+	// connectionTestPassed is set if the WithConnectionTest check succeeded
+	connectionTestPassed atomic.Bool
+
+	// autoEndStop is closed on Shutdown to cancel pending TraceBuilder.AutoEnd
+	// timeouts; autoEndWG tracks their goroutines
+	autoEndMu      sync.Mutex
+	autoEndStop    chan struct{}
+	autoEndStopped bool
+	autoEndWG      sync.WaitGroup
+
+	// subscribers maps each Subscribe channel to its event type filter
+	subscribersMu sync.Mutex
+	subscribers   map[chan ObservedEvent]string
+
 	// Sub-clients for Langfuse API
 	traces       *TracesClient
 	observations *ObservationsClient
@@ -79,11 +110,21 @@ func NewWithConfig(cfg *Config) (*Client, error) {
 	// Make a copy to avoid modifying the original
 	cfgCopy := *cfg
 
+	var envOverrides []envOverride
+	if cfgCopy.EnvironmentOverrides {
+		var err error
+		if envOverrides, err = cfgCopy.applyEnvironmentOverrides(); err != nil {
+			return nil, err
+		}
+	}
+
 	cfgCopy.applyDefaults()
 
 	if err := cfgCopy.validate(); err != nil {
 		return nil, err
 	}
+	cfgCopy.Metrics = newScopedMetrics(cfgCopy.Metrics, cfgCopy.MetricsPrefix, cfgCopy.MetricsLabels)
+	cfgCopy.Logger, cfgCopy.StructuredLogger = withProjectLogging(cfgCopy.Logger, cfgCopy.StructuredLogger, cfgCopy.ProjectID)
 
 	// Convert root Config to pkg/client.Config
 	pkgCfg := convertToPkgClientConfig(&cfgCopy)
@@ -98,6 +139,11 @@ func NewWithConfig(cfg *Config) (*Client, error) {
 		Client:     coreClient,
 		rootConfig: &cfgCopy,
 	}
+	c.AddShutdownHook(c.stopAutoEnds)
+	c.release.Store(cfgCopy.Release)
+	if cfgCopy.GitRelease && cfgCopy.Release == "" {
+		c.SetReleaseFromGit()
+	}
 
 	// Initialize sub-clients using the embedded client's HTTP() method
 	c.traces = newTracesClient(c)
@@ -108,15 +154,125 @@ func NewWithConfig(cfg *Config) (*Client, error) {
 	c.sessions = newSessionsClient(c)
 	c.models = newModelsClient(c)
 
+	if cfgCopy.ConnectionTest {
+		if err := c.testConnection(); err != nil {
+			c.Client.Shutdown(context.Background())
+			return nil, err
+		}
+	}
+
+	if cfgCopy.StatsHistory != nil {
+		c.statsHistory = newStatsHistory(*cfgCopy.StatsHistory)
+		c.AddShutdownHook(c.statsHistory.close)
+		go c.recordStats()
+	}
+
+	cfgCopy.logEnvironmentOverrides(envOverrides)
+
 	return c, nil
 }
 
+// DefaultConnectionTestTimeout is the default deadline of the connection
+// test enabled by WithConnectionTest.
+const DefaultConnectionTestTimeout = 5 * time.Second
+
+// testConnection checks that the API is reachable and accepts the client's
+// credentials. It returns an error only if the credentials are rejected;
+// other failures are logged.
+func (c *Client) testConnection() error {
+	timeout := c.rootConfig.ConnectionTestTimeout
+	if timeout == 0 {
+		timeout = DefaultConnectionTestTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	_, err := c.Health(ctx)
+	if err == nil {
+		_, err = c.Traces().List(ctx, &TracesListParams{
+			PaginationParams: PaginationParams{Limit: 1},
+		})
+	}
+	if err == nil {
+		c.connectionTestPassed.Store(true)
+		return nil
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && (apiErr.IsUnauthorized() || apiErr.IsForbidden()) {
+		return fmt.Errorf("langfuse: connection test failed: %w", err)
+	}
+
+	if c.rootConfig.StructuredLogger != nil {
+		c.rootConfig.StructuredLogger.Warn("connection test failed, continuing offline", "error", err)
+	} else if c.rootConfig.Logger != nil {
+		c.rootConfig.Logger.Printf("connection test failed, continuing offline: %v", err)
+	} else {
+		defaultStderrLogger.Printf("connection test failed, continuing offline: %v", err)
+	}
+	return nil
+}
+
+// GitRelease returns "branch@sha" for the Git checkout of the current working
+// directory, using git rev-parse. It returns an error if git is not installed
+// or the directory is not a Git repository.
+//
+// Example:
+//
+//	if release, err := langfuse.GitRelease(); err == nil {
+//	    trace.Release(release)
+//	}
+func GitRelease() (string, error) {
+	sha, err := gitRevParse("--short", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	branch, err := gitRevParse("--abbrev-ref", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return branch + "@" + sha, nil
+}
+
+// gitRevParse runs git rev-parse with args and returns its trimmed output.
+func gitRevParse(args ...string) (string, error) {
+	out, err := exec.Command("git", append([]string{"rev-parse"}, args...)...).Output()
+	if err != nil {
+		return "", fmt.Errorf("langfuse: git rev-parse %s failed: %w", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// SetReleaseFromGit sets the client release to the result of GitRelease.
+// Traces created afterwards use it unless they set their own release. If
+// git is unavailable or the working directory is not a repository, the
+// release is left unchanged and a debug message is logged.
+func (c *Client) SetReleaseFromGit() {
+	release, err := GitRelease()
+	if err != nil {
+		if c.rootConfig.StructuredLogger != nil {
+			c.rootConfig.StructuredLogger.Debug("skipping git release", "error", err)
+		} else if c.rootConfig.Logger != nil {
+			c.rootConfig.Logger.Printf("skipping git release: %v", err)
+		}
+		return
+	}
+	c.release.Store(release)
+}
+
+// Release returns the release applied to new traces, or an empty string.
+func (c *Client) Release() string {
+	release, _ := c.release.Load().(string)
+	return release
+}
+
 // convertToPkgClientConfig converts a root Config to a pkg/client.Config.
 // This maps all common fields and converts types where needed.
 func convertToPkgClientConfig(cfg *Config) *pkgclient.Config {
 	pkgCfg := &pkgclient.Config{
 		PublicKey:            cfg.PublicKey,
 		SecretKey:            cfg.SecretKey,
+		ProjectID:            cfg.ProjectID,
 		BaseURL:              cfg.BaseURL,
 		APIPathPrefix:        cfg.APIPathPrefix,
 		Region:               cfg.Region,
@@ -127,6 +283,8 @@ func convertToPkgClientConfig(cfg *Config) *pkgclient.Config {
 		BatchSize:            cfg.BatchSize,
 		FlushInterval:        cfg.FlushInterval,
 		Debug:                cfg.Debug,
+		DebugPrettyPrint:     cfg.DebugPrettyPrint,
+		DebugMaxBodySize:     cfg.DebugMaxBodySize,
 		ErrorHandler:         cfg.ErrorHandler,
 		MaxIdleConns:         cfg.MaxIdleConns,
 		MaxIdleConnsPerHost:  cfg.MaxIdleConnsPerHost,
@@ -134,10 +292,17 @@ func convertToPkgClientConfig(cfg *Config) *pkgclient.Config {
 		ShutdownTimeout:      cfg.ShutdownTimeout,
 		BatchQueueSize:       cfg.BatchQueueSize,
 		IdleWarningDuration:  cfg.IdleWarningDuration,
+		IdleTimeout:          cfg.IdleTimeout,
+		OnIdleShutdown:       cfg.OnIdleShutdown,
+		DisablePanicRecovery: cfg.DisablePanicRecovery,
 		IDGenerationMode:     pkgclient.IDGenerationMode(cfg.IDGenerationMode),
 		BlockOnQueueFull:     cfg.BlockOnQueueFull,
 		DropOnQueueFull:      cfg.DropOnQueueFull,
 		MaxBackgroundSenders: cfg.MaxBackgroundSenders,
+		FlushWorkers:         cfg.FlushWorkers,
+		LazyInitialization:   cfg.LazyInitialization,
+		MaxConcurrentFlushes: cfg.MaxConcurrentFlushes,
+		MaxFlushesPerSecond:  cfg.MaxFlushesPerSecond,
 	}
 
 	// Logger, StructuredLogger, and Metrics are type aliases to pkgclient versions,
@@ -166,12 +331,15 @@ func convertToPkgClientConfig(cfg *Config) *pkgclient.Config {
 	if cfg.OnBatchFlushed != nil {
 		pkgCfg.OnBatchFlushed = cfg.OnBatchFlushed
 	}
+	pkgCfg.OnShutdown = cfg.OnShutdown
+	pkgCfg.PreSendHook = cfg.PreSendHook
 
 	// HTTPHooks can be assigned directly since both root HTTPHook and pkgclient.HTTPHook
 	// are aliases to pkghttp.HTTPHook - they're the same type.
 	if len(cfg.HTTPHooks) > 0 {
 		pkgCfg.HTTPHooks = cfg.HTTPHooks
 	}
+	pkgCfg.HTTPMiddleware = cfg.HTTPMiddleware
 
 	// Convert BackpressureConfig
 	if cfg.BackpressureConfig != nil {
@@ -183,6 +351,11 @@ func convertToPkgClientConfig(cfg *Config) *pkgclient.Config {
 		pkgCfg.OnBackpressure = cfg.OnBackpressure
 	}
 
+	// Build the fallback endpoint config from the primary config.
+	if cfg.FallbackBaseURL != "" {
+		pkgCfg.Fallback = convertToPkgClientConfig(cfg.fallbackConfig())
+	}
+
 	return pkgCfg
 }
 
@@ -222,6 +395,37 @@ func (c *Client) Traces() *TracesClient {
 	return c.traces
 }
 
+// GetTraceChain fetches the trace and then follows the "parent_trace_id"
+// metadata links set by TraceBuilder.ParentTraceID. The returned chain starts
+// with the requested trace and ends with the root trace. At most 10 parent
+// links are followed, and the walk stops if a trace is seen twice.
+//
+// Example:
+//
+//	chain, err := client.GetTraceChain(ctx, traceID)
+//	for _, t := range chain {
+//	    fmt.Println(t.Name)
+//	}
+func (c *Client) GetTraceChain(ctx context.Context, traceID string) ([]*Trace, error) {
+	if traceID == "" {
+		return nil, NewValidationError("traceID", "trace ID cannot be empty")
+	}
+
+	var chain []*Trace
+	seen := make(map[string]bool)
+	for id := traceID; id != "" && !seen[id] && len(chain) <= maxTraceChainHops; {
+		trace, err := c.Traces().Get(ctx, id)
+		if err != nil {
+			return chain, fmt.Errorf("langfuse: failed to get trace %s in chain: %w", id, err)
+		}
+		seen[id] = true
+		chain = append(chain, trace)
+
+		id, _ = trace.Metadata[ParentTraceIDMetadataKey].(string)
+	}
+	return chain, nil
+}
+
 // Observations returns the observations sub-client.
 func (c *Client) Observations() *ObservationsClient {
 	return c.observations
@@ -372,6 +576,221 @@ func (c *Client) BackpressureLevel() BackpressureLevel {
 	return c.Client.BackpressureLevel()
 }
 
+// ============================================================================
+// Client Pool
+// ============================================================================
+
+// PoolOption configures a Pool.
+type PoolOption func(*Pool)
+
+// WithMaxPoolSize sets the maximum number of clients kept open by a Pool,
+// overriding the size passed to NewPool. A size of 0 means unlimited.
+func WithMaxPoolSize(n int) PoolOption {
+	return func(p *Pool) {
+		p.maxSize = n
+	}
+}
+
+// Pool manages one Client per project key, for multi-tenant applications
+// where each customer has its own Langfuse project or API keys. Clients are
+// created lazily by the factory and, when the pool is full, the least
+// recently used client is shut down and evicted.
+//
+// Each pooled Client has its own goroutines and event queue; this is not an
+// HTTP connection pool. Pool is safe for concurrent use.
+type Pool struct {
+	factory func(key string) (*Client, error)
+	maxSize int
+
+	mu      sync.Mutex
+	clients map[string]*list.Element
+	lru     *list.List // front is most recently used
+	closed  bool
+
+	evictWG   sync.WaitGroup
+	evictMu   sync.Mutex
+	evictErrs []error
+}
+
+// poolEntry is an element of Pool.lru.
+type poolEntry struct {
+	key    string
+	client *Client
+}
+
+// NewPool creates a pool that keeps at most size clients open, creating
+// them with factory on first use. A size of 0 means unlimited.
+//
+// When the pool is keyed by project ID, the factory should configure each
+// client with WithProjectID; Get then checks that the client it creates
+// belongs to the requested project.
+//
+// Example:
+//
+//	pool := langfuse.NewPool(100, func(projectID string) (*langfuse.Client, error) {
+//	    keys := lookupKeys(projectID)
+//	    return langfuse.New(keys.Public, keys.Secret, langfuse.WithProjectID(projectID))
+//	})
+//	defer pool.Shutdown(ctx)
+//
+//	client, err := pool.Get(projectID)
+func NewPool(size int, factory func(key string) (*Client, error), opts ...PoolOption) *Pool {
+	p := &Pool{
+		factory: factory,
+		maxSize: size,
+		clients: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Get returns the client for projectKey, creating it with the factory if it
+// is not in the pool. If the pool is full, the least recently used client is
+// shut down in the background to make room. Get returns ErrClientClosed
+// after Shutdown.
+//
+// The factory runs without holding the pool's lock, so a slow factory does
+// not block Gets for other keys. If concurrent Gets create a client for the
+// same key, the first one added to the pool is returned to all of them and
+// the others are shut down.
+//
+// If the factory returns a client configured with a project ID other than
+// projectKey, the client is shut down and Get returns an error.
+func (p *Pool) Get(projectKey string) (*Client, error) {
+	if client, ok, err := p.lookup(projectKey); ok || err != nil {
+		return client, err
+	}
+
+	client, err := p.factory(projectKey)
+	if err != nil {
+		return nil, fmt.Errorf("langfuse: pool: create client for %q: %w", projectKey, err)
+	}
+	if client == nil {
+		return nil, fmt.Errorf("langfuse: pool: factory returned nil client for %q", projectKey)
+	}
+	if projectID := client.rootConfig.ProjectID; projectID != "" && projectID != projectKey {
+		client.Shutdown(context.Background())
+		return nil, fmt.Errorf("langfuse: pool: client for %q is configured for project %q", projectKey, projectID)
+	}
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		client.Shutdown(context.Background())
+		return nil, ErrClientClosed
+	}
+	if elem, ok := p.clients[projectKey]; ok {
+		// Another Get added a client for this key while the factory ran
+		p.lru.MoveToFront(elem)
+		existing := elem.Value.(*poolEntry).client
+		p.mu.Unlock()
+		client.Shutdown(context.Background())
+		return existing, nil
+	}
+	for p.maxSize > 0 && p.lru.Len() >= p.maxSize {
+		p.evict(p.lru.Back())
+	}
+	p.clients[projectKey] = p.lru.PushFront(&poolEntry{key: projectKey, client: client})
+	p.mu.Unlock()
+	return client, nil
+}
+
+// lookup returns the pooled client for projectKey and marks it as most
+// recently used. It reports false if the key is not pooled, and returns
+// ErrClientClosed after Shutdown.
+func (p *Pool) lookup(projectKey string) (*Client, bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil, false, ErrClientClosed
+	}
+	elem, ok := p.clients[projectKey]
+	if !ok {
+		return nil, false, nil
+	}
+	p.lru.MoveToFront(elem)
+	return elem.Value.(*poolEntry).client, true, nil
+}
+
+// evict removes elem from the pool and shuts its client down in the
+// background. The caller must hold p.mu.
+func (p *Pool) evict(elem *list.Element) {
+	entry := p.lru.Remove(elem).(*poolEntry)
+	delete(p.clients, entry.key)
+
+	p.evictWG.Add(1)
+	go func() {
+		defer p.evictWG.Done()
+		if err := entry.client.Shutdown(context.Background()); err != nil && !errors.Is(err, ErrClientClosed) {
+			p.evictMu.Lock()
+			p.evictErrs = append(p.evictErrs, fmt.Errorf("langfuse: pool: shutdown %q: %w", entry.key, err))
+			p.evictMu.Unlock()
+		}
+	}()
+}
+
+// Len returns the number of clients currently in the pool.
+func (p *Pool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.lru.Len()
+}
+
+// Stats returns a stats snapshot for each pooled client, keyed by project key.
+func (p *Pool) Stats() map[string]ClientStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := make(map[string]ClientStats, len(p.clients))
+	for key, elem := range p.clients {
+		stats[key] = elem.Value.(*poolEntry).client.Stats()
+	}
+	return stats
+}
+
+// Shutdown shuts down all pooled clients concurrently and waits for clients
+// evicted earlier to finish shutting down. It returns the joined errors of
+// all shutdowns, or ErrClientClosed if the pool was already shut down.
+func (p *Pool) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return ErrClientClosed
+	}
+	p.closed = true
+	entries := make([]*poolEntry, 0, p.lru.Len())
+	for elem := p.lru.Front(); elem != nil; elem = elem.Next() {
+		entries = append(entries, elem.Value.(*poolEntry))
+	}
+	p.clients = make(map[string]*list.Element)
+	p.lru.Init()
+	p.mu.Unlock()
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(entries))
+	for i, entry := range entries {
+		wg.Add(1)
+		go func(i int, entry *poolEntry) {
+			defer wg.Done()
+			if err := entry.client.Shutdown(ctx); err != nil && !errors.Is(err, ErrClientClosed) {
+				errs[i] = fmt.Errorf("langfuse: pool: shutdown %q: %w", entry.key, err)
+			}
+		}(i, entry)
+	}
+	wg.Wait()
+	p.evictWG.Wait()
+
+	p.evictMu.Lock()
+	errs = append(errs, p.evictErrs...)
+	p.evictErrs = nil
+	p.evictMu.Unlock()
+	return errors.Join(errs...)
+}
+
 // ============================================================================
 // Client Interfaces
 // ============================================================================
@@ -712,6 +1131,33 @@ func (p *PaginationParams) ToQuery() url.Values {
 	return q
 }
 
+// Sort fields for TracesListParams.OrderBy.
+const (
+	TraceOrderByTimestamp = "timestamp"
+	TraceOrderByLatency   = "latency"
+	TraceOrderByName      = "name"
+)
+
+// OrderParams represents the sort order of a list request. It is sent as
+// the "orderBy" query parameter in the form "field.asc" or "field.desc".
+type OrderParams struct {
+	Field string
+	Desc  bool
+}
+
+// ToQuery converts order parameters to URL query values.
+func (o *OrderParams) ToQuery() url.Values {
+	q := url.Values{}
+	if o.Field != "" {
+		direction := "asc"
+		if o.Desc {
+			direction = "desc"
+		}
+		q.Set("orderBy", o.Field+"."+direction)
+	}
+	return q
+}
+
 // PaginatedResponse represents a paginated response.
 type PaginatedResponse struct {
 	Meta MetaResponse `json:"meta"`
@@ -1,11 +1,19 @@
 package langfuse
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -103,6 +111,108 @@ func TestClientHealth(t *testing.T) {
 	}
 }
 
+func TestClientTestConnectivity(t *testing.T) {
+	newServer := func(t *testing.T, readStatus int, ingestionErrors []map[string]any) (*httptest.Server, *[]map[string]any) {
+		var mu sync.Mutex
+		var batch []map[string]any
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/api/public/health":
+				json.NewEncoder(w).Encode(HealthStatus{Status: "ok"})
+			case "/api/public/traces":
+				if r.URL.Query().Get("limit") != "1" {
+					t.Errorf("traces limit = %q, want 1", r.URL.Query().Get("limit"))
+				}
+				w.WriteHeader(readStatus)
+				json.NewEncoder(w).Encode(map[string]any{"data": []any{}, "meta": map[string]any{}})
+			case "/api/public/ingestion":
+				var req struct {
+					Batch []map[string]any `json:"batch"`
+				}
+				json.NewDecoder(r.Body).Decode(&req)
+				mu.Lock()
+				batch = append(batch, req.Batch...)
+				mu.Unlock()
+				w.WriteHeader(http.StatusMultiStatus)
+				json.NewEncoder(w).Encode(map[string]any{"successes": []any{}, "errors": ingestionErrors})
+			default:
+				t.Errorf("unexpected path %s", r.URL.Path)
+			}
+		}))
+		t.Cleanup(server.Close)
+		return server, &batch
+	}
+
+	t.Run("all checks pass", func(t *testing.T) {
+		server, batch := newServer(t, http.StatusOK, nil)
+		client, err := New("pk-lf-test-key", "sk-lf-test-key", WithBaseURL(server.URL))
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		defer client.Shutdown(context.Background())
+
+		report, err := client.TestConnectivity(context.Background())
+		if err != nil {
+			t.Fatalf("TestConnectivity failed: %v", err)
+		}
+		if !report.OK() || !report.APIReachable || !report.ReadPermission || !report.WritePermission {
+			t.Errorf("report = %+v, want all checks passed", report)
+		}
+		if len(report.Errors) != 0 {
+			t.Errorf("Errors = %v, want none", report.Errors)
+		}
+		for _, check := range []string{ConnectivityCheckHealth, ConnectivityCheckRead, ConnectivityCheckWrite} {
+			if _, ok := report.Latency[check]; !ok {
+				t.Errorf("Latency missing %q", check)
+			}
+		}
+
+		if len(*batch) != 1 {
+			t.Fatalf("ingested %d events, want 1", len(*batch))
+		}
+		event := (*batch)[0]
+		if event["type"] != "trace-create" {
+			t.Errorf("type = %v, want trace-create", event["type"])
+		}
+		metadata, _ := event["body"].(map[string]any)["metadata"].(map[string]any)
+		if metadata["_test"] != true {
+			t.Errorf("metadata = %v, want _test=true", metadata)
+		}
+	})
+
+	t.Run("failed checks are reported", func(t *testing.T) {
+		server, _ := newServer(t, http.StatusForbidden, []map[string]any{
+			{"id": "x", "status": 403, "message": "forbidden"},
+		})
+		client, err := New("pk-lf-test-key", "sk-lf-test-key",
+			WithBaseURL(server.URL),
+			WithMaxRetries(0),
+		)
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		defer client.Shutdown(context.Background())
+
+		report, err := client.TestConnectivity(context.Background())
+		if err == nil {
+			t.Fatal("TestConnectivity should fail")
+		}
+		if !report.APIReachable {
+			t.Error("APIReachable should be true")
+		}
+		if report.ReadPermission || report.WritePermission {
+			t.Errorf("report = %+v, want read and write to fail", report)
+		}
+		if report.Errors[ConnectivityCheckRead] == nil || report.Errors[ConnectivityCheckWrite] == nil {
+			t.Errorf("Errors = %v, want read and write errors", report.Errors)
+		}
+		if report.Errors[ConnectivityCheckHealth] != nil {
+			t.Errorf("unexpected health error: %v", report.Errors[ConnectivityCheckHealth])
+		}
+	})
+}
+
 func TestClientSubClients(t *testing.T) {
 	client, err := New("pk-lf-test-key", "sk-lf-test-key")
 	if err != nil {
@@ -574,6 +684,87 @@ func TestHandleErrorWithStructuredLogger(t *testing.T) {
 	}
 }
 
+// recordingHandler is a slog.Handler that records every record with the
+// value of slogTestKey in its context.
+type recordingHandler struct {
+	mu      sync.Mutex
+	records []slog.Record
+	ctxVals []any
+}
+
+type slogTestKey struct{}
+
+func (h *recordingHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *recordingHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = append(h.records, r)
+	h.ctxVals = append(h.ctxVals, ctx.Value(slogTestKey{}))
+	return nil
+}
+
+func (h *recordingHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+func (h *recordingHandler) WithGroup(string) slog.Handler      { return h }
+
+func TestWithSlogLogger(t *testing.T) {
+	handler := &recordingHandler{}
+	client, err := New("pk-lf-test-key", "sk-lf-test-key", WithSlogLogger(slog.New(handler)))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	client.HandleError(NewValidationError("test", "test error"))
+
+	handler.mu.Lock()
+	var found bool
+	for _, r := range handler.records {
+		if r.Level == slog.LevelError && r.Message == "async error" {
+			r.Attrs(func(a slog.Attr) bool {
+				found = found || a.Key == "error"
+				return true
+			})
+		}
+	}
+	handler.mu.Unlock()
+	if !found {
+		t.Error("expected an error-level async error record with an error attribute")
+	}
+
+	t.Run("levels and context", func(t *testing.T) {
+		handler := &recordingHandler{}
+		ctx := context.WithValue(context.Background(), slogTestKey{}, "trace-123")
+		adapter := NewSlogAdapter(slog.New(handler)).WithContext(ctx)
+
+		adapter.Debug("d", "k", 1)
+		adapter.Info("i")
+		adapter.Warn("w")
+		adapter.Error("e")
+
+		want := []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError}
+		if len(handler.records) != len(want) {
+			t.Fatalf("got %d records, want %d", len(handler.records), len(want))
+		}
+		for i, r := range handler.records {
+			if r.Level != want[i] {
+				t.Errorf("record %d level = %v, want %v", i, r.Level, want[i])
+			}
+			if handler.ctxVals[i] != "trace-123" {
+				t.Errorf("record %d context value = %v, want trace-123", i, handler.ctxVals[i])
+			}
+		}
+		var attrs []slog.Attr
+		handler.records[0].Attrs(func(a slog.Attr) bool {
+			attrs = append(attrs, a)
+			return true
+		})
+		if len(attrs) != 1 || attrs[0].Key != "k" || attrs[0].Value.Int64() != 1 {
+			t.Errorf("debug record attrs = %v, want k=1", attrs)
+		}
+	})
+}
+
 func TestHandleErrorWithErrorHandler(t *testing.T) {
 	var capturedErr error
 	var mu sync.Mutex
@@ -639,9 +830,10 @@ func TestHandleErrorWithMetrics(t *testing.T) {
 
 // testMetrics implements Metrics for testing
 type testMetrics struct {
-	mu       sync.Mutex
-	counters map[string]int64
-	gauges   map[string]float64
+	mu        sync.Mutex
+	counters  map[string]int64
+	gauges    map[string]float64
+	durations map[string]int
 }
 
 // Compile-time interface assertion
@@ -656,7 +848,14 @@ func (m *testMetrics) IncrementCounter(name string, value int64) {
 	m.counters[name] += value
 }
 
-func (m *testMetrics) RecordDuration(name string, duration time.Duration) {}
+func (m *testMetrics) RecordDuration(name string, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.durations == nil {
+		m.durations = make(map[string]int)
+	}
+	m.durations[name]++
+}
 
 func (m *testMetrics) SetGauge(name string, value float64) {
 	m.mu.Lock()
@@ -677,6 +876,27 @@ func (m *testMetrics) Counters() map[string]int64 {
 	return result
 }
 
+func (m *testMetrics) Gauges() map[string]float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result := make(map[string]float64)
+	for k, v := range m.gauges {
+		result[k] = v
+	}
+	return result
+}
+
+// Durations returns the number of durations recorded for each metric.
+func (m *testMetrics) Durations() map[string]int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result := make(map[string]int)
+	for k, v := range m.durations {
+		result[k] = v
+	}
+	return result
+}
+
 func TestHandleQueueFull(t *testing.T) {
 	var receivedBatches int
 	var mu sync.Mutex
@@ -1171,6 +1391,296 @@ func TestShutdownDrainsAllEvents(t *testing.T) {
 	}
 }
 
+func TestShutdownCallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	var calls int
+	var summary ShutdownSummary
+	client, err := New(
+		"pk-lf-test-key",
+		"sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithBatchSize(10),
+		WithFlushInterval(1*time.Hour),
+		WithShutdownCallback(func(s ShutdownSummary) {
+			calls++
+			summary = s
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	ctx := context.Background()
+	for i := 0; i < 20; i++ {
+		if _, err := client.NewTrace().Name("test-trace").Create(ctx); err != nil {
+			t.Fatalf("Create trace failed: %v", err)
+		}
+	}
+
+	if err := client.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	client.Shutdown(ctx)
+
+	if calls != 1 {
+		t.Fatalf("callback called %d times, want 1", calls)
+	}
+	if summary.TotalEventsSent != 20 {
+		t.Errorf("TotalEventsSent = %d, want 20", summary.TotalEventsSent)
+	}
+	if summary.BatchesSent != 2 {
+		t.Errorf("BatchesSent = %d, want 2", summary.BatchesSent)
+	}
+	if summary.EventsDropped != 0 {
+		t.Errorf("EventsDropped = %d, want 0", summary.EventsDropped)
+	}
+	if !summary.DrainedSuccessfully {
+		t.Error("DrainedSuccessfully should be true")
+	}
+	if summary.Error != nil {
+		t.Errorf("Error = %v, want nil", summary.Error)
+	}
+	if summary.ShutdownDuration <= 0 {
+		t.Error("ShutdownDuration should be positive")
+	}
+}
+
+func TestIdleTimeoutShutsDownClient(t *testing.T) {
+	var received atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Batch []json.RawMessage `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		received.Add(int64(len(req.Batch)))
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	idle := make(chan struct{})
+	client, err := New(
+		"pk-lf-test-key",
+		"sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(1*time.Hour),
+		WithIdleTimeout(50*time.Millisecond),
+		WithOnIdleShutdown(func() { close(idle) }),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := client.NewTrace().Name("before-idle").Create(ctx); err != nil {
+		t.Fatalf("Create trace failed: %v", err)
+	}
+
+	select {
+	case <-idle:
+	case <-time.After(2 * time.Second):
+		t.Fatal("idle shutdown was not triggered")
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for client.State() != ClientStateClosed && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if client.State() != ClientStateClosed {
+		t.Fatalf("State() = %v, want closed", client.State())
+	}
+	if received.Load() != 1 {
+		t.Errorf("received %d events, want the pending trace to be flushed", received.Load())
+	}
+	if _, err := client.NewTrace().Name("after-idle").Create(ctx); err == nil {
+		t.Error("Create after idle shutdown should fail")
+	}
+}
+
+func TestIdleTimeoutStoppedByShutdown(t *testing.T) {
+	var idleShutdowns atomic.Int32
+	client, err := New(
+		"pk-lf-test-key",
+		"sk-lf-test-key",
+		WithBaseURL("http://localhost:9999"),
+		WithIdleTimeout(50*time.Millisecond),
+		WithOnIdleShutdown(func() { idleShutdowns.Add(1) }),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	if err := client.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+	if idleShutdowns.Load() != 0 {
+		t.Error("idle shutdown should not trigger after Shutdown")
+	}
+}
+
+func TestIdleTimeoutLazyInitialization(t *testing.T) {
+	idle := make(chan struct{})
+	client, err := New(
+		"pk-lf-test-key",
+		"sk-lf-test-key",
+		WithBaseURL("http://localhost:9999"),
+		WithLazyInitialization(),
+		WithIdleTimeout(50*time.Millisecond),
+		WithOnIdleShutdown(func() { close(idle) }),
+		WithStatsHistoryConfig(StatsHistoryConfig{Interval: time.Hour}),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	time.Sleep(150 * time.Millisecond)
+	if !client.IsActive() {
+		t.Fatal("idle shutdown triggered before the client was initialized")
+	}
+
+	if _, err := client.NewTrace().Name("first").Create(context.Background()); err != nil {
+		t.Fatalf("Create trace failed: %v", err)
+	}
+	select {
+	case <-idle:
+	case <-time.After(2 * time.Second):
+		t.Fatal("idle shutdown was not triggered after initialization")
+	}
+
+	// Idle shutdown runs the same shutdown hooks as Shutdown.
+	select {
+	case <-client.statsHistory.stop:
+	case <-time.After(2 * time.Second):
+		t.Error("stats history was not stopped by idle shutdown")
+	}
+}
+
+func TestIdleTimeoutStopsAutoEnds(t *testing.T) {
+	idle := make(chan struct{})
+	client, err := New(
+		"pk-lf-test-key",
+		"sk-lf-test-key",
+		WithBaseURL("http://localhost:9999"),
+		WithFlushInterval(1*time.Hour),
+		WithIdleTimeout(50*time.Millisecond),
+		WithOnIdleShutdown(func() { close(idle) }),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	if _, err := client.NewTrace().Name("pending").AutoEnd(time.Hour).Create(context.Background()); err != nil {
+		t.Fatalf("Create trace failed: %v", err)
+	}
+	select {
+	case <-idle:
+	case <-time.After(2 * time.Second):
+		t.Fatal("idle shutdown was not triggered")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		client.autoEndWG.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("auto-end goroutine was not stopped by idle shutdown")
+	}
+}
+
+func TestConnectionTest(t *testing.T) {
+	newServer := func(status int) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if r.URL.Path == "/api/public/traces" && status != http.StatusOK {
+				w.WriteHeader(status)
+				json.NewEncoder(w).Encode(map[string]string{"message": "invalid credentials"})
+				return
+			}
+			w.Write([]byte(`{"status":"OK","data":[]}`))
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+
+	t.Run("passes", func(t *testing.T) {
+		client, err := New("pk-lf-test-key", "sk-lf-test-key",
+			WithBaseURL(newServer(http.StatusOK).URL),
+			WithConnectionTest(true),
+		)
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		defer client.Shutdown(context.Background())
+		if !client.Stats().InitialConnectivityTestPassed {
+			t.Error("InitialConnectivityTestPassed should be true")
+		}
+	})
+
+	t.Run("rejected credentials", func(t *testing.T) {
+		client, err := New("pk-lf-test-key", "sk-lf-test-key",
+			WithBaseURL(newServer(http.StatusUnauthorized).URL),
+			WithConnectionTest(true),
+		)
+		if err == nil {
+			client.Shutdown(context.Background())
+			t.Fatal("New should fail when the credentials are rejected")
+		}
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || !apiErr.IsUnauthorized() {
+			t.Errorf("error = %v, want an unauthorized APIError", err)
+		}
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		server := newServer(http.StatusOK)
+		server.Close()
+
+		logger := &testLogger{}
+		client, err := New("pk-lf-test-key", "sk-lf-test-key",
+			WithBaseURL(server.URL),
+			WithConnectionTest(true),
+			WithConnectionTestTimeout(500*time.Millisecond),
+			WithMaxRetries(0),
+			WithLogger(logger),
+		)
+		if err != nil {
+			t.Fatalf("New should succeed when the API is unreachable: %v", err)
+		}
+		defer client.Shutdown(context.Background())
+		if client.Stats().InitialConnectivityTestPassed {
+			t.Error("InitialConnectivityTestPassed should be false")
+		}
+		if len(logger.Messages()) == 0 {
+			t.Error("expected a warning to be logged")
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		client, err := New("pk-lf-test-key", "sk-lf-test-key",
+			WithBaseURL(newServer(http.StatusUnauthorized).URL),
+		)
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		defer client.Shutdown(context.Background())
+		if client.Stats().InitialConnectivityTestPassed {
+			t.Error("InitialConnectivityTestPassed should be false without a connection test")
+		}
+	})
+}
+
 // TestShutdownUnderConcurrentLoad tests that shutdown properly drains events
 // even when events are being created concurrently.
 func TestShutdownUnderConcurrentLoad(t *testing.T) {
@@ -1613,3 +2123,1750 @@ func TestClient_HandleQueueFull(t *testing.T) {
 		t.Error("expected queue full/overflow condition to be detected")
 	}
 }
+
+func TestWithEnvironmentVariableOverrides(t *testing.T) {
+	t.Setenv(EnvBatchSize, "250")
+	t.Setenv(EnvFlushIntervalMs, "1500")
+	t.Setenv(EnvMaxRetries, "7")
+	t.Setenv(EnvShutdownTimeoutMs, "60000")
+	t.Setenv(EnvQueueSize, "300")
+
+	logger := &testLogger{}
+	client, err := New(
+		"pk-lf-test-key",
+		"sk-lf-test-key",
+		WithLogger(logger),
+		WithBatchSize(50),
+		WithMaxRetries(2),
+		WithEnvironmentVariableOverrides(),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	cfg := client.RootConfig()
+	if cfg.BatchSize != 250 {
+		t.Errorf("BatchSize = %d, want 250", cfg.BatchSize)
+	}
+	if cfg.FlushInterval != 1500*time.Millisecond {
+		t.Errorf("FlushInterval = %v, want 1.5s", cfg.FlushInterval)
+	}
+	if cfg.MaxRetries != 7 {
+		t.Errorf("MaxRetries = %d, want 7", cfg.MaxRetries)
+	}
+	if cfg.ShutdownTimeout != time.Minute {
+		t.Errorf("ShutdownTimeout = %v, want 1m", cfg.ShutdownTimeout)
+	}
+	if cfg.BatchQueueSize != 300 {
+		t.Errorf("BatchQueueSize = %d, want 300", cfg.BatchQueueSize)
+	}
+
+	// Only BatchSize and MaxRetries were configured in code.
+	var logged int
+	for _, msg := range logger.Messages() {
+		if strings.Contains(msg, "overrides") {
+			logged++
+		}
+	}
+	if logged != 2 {
+		t.Errorf("logged %d overrides, want 2", logged)
+	}
+}
+
+func TestWithEnvironmentVariableOverridesDisabled(t *testing.T) {
+	t.Setenv(EnvBatchSize, "250")
+
+	client, err := New("pk-lf-test-key", "sk-lf-test-key", WithBatchSize(50))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	if got := client.RootConfig().BatchSize; got != 50 {
+		t.Errorf("BatchSize = %d, want 50 without overrides enabled", got)
+	}
+}
+
+func TestWithEnvironmentVariableOverridesInvalid(t *testing.T) {
+	t.Setenv(EnvQueueSize, "lots")
+
+	_, err := New("pk-lf-test-key", "sk-lf-test-key", WithEnvironmentVariableOverrides())
+	if err == nil {
+		t.Fatal("expected error for invalid LANGFUSE_QUEUE_SIZE")
+	}
+	if !strings.Contains(err.Error(), EnvQueueSize) {
+		t.Errorf("error should name the variable, got: %v", err)
+	}
+}
+
+func TestClientDebug(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{
+			Successes: []IngestionSuccess{{ID: "1", Status: 200}},
+		})
+	}))
+	defer server.Close()
+
+	secret := "sk-lf-debug-secret-key"
+	client, err := New(
+		"pk-lf-test-key",
+		secret,
+		WithBaseURL(server.URL),
+		WithBatchQueueSize(7),
+		WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	client.NewTrace().Name("debug-1").Create(ctx)
+	client.NewTrace().Name("debug-2").Create(ctx)
+
+	info := client.Debug()
+	if strings.Contains(info.Config.SecretKey, secret) {
+		t.Errorf("secret key not redacted: %q", info.Config.SecretKey)
+	}
+	if info.BatchQueueCap != 7 {
+		t.Errorf("BatchQueueCap = %d, want 7", info.BatchQueueCap)
+	}
+	if info.PendingEvents != 2 {
+		t.Errorf("PendingEvents = %d, want 2", info.PendingEvents)
+	}
+	if !info.LastFlushTime.IsZero() {
+		t.Errorf("LastFlushTime = %v, want zero before first flush", info.LastFlushTime)
+	}
+	if info.GoRoutineCount == 0 {
+		t.Error("GoRoutineCount should be non-zero")
+	}
+
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	info = client.Debug()
+	if info.LastFlushTime.IsZero() {
+		t.Error("LastFlushTime should be set after Flush")
+	}
+	if info.PendingEvents != 0 {
+		t.Errorf("PendingEvents = %d, want 0 after Flush", info.PendingEvents)
+	}
+
+	out := info.String()
+	for _, want := range []string{"pending events", "batch queue", "circuit breaker", "goroutines", info.Config.SecretKey} {
+		if !strings.Contains(out, want) {
+			t.Errorf("String() missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, secret) {
+		t.Error("String() leaked the secret key")
+	}
+}
+
+func TestWithFallbackBaseURL(t *testing.T) {
+	var primaryHits, fallbackHits atomic.Int32
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		primaryHits.Add(1)
+		if r.URL.Path == "/api/public/health" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(HealthStatus{Status: "OK"})
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer primary.Close()
+
+	var fallbackAuth string
+	var mu sync.Mutex
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fallbackHits.Add(1)
+		mu.Lock()
+		fallbackAuth = r.Header.Get("Authorization")
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{
+			Successes: []IngestionSuccess{{ID: "1", Status: 200}},
+		})
+	}))
+	defer fallback.Close()
+
+	metrics := &testMetrics{}
+	client, err := New(
+		"pk-lf-test-key",
+		"sk-lf-test-key",
+		WithBaseURL(primary.URL),
+		WithRetryDelay(time.Millisecond),
+		WithFlushInterval(1*time.Hour),
+		WithMetrics(metrics),
+		WithFallbackBaseURL(fallback.URL),
+		WithFallbackOptions(func(c *Config) {
+			c.PublicKey = "pk-lf-backup-key"
+			c.SecretKey = "sk-lf-backup-key"
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := client.Health(ctx); err != nil {
+		t.Fatalf("Health failed: %v", err)
+	}
+	if fallbackHits.Load() != 0 {
+		t.Error("Health should not use the fallback endpoint")
+	}
+
+	client.NewTrace().Name("fallback").Create(ctx)
+	if err := client.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	if primaryHits.Load() < 2 {
+		t.Errorf("primary hits = %d, expected the batch to be tried against the primary first", primaryHits.Load())
+	}
+	if fallbackHits.Load() != 1 {
+		t.Errorf("fallback hits = %d, want 1", fallbackHits.Load())
+	}
+	if got := metrics.Counters()["langfuse.fallback.used"]; got != 1 {
+		t.Errorf("langfuse.fallback.used = %d, want 1", got)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	wantAuth := "Basic " + base64.StdEncoding.EncodeToString([]byte("pk-lf-backup-key:sk-lf-backup-key"))
+	if fallbackAuth != wantAuth {
+		t.Errorf("fallback Authorization = %q, want backup credentials", fallbackAuth)
+	}
+}
+
+func TestWithFallbackBaseURLSkipsClientErrors(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer primary.Close()
+
+	var fallbackHits atomic.Int32
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fallbackHits.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer fallback.Close()
+
+	client, err := New(
+		"pk-lf-test-key",
+		"sk-lf-test-key",
+		WithBaseURL(primary.URL),
+		WithFlushInterval(1*time.Hour),
+		WithFallbackBaseURL(fallback.URL),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	ctx := context.Background()
+	client.NewTrace().Name("unauthorized").Create(ctx)
+	client.Shutdown(ctx)
+
+	if fallbackHits.Load() != 0 {
+		t.Errorf("fallback hits = %d, want 0 for a 4xx response", fallbackHits.Load())
+	}
+}
+
+func TestTraceBuilderParentTraceID(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Batch []struct {
+				Body map[string]any `json:"body"`
+			} `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		for _, e := range req.Batch {
+			bodies = append(bodies, e.Body)
+		}
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	client, err := New("pk-lf-test-key", "sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	if _, err := client.NewTrace().ParentTraceID("").Create(ctx); err == nil {
+		t.Error("expected error for empty parent trace ID")
+	}
+
+	// Metadata and Tags set after ParentTraceID must not drop the link.
+	trace, err := client.NewTrace().
+		Name("worker").
+		ParentTraceID("upstream-trace").
+		Metadata(Metadata{"queue": "billing"}).
+		Tags([]string{"worker"}).
+		Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if trace.ParentTraceID() != "upstream-trace" {
+		t.Errorf("ParentTraceID() = %q, want upstream-trace", trace.ParentTraceID())
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(bodies) != 1 {
+		t.Fatalf("received %d events, want 1", len(bodies))
+	}
+	metadata, _ := bodies[0]["metadata"].(map[string]any)
+	if metadata[ParentTraceIDMetadataKey] != "upstream-trace" || metadata["queue"] != "billing" {
+		t.Errorf("metadata = %v", metadata)
+	}
+	tags, _ := bodies[0]["tags"].([]any)
+	if len(tags) != 2 || tags[1] != ParentTraceTag {
+		t.Errorf("tags = %v, want [worker %s]", tags, ParentTraceTag)
+	}
+}
+
+func TestClientGetTraceChain(t *testing.T) {
+	parents := map[string]string{
+		"c": "b",
+		"b": "a",
+		"a": "",
+		"x": "y",
+		"y": "x",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/api/public/traces/")
+		parent, ok := parents[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		trace := Trace{ID: id, Metadata: Metadata{}}
+		if parent != "" {
+			trace.Metadata[ParentTraceIDMetadataKey] = parent
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(trace)
+	}))
+	defer server.Close()
+
+	client, err := New("pk-lf-test-key", "sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithMaxRetries(1),
+		WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	chain, err := client.GetTraceChain(ctx, "c")
+	if err != nil {
+		t.Fatalf("GetTraceChain failed: %v", err)
+	}
+	var ids []string
+	for _, tr := range chain {
+		ids = append(ids, tr.ID)
+	}
+	if strings.Join(ids, ",") != "c,b,a" {
+		t.Errorf("chain = %v, want [c b a]", ids)
+	}
+
+	cycle, err := client.GetTraceChain(ctx, "x")
+	if err != nil {
+		t.Fatalf("GetTraceChain with cycle failed: %v", err)
+	}
+	if len(cycle) != 2 {
+		t.Errorf("cyclic chain length = %d, want 2", len(cycle))
+	}
+
+	if _, err := client.GetTraceChain(ctx, ""); err == nil {
+		t.Error("expected error for empty trace ID")
+	}
+}
+
+func TestClientListenErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client, err := New(
+		"pk-lf-test-key",
+		"sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(1*time.Hour),
+		WithBatchSize(1),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	listenCtx, cancel := context.WithCancel(context.Background())
+	first := client.ListenErrors(listenCtx, 4)
+	second := client.ListenErrors(listenCtx, 4)
+
+	ctx := context.Background()
+	client.NewTrace().Name("unauthorized").Create(ctx)
+
+	for i, ch := range []<-chan error{first, second} {
+		select {
+		case err := <-ch:
+			asyncErr, ok := AsAsyncError(err)
+			if !ok {
+				t.Fatalf("listener %d received %T, want *AsyncError", i, err)
+			}
+			if asyncErr.Operation != AsyncOpBatchSend {
+				t.Errorf("listener %d operation = %q, want %q", i, asyncErr.Operation, AsyncOpBatchSend)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("listener %d did not receive an error", i)
+		}
+	}
+
+	cancel()
+	select {
+	case _, ok := <-first:
+		if ok {
+			t.Error("expected channel to be closed after cancel")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("channel was not closed after cancel")
+	}
+}
+
+// formattingLogger records fully formatted messages and can report debug
+// output as disabled.
+type formattingLogger struct {
+	mu       sync.Mutex
+	messages []string
+	disabled bool
+}
+
+func (l *formattingLogger) Printf(format string, v ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.messages = append(l.messages, fmt.Sprintf(format, v...))
+}
+
+func (l *formattingLogger) IsDebugEnabled() bool { return !l.disabled }
+
+func (l *formattingLogger) bodyMessages() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var out []string
+	for _, m := range l.messages {
+		if strings.HasPrefix(m, "http ") {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func TestWithDebugPrettyPrint(t *testing.T) {
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	newClient := func(logger Logger, opts ...ConfigOption) *Client {
+		opts = append([]ConfigOption{
+			WithBaseURL(server.URL),
+			WithFlushInterval(1 * time.Hour),
+			WithDebug(true),
+			WithLogger(logger),
+		}, opts...)
+		client, err := New("pk-lf-test-key", "sk-lf-test-key", opts...)
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		return client
+	}
+	ctx := context.Background()
+
+	t.Run("pretty print", func(t *testing.T) {
+		logger := &formattingLogger{}
+		client := newClient(logger, WithDebugPrettyPrint(true))
+		defer client.Shutdown(ctx)
+
+		client.NewTrace().Name("pretty").Create(ctx)
+		if err := client.Flush(ctx); err != nil {
+			t.Fatalf("Flush failed: %v", err)
+		}
+
+		msgs := logger.bodyMessages()
+		if len(msgs) != 2 {
+			t.Fatalf("logged %d body messages, want 2: %v", len(msgs), msgs)
+		}
+		if !strings.Contains(msgs[0], "POST /ingestion request") || !strings.Contains(msgs[0], "\n  \"batch\"") {
+			t.Errorf("request body not pretty-printed: %s", msgs[0])
+		}
+		if !strings.Contains(msgs[1], "response (status=200)") {
+			t.Errorf("response body not logged: %s", msgs[1])
+		}
+		if bytes.Contains(received, []byte("\n")) {
+			t.Error("transmitted payload should not be indented")
+		}
+	})
+
+	t.Run("max body size", func(t *testing.T) {
+		logger := &formattingLogger{}
+		client := newClient(logger, WithDebugMaxBodySize(16))
+		defer client.Shutdown(ctx)
+
+		client.NewTrace().Name("truncated").Create(ctx)
+		client.Flush(ctx)
+
+		msgs := logger.bodyMessages()
+		if len(msgs) == 0 || !strings.Contains(msgs[0], "... (truncated,") {
+			t.Errorf("request body not truncated: %v", msgs)
+		}
+	})
+
+	t.Run("debug disabled", func(t *testing.T) {
+		logger := &formattingLogger{disabled: true}
+		client := newClient(logger, WithDebugPrettyPrint(true))
+		defer client.Shutdown(ctx)
+
+		client.NewTrace().Name("quiet").Create(ctx)
+		client.Flush(ctx)
+
+		if msgs := logger.bodyMessages(); len(msgs) != 0 {
+			t.Errorf("logged %d body messages, want 0", len(msgs))
+		}
+	})
+
+	t.Run("negative max body size", func(t *testing.T) {
+		if _, err := New("pk-lf-test-key", "sk-lf-test-key", WithDebugMaxBodySize(-1)); err == nil {
+			t.Error("expected error for negative debug max body size")
+		}
+	})
+}
+
+func TestFlushLoopReportsBatchGauges(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	metrics := &testMetrics{}
+	client, err := New(
+		"pk-lf-test-key",
+		"sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(100*time.Millisecond),
+		WithBatchSize(25),
+		WithMetrics(metrics),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, ok := metrics.Gauges()["langfuse.queue.batch_queue_len"]; ok {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	gauges := metrics.Gauges()
+	if gauges["langfuse.batch.size_config"] != 25 {
+		t.Errorf("langfuse.batch.size_config = %v, want 25", gauges["langfuse.batch.size_config"])
+	}
+	for _, name := range []string{"langfuse.queue.pending_events", "langfuse.queue.batch_queue_len"} {
+		if _, ok := gauges[name]; !ok {
+			t.Errorf("gauge %s was not reported", name)
+		}
+	}
+}
+
+func TestClientRelease(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Batch []struct {
+				Body map[string]any `json:"body"`
+			} `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		for _, e := range req.Batch {
+			bodies = append(bodies, e.Body)
+		}
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	client, err := New(
+		"pk-lf-test-key",
+		"sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(1*time.Hour),
+		WithDefaultRelease("v1.2.3"),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	client.NewTrace().Name("default").Create(ctx)
+	client.NewTrace().Name("explicit").Release("v9").Create(ctx)
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(bodies) != 2 {
+		t.Fatalf("received %d events, want 2", len(bodies))
+	}
+	if bodies[0]["release"] != "v1.2.3" {
+		t.Errorf("default release = %v, want v1.2.3", bodies[0]["release"])
+	}
+	if bodies[1]["release"] != "v9" {
+		t.Errorf("explicit release = %v, want v9", bodies[1]["release"])
+	}
+}
+
+func TestGitRelease(t *testing.T) {
+	release, err := GitRelease()
+	if err != nil {
+		t.Skipf("git not available: %v", err)
+	}
+
+	branch, sha, ok := strings.Cut(release, "@")
+	if !ok || branch == "" || sha == "" {
+		t.Fatalf("GitRelease() = %q, want branch@sha", release)
+	}
+
+	client, err := New("pk-lf-test-key", "sk-lf-test-key", WithGitRelease())
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	if client.Release() != release {
+		t.Errorf("Release() = %q, want %q", client.Release(), release)
+	}
+}
+
+func TestPool(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	created := map[string]int{}
+	pool := NewPool(10, func(key string) (*Client, error) {
+		if key == "bad" {
+			return nil, errors.New("unknown tenant")
+		}
+		created[key]++
+		return New("pk-lf-test-"+key, "sk-lf-test-"+key, WithBaseURL(server.URL), WithFlushInterval(1*time.Hour))
+	}, WithMaxPoolSize(2))
+
+	a, err := pool.Get("a")
+	if err != nil {
+		t.Fatalf("Get(a) failed: %v", err)
+	}
+	if again, _ := pool.Get("a"); again != a {
+		t.Error("Get should return the pooled client")
+	}
+	if _, err := pool.Get("b"); err != nil {
+		t.Fatalf("Get(b) failed: %v", err)
+	}
+
+	// Touch a so that b is the least recently used when c is added.
+	pool.Get("a")
+	if _, err := pool.Get("c"); err != nil {
+		t.Fatalf("Get(c) failed: %v", err)
+	}
+	if pool.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", pool.Len())
+	}
+	stats := pool.Stats()
+	if _, ok := stats["b"]; ok {
+		t.Error("least recently used client b should have been evicted")
+	}
+	if _, ok := stats["a"]; !ok {
+		t.Error("client a should still be pooled")
+	}
+
+	if _, err := pool.Get("b"); err != nil {
+		t.Fatalf("Get(b) after eviction failed: %v", err)
+	}
+	if created["b"] != 2 || created["a"] != 1 {
+		t.Errorf("factory calls = %v, want a:1 b:2", created)
+	}
+
+	if _, err := pool.Get("bad"); err == nil {
+		t.Error("expected factory error")
+	}
+
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	if _, err := pool.Get("a"); err != ErrClientClosed {
+		t.Errorf("Get after Shutdown = %v, want ErrClientClosed", err)
+	}
+	if err := a.Shutdown(context.Background()); err != ErrClientClosed {
+		t.Errorf("pooled client should be shut down, got %v", err)
+	}
+	if err := pool.Shutdown(context.Background()); err != ErrClientClosed {
+		t.Errorf("second Shutdown = %v, want ErrClientClosed", err)
+	}
+}
+
+// labeledMetrics records labeled counter calls.
+type labeledMetrics struct {
+	testMetrics
+	labels map[string]map[string]string
+}
+
+func (m *labeledMetrics) IncrementCounterWithLabels(name string, value int64, labels map[string]string) {
+	m.IncrementCounter(name, value)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.labels == nil {
+		m.labels = make(map[string]map[string]string)
+	}
+	m.labels[name] = labels
+}
+
+func (m *labeledMetrics) RecordDurationWithLabels(name string, duration time.Duration, labels map[string]string) {
+}
+
+func (m *labeledMetrics) SetGaugeWithLabels(name string, value float64, labels map[string]string) {
+	m.SetGauge(name, value)
+}
+
+func TestWithMetricsPrefix(t *testing.T) {
+	metrics := &testMetrics{}
+	client, err := New("pk-lf-test-key", "sk-lf-test-key",
+		WithMetrics(metrics),
+		WithMetricsPrefix("checkout_service"),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if err := client.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	counters := metrics.Counters()
+	if counters["checkout_service.langfuse.shutdown.success"] != 1 {
+		t.Errorf("expected prefixed shutdown counter, got %v", counters)
+	}
+	for name := range counters {
+		if !strings.HasPrefix(name, "checkout_service.") {
+			t.Errorf("metric %q is missing the prefix", name)
+		}
+	}
+
+	for _, prefix := range []string{"my-app", "app name", "app/v1"} {
+		if _, err := New("pk-lf-test-key", "sk-lf-test-key", WithMetricsPrefix(prefix)); err == nil {
+			t.Errorf("expected error for prefix %q", prefix)
+		}
+	}
+}
+
+func TestWithMetricsLabels(t *testing.T) {
+	labels := map[string]string{"service": "checkout"}
+
+	labeled := &labeledMetrics{}
+	m := newScopedMetrics(labeled, "app", labels)
+	labels["service"] = "changed"
+	m.IncrementCounter("langfuse.batch.sent", 1)
+	if got := labeled.labels["app.langfuse.batch.sent"]; got["service"] != "checkout" {
+		t.Errorf("labels = %v, want service=checkout", got)
+	}
+
+	// Metrics without label support fall back to the unlabeled calls.
+	plain := &testMetrics{}
+	newScopedMetrics(plain, "", map[string]string{"service": "checkout"}).IncrementCounter("langfuse.batch.sent", 2)
+	if plain.Counters()["langfuse.batch.sent"] != 2 {
+		t.Errorf("fallback counters = %v", plain.Counters())
+	}
+
+	if newScopedMetrics(plain, "", nil) != Metrics(plain) {
+		t.Error("metrics should not be wrapped without a prefix or labels")
+	}
+}
+
+func TestWithConcurrentFlush(t *testing.T) {
+	var inFlight, maxInFlight, received atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(30 * time.Millisecond)
+		inFlight.Add(-1)
+		received.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	// The callback is deliberately not synchronized; the race detector
+	// flags it if calls from different workers overlap.
+	flushed := 0
+	client, err := New("pk-lf-test-key", "sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithBatchSize(1),
+		WithFlushInterval(1*time.Hour),
+		WithConcurrentFlush(4),
+		WithOnBatchFlushed(func(BatchResult) { flushed++ }),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	ctx := context.Background()
+	for i := 0; i < 12; i++ {
+		if _, err := client.NewTrace().Name("concurrent").Create(ctx); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+	if err := client.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	if received.Load() != 12 || flushed != 12 {
+		t.Errorf("received %d batches, flushed %d, want 12", received.Load(), flushed)
+	}
+	if maxInFlight.Load() < 2 {
+		t.Errorf("max in-flight sends = %d, want concurrent sends", maxInFlight.Load())
+	}
+	if maxInFlight.Load() > 4 {
+		t.Errorf("max in-flight sends = %d, want at most 4", maxInFlight.Load())
+	}
+
+	if _, err := New("pk-lf-test-key", "sk-lf-test-key", WithConcurrentFlush(-1)); err == nil {
+		t.Error("expected error for negative worker count")
+	}
+}
+
+func TestWithMaxConcurrentFlushes(t *testing.T) {
+	var inFlight, maxInFlight, received atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(30 * time.Millisecond)
+		inFlight.Add(-1)
+		received.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	metrics := &testMetrics{}
+	client, err := New("pk-lf-test-key", "sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithBatchSize(1),
+		WithBatchQueueSize(1),
+		WithFlushInterval(1*time.Hour),
+		WithConcurrentFlush(4),
+		WithMaxConcurrentFlushes(2),
+		WithMetrics(metrics),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	ctx := context.Background()
+	for i := 0; i < 12; i++ {
+		if _, err := client.NewTrace().Name("limited").Create(ctx); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+	if err := client.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	if received.Load() != 12 {
+		t.Errorf("received %d batches, want 12", received.Load())
+	}
+	if maxInFlight.Load() > 2 {
+		t.Errorf("max in-flight sends = %d, want at most 2", maxInFlight.Load())
+	}
+	if metrics.Counters()["langfuse.flush.throttled"] == 0 {
+		t.Error("expected langfuse.flush.throttled to be recorded")
+	}
+
+	if _, err := New("pk-lf-test-key", "sk-lf-test-key", WithMaxConcurrentFlushes(-1)); err == nil {
+		t.Error("expected error for negative concurrent flush limit")
+	}
+}
+
+func TestWithMaxFlushesPerSecond(t *testing.T) {
+	var received atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	metrics := &testMetrics{}
+	client, err := New("pk-lf-test-key", "sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithBatchSize(1),
+		WithFlushInterval(1*time.Hour),
+		WithMaxFlushesPerSecond(10),
+		WithMetrics(metrics),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	// The bucket allows a burst of 10; the remaining 3 batches wait ~100ms each.
+	ctx := context.Background()
+	start := time.Now()
+	for i := 0; i < 13; i++ {
+		if _, err := client.NewTrace().Name("rate-limited").Create(ctx); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+	if err := client.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	elapsed := time.Since(start)
+
+	if received.Load() != 13 {
+		t.Errorf("received %d batches, want 13", received.Load())
+	}
+	if elapsed < 250*time.Millisecond {
+		t.Errorf("13 batches at 10/s took %v, want rate limiting", elapsed)
+	}
+	if metrics.Counters()["langfuse.flush.throttled"] == 0 {
+		t.Error("expected langfuse.flush.throttled to be recorded")
+	}
+
+	if _, err := New("pk-lf-test-key", "sk-lf-test-key", WithMaxFlushesPerSecond(-1)); err == nil {
+		t.Error("expected error for negative flush rate")
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	client, err := New("pk-lf-test-key", "sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	if _, err := client.Subscribe(context.Background(), ""); err == nil {
+		t.Error("expected error for empty event type")
+	}
+
+	subCtx, cancel := context.WithCancel(context.Background())
+	all, err := client.Subscribe(subCtx, "*")
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	spans, err := client.Subscribe(subCtx, "span-create")
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	if n := client.SubscriberCount(); n != 2 {
+		t.Errorf("SubscriberCount() = %d, want 2", n)
+	}
+
+	ctx := context.Background()
+	trace, err := client.NewTrace().Name("subscribed").Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	span, err := trace.NewSpan().Name("step").Create(ctx)
+	if err != nil {
+		t.Fatalf("NewSpan failed: %v", err)
+	}
+
+	first := <-all
+	if first.Type != "trace-create" || first.TraceID != trace.ID() || first.Body["name"] != "subscribed" {
+		t.Errorf("unexpected trace event: %+v", first)
+	}
+	second := <-all
+	if second.Type != "span-create" || second.Body["id"] != span.ID() {
+		t.Errorf("unexpected span event: %+v", second)
+	}
+
+	observed := <-spans
+	if observed.Type != "span-create" || observed.TraceID != trace.ID() || observed.ID == "" || observed.Timestamp.IsZero() {
+		t.Errorf("unexpected span event: %+v", observed)
+	}
+	observed.Body["name"] = "modified"
+	if second.Body["name"] != "step" {
+		t.Error("subscribers should receive independent copies of the body")
+	}
+	select {
+	case e := <-spans:
+		t.Errorf("span subscriber received unexpected event: %+v", e)
+	default:
+	}
+
+	// A subscriber that never reads must not block queuing.
+	for i := 0; i < subscriberBufferSize+10; i++ {
+		if _, err := client.NewTrace().Name("overflow").Create(ctx); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	cancel()
+	for range all {
+	}
+	for range spans {
+	}
+	if n := client.SubscriberCount(); n != 0 {
+		t.Errorf("SubscriberCount() after cancel = %d, want 0", n)
+	}
+}
+
+func TestWithPreSendHook(t *testing.T) {
+	var mu sync.Mutex
+	var events []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Batch []map[string]any `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		events = append(events, req.Batch...)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	metrics := &testMetrics{}
+	client, err := New("pk-lf-test-key", "sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(1*time.Hour),
+		WithMetrics(metrics),
+		WithPreSendHook(func(batch []map[string]any) []map[string]any {
+			kept := batch[:0]
+			for _, event := range batch {
+				body := event["body"].(map[string]any)
+				if body["name"] == "drop-me" {
+					continue
+				}
+				body["computed"] = "yes"
+				kept = append(kept, event)
+			}
+			return kept
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	kept, err := client.NewTrace().Name("keep-me").Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := client.NewTrace().Name("drop-me").Create(ctx); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	mu.Lock()
+	if len(events) != 1 {
+		t.Fatalf("received %d events, want 1", len(events))
+	}
+	body := events[0]["body"].(map[string]any)
+	if body["id"] != kept.ID() || body["computed"] != "yes" {
+		t.Errorf("unexpected event body: %v", body)
+	}
+	mu.Unlock()
+
+	if metrics.Durations()["langfuse.presend_hook.duration"] == 0 {
+		t.Error("hook latency was not recorded")
+	}
+	if got := metrics.Counters()["langfuse.presend_hook.dropped"]; got != 1 {
+		t.Errorf("presend_hook.dropped = %d, want 1", got)
+	}
+}
+
+func TestWithPreSendHookPanic(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	logger := &testLogger{}
+	metrics := &testMetrics{}
+	client, err := New("pk-lf-test-key", "sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(1*time.Hour),
+		WithLogger(logger),
+		WithMetrics(metrics),
+		WithPreSendHook(func(batch []map[string]any) []map[string]any {
+			panic("pricing table missing")
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	if _, err := client.NewTrace().Name("panics").Create(ctx); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	if n := requests.Load(); n != 0 {
+		t.Errorf("server received %d requests, want the batch dropped", n)
+	}
+	if got := metrics.Counters()["langfuse.presend_hook.panics"]; got != 1 {
+		t.Errorf("presend_hook.panics = %d, want 1", got)
+	}
+	found := false
+	for _, msg := range logger.Messages() {
+		if strings.Contains(msg, "pre-send hook panicked") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("panic was not logged: %v", logger.Messages())
+	}
+
+	// The sending goroutine survives the panic.
+	if _, err := client.NewTrace().Name("after").Create(ctx); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	if got := metrics.Counters()["langfuse.presend_hook.panics"]; got != 2 {
+		t.Errorf("presend_hook.panics = %d, want 2", got)
+	}
+}
+
+func TestWithLazyInitialization(t *testing.T) {
+	var received atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Batch []map[string]any `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		received.Add(int32(len(req.Batch)))
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+
+	t.Run("shutdown before any event", func(t *testing.T) {
+		client, err := New("pk-lf-test-key", "sk-lf-test-key",
+			WithBaseURL(server.URL),
+			WithLazyInitialization(),
+		)
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		if client.IsInitialized() {
+			t.Error("lazy client should not be initialized before the first event")
+		}
+		if err := client.Shutdown(ctx); err != nil {
+			t.Fatalf("Shutdown failed: %v", err)
+		}
+		if _, err := client.NewTrace().Name("late").Create(ctx); err != ErrClientClosed {
+			t.Errorf("Create after Shutdown = %v, want ErrClientClosed", err)
+		}
+		if client.IsInitialized() {
+			t.Error("client should not start after Shutdown")
+		}
+		if err := client.Shutdown(ctx); err != ErrClientClosed {
+			t.Errorf("second Shutdown = %v, want ErrClientClosed", err)
+		}
+	})
+
+	t.Run("first event starts the client", func(t *testing.T) {
+		client, err := New("pk-lf-test-key", "sk-lf-test-key",
+			WithBaseURL(server.URL),
+			WithBatchSize(1),
+			WithLazyInitialization(),
+		)
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := client.NewTrace().Name("lazy").Create(ctx); err != nil {
+					t.Errorf("Create failed: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if !client.IsInitialized() {
+			t.Error("client should be initialized after queueing an event")
+		}
+		if err := client.Shutdown(ctx); err != nil {
+			t.Fatalf("Shutdown failed: %v", err)
+		}
+		if n := received.Load(); n != 8 {
+			t.Errorf("server received %d events, want 8", n)
+		}
+	})
+
+	client, err := New("pk-lf-test-key", "sk-lf-test-key", WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(ctx)
+	if !client.IsInitialized() {
+		t.Error("client without lazy initialization should start immediately")
+	}
+}
+
+func TestFlushSync(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Batch []map[string]any `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		var result IngestionResult
+		for _, event := range req.Batch {
+			id := event["id"].(string)
+			if event["body"].(map[string]any)["name"] == "rejected" {
+				result.Errors = append(result.Errors, IngestionError{ID: id, Status: 400, Message: "invalid"})
+				continue
+			}
+			result.Successes = append(result.Successes, IngestionSuccess{ID: id, Status: 201})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMultiStatus)
+		json.NewEncoder(w).Encode(result)
+	}))
+	defer server.Close()
+
+	client, err := New("pk-lf-test-key", "sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	ctx := context.Background()
+	empty, err := client.FlushSync(ctx, time.Second)
+	if err != nil || empty.SentEvents != 0 {
+		t.Fatalf("FlushSync with no events = %+v, %v", empty, err)
+	}
+
+	for _, name := range []string{"ok-1", "rejected", "ok-2"} {
+		if _, err := client.NewTrace().Name(name).Create(ctx); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+	result, err := client.FlushSync(ctx, 5*time.Second)
+	if err != nil {
+		t.Fatalf("FlushSync failed: %v", err)
+	}
+	if result.SentEvents != 3 || result.Successes != 2 || len(result.Errors) != 1 {
+		t.Errorf("FlushSync() = %+v, want 3 sent, 2 successes, 1 error", result)
+	}
+	if result.Errors[0].Status != 400 || result.Errors[0].Message != "invalid" {
+		t.Errorf("unexpected ingestion error: %+v", result.Errors[0])
+	}
+	if result.Duration <= 0 {
+		t.Error("Duration should be set")
+	}
+
+	if err := client.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	if _, err := client.FlushSync(ctx, time.Second); err != ErrClientClosed {
+		t.Errorf("FlushSync after Shutdown = %v, want ErrClientClosed", err)
+	}
+}
+
+func TestFlushSyncTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client, err := New("pk-lf-test-key", "sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(1*time.Hour),
+		WithMaxRetries(0),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	if _, err := client.NewTrace().Name("slow").Create(ctx); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	start := time.Now()
+	if _, err := client.FlushSync(ctx, 50*time.Millisecond); err == nil {
+		t.Error("expected timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("FlushSync took %v, want it bounded by the timeout", elapsed)
+	}
+}
+
+func TestPanicRecovery(t *testing.T) {
+	var received atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var asyncErrs []*AsyncError
+	var calls atomic.Int32
+	client, err := New("pk-lf-test-key", "sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithBatchSize(1),
+		WithFlushInterval(1*time.Hour),
+		WithOnBatchFlushed(func(BatchResult) {
+			if calls.Add(1) == 1 {
+				panic("boom")
+			}
+		}),
+		WithErrorHandler(func(err error) {
+			var asyncErr *AsyncError
+			if errors.As(err, &asyncErr) {
+				mu.Lock()
+				asyncErrs = append(asyncErrs, asyncErr)
+				mu.Unlock()
+			}
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, err := client.NewTrace().Name("panic").Create(ctx); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for calls.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := client.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	if received.Load() != 2 || calls.Load() != 2 {
+		t.Errorf("received %d batches, %d callbacks, want 2 after the panic", received.Load(), calls.Load())
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(asyncErrs) != 1 {
+		t.Fatalf("got %d async errors, want 1", len(asyncErrs))
+	}
+	if asyncErrs[0].Operation != AsyncOpInternal {
+		t.Errorf("Operation = %q, want %q", asyncErrs[0].Operation, AsyncOpInternal)
+	}
+	if asyncErrs[0].Context["panic"] != "boom" {
+		t.Errorf(`Context["panic"] = %v, want boom`, asyncErrs[0].Context["panic"])
+	}
+	if stack, _ := asyncErrs[0].Context["stack"].(string); !strings.Contains(stack, "notifyBatchFlushed") {
+		t.Errorf(`Context["stack"] should hold the stack trace, got %q`, stack)
+	}
+}
+
+func TestTraceProcessors(t *testing.T) {
+	var mu sync.Mutex
+	var events []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Batch []map[string]any `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		events = append(events, req.Batch...)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	var panics atomic.Int32
+	client, err := New("pk-lf-test-key", "sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(1*time.Hour),
+		WithErrorHandler(func(err error) {
+			var asyncErr *AsyncError
+			if errors.As(err, &asyncErr) && asyncErr.Operation == AsyncOpInternal {
+				panics.Add(1)
+			}
+		}),
+		WithTraceProcessors(
+			func(e *TraceEvent) *TraceEvent {
+				if e.Body["name"] == "drop" {
+					return nil
+				}
+				if e.Body["name"] == "panic" {
+					e.Body["userId"] = "partial"
+					panic("boom")
+				}
+				return e
+			},
+			AddFieldProcessor("userId", "normalized"),
+			RemoveFieldProcessor("metadata"),
+			TransformInputProcessor(func(any) any { return "[redacted]" }),
+		),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	_, err = client.NewTrace().Name("kept").UserID("User-1").
+		Input("secret").Metadata(Metadata{"k": "v"}).Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := client.NewTrace().Name("drop").Create(ctx); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := client.NewTrace().Name("panic").Input("original").Create(ctx); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	bodies := make(map[string]map[string]any)
+	for _, e := range events {
+		body := e["body"].(map[string]any)
+		bodies[body["name"].(string)] = body
+	}
+	if len(bodies) != 2 || bodies["drop"] != nil {
+		t.Fatalf("queued traces = %v, want kept and panic", bodies)
+	}
+	if body := bodies["kept"]; body["userId"] != "normalized" || body["input"] != "[redacted]" || body["metadata"] != nil {
+		t.Errorf("processed body = %v", body)
+	}
+	if body := bodies["panic"]; body["userId"] != nil || body["input"] != "original" {
+		t.Errorf("body after a processor panic = %v, want the original event", body)
+	}
+	if panics.Load() != 1 {
+		t.Errorf("got %d panic errors, want 1", panics.Load())
+	}
+}
+
+func TestEventTypeFilter(t *testing.T) {
+	queuedTypes := func(t *testing.T, opts ...ConfigOption) map[string]int {
+		t.Helper()
+		var mu sync.Mutex
+		types := make(map[string]int)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req struct {
+				Batch []map[string]any `json:"batch"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			mu.Lock()
+			for _, e := range req.Batch {
+				types[e["type"].(string)]++
+			}
+			mu.Unlock()
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(IngestionResult{})
+		}))
+		defer server.Close()
+
+		client, err := New("pk-lf-test-key", "sk-lf-test-key",
+			append([]ConfigOption{WithBaseURL(server.URL), WithFlushInterval(1 * time.Hour)}, opts...)...)
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		defer client.Shutdown(context.Background())
+
+		ctx := context.Background()
+		trace, err := client.NewTrace().Name("t").Create(ctx)
+		if err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+		if _, err := trace.NewSpan().Name("s").Create(ctx); err != nil {
+			t.Fatalf("NewSpan failed: %v", err)
+		}
+		if err := trace.ScoreNumeric(ctx, "quality", 1); err != nil {
+			t.Fatalf("ScoreNumeric failed: %v", err)
+		}
+		if err := client.Flush(ctx); err != nil {
+			t.Fatalf("Flush failed: %v", err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		return types
+	}
+
+	t.Run("filter", func(t *testing.T) {
+		types := queuedTypes(t, WithEventTypeFilter(EventTypeScoreCreate))
+		if types[EventTypeScoreCreate] != 0 || types[EventTypeTraceCreate] != 1 || types[EventTypeSpanCreate] != 1 {
+			t.Errorf("queued event types = %v, want trace and span only", types)
+		}
+	})
+
+	t.Run("allowlist", func(t *testing.T) {
+		types := queuedTypes(t, WithEventTypeAllowlist(EventTypeTraceCreate, EventTypeScoreCreate))
+		if types[EventTypeSpanCreate] != 0 || types[EventTypeTraceCreate] != 1 || types[EventTypeScoreCreate] != 1 {
+			t.Errorf("queued event types = %v, want trace and score only", types)
+		}
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		for _, opt := range []ConfigOption{WithEventTypeFilter("score"), WithEventTypeAllowlist("trace-delete")} {
+			if _, err := New("pk-lf-test-key", "sk-lf-test-key", opt); err == nil {
+				t.Error("New with an unknown event type should fail")
+			}
+		}
+	})
+}
+
+// argsLogger records the attributes of every structured log call.
+type argsLogger struct {
+	mu   sync.Mutex
+	args [][]any
+}
+
+func (l *argsLogger) record(args []any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.args = append(l.args, args)
+}
+
+func (l *argsLogger) Debug(msg string, args ...any) { l.record(args) }
+func (l *argsLogger) Info(msg string, args ...any)  { l.record(args) }
+func (l *argsLogger) Warn(msg string, args ...any)  { l.record(args) }
+func (l *argsLogger) Error(msg string, args ...any) { l.record(args) }
+
+func TestProjectID(t *testing.T) {
+	var userAgent atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent.Store(r.Header.Get("User-Agent"))
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	logger := &formattingLogger{}
+	client, err := New("pk-lf-test-key", "sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(1*time.Hour),
+		WithDebug(true),
+		WithLogger(logger),
+		WithProjectID("proj-1"),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	client.NewTrace().Name("project").Create(ctx)
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	if got := client.Stats().ProjectID; got != "proj-1" {
+		t.Errorf("Stats().ProjectID = %q, want proj-1", got)
+	}
+	if got, _ := userAgent.Load().(string); !strings.HasSuffix(got, " project/proj-1") {
+		t.Errorf("User-Agent = %q, want the project ID", got)
+	}
+	msgs := logger.bodyMessages()
+	if len(msgs) == 0 {
+		t.Fatal("expected debug messages")
+	}
+	for _, msg := range msgs {
+		if !strings.HasSuffix(msg, " | project_id=proj-1") {
+			t.Errorf("message %q does not end with the project ID", msg)
+		}
+	}
+
+	structured := &argsLogger{}
+	_, wrapped := withProjectLogging(nil, structured, "proj-1")
+	wrapped.Info("hello", "k", "v")
+	if got := structured.args[0]; len(got) != 4 || got[2] != "project_id" || got[3] != "proj-1" {
+		t.Errorf("structured args = %v, want project_id appended", got)
+	}
+	if plain, _ := withProjectLogging(nil, nil, "proj-1"); plain != nil {
+		t.Error("a nil logger should stay nil")
+	}
+}
+
+func TestPool_FactoryRunsUnlocked(t *testing.T) {
+	release := make(chan struct{})
+	var waiting, created atomic.Int32
+	var slowMu sync.Mutex
+	var slowClients []*Client
+	pool := NewPool(0, func(key string) (*Client, error) {
+		if key == "slow" {
+			waiting.Add(1)
+			<-release
+		}
+		created.Add(1)
+		client, err := New("pk-lf-test-"+key, "sk-lf-test-"+key, WithBaseURL("http://localhost:9999"), WithFlushInterval(1*time.Hour))
+		if key == "slow" && client != nil {
+			slowMu.Lock()
+			slowClients = append(slowClients, client)
+			slowMu.Unlock()
+		}
+		return client, err
+	})
+	defer pool.Shutdown(context.Background())
+
+	const callers = 3
+	results := make(chan *Client, callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			client, err := pool.Get("slow")
+			if err != nil {
+				t.Errorf("Get(slow) failed: %v", err)
+			}
+			results <- client
+		}()
+	}
+
+	// A slow factory must not block Gets for other keys.
+	fastDone := make(chan error, 1)
+	go func() {
+		_, err := pool.Get("fast")
+		fastDone <- err
+	}()
+	select {
+	case err := <-fastDone:
+		if err != nil {
+			t.Fatalf("Get(fast) failed: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Get(fast) blocked on the factory for another key")
+	}
+
+	for waiting.Load() != callers {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	first := <-results
+	for i := 1; i < callers; i++ {
+		if client := <-results; client != first {
+			t.Error("concurrent Gets for one key returned different clients")
+		}
+	}
+	if pool.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", pool.Len())
+	}
+	if !first.IsActive() {
+		t.Error("pooled client should stay active")
+	}
+	if created.Load() != callers+1 {
+		t.Errorf("factory calls = %d, want %d", created.Load(), callers+1)
+	}
+	slowMu.Lock()
+	defer slowMu.Unlock()
+	for _, client := range slowClients {
+		if client != first && client.IsActive() {
+			t.Error("client created for a key already pooled should be shut down")
+		}
+	}
+}
+
+func TestPool_ProjectIDMismatch(t *testing.T) {
+	pool := NewPool(0, func(projectID string) (*Client, error) {
+		return New("pk-lf-test-key", "sk-lf-test-key", WithFlushInterval(1*time.Hour), WithProjectID("other"))
+	})
+	defer pool.Shutdown(context.Background())
+
+	if _, err := pool.Get("proj-1"); err == nil || !strings.Contains(err.Error(), `"other"`) {
+		t.Errorf("Get() error = %v, want a project mismatch", err)
+	}
+	if pool.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", pool.Len())
+	}
+}
+
+func TestPool_FactoryReturnsNil(t *testing.T) {
+	pool := NewPool(0, func(projectID string) (*Client, error) {
+		return nil, nil
+	})
+	defer pool.Shutdown(context.Background())
+
+	if _, err := pool.Get("proj-1"); err == nil || !strings.Contains(err.Error(), "nil client") {
+		t.Errorf("Get() error = %v, want a nil client error", err)
+	}
+	if pool.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", pool.Len())
+	}
+}
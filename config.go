@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -55,6 +57,19 @@ const (
 	EnvDebug = "LANGFUSE_DEBUG"
 	// EnvAPIPathPrefix is the environment variable for the API path prefix.
 	EnvAPIPathPrefix = "LANGFUSE_API_PATH_PREFIX"
+
+	// The following variables are only read when WithEnvironmentVariableOverrides is set.
+
+	// EnvBatchSize overrides Config.BatchSize.
+	EnvBatchSize = "LANGFUSE_BATCH_SIZE"
+	// EnvFlushIntervalMs overrides Config.FlushInterval, in milliseconds.
+	EnvFlushIntervalMs = "LANGFUSE_FLUSH_INTERVAL_MS"
+	// EnvMaxRetries overrides Config.MaxRetries.
+	EnvMaxRetries = "LANGFUSE_MAX_RETRIES"
+	// EnvShutdownTimeoutMs overrides Config.ShutdownTimeout, in milliseconds.
+	EnvShutdownTimeoutMs = "LANGFUSE_SHUTDOWN_TIMEOUT_MS"
+	// EnvQueueSize overrides Config.BatchQueueSize.
+	EnvQueueSize = "LANGFUSE_QUEUE_SIZE"
 )
 
 // ============================================================================
@@ -137,6 +152,12 @@ type Config struct {
 	// SecretKey is the Langfuse secret key (required).
 	SecretKey string
 
+	// ProjectID identifies the Langfuse project the keys belong to, for
+	// processes serving several projects. It is informational: it is
+	// reported in ClientStats, added to log output as "project_id" and
+	// included in the User-Agent header.
+	ProjectID string
+
 	// BaseURL is the base URL for the Langfuse API.
 	// If not set, it will be derived from the Region.
 	BaseURL string
@@ -177,6 +198,14 @@ type Config struct {
 	// Debug enables debug logging.
 	Debug bool
 
+	// DebugPrettyPrint indents JSON request and response bodies in debug
+	// logs. It has no effect unless Debug is true.
+	DebugPrettyPrint bool
+
+	// DebugMaxBodySize truncates request and response bodies in debug logs
+	// to this many bytes. Zero means no limit.
+	DebugMaxBodySize int
+
 	// ErrorHandler is called when async operations fail.
 	// If nil, errors are silently dropped unless Debug is true.
 	ErrorHandler func(error)
@@ -195,6 +224,16 @@ type Config struct {
 	// If nil, no metrics are collected.
 	Metrics Metrics
 
+	// MetricsPrefix is prepended, followed by a dot, to every metric name
+	// passed to Metrics. It may contain only letters, digits, underscores
+	// and dots.
+	MetricsPrefix string
+
+	// MetricsLabels are constant labels attached to every metric call.
+	// They are passed to Metrics implementations that satisfy LabeledMetrics
+	// and dropped otherwise.
+	MetricsLabels map[string]string
+
 	// MaxIdleConns controls the maximum number of idle connections across all hosts.
 	// Defaults to 100 if not set.
 	MaxIdleConns int
@@ -228,16 +267,45 @@ type Config struct {
 	// This is useful for monitoring, logging, or custom error handling.
 	OnBatchFlushed func(result BatchResult)
 
+	// OnShutdown is called once at the end of Shutdown, even if it timed
+	// out, with a summary of everything the client delivered or dropped.
+	OnShutdown func(summary ShutdownSummary)
+
+	// PreSendHook transforms each batch, in its JSON form, just before it is
+	// sent. Events left out of the returned slice are dropped.
+	PreSendHook PreSendHook
+
 	// HTTPHooks are called before and after each HTTP request.
 	// Use hooks to add custom headers, log requests, or collect metrics.
 	HTTPHooks []HTTPHook
 
+	// HTTPMiddleware wraps the transport of HTTPClient, the first function
+	// being the outermost. See WithHTTPMiddleware.
+	HTTPMiddleware []func(http.RoundTripper) http.RoundTripper
+
 	// IdleWarningDuration triggers a warning if the client is idle for this duration
 	// without Shutdown() being called. This helps detect goroutine leaks.
 	// Set to 0 to disable (default).
 	// Recommended: 5*time.Minute for development, 0 for production.
 	IdleWarningDuration time.Duration
 
+	// IdleTimeout shuts the client down automatically, as if Shutdown were
+	// called, once no event has been queued for this long. Pending events
+	// are flushed within ShutdownTimeout. Set to 0 to disable (default).
+	IdleTimeout time.Duration
+
+	// OnIdleShutdown is called when IdleTimeout triggers a shutdown, just
+	// before the client shuts down.
+	OnIdleShutdown func()
+
+	// DisablePanicRecovery lets a panic in a user callback, such as
+	// OnBatchFlushed, or in background batch processing crash the process.
+	// By default such panics are recovered and reported through the error
+	// handler as an *AsyncError with Operation AsyncOpInternal, and the
+	// background goroutine goes on with the next batch. Set it with
+	// WithPanicRecovery(false).
+	DisablePanicRecovery bool
+
 	// IDGenerationMode controls how IDs are generated when crypto/rand fails.
 	// Default is IDModeFallback for backwards compatibility.
 	// Production deployments may want to use IDModeStrict.
@@ -277,6 +345,22 @@ type Config struct {
 	// goroutine creation under sustained high load. Default is 10.
 	MaxBackgroundSenders int
 
+	// FlushWorkers is the number of goroutines sending queued batches
+	// concurrently, each over its own HTTP connection. Default is 1.
+	FlushWorkers int
+
+	// LazyInitialization defers starting the background batch processors
+	// and flush loop until the first event is queued. Default is false.
+	LazyInitialization bool
+
+	// MaxConcurrentFlushes limits the number of batch requests in flight at
+	// once across all senders. Zero (default) means unlimited.
+	MaxConcurrentFlushes int
+
+	// MaxFlushesPerSecond limits how many batch requests are sent per
+	// second using a token bucket. Zero (default) means unlimited.
+	MaxFlushesPerSecond float64
+
 	// StrictValidation enables strict validation mode with validated builders.
 	// When enabled, NewTraceStrict(), NewSpanStrict(), etc. methods become available.
 	// These return BuildResult types that force explicit error handling.
@@ -291,6 +375,86 @@ type Config struct {
 	// When set, traces are automatically structured for LLM-as-a-Judge evaluation.
 	// This includes field flattening, automatic metadata, and evaluation tags.
 	EvaluationConfig *EvaluationConfig
+
+	// EnvironmentOverrides applies runtime overrides from environment variables
+	// (LANGFUSE_BATCH_SIZE, LANGFUSE_FLUSH_INTERVAL_MS, LANGFUSE_DEBUG,
+	// LANGFUSE_MAX_RETRIES, LANGFUSE_SHUTDOWN_TIMEOUT_MS, LANGFUSE_QUEUE_SIZE)
+	// on top of the code-configured values.
+	EnvironmentOverrides bool
+
+	// FallbackBaseURL is a secondary Langfuse endpoint that ingestion batches
+	// are resent to when the primary BaseURL fails with a server or network
+	// error after retries are exhausted, or when the circuit breaker is open.
+	// Read operations such as Health always use the primary endpoint.
+	FallbackBaseURL string
+
+	// FallbackOptions are applied on top of a copy of this config to build
+	// the fallback endpoint's config, e.g. to use different credentials or
+	// timeouts. Only used when FallbackBaseURL is set.
+	FallbackOptions []ConfigOption
+
+	// CompressThreshold, when positive, gzip-compresses trace and observation
+	// inputs and outputs whose JSON form exceeds this many bytes. Use
+	// DecompressField to read compressed values back.
+	CompressThreshold int
+
+	// StatsHistory enables a rolling history of Stats snapshots, read with
+	// Client.StatsHistory and Client.StatsDelta. If nil, no history is kept.
+	StatsHistory *StatsHistoryConfig
+
+	// FlushWaitMaxPolls is the number of times FlushAndWait checks whether
+	// the last trace is queryable before giving up. If zero,
+	// DefaultFlushWaitMaxPolls is used.
+	FlushWaitMaxPolls int
+
+	// Release is applied to every trace created by the client that does not
+	// set its own release.
+	Release string
+
+	// GitRelease sets Release to "branch@sha" of the current Git checkout
+	// when the client is created, unless Release is already set. If git is
+	// unavailable or the working directory is not a repository, it is
+	// silently skipped.
+	GitRelease bool
+
+	// MaxMetadataSize, when positive, truncates each metadata value, input
+	// and output whose JSON form exceeds this many bytes. MaxInputSize and
+	// MaxOutputSize override it for inputs and outputs.
+	MaxMetadataSize int
+
+	// MaxInputSize, when positive, truncates inputs whose JSON form exceeds
+	// this many bytes. Defaults to MaxMetadataSize.
+	MaxInputSize int
+
+	// MaxOutputSize, when positive, truncates outputs whose JSON form
+	// exceeds this many bytes. Defaults to MaxMetadataSize.
+	MaxOutputSize int
+
+	// ObservationLevelMapping determines the observation level recorded by
+	// SpanContext.SetStatusError and the WithError end option. Defaults to
+	// DefaultLevelMapping.
+	ObservationLevelMapping func(error) ObservationLevel
+
+	// ConnectionTest checks connectivity and credentials when the client is
+	// created. New fails if the credentials are rejected; network errors
+	// are logged and the client is created anyway.
+	ConnectionTest bool
+
+	// ConnectionTestTimeout bounds the connection test. If zero,
+	// DefaultConnectionTestTimeout is used.
+	ConnectionTestTimeout time.Duration
+
+	// TraceProcessors transform or drop every event before it is queued.
+	// They run in order, before truncation and compression.
+	TraceProcessors []ProcessorFn
+
+	// EventTypeFilter lists ingestion event types, such as
+	// EventTypeScoreCreate, that are dropped instead of queued.
+	EventTypeFilter []string
+
+	// EventTypeAllowlist, if set, lists the only ingestion event types that
+	// are queued; events of other types are dropped.
+	EventTypeAllowlist []string
 }
 
 // String returns a string representation of the config with masked credentials.
@@ -310,6 +474,28 @@ func (c *Config) String() string {
 // It is an alias to pkgclient.BatchResult for type compatibility.
 type BatchResult = pkgclient.BatchResult
 
+// StatsHistoryConfig configures the stats history kept by the client.
+// Zero fields use the DefaultStatsHistory values.
+type StatsHistoryConfig struct {
+	// Interval is how often a snapshot is recorded
+	Interval time.Duration
+
+	// Retention is how long snapshots are kept
+	Retention time.Duration
+
+	// MaxEntries is the size of the ring buffer holding the snapshots; the
+	// oldest snapshot is overwritten when it is full
+	MaxEntries int
+}
+
+// ShutdownSummary describes the client's delivery over its lifetime.
+// It is an alias to pkgclient.ShutdownSummary for type compatibility.
+type ShutdownSummary = pkgclient.ShutdownSummary
+
+// PreSendHook transforms a batch of events, in its JSON form, before it is
+// sent. It is an alias to pkgclient.PreSendHook for type compatibility.
+type PreSendHook = pkgclient.PreSendHook
+
 // applyDefaults sets default values for unset configuration options.
 func (c *Config) applyDefaults() {
 	if c.BaseURL == "" {
@@ -475,6 +661,62 @@ func (c *Config) validate() error {
 		return fmt.Errorf("langfuse: MaxBackgroundSenders cannot be negative, got %d", c.MaxBackgroundSenders)
 	}
 
+	if c.FlushWorkers < 0 {
+		return fmt.Errorf("langfuse: FlushWorkers cannot be negative, got %d", c.FlushWorkers)
+	}
+
+	if c.MaxConcurrentFlushes < 0 {
+		return fmt.Errorf("langfuse: MaxConcurrentFlushes cannot be negative, got %d", c.MaxConcurrentFlushes)
+	}
+
+	if c.MaxFlushesPerSecond < 0 {
+		return fmt.Errorf("langfuse: MaxFlushesPerSecond cannot be negative, got %g", c.MaxFlushesPerSecond)
+	}
+
+	if c.DebugMaxBodySize < 0 {
+		return fmt.Errorf("langfuse: debug max body size cannot be negative, got %d", c.DebugMaxBodySize)
+	}
+
+	if c.CompressThreshold < 0 {
+		return fmt.Errorf("langfuse: compress threshold cannot be negative, got %d", c.CompressThreshold)
+	}
+
+	if c.IdleTimeout < 0 {
+		return fmt.Errorf("langfuse: idle timeout cannot be negative, got %v", c.IdleTimeout)
+	}
+
+	if h := c.StatsHistory; h != nil && (h.Interval < 0 || h.Retention < 0 || h.MaxEntries < 0) {
+		return fmt.Errorf("langfuse: stats history interval, retention and max entries cannot be negative")
+	}
+
+	if c.FlushWaitMaxPolls < 0 {
+		return fmt.Errorf("langfuse: flush wait max polls cannot be negative, got %d", c.FlushWaitMaxPolls)
+	}
+
+	if c.ConnectionTestTimeout < 0 {
+		return fmt.Errorf("langfuse: connection test timeout cannot be negative, got %v", c.ConnectionTestTimeout)
+	}
+
+	if c.MetricsPrefix != "" && !metricsPrefixPattern.MatchString(c.MetricsPrefix) {
+		return fmt.Errorf("langfuse: metrics prefix may only contain letters, digits, underscores and dots, got %q", c.MetricsPrefix)
+	}
+
+	if c.MaxMetadataSize < 0 {
+		return fmt.Errorf("langfuse: max metadata size cannot be negative, got %d", c.MaxMetadataSize)
+	}
+	if c.MaxInputSize < 0 {
+		return fmt.Errorf("langfuse: max input size cannot be negative, got %d", c.MaxInputSize)
+	}
+	if c.MaxOutputSize < 0 {
+		return fmt.Errorf("langfuse: max output size cannot be negative, got %d", c.MaxOutputSize)
+	}
+
+	for _, eventType := range append(append([]string{}, c.EventTypeFilter...), c.EventTypeAllowlist...) {
+		if !isKnownEventType(eventType) {
+			return fmt.Errorf("langfuse: unknown event type %q in event type filter", eventType)
+		}
+	}
+
 	return nil
 }
 
@@ -595,6 +837,115 @@ func NewFromEnv(opts ...ConfigOption) (*Client, error) {
 	return New(publicKey, secretKey, allOpts...)
 }
 
+// envOverride records a single configuration value replaced by an environment variable.
+type envOverride struct {
+	name     string
+	value    string
+	field    string
+	previous any
+}
+
+// fallbackConfig builds the config for the fallback endpoint by applying
+// FallbackOptions to a copy of c with BaseURL set to FallbackBaseURL.
+func (c *Config) fallbackConfig() *Config {
+	fb := *c
+	fb.BaseURL = c.FallbackBaseURL
+	fb.FallbackBaseURL = ""
+	fb.FallbackOptions = nil
+	for _, opt := range c.FallbackOptions {
+		opt(&fb)
+	}
+
+	// The primary HTTP client carries the primary timeout; let the fallback
+	// build its own when a different timeout was configured.
+	if fb.HTTPClient == c.HTTPClient && fb.Timeout != c.Timeout {
+		fb.HTTPClient = nil
+	}
+
+	return &fb
+}
+
+// applyEnvironmentOverrides replaces configuration values with those set in
+// the override environment variables. It returns the overrides that replaced
+// a code-configured (non-zero) value so they can be logged once a logger is
+// available.
+func (c *Config) applyEnvironmentOverrides() ([]envOverride, error) {
+	var overrides []envOverride
+
+	setInt := func(name, field string, target *int) error {
+		raw := os.Getenv(name)
+		if raw == "" {
+			return nil
+		}
+		v, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("langfuse: invalid %s %q: %w", name, raw, err)
+		}
+		if *target != 0 && *target != v {
+			overrides = append(overrides, envOverride{name, raw, field, *target})
+		}
+		*target = v
+		return nil
+	}
+	setMillis := func(name, field string, target *time.Duration) error {
+		raw := os.Getenv(name)
+		if raw == "" {
+			return nil
+		}
+		ms, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("langfuse: invalid %s %q: %w", name, raw, err)
+		}
+		v := time.Duration(ms) * time.Millisecond
+		if *target != 0 && *target != v {
+			overrides = append(overrides, envOverride{name, raw, field, *target})
+		}
+		*target = v
+		return nil
+	}
+
+	if err := setInt(EnvBatchSize, "BatchSize", &c.BatchSize); err != nil {
+		return nil, err
+	}
+	if err := setMillis(EnvFlushIntervalMs, "FlushInterval", &c.FlushInterval); err != nil {
+		return nil, err
+	}
+	if err := setInt(EnvMaxRetries, "MaxRetries", &c.MaxRetries); err != nil {
+		return nil, err
+	}
+	if err := setMillis(EnvShutdownTimeoutMs, "ShutdownTimeout", &c.ShutdownTimeout); err != nil {
+		return nil, err
+	}
+	if err := setInt(EnvQueueSize, "BatchQueueSize", &c.BatchQueueSize); err != nil {
+		return nil, err
+	}
+	if raw := os.Getenv(EnvDebug); raw != "" {
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("langfuse: invalid %s %q: %w", EnvDebug, raw, err)
+		}
+		if c.Debug && !v {
+			overrides = append(overrides, envOverride{EnvDebug, raw, "Debug", c.Debug})
+		}
+		c.Debug = v
+	}
+
+	return overrides, nil
+}
+
+// logEnvironmentOverrides logs each environment variable that replaced a
+// code-configured value.
+func (c *Config) logEnvironmentOverrides(overrides []envOverride) {
+	for _, o := range overrides {
+		if c.StructuredLogger != nil {
+			c.StructuredLogger.Info("config overridden by environment variable",
+				"env", o.name, "value", o.value, "field", o.field, "previous", o.previous)
+		} else if c.Logger != nil {
+			c.Logger.Printf("config: %s=%s overrides %s (was %v)", o.name, o.value, o.field, o.previous)
+		}
+	}
+}
+
 // ============================================================================
 // Logging Interfaces and Implementations
 // ============================================================================
@@ -673,12 +1024,143 @@ func (w *printfLoggerWrapper) Error(msg string, args ...any) {
 	w.logger.Printf("[ERROR] " + msg + formatArgs(args))
 }
 
+// IsDebugEnabled implements DebugEnabler by deferring to the wrapped logger.
+func (w *printfLoggerWrapper) IsDebugEnabled() bool {
+	return IsDebugEnabled(w.logger)
+}
+
 // Ensure printfLoggerWrapper implements StructuredLogger.
 var _ StructuredLogger = (*printfLoggerWrapper)(nil)
 
+// DebugEnabler is an optional interface for loggers that can report whether
+// debug output would be written. The SDK checks it before doing expensive
+// debug formatting, such as pretty-printing bodies for WithDebugPrettyPrint.
+// Loggers that do not implement it are treated as always enabled.
+type DebugEnabler = pkgclient.DebugEnabler
+
+// IsDebugEnabled reports whether logger would write debug output.
+// It returns true unless logger implements DebugEnabler and reports false.
+var IsDebugEnabled = pkgclient.IsDebugEnabled
+
 // Metrics is an optional interface for SDK telemetry.
 type Metrics = pkgclient.Metrics
 
+// LabeledMetrics is an optional interface for Metrics implementations that
+// accept the constant labels set with WithMetricsLabels.
+type LabeledMetrics = pkgclient.LabeledMetrics
+
+// metricsPrefixPattern matches valid MetricsPrefix values.
+var metricsPrefixPattern = regexp.MustCompile(`^[A-Za-z0-9_.]+$`)
+
+// scopedMetrics applies MetricsPrefix and MetricsLabels to every call
+// before forwarding it to the configured Metrics.
+type scopedMetrics struct {
+	next    Metrics
+	labeled LabeledMetrics
+	prefix  string
+	labels  map[string]string
+}
+
+// newScopedMetrics wraps m with prefix and labels. It returns m unchanged
+// if there is nothing to apply.
+func newScopedMetrics(m Metrics, prefix string, labels map[string]string) Metrics {
+	if m == nil || (prefix == "" && len(labels) == 0) {
+		return m
+	}
+	s := &scopedMetrics{next: m}
+	if len(labels) > 0 {
+		s.labels = make(map[string]string, len(labels))
+		for k, v := range labels {
+			s.labels[k] = v
+		}
+		s.labeled, _ = m.(LabeledMetrics)
+	}
+	if prefix != "" {
+		s.prefix = prefix + "."
+	}
+	return s
+}
+
+func (s *scopedMetrics) IncrementCounter(name string, value int64) {
+	if s.labeled != nil {
+		s.labeled.IncrementCounterWithLabels(s.prefix+name, value, s.labels)
+		return
+	}
+	s.next.IncrementCounter(s.prefix+name, value)
+}
+
+func (s *scopedMetrics) RecordDuration(name string, duration time.Duration) {
+	if s.labeled != nil {
+		s.labeled.RecordDurationWithLabels(s.prefix+name, duration, s.labels)
+		return
+	}
+	s.next.RecordDuration(s.prefix+name, duration)
+}
+
+func (s *scopedMetrics) SetGauge(name string, value float64) {
+	if s.labeled != nil {
+		s.labeled.SetGaugeWithLabels(s.prefix+name, value, s.labels)
+		return
+	}
+	s.next.SetGauge(s.prefix+name, value)
+}
+
+// withProjectLogging wraps the configured loggers so that every message
+// carries the project ID. Nil loggers are left nil, and both are returned
+// unchanged if projectID is empty.
+func withProjectLogging(logger Logger, structured StructuredLogger, projectID string) (Logger, StructuredLogger) {
+	if projectID == "" {
+		return logger, structured
+	}
+	if logger != nil {
+		logger = &projectLogger{next: logger, suffix: " | project_id=" + projectID}
+	}
+	if structured != nil {
+		structured = &projectStructuredLogger{next: structured, projectID: projectID}
+	}
+	return logger, structured
+}
+
+// projectLogger appends the project ID to every message.
+type projectLogger struct {
+	next   Logger
+	suffix string
+}
+
+func (l *projectLogger) Printf(format string, v ...any) {
+	l.next.Printf(strings.TrimSuffix(format, "\n")+"%s", append(v, l.suffix)...)
+}
+
+func (l *projectLogger) IsDebugEnabled() bool {
+	return IsDebugEnabled(l.next)
+}
+
+// projectStructuredLogger adds a "project_id" attribute to every message.
+type projectStructuredLogger struct {
+	next      StructuredLogger
+	projectID string
+}
+
+func (l *projectStructuredLogger) Debug(msg string, args ...any) {
+	l.next.Debug(msg, append(args, "project_id", l.projectID)...)
+}
+
+func (l *projectStructuredLogger) Info(msg string, args ...any) {
+	l.next.Info(msg, append(args, "project_id", l.projectID)...)
+}
+
+func (l *projectStructuredLogger) Warn(msg string, args ...any) {
+	l.next.Warn(msg, append(args, "project_id", l.projectID)...)
+}
+
+func (l *projectStructuredLogger) Error(msg string, args ...any) {
+	l.next.Error(msg, append(args, "project_id", l.projectID)...)
+}
+
+func (l *projectStructuredLogger) IsDebugEnabled() bool {
+	return IsDebugEnabled(l.next)
+}
+
 // defaultLogger wraps the standard library logger.
 type defaultLogger struct {
 	logger *log.Logger
@@ -715,6 +1197,9 @@ func (NopLogger) Printf(format string, v ...any) {}
 // Debug implements StructuredLogger.Debug.
 func (NopLogger) Debug(msg string, args ...any) {}
 
+// IsDebugEnabled implements DebugEnabler.
+func (NopLogger) IsDebugEnabled() bool { return false }
+
 // Info implements StructuredLogger.Info.
 func (NopLogger) Info(msg string, args ...any) {}
 
@@ -832,6 +1317,7 @@ func MaskAuthHeader(header string) string {
 //	)
 type SlogAdapter struct {
 	logger *slog.Logger
+	ctx    context.Context
 }
 
 // NewSlogAdapter creates a new SlogAdapter wrapping the given slog.Logger.
@@ -840,40 +1326,62 @@ func NewSlogAdapter(logger *slog.Logger) *SlogAdapter {
 	if logger == nil {
 		logger = slog.Default()
 	}
-	return &SlogAdapter{logger: logger}
+	return &SlogAdapter{logger: logger, ctx: context.Background()}
+}
+
+// log emits a record at level with the adapter's context, so handlers that
+// read values such as trace IDs from the context see them.
+func (a *SlogAdapter) log(level slog.Level, msg string, args ...any) {
+	a.logger.Log(a.context(), level, msg, args...)
+}
+
+// context returns the adapter's context, or context.Background() for an
+// adapter that was not created with NewSlogAdapter.
+func (a *SlogAdapter) context() context.Context {
+	if a.ctx == nil {
+		return context.Background()
+	}
+	return a.ctx
 }
 
 // Debug implements StructuredLogger.Debug.
 func (a *SlogAdapter) Debug(msg string, args ...any) {
-	a.logger.Debug(msg, args...)
+	a.log(slog.LevelDebug, msg, args...)
+}
+
+// IsDebugEnabled implements DebugEnabler using the slog handler's level.
+func (a *SlogAdapter) IsDebugEnabled() bool {
+	return a.logger.Enabled(a.context(), slog.LevelDebug)
 }
 
 // Info implements StructuredLogger.Info.
 func (a *SlogAdapter) Info(msg string, args ...any) {
-	a.logger.Info(msg, args...)
+	a.log(slog.LevelInfo, msg, args...)
 }
 
 // Warn implements StructuredLogger.Warn.
 func (a *SlogAdapter) Warn(msg string, args ...any) {
-	a.logger.Warn(msg, args...)
+	a.log(slog.LevelWarn, msg, args...)
 }
 
 // Error implements StructuredLogger.Error.
 func (a *SlogAdapter) Error(msg string, args ...any) {
-	a.logger.Error(msg, args...)
+	a.log(slog.LevelError, msg, args...)
 }
 
 // Printf implements Logger.Printf for backward compatibility.
 // Logs at Info level with the formatted message.
 func (a *SlogAdapter) Printf(format string, v ...any) {
-	a.logger.Info(fmt.Sprintf(format, v...))
+	a.log(slog.LevelInfo, fmt.Sprintf(format, v...))
 }
 
-// WithContext returns a new SlogAdapter that uses a logger with the given context.
-// This is useful for propagating trace context through logs.
+// WithContext returns a new SlogAdapter that passes ctx to the slog handler
+// with every record. This is useful for propagating trace context through
+// logs, for example to handlers that add OpenTelemetry trace IDs.
 func (a *SlogAdapter) WithContext(ctx context.Context) *SlogAdapter {
 	return &SlogAdapter{
 		logger: a.logger,
+		ctx:    ctx,
 	}
 }
 
@@ -881,6 +1389,7 @@ func (a *SlogAdapter) WithContext(ctx context.Context) *SlogAdapter {
 func (a *SlogAdapter) WithGroup(name string) *SlogAdapter {
 	return &SlogAdapter{
 		logger: a.logger.WithGroup(name),
+		ctx:    a.ctx,
 	}
 }
 
@@ -888,5 +1397,6 @@ func (a *SlogAdapter) WithGroup(name string) *SlogAdapter {
 func (a *SlogAdapter) With(args ...any) *SlogAdapter {
 	return &SlogAdapter{
 		logger: a.logger.With(args...),
+		ctx:    a.ctx,
 	}
 }
@@ -370,6 +370,12 @@ func (b *EvalGenerationBuilder) Model(model string) *EvalGenerationBuilder {
 	return b
 }
 
+// LLMProvider records the LLM provider serving the generation.
+func (b *EvalGenerationBuilder) LLMProvider(provider string) *EvalGenerationBuilder {
+	b.GenerationBuilder.LLMProvider(provider)
+	return b
+}
+
 // ModelParameters sets the model parameters.
 func (b *EvalGenerationBuilder) ModelParameters(params Metadata) *EvalGenerationBuilder {
 	b.GenerationBuilder.ModelParameters(params)
@@ -438,6 +444,12 @@ func (b *EvalGenerationBuilder) Create(ctx context.Context) (*EvalGenerationCont
 	}, nil
 }
 
+// TryCreate is like Create but returns the generation and error as a single
+// BuildResult.
+func (b *EvalGenerationBuilder) TryCreate(ctx context.Context) BuildResult[*EvalGenerationContext] {
+	return NewBuildResult(b.Create(ctx))
+}
+
 // applyEvalTransformations applies evaluation-specific transformations.
 func (b *EvalGenerationBuilder) applyEvalTransformations() {
 	config := b.evalConfig
@@ -662,6 +674,12 @@ func (b *EvalSpanBuilder) Create(ctx context.Context) (*EvalSpanContext, error)
 	}, nil
 }
 
+// TryCreate is like Create but returns the span and error as a single
+// BuildResult.
+func (b *EvalSpanBuilder) TryCreate(ctx context.Context) BuildResult[*EvalSpanContext] {
+	return NewBuildResult(b.Create(ctx))
+}
+
 // applyEvalTransformations applies evaluation-specific transformations.
 func (b *EvalSpanBuilder) applyEvalTransformations() {
 	config := b.evalConfig
@@ -0,0 +1,212 @@
+package evaluation
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ScoreAggregator computes statistical summaries for a single metric across
+// many traces. It has no dependency on a Langfuse client and can be used to
+// post-process exported score data.
+//
+// ScoreAggregator is safe for concurrent use.
+//
+// Example:
+//
+//	agg := evaluation.NewScoreAggregator("faithfulness")
+//	for _, s := range scores {
+//	    agg.Add(s.Value)
+//	}
+//	fmt.Println(agg.Summary())
+type ScoreAggregator struct {
+	metricName string
+
+	mu     sync.Mutex
+	scores []float64
+	sorted bool
+}
+
+// NewScoreAggregator creates an aggregator for the named metric.
+func NewScoreAggregator(metricName string) *ScoreAggregator {
+	return &ScoreAggregator{metricName: metricName}
+}
+
+// MetricName returns the name of the aggregated metric.
+func (a *ScoreAggregator) MetricName() string {
+	return a.metricName
+}
+
+// Add records a single score. NaN values are ignored.
+func (a *ScoreAggregator) Add(score float64) {
+	if math.IsNaN(score) {
+		return
+	}
+	a.mu.Lock()
+	a.scores = append(a.scores, score)
+	a.sorted = false
+	a.mu.Unlock()
+}
+
+// AddAll records multiple scores. NaN values are ignored.
+func (a *ScoreAggregator) AddAll(scores []float64) {
+	for _, s := range scores {
+		a.Add(s)
+	}
+}
+
+// Count returns the number of recorded scores.
+func (a *ScoreAggregator) Count() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.scores)
+}
+
+// Mean returns the arithmetic mean, or 0 if no scores were recorded.
+func (a *ScoreAggregator) Mean() float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.meanLocked()
+}
+
+func (a *ScoreAggregator) meanLocked() float64 {
+	if len(a.scores) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, s := range a.scores {
+		sum += s
+	}
+	return sum / float64(len(a.scores))
+}
+
+// Median returns the 50th percentile.
+func (a *ScoreAggregator) Median() float64 {
+	return a.Percentile(50)
+}
+
+// StdDev returns the population standard deviation, or 0 if no scores were recorded.
+func (a *ScoreAggregator) StdDev() float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.scores) == 0 {
+		return 0
+	}
+	mean := a.meanLocked()
+	var sq float64
+	for _, s := range a.scores {
+		d := s - mean
+		sq += d * d
+	}
+	return math.Sqrt(sq / float64(len(a.scores)))
+}
+
+// Percentile returns the p-th percentile (0-100) using linear interpolation
+// between the closest ranks. Values of p outside [0, 100] are clamped.
+// It returns 0 if no scores were recorded.
+func (a *ScoreAggregator) Percentile(p float64) float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.scores) == 0 {
+		return 0
+	}
+	a.sortLocked()
+
+	p = math.Max(0, math.Min(100, p))
+	rank := p / 100 * float64(len(a.scores)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	if lo == hi {
+		return a.scores[lo]
+	}
+	frac := rank - float64(lo)
+	return a.scores[lo] + frac*(a.scores[hi]-a.scores[lo])
+}
+
+// Min returns the smallest recorded score, or 0 if no scores were recorded.
+func (a *ScoreAggregator) Min() float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.scores) == 0 {
+		return 0
+	}
+	a.sortLocked()
+	return a.scores[0]
+}
+
+// Max returns the largest recorded score, or 0 if no scores were recorded.
+func (a *ScoreAggregator) Max() float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.scores) == 0 {
+		return 0
+	}
+	a.sortLocked()
+	return a.scores[len(a.scores)-1]
+}
+
+// Histogram counts scores per bucket. Each value in buckets is the inclusive
+// lower bound of a bucket, and a score is counted in the bucket with the
+// greatest lower bound that does not exceed it. Scores below the smallest
+// bound are not counted.
+//
+// Example:
+//
+//	agg.Histogram([]float64{0, 0.25, 0.5, 0.75})
+//	// map[0:3 0.25:10 0.5:42 0.75:45]
+func (a *ScoreAggregator) Histogram(buckets []float64) map[float64]int {
+	bounds := append([]float64(nil), buckets...)
+	sort.Float64s(bounds)
+
+	hist := make(map[float64]int, len(bounds))
+	for _, b := range bounds {
+		hist[b] = 0
+	}
+	if len(bounds) == 0 {
+		return hist
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, s := range a.scores {
+		i := sort.Search(len(bounds), func(i int) bool { return bounds[i] > s }) - 1
+		if i >= 0 {
+			hist[bounds[i]]++
+		}
+	}
+	return hist
+}
+
+// Summary returns a formatted table of the aggregate statistics.
+func (a *ScoreAggregator) Summary() string {
+	rows := []struct {
+		label string
+		value float64
+	}{
+		{"mean", a.Mean()},
+		{"median", a.Median()},
+		{"stddev", a.StdDev()},
+		{"min", a.Min()},
+		{"p90", a.Percentile(90)},
+		{"p99", a.Percentile(99)},
+		{"max", a.Max()},
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "metric: %s\n", a.metricName)
+	fmt.Fprintf(&sb, "%-8s %10d\n", "count", a.Count())
+	for _, r := range rows {
+		fmt.Fprintf(&sb, "%-8s %10.4f\n", r.label, r.value)
+	}
+	return sb.String()
+}
+
+// sortLocked sorts the recorded scores in place. Callers must hold a.mu.
+func (a *ScoreAggregator) sortLocked() {
+	if !a.sorted {
+		sort.Float64s(a.scores)
+		a.sorted = true
+	}
+}
@@ -0,0 +1,98 @@
+package evaluation
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestScoreAggregatorEmpty(t *testing.T) {
+	agg := NewScoreAggregator("empty")
+
+	if agg.Count() != 0 {
+		t.Errorf("Count() = %d, want 0", agg.Count())
+	}
+	for name, got := range map[string]float64{
+		"Mean":   agg.Mean(),
+		"Median": agg.Median(),
+		"StdDev": agg.StdDev(),
+		"Min":    agg.Min(),
+		"Max":    agg.Max(),
+	} {
+		if got != 0 {
+			t.Errorf("%s() = %v, want 0", name, got)
+		}
+	}
+}
+
+func TestScoreAggregatorStatistics(t *testing.T) {
+	agg := NewScoreAggregator("relevance")
+	agg.AddAll([]float64{4, 1, 3, 2})
+	agg.Add(5)
+	agg.Add(math.NaN())
+
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"Mean", agg.Mean(), 3},
+		{"Median", agg.Median(), 3},
+		{"StdDev", agg.StdDev(), math.Sqrt(2)},
+		{"Min", agg.Min(), 1},
+		{"Max", agg.Max(), 5},
+		{"P0", agg.Percentile(0), 1},
+		{"P25", agg.Percentile(25), 2},
+		{"P90", agg.Percentile(90), 4.6},
+		{"P100", agg.Percentile(100), 5},
+		{"P clamped", agg.Percentile(150), 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !approxEqual(tt.got, tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+
+	if agg.Count() != 5 {
+		t.Errorf("Count() = %d, want 5 (NaN ignored)", agg.Count())
+	}
+}
+
+func TestScoreAggregatorHistogram(t *testing.T) {
+	agg := NewScoreAggregator("quality")
+	agg.AddAll([]float64{-0.1, 0, 0.1, 0.3, 0.5, 0.6, 0.99, 1})
+
+	hist := agg.Histogram([]float64{0.5, 0, 0.25})
+
+	want := map[float64]int{0: 2, 0.25: 1, 0.5: 4}
+	if len(hist) != len(want) {
+		t.Fatalf("Histogram() = %v, want %v", hist, want)
+	}
+	for k, v := range want {
+		if hist[k] != v {
+			t.Errorf("bucket %v = %d, want %d", k, hist[k], v)
+		}
+	}
+
+	if got := agg.Histogram(nil); len(got) != 0 {
+		t.Errorf("Histogram(nil) = %v, want empty", got)
+	}
+}
+
+func TestScoreAggregatorSummary(t *testing.T) {
+	agg := NewScoreAggregator("faithfulness")
+	agg.AddAll([]float64{0.5, 0.75, 1})
+
+	summary := agg.Summary()
+	for _, want := range []string{"metric: faithfulness", "count", "mean", "median", "stddev", "p90", "max"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("Summary() missing %q:\n%s", want, summary)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package evaluation
+
+import (
+	"context"
+	"fmt"
+
+	langfuse "github.com/jdziat/langfuse-go"
+)
+
+// BiasScoreName is the score name used by
+// BiasDetectionTraceContext.UpdateBiasScore.
+const BiasScoreName = "bias_score"
+
+// ControlResponsesMetadataKey is the trace metadata key holding the control
+// responses of a bias detection trace.
+const ControlResponsesMetadataKey = "control_responses"
+
+// BiasDetectionTraceBuilder provides a fluent interface for creating bias
+// detection traces, where a response is compared against the responses to
+// controlled variants of the same prompt.
+type BiasDetectionTraceBuilder struct {
+	*langfuse.TraceBuilder
+	biasInput        *BiasDetectionInput
+	biasOutput       *BiasDetectionOutput
+	controlResponses []ControlResponse
+	metadata         map[string]any
+	tags             []string
+	evaluatorConfig  *EvaluatorConfig
+}
+
+// NewBiasDetectionTrace creates a new bias detection trace builder. The bias
+// category is added to the trace tags, and the control responses are
+// recorded in the trace metadata under ControlResponsesMetadataKey.
+//
+// Example:
+//
+//	trace, err := evaluation.NewBiasDetectionTrace(client, "hiring-bias").
+//	    Prompt("Should we hire John, a senior engineer?").
+//	    Response("Yes, John is well qualified.").
+//	    ControlResponses([]evaluation.ControlResponse{
+//	        {VariantDescription: "name changed to Jane", Response: "Jane may lack experience."},
+//	    }).
+//	    BiasCategory("gender").
+//	    Create(ctx)
+//	trace.UpdateBiasScore(ctx, 0.8, []string{"Jane may lack experience."})
+func NewBiasDetectionTrace(client *langfuse.Client, name string) *BiasDetectionTraceBuilder {
+	return &BiasDetectionTraceBuilder{
+		TraceBuilder: client.NewTrace().Name(name),
+		biasInput:    &BiasDetectionInput{},
+		biasOutput:   &BiasDetectionOutput{},
+	}
+}
+
+// Prompt sets the prompt given to the model.
+func (b *BiasDetectionTraceBuilder) Prompt(prompt string) *BiasDetectionTraceBuilder {
+	b.biasInput.Prompt = prompt
+	return b
+}
+
+// Response sets the model's response to the prompt.
+func (b *BiasDetectionTraceBuilder) Response(response string) *BiasDetectionTraceBuilder {
+	b.biasOutput.Response = response
+	return b
+}
+
+// ControlResponses sets the responses to variants of the prompt.
+func (b *BiasDetectionTraceBuilder) ControlResponses(responses []ControlResponse) *BiasDetectionTraceBuilder {
+	b.controlResponses = responses
+	return b
+}
+
+// BiasCategory sets the kind of bias evaluated, e.g. "gender", "political"
+// or "racial".
+func (b *BiasDetectionTraceBuilder) BiasCategory(category string) *BiasDetectionTraceBuilder {
+	b.biasInput.BiasCategory = category
+	return b
+}
+
+// ID sets the trace ID.
+func (b *BiasDetectionTraceBuilder) ID(id string) *BiasDetectionTraceBuilder {
+	b.TraceBuilder.ID(id)
+	return b
+}
+
+// UserID sets the user ID.
+func (b *BiasDetectionTraceBuilder) UserID(userID string) *BiasDetectionTraceBuilder {
+	b.TraceBuilder.UserID(userID)
+	return b
+}
+
+// SessionID sets the session ID.
+func (b *BiasDetectionTraceBuilder) SessionID(sessionID string) *BiasDetectionTraceBuilder {
+	b.TraceBuilder.SessionID(sessionID)
+	return b
+}
+
+// Tags sets the trace tags. The bias category is added to them.
+func (b *BiasDetectionTraceBuilder) Tags(tags []string) *BiasDetectionTraceBuilder {
+	b.tags = tags
+	b.TraceBuilder.Tags(tags)
+	return b
+}
+
+// Metadata sets the trace metadata.
+func (b *BiasDetectionTraceBuilder) Metadata(metadata map[string]any) *BiasDetectionTraceBuilder {
+	b.metadata = metadata
+	b.TraceBuilder.Metadata(metadata)
+	return b
+}
+
+// WithEvaluatorConfig records the judge configuration under
+// EvaluatorConfigMetadataKey in the trace metadata.
+func (b *BiasDetectionTraceBuilder) WithEvaluatorConfig(cfg EvaluatorConfig) *BiasDetectionTraceBuilder {
+	b.evaluatorConfig = &cfg
+	return b
+}
+
+// Release sets the release version.
+func (b *BiasDetectionTraceBuilder) Release(release string) *BiasDetectionTraceBuilder {
+	b.TraceBuilder.Release(release)
+	return b
+}
+
+// Version sets the version.
+func (b *BiasDetectionTraceBuilder) Version(version string) *BiasDetectionTraceBuilder {
+	b.TraceBuilder.Version(version)
+	return b
+}
+
+// Environment sets the environment.
+func (b *BiasDetectionTraceBuilder) Environment(env string) *BiasDetectionTraceBuilder {
+	b.TraceBuilder.Environment(env)
+	return b
+}
+
+// Public sets whether the trace is public.
+func (b *BiasDetectionTraceBuilder) Public(public bool) *BiasDetectionTraceBuilder {
+	b.TraceBuilder.Public(public)
+	return b
+}
+
+// Validate validates the bias detection trace configuration.
+func (b *BiasDetectionTraceBuilder) Validate() error {
+	if b.biasInput.Prompt == "" {
+		return fmt.Errorf("prompt is required for bias detection traces")
+	}
+	if b.biasInput.BiasCategory == "" {
+		return fmt.Errorf("bias category is required for bias detection traces")
+	}
+	for i, control := range b.controlResponses {
+		if control.VariantDescription == "" || control.Response == "" {
+			return fmt.Errorf("control response %d requires a variant description and a response", i)
+		}
+	}
+	return b.TraceBuilder.Validate()
+}
+
+// Create creates the bias detection trace.
+func (b *BiasDetectionTraceBuilder) Create(ctx context.Context) (*BiasDetectionTraceContext, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	b.TraceBuilder.Input(b.biasInput)
+	if b.biasOutput.Response != "" {
+		b.TraceBuilder.Output(b.biasOutput)
+	}
+	b.TraceBuilder.Tags(append(append([]string{}, b.tags...), b.biasInput.BiasCategory))
+
+	metadata := make(map[string]any, len(b.metadata)+1)
+	for k, v := range b.metadata {
+		metadata[k] = v
+	}
+	if len(b.controlResponses) > 0 {
+		metadata[ControlResponsesMetadataKey] = b.controlResponses
+	}
+	if b.evaluatorConfig != nil {
+		metadata = withEvaluatorConfig(metadata, b.evaluatorConfig)
+	}
+	if len(metadata) > 0 {
+		b.TraceBuilder.Metadata(metadata)
+	}
+
+	traceCtx, err := b.TraceBuilder.Create(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BiasDetectionTraceContext{
+		TraceContext:     traceCtx,
+		input:            b.biasInput,
+		output:           b.biasOutput,
+		controlResponses: b.controlResponses,
+	}, nil
+}
+
+// TryCreate is like Create but returns the trace and error as a single
+// BuildResult.
+func (b *BiasDetectionTraceBuilder) TryCreate(ctx context.Context) langfuse.BuildResult[*BiasDetectionTraceContext] {
+	return langfuse.NewBuildResult(b.Create(ctx))
+}
+
+// BiasDetectionTraceContext provides context for a bias detection trace
+// with typed methods.
+type BiasDetectionTraceContext struct {
+	*langfuse.TraceContext
+	input            *BiasDetectionInput
+	output           *BiasDetectionOutput
+	controlResponses []ControlResponse
+}
+
+// GetInput returns the bias detection input.
+func (c *BiasDetectionTraceContext) GetInput() *BiasDetectionInput {
+	return c.input
+}
+
+// GetOutput returns the bias detection output.
+func (c *BiasDetectionTraceContext) GetOutput() *BiasDetectionOutput {
+	return c.output
+}
+
+// ControlResponses returns the responses to variants of the prompt.
+func (c *BiasDetectionTraceContext) ControlResponses() []ControlResponse {
+	return c.controlResponses
+}
+
+// UpdateResponse sets the model's response to the prompt.
+func (c *BiasDetectionTraceContext) UpdateResponse(ctx context.Context, response string) error {
+	c.output = &BiasDetectionOutput{Response: response}
+	return c.Update().Output(c.output).Apply(ctx)
+}
+
+// ValidateForEvaluation checks if the trace has all required fields for evaluation.
+func (c *BiasDetectionTraceContext) ValidateForEvaluation() error {
+	if c.output == nil || c.output.Response == "" {
+		return fmt.Errorf("response is required before evaluation")
+	}
+	return ValidateFor(c.input, c.output, BiasEvaluator)
+}
+
+// UpdateBiasScore records a "bias_score" score between 0 (unbiased) and 1
+// (biased) on the trace. The claims in the response found to be biased, if
+// any, are recorded in the score metadata as "biased_claims".
+func (c *BiasDetectionTraceContext) UpdateBiasScore(ctx context.Context, score float64, biasedClaims []string) error {
+	if score < 0 || score > 1 {
+		return fmt.Errorf("bias score must be between 0 and 1, got %v", score)
+	}
+
+	builder := c.NewScore().Name(BiasScoreName).NumericValue(score)
+	if len(biasedClaims) > 0 {
+		builder = builder.Metadata(langfuse.Metadata{"biased_claims": biasedClaims})
+	}
+	return builder.Create(ctx)
+}
@@ -0,0 +1,112 @@
+package evaluation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	langfuse "github.com/jdziat/langfuse-go"
+	"github.com/jdziat/langfuse-go/langfusetest"
+)
+
+func TestBiasDetectionTraceBuilder_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *BiasDetectionInput
+		controls []ControlResponse
+	}{
+		{name: "missing prompt", input: &BiasDetectionInput{BiasCategory: "gender"}},
+		{name: "missing bias category", input: &BiasDetectionInput{Prompt: "p"}},
+		{
+			name:     "incomplete control response",
+			input:    &BiasDetectionInput{Prompt: "p", BiasCategory: "gender"},
+			controls: []ControlResponse{{VariantDescription: "female name"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := &BiasDetectionTraceBuilder{biasInput: tt.input, biasOutput: &BiasDetectionOutput{}, controlResponses: tt.controls}
+			if err := builder.Validate(); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestBiasDetectionTraceContext_ValidateForEvaluation(t *testing.T) {
+	input := &BiasDetectionInput{Prompt: "p", BiasCategory: "gender"}
+
+	valid := &BiasDetectionTraceContext{input: input, output: &BiasDetectionOutput{Response: "r"}}
+	if err := valid.ValidateForEvaluation(); err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+
+	missing := &BiasDetectionTraceContext{input: input, output: &BiasDetectionOutput{}}
+	if err := missing.ValidateForEvaluation(); err == nil {
+		t.Error("expected error without a response")
+	}
+
+	if err := valid.UpdateBiasScore(context.Background(), 1.5, nil); err == nil {
+		t.Error("expected error for a score above 1")
+	}
+}
+
+func TestBiasDetectionTrace_TagsAndMetadata(t *testing.T) {
+	server := langfusetest.NewMockServer()
+	defer server.Close()
+
+	client, err := langfuse.New("pk-lf-test-key", "sk-lf-test-key",
+		langfuse.WithBaseURL(server.URL),
+		langfuse.WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	trace, err := NewBiasDetectionTrace(client, "hiring-bias").
+		Prompt("Should we hire John?").
+		Response("Yes, John is well qualified.").
+		ControlResponses([]ControlResponse{{VariantDescription: "name changed to Jane", Response: "Jane may lack experience."}}).
+		BiasCategory("gender").
+		Tags([]string{"hiring"}).
+		Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := trace.ValidateForEvaluation(); err != nil {
+		t.Errorf("ValidateForEvaluation failed: %v", err)
+	}
+	if err := trace.UpdateBiasScore(ctx, 0.8, []string{"Jane may lack experience."}); err != nil {
+		t.Fatalf("UpdateBiasScore failed: %v", err)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	traces, scores := server.TracesCreated(), server.ScoresCreated()
+	if len(traces) == 0 || len(scores) == 0 {
+		t.Fatalf("received %d traces and %d scores, want at least one of each", len(traces), len(scores))
+	}
+	traceBody, scoreBody := traces[len(traces)-1], scores[len(scores)-1]
+
+	tags, _ := traceBody["tags"].([]any)
+	if len(tags) != 2 || tags[0] != "hiring" || tags[1] != "gender" {
+		t.Errorf("tags = %v, want [hiring gender]", traceBody["tags"])
+	}
+	metadata, _ := traceBody["metadata"].(map[string]any)
+	controls, _ := metadata[ControlResponsesMetadataKey].([]any)
+	if len(controls) != 1 || controls[0].(map[string]any)["variant_description"] != "name changed to Jane" {
+		t.Errorf("control responses metadata = %v", metadata[ControlResponsesMetadataKey])
+	}
+
+	if scoreBody["name"] != BiasScoreName || scoreBody["value"] != 0.8 {
+		t.Errorf("score = %v, want %s 0.8", scoreBody, BiasScoreName)
+	}
+	scoreMetadata, _ := scoreBody["metadata"].(map[string]any)
+	if claims, _ := scoreMetadata["biased_claims"].([]any); len(claims) != 1 {
+		t.Errorf("biased_claims = %v, want 1 claim", scoreMetadata["biased_claims"])
+	}
+}
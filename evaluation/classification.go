@@ -10,7 +10,9 @@ import (
 // ClassificationTraceBuilder provides a fluent interface for creating classification-ready traces.
 type ClassificationTraceBuilder struct {
 	*langfuse.TraceBuilder
-	classInput *ClassificationInput
+	classInput      *ClassificationInput
+	metadata        map[string]any
+	evaluatorConfig *EvaluatorConfig
 }
 
 // NewClassificationTrace creates a new classification trace builder.
@@ -65,10 +67,18 @@ func (b *ClassificationTraceBuilder) Tags(tags []string) *ClassificationTraceBui
 
 // Metadata sets the trace metadata.
 func (b *ClassificationTraceBuilder) Metadata(metadata map[string]any) *ClassificationTraceBuilder {
+	b.metadata = metadata
 	b.TraceBuilder.Metadata(metadata)
 	return b
 }
 
+// WithEvaluatorConfig records the judge configuration under
+// EvaluatorConfigMetadataKey in the trace metadata.
+func (b *ClassificationTraceBuilder) WithEvaluatorConfig(cfg EvaluatorConfig) *ClassificationTraceBuilder {
+	b.evaluatorConfig = &cfg
+	return b
+}
+
 // Release sets the release version.
 func (b *ClassificationTraceBuilder) Release(release string) *ClassificationTraceBuilder {
 	b.TraceBuilder.Release(release)
@@ -109,6 +119,10 @@ func (b *ClassificationTraceBuilder) Create(ctx context.Context) (*Classificatio
 
 	b.TraceBuilder.Input(b.classInput)
 
+	if b.evaluatorConfig != nil {
+		b.TraceBuilder.Metadata(withEvaluatorConfig(b.metadata, b.evaluatorConfig))
+	}
+
 	traceCtx, err := b.TraceBuilder.Create(ctx)
 	if err != nil {
 		return nil, err
@@ -120,6 +134,12 @@ func (b *ClassificationTraceBuilder) Create(ctx context.Context) (*Classificatio
 	}, nil
 }
 
+// TryCreate is like Create but returns the trace and error as a single
+// BuildResult.
+func (b *ClassificationTraceBuilder) TryCreate(ctx context.Context) langfuse.BuildResult[*ClassificationTraceContext] {
+	return langfuse.NewBuildResult(b.Create(ctx))
+}
+
 // ClassificationTraceContext provides context for a classification trace with typed methods.
 type ClassificationTraceContext struct {
 	*langfuse.TraceContext
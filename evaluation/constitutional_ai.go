@@ -0,0 +1,286 @@
+package evaluation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	langfuse "github.com/jdziat/langfuse-go"
+)
+
+// CritiqueScoreName is the score name used by
+// ConstitutionalAITraceContext.UpdateWithCritiqueScore.
+const CritiqueScoreName = "critique_score"
+
+// Span names of the constitutional AI stages.
+const (
+	InitialResponseSpanName = "initial-response"
+	CritiqueSpanName        = "critique"
+	RevisedResponseSpanName = "revised-response"
+)
+
+// ConstitutionalAITraceBuilder provides a fluent interface for creating
+// constitutional AI traces, where an initial response is critiqued against a
+// list of principles and then revised.
+type ConstitutionalAITraceBuilder struct {
+	*langfuse.TraceBuilder
+	caiInput        *ConstitutionalAIInput
+	caiOutput       *ConstitutionalAIOutput
+	metadata        map[string]any
+	evaluatorConfig *EvaluatorConfig
+}
+
+// NewConstitutionalAITrace creates a new constitutional AI trace builder.
+// The initial response, critique, and revised response are each recorded as
+// a child span of the trace.
+//
+// Example:
+//
+//	trace, err := evaluation.NewConstitutionalAITrace(client, "harmless-assistant").
+//	    UserQuery("How do I pick a lock?").
+//	    Constitution([]string{"Do not help with illegal activities."}).
+//	    InitialResponse("Insert a tension wrench and ...").
+//	    Critique("The response explains how to break into property.").
+//	    RevisedResponse("If you are locked out, contact a licensed locksmith.").
+//	    Create(ctx)
+//	trace.UpdateHarmScore(0.8, 0.1)
+//	fmt.Println(trace.HarmfulnessReduction())
+func NewConstitutionalAITrace(client *langfuse.Client, name string) *ConstitutionalAITraceBuilder {
+	return &ConstitutionalAITraceBuilder{
+		TraceBuilder: client.NewTrace().Name(name),
+		caiInput:     &ConstitutionalAIInput{},
+		caiOutput:    &ConstitutionalAIOutput{},
+	}
+}
+
+// UserQuery sets the user's request.
+func (b *ConstitutionalAITraceBuilder) UserQuery(query string) *ConstitutionalAITraceBuilder {
+	b.caiInput.UserQuery = query
+	return b
+}
+
+// Constitution sets the principles responses are critiqued against.
+func (b *ConstitutionalAITraceBuilder) Constitution(principles []string) *ConstitutionalAITraceBuilder {
+	b.caiInput.Constitution = principles
+	return b
+}
+
+// InitialResponse sets the response before critique.
+func (b *ConstitutionalAITraceBuilder) InitialResponse(response string) *ConstitutionalAITraceBuilder {
+	b.caiOutput.InitialResponse = response
+	return b
+}
+
+// Critique sets the critique of the initial response.
+func (b *ConstitutionalAITraceBuilder) Critique(critique string) *ConstitutionalAITraceBuilder {
+	b.caiOutput.Critique = critique
+	return b
+}
+
+// RevisedResponse sets the response after revision.
+func (b *ConstitutionalAITraceBuilder) RevisedResponse(response string) *ConstitutionalAITraceBuilder {
+	b.caiOutput.RevisedResponse = response
+	return b
+}
+
+// ID sets the trace ID.
+func (b *ConstitutionalAITraceBuilder) ID(id string) *ConstitutionalAITraceBuilder {
+	b.TraceBuilder.ID(id)
+	return b
+}
+
+// UserID sets the user ID.
+func (b *ConstitutionalAITraceBuilder) UserID(userID string) *ConstitutionalAITraceBuilder {
+	b.TraceBuilder.UserID(userID)
+	return b
+}
+
+// SessionID sets the session ID.
+func (b *ConstitutionalAITraceBuilder) SessionID(sessionID string) *ConstitutionalAITraceBuilder {
+	b.TraceBuilder.SessionID(sessionID)
+	return b
+}
+
+// Tags sets the trace tags.
+func (b *ConstitutionalAITraceBuilder) Tags(tags []string) *ConstitutionalAITraceBuilder {
+	b.TraceBuilder.Tags(tags)
+	return b
+}
+
+// Metadata sets the trace metadata.
+func (b *ConstitutionalAITraceBuilder) Metadata(metadata map[string]any) *ConstitutionalAITraceBuilder {
+	b.metadata = metadata
+	b.TraceBuilder.Metadata(metadata)
+	return b
+}
+
+// WithEvaluatorConfig records the judge configuration under
+// EvaluatorConfigMetadataKey in the trace metadata.
+func (b *ConstitutionalAITraceBuilder) WithEvaluatorConfig(cfg EvaluatorConfig) *ConstitutionalAITraceBuilder {
+	b.evaluatorConfig = &cfg
+	return b
+}
+
+// Release sets the release version.
+func (b *ConstitutionalAITraceBuilder) Release(release string) *ConstitutionalAITraceBuilder {
+	b.TraceBuilder.Release(release)
+	return b
+}
+
+// Version sets the version.
+func (b *ConstitutionalAITraceBuilder) Version(version string) *ConstitutionalAITraceBuilder {
+	b.TraceBuilder.Version(version)
+	return b
+}
+
+// Environment sets the environment.
+func (b *ConstitutionalAITraceBuilder) Environment(env string) *ConstitutionalAITraceBuilder {
+	b.TraceBuilder.Environment(env)
+	return b
+}
+
+// Public sets whether the trace is public.
+func (b *ConstitutionalAITraceBuilder) Public(public bool) *ConstitutionalAITraceBuilder {
+	b.TraceBuilder.Public(public)
+	return b
+}
+
+// Validate validates the constitutional AI trace configuration.
+func (b *ConstitutionalAITraceBuilder) Validate() error {
+	if b.caiInput.UserQuery == "" {
+		return fmt.Errorf("user query is required for constitutional AI traces")
+	}
+	if len(b.caiInput.Constitution) == 0 {
+		return fmt.Errorf("at least one constitution principle is required for constitutional AI traces")
+	}
+	return b.TraceBuilder.Validate()
+}
+
+// Create creates the constitutional AI trace and records each stage that is
+// set as a child span. The user query and constitution are recorded as the
+// trace input; the responses and critique, if set, are recorded as the
+// trace output.
+func (b *ConstitutionalAITraceBuilder) Create(ctx context.Context) (*ConstitutionalAITraceContext, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	b.TraceBuilder.Input(b.caiInput)
+	if *b.caiOutput != (ConstitutionalAIOutput{}) {
+		b.TraceBuilder.Output(b.caiOutput)
+	}
+
+	if b.evaluatorConfig != nil {
+		b.TraceBuilder.Metadata(withEvaluatorConfig(b.metadata, b.evaluatorConfig))
+	}
+
+	traceCtx, err := b.TraceBuilder.Create(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &ConstitutionalAITraceContext{
+		TraceContext: traceCtx,
+		input:        b.caiInput,
+		output:       b.caiOutput,
+	}
+	if err := c.recordStages(ctx); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// TryCreate is like Create but returns the trace and error as a single
+// BuildResult.
+func (b *ConstitutionalAITraceBuilder) TryCreate(ctx context.Context) langfuse.BuildResult[*ConstitutionalAITraceContext] {
+	return langfuse.NewBuildResult(b.Create(ctx))
+}
+
+// ConstitutionalAITraceContext provides context for a constitutional AI
+// trace with typed methods.
+type ConstitutionalAITraceContext struct {
+	*langfuse.TraceContext
+	input  *ConstitutionalAIInput
+	output *ConstitutionalAIOutput
+
+	initialHarm float64
+	revisedHarm float64
+	harmScored  bool
+}
+
+// GetInput returns the constitutional AI input.
+func (c *ConstitutionalAITraceContext) GetInput() *ConstitutionalAIInput {
+	return c.input
+}
+
+// GetOutput returns the constitutional AI output.
+func (c *ConstitutionalAITraceContext) GetOutput() *ConstitutionalAIOutput {
+	return c.output
+}
+
+// ValidateForEvaluation checks if the trace has all required fields for evaluation.
+func (c *ConstitutionalAITraceContext) ValidateForEvaluation() error {
+	return ValidateFor(c.input, c.output, ConstitutionalAIEvaluator)
+}
+
+// UpdateHarmScore sets the harmfulness scores of the initial and revised
+// responses, as given by a harm classifier or judge.
+func (c *ConstitutionalAITraceContext) UpdateHarmScore(initial, revised float64) {
+	c.initialHarm = initial
+	c.revisedHarm = revised
+	c.harmScored = true
+}
+
+// HarmfulnessReduction returns how much the revision reduced harmfulness:
+// the initial harm score minus the revised harm score. It is negative if the
+// revision made the response more harmful, and 0 until UpdateHarmScore is
+// called.
+func (c *ConstitutionalAITraceContext) HarmfulnessReduction() float64 {
+	if !c.harmScored {
+		return 0
+	}
+	return c.initialHarm - c.revisedHarm
+}
+
+// UpdateWithCritiqueScore records the quality of the critique as a numeric
+// score named "critique_score" on the trace.
+func (c *ConstitutionalAITraceContext) UpdateWithCritiqueScore(ctx context.Context, score float64) error {
+	return c.ScoreNumeric(ctx, CritiqueScoreName, score)
+}
+
+// recordStages creates a child span for each stage that is set.
+func (c *ConstitutionalAITraceContext) recordStages(ctx context.Context) error {
+	stages := []struct {
+		name   string
+		input  any
+		output string
+	}{
+		{InitialResponseSpanName, map[string]any{"user_query": c.input.UserQuery}, c.output.InitialResponse},
+		{CritiqueSpanName, map[string]any{
+			"constitution":     c.input.Constitution,
+			"initial_response": c.output.InitialResponse,
+		}, c.output.Critique},
+		{RevisedResponseSpanName, map[string]any{
+			"initial_response": c.output.InitialResponse,
+			"critique":         c.output.Critique,
+		}, c.output.RevisedResponse},
+	}
+
+	for _, stage := range stages {
+		if stage.output == "" {
+			continue
+		}
+		now := time.Now()
+		_, err := c.NewSpan().
+			Name(stage.name).
+			StartTime(now).
+			EndTime(now).
+			Input(stage.input).
+			Output(stage.output).
+			Create(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to record %s stage: %w", stage.name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package evaluation
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	langfuse "github.com/jdziat/langfuse-go"
+	"github.com/jdziat/langfuse-go/langfusetest"
+)
+
+func TestConstitutionalAITraceBuilder_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		input *ConstitutionalAIInput
+	}{
+		{name: "missing user query", input: &ConstitutionalAIInput{Constitution: []string{"be harmless"}}},
+		{name: "missing constitution", input: &ConstitutionalAIInput{UserQuery: "q"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := &ConstitutionalAITraceBuilder{caiInput: tt.input, caiOutput: &ConstitutionalAIOutput{}}
+			if err := builder.Validate(); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestConstitutionalAITraceContext_ValidateForEvaluation(t *testing.T) {
+	input := &ConstitutionalAIInput{UserQuery: "q", Constitution: []string{"be harmless"}}
+
+	valid := &ConstitutionalAITraceContext{
+		input:  input,
+		output: &ConstitutionalAIOutput{InitialResponse: "a", RevisedResponse: "b"},
+	}
+	if err := valid.ValidateForEvaluation(); err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+
+	missing := &ConstitutionalAITraceContext{input: input, output: &ConstitutionalAIOutput{InitialResponse: "a"}}
+	if err := missing.ValidateForEvaluation(); err == nil {
+		t.Error("expected error without a revised response")
+	}
+}
+
+func TestConstitutionalAITraceContext_HarmfulnessReduction(t *testing.T) {
+	c := &ConstitutionalAITraceContext{}
+	if got := c.HarmfulnessReduction(); got != 0 {
+		t.Errorf("HarmfulnessReduction() before UpdateHarmScore = %v, want 0", got)
+	}
+	c.UpdateHarmScore(0.8, 0.1)
+	if got := c.HarmfulnessReduction(); math.Abs(got-0.7) > 1e-9 {
+		t.Errorf("HarmfulnessReduction() = %v, want 0.7", got)
+	}
+}
+
+func TestConstitutionalAITrace_RecordsStagesAsSpans(t *testing.T) {
+	server := langfusetest.NewMockServer()
+	defer server.Close()
+
+	client, err := langfuse.New("pk-lf-test-key", "sk-lf-test-key",
+		langfuse.WithBaseURL(server.URL),
+		langfuse.WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	trace, err := NewConstitutionalAITrace(client, "harmless").
+		UserQuery("How do I pick a lock?").
+		Constitution([]string{"Do not help with illegal activities."}).
+		InitialResponse("Insert a tension wrench.").
+		Critique("Explains how to break in.").
+		RevisedResponse("Contact a locksmith.").
+		Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := trace.ValidateForEvaluation(); err != nil {
+		t.Errorf("ValidateForEvaluation failed: %v", err)
+	}
+	if err := trace.UpdateWithCritiqueScore(ctx, 0.9); err != nil {
+		t.Fatalf("UpdateWithCritiqueScore failed: %v", err)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	var spanNames []string
+	for _, e := range server.RequestsByType("span-create") {
+		if e.Body["traceId"] != trace.ID() {
+			t.Errorf("span traceId = %v, want %s", e.Body["traceId"], trace.ID())
+		}
+		spanNames = append(spanNames, e.Body["name"].(string))
+	}
+	var critiqueScore any
+	for _, body := range server.ScoresCreated() {
+		if body["name"] == CritiqueScoreName {
+			critiqueScore = body["value"]
+		}
+	}
+	want := []string{InitialResponseSpanName, CritiqueSpanName, RevisedResponseSpanName}
+	if len(spanNames) != len(want) {
+		t.Fatalf("span names = %v, want %v", spanNames, want)
+	}
+	for i := range want {
+		if spanNames[i] != want[i] {
+			t.Errorf("span %d = %q, want %q", i, spanNames[i], want[i])
+		}
+	}
+	if critiqueScore != 0.9 {
+		t.Errorf("critique score = %v, want 0.9", critiqueScore)
+	}
+}
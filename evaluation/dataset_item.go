@@ -0,0 +1,101 @@
+package evaluation
+
+import (
+	"encoding/json"
+	"fmt"
+
+	langfuse "github.com/jdziat/langfuse-go"
+)
+
+// WorkflowTypeMetadataKey is the dataset item metadata key holding the
+// EvaluationType of the item, as set by GoldenDatasetBuilder.WorkflowType.
+const WorkflowTypeMetadataKey = "workflow_type"
+
+// WorkflowType returns the workflow type recorded in the item's metadata
+// under WorkflowTypeMetadataKey, or "" if there is none.
+func WorkflowType(item *langfuse.DatasetItem) string {
+	if item == nil {
+		return ""
+	}
+	workflowType, _ := item.Metadata[WorkflowTypeMetadataKey].(string)
+	return workflowType
+}
+
+// ToRAGInput decodes the item's input as a RAGInput. If the input has no
+// ground truth, a string expected output is used instead. It returns an
+// error if the query or context is missing.
+//
+// Example:
+//
+//	for _, item := range items.Data {
+//	    input, err := evaluation.ToRAGInput(&item)
+//	    if err != nil {
+//	        return err
+//	    }
+//	    trace, _ := evaluation.NewRAGTrace(client, "rag-eval").
+//	        Query(input.Query).
+//	        Context(input.Context...).
+//	        GroundTruth(input.GroundTruth).
+//	        Create(ctx)
+//	}
+func ToRAGInput(item *langfuse.DatasetItem) (*RAGInput, error) {
+	input := &RAGInput{}
+	if err := decodeItemInput(item, input, RAGEvaluator); err != nil {
+		return nil, err
+	}
+	if input.GroundTruth == "" {
+		input.GroundTruth, _ = item.ExpectedOutput.(string)
+	}
+	return input, nil
+}
+
+// ToQAInput decodes the item's input as a QAInput. If the input has no
+// ground truth, a string expected output is used instead. It returns an
+// error if the query is missing.
+func ToQAInput(item *langfuse.DatasetItem) (*QAInput, error) {
+	input := &QAInput{}
+	if err := decodeItemInput(item, input, QAEvaluator); err != nil {
+		return nil, err
+	}
+	if input.GroundTruth == "" {
+		input.GroundTruth, _ = item.ExpectedOutput.(string)
+	}
+	return input, nil
+}
+
+// ToSummarizationInput decodes the item's input as a SummarizationInput. If
+// the input has no ground truth, a string expected output is used instead.
+// It returns an error if the text to summarize is missing.
+func ToSummarizationInput(item *langfuse.DatasetItem) (*SummarizationInput, error) {
+	input := &SummarizationInput{}
+	if err := decodeItemInput(item, input, SummarizationEvaluator); err != nil {
+		return nil, err
+	}
+	if input.GroundTruth == "" {
+		input.GroundTruth, _ = item.ExpectedOutput.(string)
+	}
+	return input, nil
+}
+
+// decodeItemInput decodes the item's input into target through its JSON
+// form and checks it has the input fields required by reqs.
+func decodeItemInput(item *langfuse.DatasetItem, target any, reqs EvaluatorRequirements) error {
+	if item == nil {
+		return fmt.Errorf("dataset item is required")
+	}
+	if item.Input == nil {
+		return fmt.Errorf("dataset item %s has no input", item.ID)
+	}
+
+	data, err := json.Marshal(item.Input)
+	if err != nil {
+		return fmt.Errorf("failed to encode input of dataset item %s: %w", item.ID, err)
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("input of dataset item %s is not a valid %s input: %w", item.ID, reqs.Name, err)
+	}
+	if err := ValidateInput(target, reqs); err != nil {
+		return fmt.Errorf("dataset item %s: %w", item.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package evaluation
+
+import (
+	"strings"
+	"testing"
+
+	langfuse "github.com/jdziat/langfuse-go"
+)
+
+func TestToRAGInput(t *testing.T) {
+	item := &langfuse.DatasetItem{
+		ID:             "item-1",
+		Input:          map[string]any{"query": "What is Go?", "context": []any{"Go is a language."}},
+		ExpectedOutput: "A programming language.",
+	}
+	input, err := ToRAGInput(item)
+	if err != nil {
+		t.Fatalf("ToRAGInput failed: %v", err)
+	}
+	if input.Query != "What is Go?" || len(input.Context) != 1 || input.GroundTruth != "A programming language." {
+		t.Errorf("ToRAGInput() = %+v", input)
+	}
+
+	_, err = ToRAGInput(&langfuse.DatasetItem{ID: "item-2", Input: map[string]any{"query": "q"}})
+	if err == nil || !strings.Contains(err.Error(), "item-2") || !strings.Contains(err.Error(), "context") {
+		t.Errorf("ToRAGInput() error = %v, want the item ID and missing context", err)
+	}
+}
+
+func TestToQAAndSummarizationInput(t *testing.T) {
+	qa, err := ToQAInput(&langfuse.DatasetItem{Input: QAInput{Query: "q", GroundTruth: "a"}, ExpectedOutput: "other"})
+	if err != nil || qa.Query != "q" || qa.GroundTruth != "a" {
+		t.Errorf("ToQAInput() = %+v, %v", qa, err)
+	}
+	if _, err := ToQAInput(&langfuse.DatasetItem{Input: "plain text"}); err == nil {
+		t.Error("ToQAInput should fail for a non-object input")
+	}
+
+	summary, err := ToSummarizationInput(&langfuse.DatasetItem{Input: map[string]any{"input": "long text", "max_length": 50}})
+	if err != nil || summary.Input != "long text" || summary.MaxLength != 50 {
+		t.Errorf("ToSummarizationInput() = %+v, %v", summary, err)
+	}
+	if _, err := ToSummarizationInput(&langfuse.DatasetItem{}); err == nil {
+		t.Error("ToSummarizationInput should fail without input")
+	}
+}
+
+func TestWorkflowType(t *testing.T) {
+	item := &langfuse.DatasetItem{Metadata: langfuse.Metadata{WorkflowTypeMetadataKey: "rag"}}
+	if got := WorkflowType(item); got != "rag" {
+		t.Errorf("WorkflowType() = %q, want rag", got)
+	}
+	if got := WorkflowType(&langfuse.DatasetItem{}); got != "" {
+		t.Errorf("WorkflowType() = %q, want empty", got)
+	}
+}
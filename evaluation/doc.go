@@ -35,12 +35,18 @@
 //	    log.Printf("Missing fields: %v", err)
 //	}
 //
+// To see why fetched traces are rejected, render a validation report with
+// links to each trace in the Langfuse UI:
+//
+//	html, err := evaluation.GenerateValidationReport(client, traces, evaluation.RAGEvaluator)
+//
 // # Evaluator Requirements
 //
 // Pre-defined evaluator requirements are available:
 //   - RAGEvaluator: Requires query, context, output
 //   - QAEvaluator: Requires query, output
 //   - SummarizationEvaluator: Requires input, output
+//   - TranslationEvaluator: Requires source_text, target_language, output
 //   - ClassificationEvaluator: Requires input, output
 //   - And more...
 package evaluation
@@ -0,0 +1,240 @@
+package evaluation
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	langfuse "github.com/jdziat/langfuse-go"
+)
+
+// DocumentQATraceBuilder provides a fluent interface for creating traces of
+// questions answered from a paged document, with page and section
+// citations.
+type DocumentQATraceBuilder struct {
+	*langfuse.TraceBuilder
+	docInput        *DocumentQAInput
+	docOutput       *DocumentQAOutput
+	metadata        map[string]any
+	evaluatorConfig *EvaluatorConfig
+}
+
+// NewDocumentQATrace creates a new document question answering trace
+// builder.
+//
+// Example:
+//
+//	trace, err := evaluation.NewDocumentQATrace(client, "contract-qa").
+//	    Document(contractText, 12).
+//	    Question("What is the notice period?").
+//	    Answer("90 days").
+//	    Citations([]evaluation.Citation{
+//	        {Page: 4, Section: "8.2 Termination", Excerpt: "ninety (90) days written notice"},
+//	    }).
+//	    GroundTruth("90 days").
+//	    Create(ctx)
+//	coverage := trace.CitationCoverage()
+func NewDocumentQATrace(client *langfuse.Client, name string) *DocumentQATraceBuilder {
+	return &DocumentQATraceBuilder{
+		TraceBuilder: client.NewTrace().Name(name),
+		docInput:     &DocumentQAInput{},
+		docOutput:    &DocumentQAOutput{},
+	}
+}
+
+// Document sets the document content and its number of pages. The document
+// length, in characters, is recorded alongside them.
+func (b *DocumentQATraceBuilder) Document(content string, pages int) *DocumentQATraceBuilder {
+	b.docInput.Document = content
+	b.docInput.DocumentLength = utf8.RuneCountInString(content)
+	b.docInput.PageCount = pages
+	return b
+}
+
+// Question sets the question about the document.
+func (b *DocumentQATraceBuilder) Question(question string) *DocumentQATraceBuilder {
+	b.docInput.Question = question
+	return b
+}
+
+// Answer sets the answer to the question.
+func (b *DocumentQATraceBuilder) Answer(answer string) *DocumentQATraceBuilder {
+	b.docOutput.Answer = answer
+	return b
+}
+
+// Citations sets the passages of the document the answer is based on.
+func (b *DocumentQATraceBuilder) Citations(citations []Citation) *DocumentQATraceBuilder {
+	b.docOutput.Citations = citations
+	return b
+}
+
+// GroundTruth sets the expected answer for evaluation.
+func (b *DocumentQATraceBuilder) GroundTruth(truth string) *DocumentQATraceBuilder {
+	b.docInput.GroundTruth = truth
+	return b
+}
+
+// ID sets the trace ID.
+func (b *DocumentQATraceBuilder) ID(id string) *DocumentQATraceBuilder {
+	b.TraceBuilder.ID(id)
+	return b
+}
+
+// UserID sets the user ID.
+func (b *DocumentQATraceBuilder) UserID(userID string) *DocumentQATraceBuilder {
+	b.TraceBuilder.UserID(userID)
+	return b
+}
+
+// SessionID sets the session ID.
+func (b *DocumentQATraceBuilder) SessionID(sessionID string) *DocumentQATraceBuilder {
+	b.TraceBuilder.SessionID(sessionID)
+	return b
+}
+
+// Tags sets the trace tags.
+func (b *DocumentQATraceBuilder) Tags(tags []string) *DocumentQATraceBuilder {
+	b.TraceBuilder.Tags(tags)
+	return b
+}
+
+// Metadata sets the trace metadata.
+func (b *DocumentQATraceBuilder) Metadata(metadata map[string]any) *DocumentQATraceBuilder {
+	b.metadata = metadata
+	b.TraceBuilder.Metadata(metadata)
+	return b
+}
+
+// WithEvaluatorConfig records the judge configuration under
+// EvaluatorConfigMetadataKey in the trace metadata.
+func (b *DocumentQATraceBuilder) WithEvaluatorConfig(cfg EvaluatorConfig) *DocumentQATraceBuilder {
+	b.evaluatorConfig = &cfg
+	return b
+}
+
+// Release sets the release version.
+func (b *DocumentQATraceBuilder) Release(release string) *DocumentQATraceBuilder {
+	b.TraceBuilder.Release(release)
+	return b
+}
+
+// Version sets the version.
+func (b *DocumentQATraceBuilder) Version(version string) *DocumentQATraceBuilder {
+	b.TraceBuilder.Version(version)
+	return b
+}
+
+// Environment sets the environment.
+func (b *DocumentQATraceBuilder) Environment(env string) *DocumentQATraceBuilder {
+	b.TraceBuilder.Environment(env)
+	return b
+}
+
+// Public sets whether the trace is public.
+func (b *DocumentQATraceBuilder) Public(public bool) *DocumentQATraceBuilder {
+	b.TraceBuilder.Public(public)
+	return b
+}
+
+// Validate validates the document QA trace configuration.
+func (b *DocumentQATraceBuilder) Validate() error {
+	if b.docInput.Document == "" {
+		return fmt.Errorf("document is required for document QA traces")
+	}
+	if b.docInput.PageCount < 1 {
+		return fmt.Errorf("page count must be at least 1, got %d", b.docInput.PageCount)
+	}
+	if b.docInput.Question == "" {
+		return fmt.Errorf("question is required for document QA traces")
+	}
+	for i, c := range b.docOutput.Citations {
+		if c.Page < 1 || c.Page > b.docInput.PageCount {
+			return fmt.Errorf("citation %d cites page %d, want a page between 1 and %d", i, c.Page, b.docInput.PageCount)
+		}
+	}
+	return b.TraceBuilder.Validate()
+}
+
+// Create creates the document QA trace and returns a context for updating
+// it. The document, its length and page count, the question, and the ground
+// truth are recorded as the trace input; the answer and citations, if set,
+// are recorded as the trace output.
+func (b *DocumentQATraceBuilder) Create(ctx context.Context) (*DocumentQATraceContext, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	b.TraceBuilder.Input(b.docInput)
+	if b.docOutput.Answer != "" || len(b.docOutput.Citations) > 0 {
+		b.TraceBuilder.Output(b.docOutput)
+	}
+
+	if b.evaluatorConfig != nil {
+		b.TraceBuilder.Metadata(withEvaluatorConfig(b.metadata, b.evaluatorConfig))
+	}
+
+	traceCtx, err := b.TraceBuilder.Create(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DocumentQATraceContext{
+		TraceContext: traceCtx,
+		input:        b.docInput,
+		output:       b.docOutput,
+	}, nil
+}
+
+// TryCreate is like Create but returns the trace and error as a single
+// BuildResult.
+func (b *DocumentQATraceBuilder) TryCreate(ctx context.Context) langfuse.BuildResult[*DocumentQATraceContext] {
+	return langfuse.NewBuildResult(b.Create(ctx))
+}
+
+// DocumentQATraceContext provides context for a document QA trace with
+// typed methods.
+type DocumentQATraceContext struct {
+	*langfuse.TraceContext
+	input  *DocumentQAInput
+	output *DocumentQAOutput
+}
+
+// GetInput returns the document QA input.
+func (d *DocumentQATraceContext) GetInput() *DocumentQAInput {
+	return d.input
+}
+
+// GetOutput returns the document QA output.
+func (d *DocumentQATraceContext) GetOutput() *DocumentQAOutput {
+	return d.output
+}
+
+// UpdateOutput sets the answer and citations and updates the trace output.
+func (d *DocumentQATraceContext) UpdateOutput(ctx context.Context, answer string, citations []Citation) error {
+	d.output = &DocumentQAOutput{Answer: answer, Citations: citations}
+	return d.Update().Output(d.output).Apply(ctx)
+}
+
+// ValidateForEvaluation checks if the trace has all required fields for evaluation.
+func (d *DocumentQATraceContext) ValidateForEvaluation() error {
+	return ValidateFor(d.input, d.output, DocumentQAEvaluator)
+}
+
+// CitationCoverage returns the fraction of the document's pages that are
+// cited at least once. Citations of pages outside the document are ignored.
+// It returns 0 if the page count is not positive.
+func (d *DocumentQATraceContext) CitationCoverage() float64 {
+	pages := d.input.PageCount
+	if pages < 1 || d.output == nil {
+		return 0
+	}
+
+	cited := make(map[int]bool)
+	for _, c := range d.output.Citations {
+		if c.Page >= 1 && c.Page <= pages {
+			cited[c.Page] = true
+		}
+	}
+	return float64(len(cited)) / float64(pages)
+}
@@ -0,0 +1,150 @@
+package evaluation
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/jdziat/langfuse-go/langfusetest"
+)
+
+func TestDocumentQATraceBuilder_FluentAPI(t *testing.T) {
+	builder := &DocumentQATraceBuilder{
+		docInput:  &DocumentQAInput{},
+		docOutput: &DocumentQAOutput{},
+	}
+
+	result := builder.
+		Document("Résumé of terms", 3).
+		Question("What is the notice period?").
+		Answer("90 days").
+		Citations([]Citation{{Page: 2, Section: "Termination", Excerpt: "90 days"}}).
+		GroundTruth("90 days")
+
+	if result != builder {
+		t.Error("fluent methods should return the same builder")
+	}
+	if builder.docInput.DocumentLength != 15 {
+		t.Errorf("DocumentLength = %d, want 15 characters", builder.docInput.DocumentLength)
+	}
+	if builder.docInput.PageCount != 3 || builder.docInput.Question != "What is the notice period?" {
+		t.Errorf("input not set correctly: %+v", builder.docInput)
+	}
+	if builder.docOutput.Answer != "90 days" || len(builder.docOutput.Citations) != 1 {
+		t.Errorf("output not set correctly: %+v", builder.docOutput)
+	}
+}
+
+func TestDocumentQATraceBuilder_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     *DocumentQAInput
+		citations []Citation
+	}{
+		{"missing document", &DocumentQAInput{PageCount: 1, Question: "q"}, nil},
+		{"missing pages", &DocumentQAInput{Document: "d", Question: "q"}, nil},
+		{"missing question", &DocumentQAInput{Document: "d", PageCount: 1}, nil},
+		{"citation out of range", &DocumentQAInput{Document: "d", PageCount: 2, Question: "q"}, []Citation{{Page: 3}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := &DocumentQATraceBuilder{docInput: tt.input, docOutput: &DocumentQAOutput{Citations: tt.citations}}
+			if err := builder.Validate(); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestDocumentQATraceContext_ValidateForEvaluation(t *testing.T) {
+	input := &DocumentQAInput{Document: "d", DocumentLength: 1, PageCount: 1, Question: "q"}
+
+	tests := []struct {
+		name        string
+		output      *DocumentQAOutput
+		expectError bool
+	}{
+		{name: "valid", output: &DocumentQAOutput{Answer: "a", Citations: []Citation{{Page: 1}}}},
+		{name: "missing citations", output: &DocumentQAOutput{Answer: "a"}, expectError: true},
+		{name: "missing answer", output: &DocumentQAOutput{Citations: []Citation{{Page: 1}}}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &DocumentQATraceContext{input: input, output: tt.output}
+			err := ctx.ValidateForEvaluation()
+			if tt.expectError && err == nil {
+				t.Error("expected error, got nil")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestDocumentQATraceContext_CitationCoverage(t *testing.T) {
+	tests := []struct {
+		name      string
+		pages     int
+		citations []Citation
+		want      float64
+	}{
+		{"no citations", 4, nil, 0},
+		{"distinct pages", 4, []Citation{{Page: 1}, {Page: 3}}, 0.5},
+		{"repeated page", 4, []Citation{{Page: 2, Section: "a"}, {Page: 2, Section: "b"}}, 0.25},
+		{"out of range ignored", 2, []Citation{{Page: 2}, {Page: 9}}, 0.5},
+		{"no pages", 0, []Citation{{Page: 1}}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &DocumentQATraceContext{
+				input:  &DocumentQAInput{PageCount: tt.pages},
+				output: &DocumentQAOutput{Citations: tt.citations},
+			}
+			if got := ctx.CitationCoverage(); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("CitationCoverage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocumentQATrace_Create(t *testing.T) {
+	client, server := langfusetest.NewTestClient(t)
+	ctx := context.Background()
+
+	trace, err := NewDocumentQATrace(client, "contract-qa").
+		Document("Either party may terminate with 90 days notice.", 12).
+		Question("What is the notice period?").
+		Answer("90 days").
+		Citations([]Citation{{Page: 4, Section: "8.2 Termination", Excerpt: "90 days notice"}}).
+		Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := trace.ValidateForEvaluation(); err != nil {
+		t.Errorf("ValidateForEvaluation() error = %v", err)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	traces := server.TracesCreated()
+	if len(traces) != 1 {
+		t.Fatalf("got %d traces, want 1", len(traces))
+	}
+	input := traces[0]["input"].(map[string]any)
+	if input["page_count"] != float64(12) || input["document_length"] != float64(47) || input["question"] != "What is the notice period?" {
+		t.Errorf("trace input = %v", input)
+	}
+	output := traces[0]["output"].(map[string]any)
+	citations := output["citations"].([]any)
+	if output["answer"] != "90 days" || len(citations) != 1 {
+		t.Fatalf("trace output = %v", output)
+	}
+	if citation := citations[0].(map[string]any); citation["page"] != float64(4) || citation["section"] != "8.2 Termination" {
+		t.Errorf("citation = %v", citation)
+	}
+}
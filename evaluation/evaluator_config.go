@@ -0,0 +1,44 @@
+package evaluation
+
+// EvaluatorConfigMetadataKey is the trace metadata key under which an
+// EvaluatorConfig is stored.
+const EvaluatorConfigMetadataKey = "_evaluator_config"
+
+// EvaluatorConfig describes the judge configuration used to evaluate a
+// trace, so evaluation runs can be reproduced and compared.
+//
+// Example:
+//
+//	trace, _ := evaluation.NewRAGTrace(client, "document-qa").
+//	    Query(query).
+//	    Context(chunks...).
+//	    WithEvaluatorConfig(evaluation.EvaluatorConfig{
+//	        JudgeModel:       "gpt-4o-2024-08-06",
+//	        JudgeTemperature: 0,
+//	        EvaluatorVersion: "faithfulness-v3",
+//	    }).
+//	    Create(ctx)
+type EvaluatorConfig struct {
+	// JudgeModel is the model used as the judge
+	JudgeModel string `json:"judge_model,omitempty"`
+
+	// JudgeTemperature is the sampling temperature of the judge model
+	JudgeTemperature float64 `json:"judge_temperature"`
+
+	// JudgeSystemPrompt is the system prompt given to the judge model
+	JudgeSystemPrompt string `json:"judge_system_prompt,omitempty"`
+
+	// EvaluatorVersion identifies the version of the evaluator
+	EvaluatorVersion string `json:"evaluator_version,omitempty"`
+}
+
+// withEvaluatorConfig returns a copy of metadata with cfg stored under
+// EvaluatorConfigMetadataKey.
+func withEvaluatorConfig(metadata map[string]any, cfg *EvaluatorConfig) map[string]any {
+	merged := make(map[string]any, len(metadata)+1)
+	for k, v := range metadata {
+		merged[k] = v
+	}
+	merged[EvaluatorConfigMetadataKey] = *cfg
+	return merged
+}
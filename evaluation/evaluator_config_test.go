@@ -0,0 +1,80 @@
+package evaluation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jdziat/langfuse-go/langfusetest"
+)
+
+func TestWithEvaluatorConfig_StoredInMetadata(t *testing.T) {
+	client, server := langfusetest.NewTestClient(t)
+	ctx := context.Background()
+
+	cfg := EvaluatorConfig{
+		JudgeModel:        "gpt-4o",
+		JudgeTemperature:  0.2,
+		JudgeSystemPrompt: "You are a strict grader.",
+		EvaluatorVersion:  "v3",
+	}
+
+	// Metadata set after the config must not drop it.
+	if _, err := NewRAGTrace(client, "rag").
+		Query("q").
+		Context("c").
+		WithEvaluatorConfig(cfg).
+		Metadata(map[string]any{"team": "search"}).
+		Create(ctx); err != nil {
+		t.Fatalf("RAG Create() error = %v", err)
+	}
+	if _, err := NewGroundednessTrace(client, "grounded").
+		Query("q").
+		SourceDocuments([]SourceDocument{{ID: "d1", Content: "c"}}).
+		WithEvaluatorConfig(cfg).
+		Create(ctx); err != nil {
+		t.Fatalf("Groundedness Create() error = %v", err)
+	}
+	if _, err := NewQATrace(client, "qa").Query("q").Create(ctx); err != nil {
+		t.Fatalf("QA Create() error = %v", err)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	traces := server.TracesCreated()
+	if len(traces) != 3 {
+		t.Fatalf("got %d traces, want 3", len(traces))
+	}
+	for _, trace := range traces[:2] {
+		metadata, _ := trace["metadata"].(map[string]any)
+		stored, ok := metadata[EvaluatorConfigMetadataKey].(map[string]any)
+		if !ok {
+			t.Fatalf("trace %v missing %s: %v", trace["name"], EvaluatorConfigMetadataKey, metadata)
+		}
+		if stored["judge_model"] != "gpt-4o" || stored["judge_temperature"] != 0.2 || stored["evaluator_version"] != "v3" {
+			t.Errorf("stored config = %v", stored)
+		}
+	}
+	if metadata, _ := traces[0]["metadata"].(map[string]any); metadata["team"] != "search" {
+		t.Errorf("user metadata not preserved: %v", metadata)
+	}
+	if metadata, _ := traces[2]["metadata"].(map[string]any); metadata[EvaluatorConfigMetadataKey] != nil {
+		t.Errorf("trace without config has %s: %v", EvaluatorConfigMetadataKey, metadata)
+	}
+}
+
+func TestValidateDetailed_EvaluatorConfig(t *testing.T) {
+	reqs := NewEvaluatorRequirement("judge").
+		RequireInputField("query").
+		WithEvaluatorConfig(EvaluatorConfig{JudgeModel: "gpt-4o", EvaluatorVersion: "v1"}).
+		Build()
+
+	result := ValidateDetailed(map[string]any{"query": "q"}, nil, reqs)
+	if result.EvaluatorConfig == nil || result.EvaluatorConfig.JudgeModel != "gpt-4o" {
+		t.Errorf("EvaluatorConfig = %+v, want judge config", result.EvaluatorConfig)
+	}
+
+	if result := ValidateDetailed(&RAGInput{Query: "q"}, nil, RAGEvaluator); result.EvaluatorConfig != nil {
+		t.Errorf("EvaluatorConfig = %+v, want nil", result.EvaluatorConfig)
+	}
+}
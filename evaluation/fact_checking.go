@@ -0,0 +1,279 @@
+package evaluation
+
+import (
+	"context"
+	"fmt"
+
+	langfuse "github.com/jdziat/langfuse-go"
+)
+
+// FactCheckingAccuracyScoreName is the score name used by
+// FactCheckingTraceContext.UpdateWithAccuracy.
+const FactCheckingAccuracyScoreName = "fact_checking_accuracy"
+
+// FactCheckingTraceBuilder provides a fluent interface for creating claim
+// verification traces.
+type FactCheckingTraceBuilder struct {
+	*langfuse.TraceBuilder
+	factInput       *FactCheckingInput
+	factOutput      *FactCheckingOutput
+	metadata        map[string]any
+	evaluatorConfig *EvaluatorConfig
+}
+
+// NewFactCheckingTrace creates a new fact-checking trace builder.
+//
+// Example:
+//
+//	trace, err := evaluation.NewFactCheckingTrace(client, "verify-claims").
+//	    Claims([]string{"The Eiffel Tower is in Paris."}).
+//	    SourceDocuments([]string{"The Eiffel Tower is a landmark in Paris, France."}).
+//	    Verdicts([]evaluation.FactVerdict{{
+//	        Claim:    "The Eiffel Tower is in Paris.",
+//	        Verdict:  evaluation.FactVerdictSupported,
+//	        Evidence: "a landmark in Paris, France",
+//	    }}).
+//	    GroundTruth([]string{evaluation.FactVerdictSupported}).
+//	    Create(ctx)
+//	trace.UpdateWithAccuracy(ctx)
+func NewFactCheckingTrace(client *langfuse.Client, name string) *FactCheckingTraceBuilder {
+	return &FactCheckingTraceBuilder{
+		TraceBuilder: client.NewTrace().Name(name),
+		factInput:    &FactCheckingInput{},
+		factOutput:   &FactCheckingOutput{},
+	}
+}
+
+// Claims sets the claims to verify.
+func (b *FactCheckingTraceBuilder) Claims(claims []string) *FactCheckingTraceBuilder {
+	b.factInput.Claims = claims
+	return b
+}
+
+// SourceDocuments sets the documents claims are verified against.
+func (b *FactCheckingTraceBuilder) SourceDocuments(docs []string) *FactCheckingTraceBuilder {
+	b.factInput.SourceDocuments = docs
+	return b
+}
+
+// Verdicts sets the model's verdicts on the claims.
+func (b *FactCheckingTraceBuilder) Verdicts(verdicts []FactVerdict) *FactCheckingTraceBuilder {
+	b.factOutput.Verdicts = verdicts
+	return b
+}
+
+// GroundTruth sets the expected verdict for each claim, in the same order
+// as Claims.
+func (b *FactCheckingTraceBuilder) GroundTruth(verdicts []string) *FactCheckingTraceBuilder {
+	b.factInput.GroundTruth = verdicts
+	return b
+}
+
+// ID sets the trace ID.
+func (b *FactCheckingTraceBuilder) ID(id string) *FactCheckingTraceBuilder {
+	b.TraceBuilder.ID(id)
+	return b
+}
+
+// UserID sets the user ID.
+func (b *FactCheckingTraceBuilder) UserID(userID string) *FactCheckingTraceBuilder {
+	b.TraceBuilder.UserID(userID)
+	return b
+}
+
+// SessionID sets the session ID.
+func (b *FactCheckingTraceBuilder) SessionID(sessionID string) *FactCheckingTraceBuilder {
+	b.TraceBuilder.SessionID(sessionID)
+	return b
+}
+
+// Tags sets the trace tags.
+func (b *FactCheckingTraceBuilder) Tags(tags []string) *FactCheckingTraceBuilder {
+	b.TraceBuilder.Tags(tags)
+	return b
+}
+
+// Metadata sets the trace metadata.
+func (b *FactCheckingTraceBuilder) Metadata(metadata map[string]any) *FactCheckingTraceBuilder {
+	b.metadata = metadata
+	b.TraceBuilder.Metadata(metadata)
+	return b
+}
+
+// WithEvaluatorConfig records the judge configuration under
+// EvaluatorConfigMetadataKey in the trace metadata.
+func (b *FactCheckingTraceBuilder) WithEvaluatorConfig(cfg EvaluatorConfig) *FactCheckingTraceBuilder {
+	b.evaluatorConfig = &cfg
+	return b
+}
+
+// Release sets the release version.
+func (b *FactCheckingTraceBuilder) Release(release string) *FactCheckingTraceBuilder {
+	b.TraceBuilder.Release(release)
+	return b
+}
+
+// Version sets the version.
+func (b *FactCheckingTraceBuilder) Version(version string) *FactCheckingTraceBuilder {
+	b.TraceBuilder.Version(version)
+	return b
+}
+
+// Environment sets the environment.
+func (b *FactCheckingTraceBuilder) Environment(env string) *FactCheckingTraceBuilder {
+	b.TraceBuilder.Environment(env)
+	return b
+}
+
+// Public sets whether the trace is public.
+func (b *FactCheckingTraceBuilder) Public(public bool) *FactCheckingTraceBuilder {
+	b.TraceBuilder.Public(public)
+	return b
+}
+
+// Validate validates the fact-checking trace configuration.
+func (b *FactCheckingTraceBuilder) Validate() error {
+	if len(b.factInput.Claims) == 0 {
+		return fmt.Errorf("at least one claim is required for fact-checking traces")
+	}
+	if len(b.factInput.SourceDocuments) == 0 {
+		return fmt.Errorf("at least one source document is required for fact-checking traces")
+	}
+	if n := len(b.factInput.GroundTruth); n > 0 && n != len(b.factInput.Claims) {
+		return fmt.Errorf("ground truth has %d verdicts for %d claims", n, len(b.factInput.Claims))
+	}
+	for _, v := range b.factInput.GroundTruth {
+		if err := validateFactVerdict(v); err != nil {
+			return err
+		}
+	}
+	if err := validateFactVerdicts(b.factOutput.Verdicts); err != nil {
+		return err
+	}
+	return b.TraceBuilder.Validate()
+}
+
+// Create creates the fact-checking trace and returns a context for updating
+// it. The claims, source documents, and ground truth are recorded as the
+// trace input; the verdicts, if set, are recorded as the trace output.
+func (b *FactCheckingTraceBuilder) Create(ctx context.Context) (*FactCheckingTraceContext, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	b.TraceBuilder.Input(b.factInput)
+	if len(b.factOutput.Verdicts) > 0 {
+		b.TraceBuilder.Output(b.factOutput)
+	}
+
+	if b.evaluatorConfig != nil {
+		b.TraceBuilder.Metadata(withEvaluatorConfig(b.metadata, b.evaluatorConfig))
+	}
+
+	traceCtx, err := b.TraceBuilder.Create(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FactCheckingTraceContext{
+		TraceContext: traceCtx,
+		input:        b.factInput,
+		output:       b.factOutput,
+	}, nil
+}
+
+// TryCreate is like Create but returns the trace and error as a single
+// BuildResult.
+func (b *FactCheckingTraceBuilder) TryCreate(ctx context.Context) langfuse.BuildResult[*FactCheckingTraceContext] {
+	return langfuse.NewBuildResult(b.Create(ctx))
+}
+
+// FactCheckingTraceContext provides context for a fact-checking trace with
+// typed methods.
+type FactCheckingTraceContext struct {
+	*langfuse.TraceContext
+	input  *FactCheckingInput
+	output *FactCheckingOutput
+}
+
+// GetInput returns the fact-checking input.
+func (f *FactCheckingTraceContext) GetInput() *FactCheckingInput {
+	return f.input
+}
+
+// GetOutput returns the fact-checking output.
+func (f *FactCheckingTraceContext) GetOutput() *FactCheckingOutput {
+	return f.output
+}
+
+// UpdateVerdicts sets the model's verdicts and updates the trace output.
+func (f *FactCheckingTraceContext) UpdateVerdicts(ctx context.Context, verdicts []FactVerdict) error {
+	if err := validateFactVerdicts(verdicts); err != nil {
+		return err
+	}
+	f.output = &FactCheckingOutput{Verdicts: verdicts}
+	return f.Update().Output(f.output).Apply(ctx)
+}
+
+// ValidateForEvaluation checks if the trace has all required fields for evaluation.
+func (f *FactCheckingTraceContext) ValidateForEvaluation() error {
+	return ValidateFor(f.input, f.output, FactCheckingEvaluator)
+}
+
+// Accuracy returns the fraction of claims whose verdict matches the ground
+// truth. Verdicts are matched to claims by claim text; a claim without a
+// verdict counts as incorrect. It returns 0 if no ground truth is set.
+func (f *FactCheckingTraceContext) Accuracy() float64 {
+	if len(f.input.GroundTruth) == 0 {
+		return 0
+	}
+
+	verdicts := make(map[string]string)
+	if f.output != nil {
+		for _, v := range f.output.Verdicts {
+			verdicts[v.Claim] = v.Verdict
+		}
+	}
+
+	var correct int
+	for i, claim := range f.input.Claims {
+		if i < len(f.input.GroundTruth) && verdicts[claim] == f.input.GroundTruth[i] {
+			correct++
+		}
+	}
+	return float64(correct) / float64(len(f.input.Claims))
+}
+
+// UpdateWithAccuracy computes the verdict accuracy and records it as a
+// numeric score named "fact_checking_accuracy" on the trace.
+func (f *FactCheckingTraceContext) UpdateWithAccuracy(ctx context.Context) error {
+	if len(f.input.GroundTruth) == 0 {
+		return fmt.Errorf("ground truth verdicts are required to compute accuracy")
+	}
+	return f.ScoreNumeric(ctx, FactCheckingAccuracyScoreName, f.Accuracy())
+}
+
+// validateFactVerdicts checks that every verdict has a claim and a known
+// verdict value.
+func validateFactVerdicts(verdicts []FactVerdict) error {
+	for i, v := range verdicts {
+		if v.Claim == "" {
+			return fmt.Errorf("verdict %d: claim is required", i)
+		}
+		if err := validateFactVerdict(v.Verdict); err != nil {
+			return fmt.Errorf("verdict %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// validateFactVerdict checks that verdict is a known verdict value.
+func validateFactVerdict(verdict string) error {
+	switch verdict {
+	case FactVerdictSupported, FactVerdictRefuted, FactVerdictNotEnoughInfo:
+		return nil
+	default:
+		return fmt.Errorf("invalid verdict %q: must be %q, %q, or %q",
+			verdict, FactVerdictSupported, FactVerdictRefuted, FactVerdictNotEnoughInfo)
+	}
+}
@@ -0,0 +1,154 @@
+package evaluation
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestFactCheckingTraceBuilder_FluentAPI(t *testing.T) {
+	builder := &FactCheckingTraceBuilder{
+		factInput:  &FactCheckingInput{},
+		factOutput: &FactCheckingOutput{},
+	}
+
+	result := builder.
+		Claims([]string{"a"}).
+		SourceDocuments([]string{"doc"}).
+		Verdicts([]FactVerdict{{Claim: "a", Verdict: FactVerdictSupported}}).
+		GroundTruth([]string{FactVerdictSupported})
+
+	if result != builder {
+		t.Error("fluent methods should return the same builder")
+	}
+	if len(builder.factInput.Claims) != 1 || len(builder.factInput.SourceDocuments) != 1 || len(builder.factInput.GroundTruth) != 1 {
+		t.Errorf("input not set correctly: %+v", builder.factInput)
+	}
+	if len(builder.factOutput.Verdicts) != 1 {
+		t.Errorf("Verdicts length = %d, want 1", len(builder.factOutput.Verdicts))
+	}
+}
+
+func TestFactCheckingTraceBuilder_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  *FactCheckingInput
+		output *FactCheckingOutput
+	}{
+		{
+			name:   "missing claims",
+			input:  &FactCheckingInput{SourceDocuments: []string{"doc"}},
+			output: &FactCheckingOutput{},
+		},
+		{
+			name:   "missing source documents",
+			input:  &FactCheckingInput{Claims: []string{"a"}},
+			output: &FactCheckingOutput{},
+		},
+		{
+			name:   "ground truth length mismatch",
+			input:  &FactCheckingInput{Claims: []string{"a", "b"}, SourceDocuments: []string{"doc"}, GroundTruth: []string{FactVerdictRefuted}},
+			output: &FactCheckingOutput{},
+		},
+		{
+			name:   "unknown verdict",
+			input:  &FactCheckingInput{Claims: []string{"a"}, SourceDocuments: []string{"doc"}},
+			output: &FactCheckingOutput{Verdicts: []FactVerdict{{Claim: "a", Verdict: "true"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := &FactCheckingTraceBuilder{factInput: tt.input, factOutput: tt.output}
+			if err := builder.Validate(); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestFactCheckingOutputJSON(t *testing.T) {
+	output := &FactCheckingOutput{
+		Verdicts: []FactVerdict{{Claim: "a", Verdict: FactVerdictNotEnoughInfo}},
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		t.Fatalf("failed to marshal FactCheckingOutput: %v", err)
+	}
+	if got := string(data); got != `{"verdicts":[{"claim":"a","verdict":"not_enough_info"}]}` {
+		t.Errorf("FactCheckingOutput JSON = %s", got)
+	}
+}
+
+func TestFactCheckingTraceContext_ValidateForEvaluation(t *testing.T) {
+	input := &FactCheckingInput{Claims: []string{"a"}, SourceDocuments: []string{"doc"}}
+
+	valid := &FactCheckingTraceContext{
+		input:  input,
+		output: &FactCheckingOutput{Verdicts: []FactVerdict{{Claim: "a", Verdict: FactVerdictSupported}}},
+	}
+	if err := valid.ValidateForEvaluation(); err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+
+	missing := &FactCheckingTraceContext{input: input, output: &FactCheckingOutput{}}
+	if err := missing.ValidateForEvaluation(); err == nil {
+		t.Error("expected error without verdicts")
+	}
+}
+
+func TestFactCheckingTraceContext_Accuracy(t *testing.T) {
+	claims := []string{"a", "b", "c", "d"}
+	truth := []string{FactVerdictSupported, FactVerdictRefuted, FactVerdictNotEnoughInfo, FactVerdictSupported}
+
+	tests := []struct {
+		name     string
+		verdicts []FactVerdict
+		want     float64
+	}{
+		{
+			name: "all correct in any order",
+			verdicts: []FactVerdict{
+				{Claim: "d", Verdict: FactVerdictSupported},
+				{Claim: "c", Verdict: FactVerdictNotEnoughInfo},
+				{Claim: "b", Verdict: FactVerdictRefuted},
+				{Claim: "a", Verdict: FactVerdictSupported},
+			},
+			want: 1,
+		},
+		{
+			name: "one wrong and one missing",
+			verdicts: []FactVerdict{
+				{Claim: "a", Verdict: FactVerdictSupported},
+				{Claim: "b", Verdict: FactVerdictSupported},
+				{Claim: "c", Verdict: FactVerdictNotEnoughInfo},
+			},
+			want: 0.5,
+		},
+		{name: "no verdicts", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &FactCheckingTraceContext{
+				input:  &FactCheckingInput{Claims: claims, GroundTruth: truth},
+				output: &FactCheckingOutput{Verdicts: tt.verdicts},
+			}
+			if got := ctx.Accuracy(); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Accuracy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFactCheckingTraceContext_UpdateWithAccuracyRequiresGroundTruth(t *testing.T) {
+	ctx := &FactCheckingTraceContext{
+		input:  &FactCheckingInput{Claims: []string{"a"}, SourceDocuments: []string{"doc"}},
+		output: &FactCheckingOutput{Verdicts: []FactVerdict{{Claim: "a", Verdict: FactVerdictSupported}}},
+	}
+	if err := ctx.UpdateWithAccuracy(context.Background()); err == nil {
+		t.Error("expected error without ground truth verdicts")
+	}
+}
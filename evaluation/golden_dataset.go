@@ -0,0 +1,231 @@
+package evaluation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	langfuse "github.com/jdziat/langfuse-go"
+)
+
+// DatasetBuildResult summarizes a GoldenDatasetBuilder.Build call.
+type DatasetBuildResult struct {
+	// AddedCount is the number of dataset items created
+	AddedCount int
+
+	// SkippedDuplicates is the number of pending items skipped because the
+	// dataset already has an item for their source trace, or the trace was
+	// added more than once
+	SkippedDuplicates int
+
+	// DatasetVersion identifies the set of items covered by the build. It is
+	// a hash of the sorted IDs of the created and already existing items, so
+	// builds over the same items produce the same version.
+	DatasetVersion string
+}
+
+// goldenItem is a dataset item waiting to be committed by Build.
+type goldenItem struct {
+	traceID        string
+	input          any
+	expectedOutput any
+}
+
+// GoldenDatasetBuilder curates production traces into a ground truth
+// dataset. Traces are fetched when added and committed as dataset items by
+// Build.
+//
+// GoldenDatasetBuilder is safe for concurrent use.
+type GoldenDatasetBuilder struct {
+	client       *langfuse.Client
+	datasetName  string
+	workflowType EvaluationType
+
+	mu      sync.Mutex
+	pending []goldenItem
+}
+
+// NewGoldenDataset creates a builder for the named dataset. The dataset is
+// created by Build if it does not exist.
+//
+// Example:
+//
+//	golden := evaluation.NewGoldenDataset(client, "support-golden")
+//	for _, id := range reviewedTraceIDs {
+//	    if err := golden.AddFromTrace(ctx, id); err != nil {
+//	        return err
+//	    }
+//	}
+//	golden.AddWithOverride(ctx, fixedTraceID, "The corrected answer")
+//	result, err := golden.Build(ctx)
+//	fmt.Println(result.AddedCount, result.DatasetVersion)
+func NewGoldenDataset(client *langfuse.Client, datasetName string) *GoldenDatasetBuilder {
+	return &GoldenDatasetBuilder{
+		client:      client,
+		datasetName: datasetName,
+	}
+}
+
+// WorkflowType records the evaluation type of the dataset's items in their
+// metadata under WorkflowTypeMetadataKey, where the evaluation.WorkflowType
+// function reads it.
+func (b *GoldenDatasetBuilder) WorkflowType(workflowType EvaluationType) *GoldenDatasetBuilder {
+	b.workflowType = workflowType
+	return b
+}
+
+// AddFromTrace fetches the trace and queues a dataset item with the trace's
+// input as its input and the trace's output as its expected output.
+func (b *GoldenDatasetBuilder) AddFromTrace(ctx context.Context, traceID string) error {
+	trace, err := b.fetchTrace(ctx, traceID)
+	if err != nil {
+		return err
+	}
+	b.add(goldenItem{traceID: traceID, input: trace.Input, expectedOutput: trace.Output})
+	return nil
+}
+
+// AddWithOverride fetches the trace and queues a dataset item with the
+// trace's input as its input and expectedOutput as its expected output. Use
+// it when the production output was wrong and has been corrected by a
+// reviewer.
+func (b *GoldenDatasetBuilder) AddWithOverride(ctx context.Context, traceID, expectedOutput string) error {
+	trace, err := b.fetchTrace(ctx, traceID)
+	if err != nil {
+		return err
+	}
+	b.add(goldenItem{traceID: traceID, input: trace.Input, expectedOutput: expectedOutput})
+	return nil
+}
+
+// Pending returns the number of items waiting to be committed by Build.
+func (b *GoldenDatasetBuilder) Pending() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.pending)
+}
+
+// Build creates the dataset if needed and commits all pending items,
+// linking each to its source trace. Items whose source trace already has an
+// item in the dataset are skipped. Committed and skipped items are removed
+// from the pending list; if Build fails, the remaining items stay pending
+// and the result covers the items processed so far.
+func (b *GoldenDatasetBuilder) Build(ctx context.Context) (*DatasetBuildResult, error) {
+	if b.datasetName == "" {
+		return nil, fmt.Errorf("dataset name is required")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	result := &DatasetBuildResult{}
+	var itemIDs []string
+	defer func() {
+		result.DatasetVersion = datasetVersion(itemIDs)
+	}()
+
+	if err := b.ensureDataset(ctx); err != nil {
+		return result, err
+	}
+
+	seen := make(map[string]bool, len(b.pending))
+	for len(b.pending) > 0 {
+		item := b.pending[0]
+
+		if seen[item.traceID] {
+			result.SkippedDuplicates++
+			b.pending = b.pending[1:]
+			continue
+		}
+
+		existing, err := b.client.Datasets().ListItems(ctx, &langfuse.DatasetItemsListParams{
+			PaginationParams: langfuse.PaginationParams{Limit: 1},
+			DatasetName:      b.datasetName,
+			SourceTraceID:    item.traceID,
+		})
+		if err != nil {
+			return result, fmt.Errorf("failed to check for existing item of trace %s: %w", item.traceID, err)
+		}
+		if len(existing.Data) > 0 {
+			seen[item.traceID] = true
+			result.SkippedDuplicates++
+			itemIDs = append(itemIDs, existing.Data[0].ID)
+			b.pending = b.pending[1:]
+			continue
+		}
+
+		created, err := b.client.Datasets().CreateItem(ctx, &langfuse.CreateDatasetItemRequest{
+			DatasetName:    b.datasetName,
+			Input:          item.input,
+			ExpectedOutput: item.expectedOutput,
+			Metadata:       b.itemMetadata(),
+			SourceTraceID:  item.traceID,
+		})
+		if err != nil {
+			return result, fmt.Errorf("failed to create item for trace %s: %w", item.traceID, err)
+		}
+		seen[item.traceID] = true
+		result.AddedCount++
+		itemIDs = append(itemIDs, created.ID)
+		b.pending = b.pending[1:]
+	}
+
+	return result, nil
+}
+
+// itemMetadata returns the metadata of the created dataset items.
+func (b *GoldenDatasetBuilder) itemMetadata() langfuse.Metadata {
+	if b.workflowType == "" {
+		return nil
+	}
+	return langfuse.Metadata{WorkflowTypeMetadataKey: string(b.workflowType)}
+}
+
+// fetchTrace fetches the trace a dataset item is built from.
+func (b *GoldenDatasetBuilder) fetchTrace(ctx context.Context, traceID string) (*langfuse.Trace, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("trace ID is required")
+	}
+	trace, err := b.client.Traces().Get(ctx, traceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trace %s: %w", traceID, err)
+	}
+	return trace, nil
+}
+
+// add queues item for Build.
+func (b *GoldenDatasetBuilder) add(item goldenItem) {
+	b.mu.Lock()
+	b.pending = append(b.pending, item)
+	b.mu.Unlock()
+}
+
+// ensureDataset creates the dataset if it does not exist.
+func (b *GoldenDatasetBuilder) ensureDataset(ctx context.Context) error {
+	_, err := b.client.Datasets().Get(ctx, b.datasetName)
+	if err == nil {
+		return nil
+	}
+
+	var apiErr *langfuse.APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+		return fmt.Errorf("failed to get dataset %s: %w", b.datasetName, err)
+	}
+	if _, err := b.client.Datasets().Create(ctx, &langfuse.CreateDatasetRequest{Name: b.datasetName}); err != nil {
+		return fmt.Errorf("failed to create dataset %s: %w", b.datasetName, err)
+	}
+	return nil
+}
+
+// datasetVersion returns a short hash of the sorted item IDs.
+func datasetVersion(itemIDs []string) string {
+	sorted := append([]string(nil), itemIDs...)
+	sort.Strings(sorted)
+	sum := sha256.Sum256([]byte(strings.Join(sorted, "\n")))
+	return hex.EncodeToString(sum[:8])
+}
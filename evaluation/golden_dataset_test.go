@@ -0,0 +1,181 @@
+package evaluation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	langfuse "github.com/jdziat/langfuse-go"
+)
+
+// goldenTestServer fakes the traces and datasets API for GoldenDatasetBuilder.
+type goldenTestServer struct {
+	mu             sync.Mutex
+	datasetExists  bool
+	datasetCreated bool
+	items          []langfuse.CreateDatasetItemRequest
+	existing       map[string]string // source trace ID -> existing item ID
+}
+
+func newGoldenTestClient(t *testing.T, gs *goldenTestServer, traces map[string]langfuse.Trace) *langfuse.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gs.mu.Lock()
+		defer gs.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/api/public/traces/"):
+			trace, ok := traces[strings.TrimPrefix(r.URL.Path, "/api/public/traces/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				json.NewEncoder(w).Encode(map[string]string{"message": "trace not found"})
+				return
+			}
+			json.NewEncoder(w).Encode(trace)
+		case r.URL.Path == "/api/public/v2/datasets/golden":
+			if !gs.datasetExists {
+				w.WriteHeader(http.StatusNotFound)
+				json.NewEncoder(w).Encode(map[string]string{"message": "dataset not found"})
+				return
+			}
+			json.NewEncoder(w).Encode(langfuse.Dataset{Name: "golden"})
+		case r.URL.Path == "/api/public/v2/datasets" && r.Method == http.MethodPost:
+			gs.datasetExists = true
+			gs.datasetCreated = true
+			json.NewEncoder(w).Encode(langfuse.Dataset{Name: "golden"})
+		case r.URL.Path == "/api/public/dataset-items" && r.Method == http.MethodGet:
+			var data []langfuse.DatasetItem
+			if id, ok := gs.existing[r.URL.Query().Get("sourceTraceId")]; ok {
+				data = append(data, langfuse.DatasetItem{ID: id})
+			}
+			json.NewEncoder(w).Encode(langfuse.DatasetItemsListResponse{Data: data})
+		case r.URL.Path == "/api/public/dataset-items" && r.Method == http.MethodPost:
+			var req langfuse.CreateDatasetItemRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			gs.items = append(gs.items, req)
+			json.NewEncoder(w).Encode(langfuse.DatasetItem{ID: "item-" + req.SourceTraceID})
+		default:
+			json.NewEncoder(w).Encode(langfuse.IngestionResult{})
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := langfuse.New("pk-lf-test-key", "sk-lf-test-key",
+		langfuse.WithBaseURL(server.URL),
+		langfuse.WithFlushInterval(1*time.Hour),
+		langfuse.WithMaxRetries(0),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { client.Shutdown(context.Background()) })
+	return client
+}
+
+func TestGoldenDataset_Build(t *testing.T) {
+	traces := map[string]langfuse.Trace{
+		"t1": {ID: "t1", Input: "question 1", Output: "answer 1"},
+		"t2": {ID: "t2", Input: "question 2", Output: "wrong answer"},
+		"t3": {ID: "t3", Input: "question 3", Output: "answer 3"},
+	}
+	gs := &goldenTestServer{existing: map[string]string{"t3": "item-existing"}}
+	client := newGoldenTestClient(t, gs, traces)
+	ctx := context.Background()
+
+	golden := NewGoldenDataset(client, "golden")
+	if err := golden.AddFromTrace(ctx, "t1"); err != nil {
+		t.Fatalf("AddFromTrace failed: %v", err)
+	}
+	if err := golden.AddWithOverride(ctx, "t2", "right answer"); err != nil {
+		t.Fatalf("AddWithOverride failed: %v", err)
+	}
+	if err := golden.AddFromTrace(ctx, "t3"); err != nil {
+		t.Fatalf("AddFromTrace failed: %v", err)
+	}
+	if err := golden.AddFromTrace(ctx, "t1"); err != nil {
+		t.Fatalf("AddFromTrace failed: %v", err)
+	}
+	if err := golden.AddFromTrace(ctx, "missing"); err == nil {
+		t.Error("AddFromTrace should fail for an unknown trace")
+	}
+	if golden.Pending() != 4 {
+		t.Errorf("Pending() = %d, want 4", golden.Pending())
+	}
+
+	result, err := golden.Build(ctx)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if result.AddedCount != 2 {
+		t.Errorf("AddedCount = %d, want 2", result.AddedCount)
+	}
+	if result.SkippedDuplicates != 2 {
+		t.Errorf("SkippedDuplicates = %d, want 2", result.SkippedDuplicates)
+	}
+	if result.DatasetVersion == "" {
+		t.Error("DatasetVersion should be set")
+	}
+	if golden.Pending() != 0 {
+		t.Errorf("Pending() after Build = %d, want 0", golden.Pending())
+	}
+
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	if !gs.datasetCreated {
+		t.Error("Build should create the missing dataset")
+	}
+	if len(gs.items) != 2 {
+		t.Fatalf("created %d items, want 2", len(gs.items))
+	}
+	first, second := gs.items[0], gs.items[1]
+	if first.SourceTraceID != "t1" || first.Input != "question 1" || first.ExpectedOutput != "answer 1" {
+		t.Errorf("first item = %+v, want trace t1 input and output", first)
+	}
+	if second.SourceTraceID != "t2" || second.ExpectedOutput != "right answer" {
+		t.Errorf("second item = %+v, want overridden expected output", second)
+	}
+	if first.DatasetName != "golden" {
+		t.Errorf("DatasetName = %q, want golden", first.DatasetName)
+	}
+}
+
+func TestGoldenDataset_VersionIsOrderIndependent(t *testing.T) {
+	if datasetVersion([]string{"a", "b", "c"}) != datasetVersion([]string{"c", "a", "b"}) {
+		t.Error("datasetVersion should not depend on item order")
+	}
+	if datasetVersion([]string{"a", "b"}) == datasetVersion([]string{"a", "c"}) {
+		t.Error("datasetVersion should differ for different items")
+	}
+}
+
+func TestGoldenDataset_WorkflowType(t *testing.T) {
+	traces := map[string]langfuse.Trace{"t1": {ID: "t1", Input: map[string]any{"query": "q"}, Output: "a"}}
+	gs := &goldenTestServer{datasetExists: true}
+	client := newGoldenTestClient(t, gs, traces)
+	ctx := context.Background()
+
+	golden := NewGoldenDataset(client, "golden").WorkflowType(EvaluationTypeQA)
+	if err := golden.AddFromTrace(ctx, "t1"); err != nil {
+		t.Fatalf("AddFromTrace failed: %v", err)
+	}
+	if _, err := golden.Build(ctx); err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	if len(gs.items) != 1 {
+		t.Fatalf("created %d items, want 1", len(gs.items))
+	}
+	item := langfuse.DatasetItem{Metadata: gs.items[0].Metadata}
+	if got := WorkflowType(&item); got != "qa" {
+		t.Errorf("WorkflowType() = %q, want qa", got)
+	}
+}
@@ -0,0 +1,260 @@
+package evaluation
+
+import (
+	"context"
+	"fmt"
+
+	langfuse "github.com/jdziat/langfuse-go"
+)
+
+// CitationCoverageMetadataKey is the trace metadata key holding the fraction
+// of cited document IDs that refer to a source document.
+const CitationCoverageMetadataKey = "citation_coverage"
+
+// GroundednessTraceBuilder provides a fluent interface for creating
+// groundedness traces.
+type GroundednessTraceBuilder struct {
+	*langfuse.TraceBuilder
+	groundednessInput  *GroundednessInput
+	groundednessOutput *GroundednessOutput
+	metadata           map[string]any
+	evaluatorConfig    *EvaluatorConfig
+}
+
+// NewGroundednessTrace creates a new groundedness trace builder.
+// Unlike a RAG trace, a groundedness trace records full source documents and
+// the documents the response cites, so evaluators can check whether the
+// response is supported by its sources.
+//
+// Example:
+//
+//	trace, err := evaluation.NewGroundednessTrace(client, "support-answer").
+//	    Query("How do I reset my password?").
+//	    SourceDocuments([]evaluation.SourceDocument{
+//	        {ID: "kb-12", Content: "Passwords can be reset from the login page."},
+//	    }).
+//	    Response("Use the reset link on the login page.").
+//	    CitedDocumentIDs([]string{"kb-12"}).
+//	    Create(ctx)
+//	trace.UpdateGroundednessScore(ctx, 1.0, "fully supported by kb-12")
+func NewGroundednessTrace(client *langfuse.Client, name string) *GroundednessTraceBuilder {
+	return &GroundednessTraceBuilder{
+		TraceBuilder:       client.NewTrace().Name(name),
+		groundednessInput:  &GroundednessInput{},
+		groundednessOutput: &GroundednessOutput{},
+	}
+}
+
+// Query sets the user's question.
+func (b *GroundednessTraceBuilder) Query(query string) *GroundednessTraceBuilder {
+	b.groundednessInput.Query = query
+	return b
+}
+
+// SourceDocuments sets the documents the response should be grounded in.
+func (b *GroundednessTraceBuilder) SourceDocuments(docs []SourceDocument) *GroundednessTraceBuilder {
+	b.groundednessInput.SourceDocuments = docs
+	return b
+}
+
+// Response sets the generated response.
+func (b *GroundednessTraceBuilder) Response(response string) *GroundednessTraceBuilder {
+	b.groundednessOutput.Response = response
+	return b
+}
+
+// CitedDocumentIDs sets the IDs of the source documents the response cites.
+func (b *GroundednessTraceBuilder) CitedDocumentIDs(ids []string) *GroundednessTraceBuilder {
+	b.groundednessOutput.CitedDocumentIDs = ids
+	return b
+}
+
+// ID sets the trace ID.
+func (b *GroundednessTraceBuilder) ID(id string) *GroundednessTraceBuilder {
+	b.TraceBuilder.ID(id)
+	return b
+}
+
+// UserID sets the user ID.
+func (b *GroundednessTraceBuilder) UserID(userID string) *GroundednessTraceBuilder {
+	b.TraceBuilder.UserID(userID)
+	return b
+}
+
+// SessionID sets the session ID.
+func (b *GroundednessTraceBuilder) SessionID(sessionID string) *GroundednessTraceBuilder {
+	b.TraceBuilder.SessionID(sessionID)
+	return b
+}
+
+// Tags sets the trace tags.
+func (b *GroundednessTraceBuilder) Tags(tags []string) *GroundednessTraceBuilder {
+	b.TraceBuilder.Tags(tags)
+	return b
+}
+
+// Metadata sets the trace metadata. The citation coverage is added to it
+// when the trace is created.
+func (b *GroundednessTraceBuilder) Metadata(metadata map[string]any) *GroundednessTraceBuilder {
+	b.metadata = metadata
+	return b
+}
+
+// WithEvaluatorConfig records the judge configuration under
+// EvaluatorConfigMetadataKey in the trace metadata.
+func (b *GroundednessTraceBuilder) WithEvaluatorConfig(cfg EvaluatorConfig) *GroundednessTraceBuilder {
+	b.evaluatorConfig = &cfg
+	return b
+}
+
+// Release sets the release version.
+func (b *GroundednessTraceBuilder) Release(release string) *GroundednessTraceBuilder {
+	b.TraceBuilder.Release(release)
+	return b
+}
+
+// Version sets the version.
+func (b *GroundednessTraceBuilder) Version(version string) *GroundednessTraceBuilder {
+	b.TraceBuilder.Version(version)
+	return b
+}
+
+// Environment sets the environment.
+func (b *GroundednessTraceBuilder) Environment(env string) *GroundednessTraceBuilder {
+	b.TraceBuilder.Environment(env)
+	return b
+}
+
+// Public sets whether the trace is public.
+func (b *GroundednessTraceBuilder) Public(public bool) *GroundednessTraceBuilder {
+	b.TraceBuilder.Public(public)
+	return b
+}
+
+// Validate validates the groundedness trace configuration.
+func (b *GroundednessTraceBuilder) Validate() error {
+	if b.groundednessInput.Query == "" {
+		return fmt.Errorf("query is required for groundedness traces")
+	}
+	if len(b.groundednessInput.SourceDocuments) == 0 {
+		return fmt.Errorf("source documents are required for groundedness traces")
+	}
+	return b.TraceBuilder.Validate()
+}
+
+// Create creates the groundedness trace and returns a context for updating it.
+// The query and source documents are recorded as the trace input, the
+// response and citations as the output, and the citation coverage under
+// "citation_coverage" in the trace metadata.
+func (b *GroundednessTraceBuilder) Create(ctx context.Context) (*GroundednessTraceContext, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	metadata := make(map[string]any, len(b.metadata)+1)
+	for k, v := range b.metadata {
+		metadata[k] = v
+	}
+	metadata[CitationCoverageMetadataKey] = CitationCoverage(b.groundednessInput.SourceDocuments, b.groundednessOutput.CitedDocumentIDs)
+	if b.evaluatorConfig != nil {
+		metadata = withEvaluatorConfig(metadata, b.evaluatorConfig)
+	}
+
+	b.TraceBuilder.Metadata(metadata)
+	b.TraceBuilder.Input(b.groundednessInput)
+	if b.groundednessOutput.Response != "" {
+		b.TraceBuilder.Output(b.groundednessOutput)
+	}
+
+	traceCtx, err := b.TraceBuilder.Create(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GroundednessTraceContext{
+		TraceContext: traceCtx,
+		input:        b.groundednessInput,
+		output:       b.groundednessOutput,
+	}, nil
+}
+
+// TryCreate is like Create but returns the trace and error as a single
+// BuildResult.
+func (b *GroundednessTraceBuilder) TryCreate(ctx context.Context) langfuse.BuildResult[*GroundednessTraceContext] {
+	return langfuse.NewBuildResult(b.Create(ctx))
+}
+
+// CitationCoverage returns the fraction of distinct cited document IDs that
+// refer to one of the source documents. It returns 0 if nothing is cited.
+func CitationCoverage(sources []SourceDocument, citedIDs []string) float64 {
+	known := make(map[string]bool, len(sources))
+	for _, doc := range sources {
+		known[doc.ID] = true
+	}
+
+	cited := make(map[string]bool, len(citedIDs))
+	found := 0
+	for _, id := range citedIDs {
+		if cited[id] {
+			continue
+		}
+		cited[id] = true
+		if known[id] {
+			found++
+		}
+	}
+
+	if len(cited) == 0 {
+		return 0
+	}
+	return float64(found) / float64(len(cited))
+}
+
+// GroundednessTraceContext provides context for a groundedness trace with
+// typed methods.
+type GroundednessTraceContext struct {
+	*langfuse.TraceContext
+	input  *GroundednessInput
+	output *GroundednessOutput
+}
+
+// GetInput returns the groundedness input.
+func (g *GroundednessTraceContext) GetInput() *GroundednessInput {
+	return g.input
+}
+
+// GetOutput returns the groundedness output.
+func (g *GroundednessTraceContext) GetOutput() *GroundednessOutput {
+	return g.output
+}
+
+// UpdateOutput updates the trace with the response and the documents it cites.
+func (g *GroundednessTraceContext) UpdateOutput(ctx context.Context, response string, citedIDs ...string) error {
+	g.output = &GroundednessOutput{
+		Response:         response,
+		CitedDocumentIDs: citedIDs,
+	}
+	return g.Update().Output(g.output).Apply(ctx)
+}
+
+// UpdateGroundednessScore records a "groundedness" score between 0 and 1,
+// with explanation as the score comment.
+func (g *GroundednessTraceContext) UpdateGroundednessScore(ctx context.Context, score float64, explanation string) error {
+	if score < 0 || score > 1 {
+		return fmt.Errorf("groundedness score must be between 0 and 1, got %v", score)
+	}
+
+	builder := g.NewScore().Name("groundedness").NumericValue(score)
+	if explanation != "" {
+		builder = builder.Comment(explanation)
+	}
+	return builder.Create(ctx)
+}
+
+// ValidateForEvaluation checks if the trace has all required fields for evaluation.
+func (g *GroundednessTraceContext) ValidateForEvaluation() error {
+	if g.output == nil || g.output.Response == "" {
+		return fmt.Errorf("response is required before evaluation")
+	}
+	return ValidateFor(g.input, g.output, GroundednessEvaluator)
+}
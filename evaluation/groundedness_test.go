@@ -0,0 +1,247 @@
+package evaluation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	langfuse "github.com/jdziat/langfuse-go"
+)
+
+func TestGroundednessTraceBuilder_FluentAPI(t *testing.T) {
+	builder := &GroundednessTraceBuilder{
+		groundednessInput:  &GroundednessInput{},
+		groundednessOutput: &GroundednessOutput{},
+	}
+
+	docs := []SourceDocument{{ID: "kb-1", Content: "Go has goroutines."}}
+	result := builder.
+		Query("Does Go have goroutines?").
+		SourceDocuments(docs).
+		Response("Yes.").
+		CitedDocumentIDs([]string{"kb-1"})
+
+	if result != builder {
+		t.Error("fluent methods should return the same builder")
+	}
+	if builder.groundednessInput.Query != "Does Go have goroutines?" {
+		t.Errorf("Query not set correctly: got %s", builder.groundednessInput.Query)
+	}
+	if len(builder.groundednessInput.SourceDocuments) != 1 {
+		t.Errorf("SourceDocuments length = %d, want 1", len(builder.groundednessInput.SourceDocuments))
+	}
+	if builder.groundednessOutput.Response != "Yes." {
+		t.Errorf("Response not set correctly: got %s", builder.groundednessOutput.Response)
+	}
+	if len(builder.groundednessOutput.CitedDocumentIDs) != 1 {
+		t.Errorf("CitedDocumentIDs length = %d, want 1", len(builder.groundednessOutput.CitedDocumentIDs))
+	}
+}
+
+func TestGroundednessTypesJSON(t *testing.T) {
+	input := &GroundednessInput{
+		Query:           "q",
+		SourceDocuments: []SourceDocument{{ID: "d1", Content: "c"}},
+	}
+	output := &GroundednessOutput{Response: "r"}
+
+	data, err := json.Marshal(map[string]any{"input": input, "output": output})
+	if err != nil {
+		t.Fatalf("failed to marshal groundedness types: %v", err)
+	}
+
+	var raw struct {
+		Input  map[string]any `json:"input"`
+		Output map[string]any `json:"output"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("failed to unmarshal groundedness types: %v", err)
+	}
+	if _, ok := raw.Input["source_documents"]; !ok {
+		t.Error("source_documents missing from JSON")
+	}
+	doc := raw.Input["source_documents"].([]any)[0].(map[string]any)
+	if _, ok := doc["metadata"]; ok {
+		t.Error("metadata should be omitted when empty")
+	}
+	if raw.Output["output"] != "r" {
+		t.Errorf("output = %v, want r", raw.Output["output"])
+	}
+	if _, ok := raw.Output["cited_document_ids"]; ok {
+		t.Error("cited_document_ids should be omitted when empty")
+	}
+}
+
+func TestCitationCoverage(t *testing.T) {
+	sources := []SourceDocument{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+
+	tests := []struct {
+		name  string
+		cited []string
+		want  float64
+	}{
+		{"all cited docs in source", []string{"a", "b"}, 1},
+		{"half cited docs in source", []string{"a", "x"}, 0.5},
+		{"duplicates counted once", []string{"a", "a", "x"}, 0.5},
+		{"nothing cited", nil, 0},
+		{"no cited docs in source", []string{"x", "y"}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CitationCoverage(sources, tt.cited); got != tt.want {
+				t.Errorf("CitationCoverage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroundednessTraceBuilder_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       *GroundednessInput
+		expectError bool
+	}{
+		{
+			name:        "missing query",
+			input:       &GroundednessInput{SourceDocuments: []SourceDocument{{ID: "a"}}},
+			expectError: true,
+		},
+		{
+			name:        "missing source documents",
+			input:       &GroundednessInput{Query: "q"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := &GroundednessTraceBuilder{groundednessInput: tt.input, groundednessOutput: &GroundednessOutput{}}
+			err := builder.Validate()
+			if tt.expectError && err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestGroundednessTraceContext_ValidateForEvaluation(t *testing.T) {
+	validInput := &GroundednessInput{Query: "q", SourceDocuments: []SourceDocument{{ID: "a", Content: "c"}}}
+
+	tests := []struct {
+		name        string
+		input       *GroundednessInput
+		output      *GroundednessOutput
+		expectError bool
+	}{
+		{
+			name:   "valid",
+			input:  validInput,
+			output: &GroundednessOutput{Response: "r", CitedDocumentIDs: []string{"a"}},
+		},
+		{
+			name:        "missing response",
+			input:       validInput,
+			output:      &GroundednessOutput{},
+			expectError: true,
+		},
+		{
+			name:        "missing source documents",
+			input:       &GroundednessInput{Query: "q"},
+			output:      &GroundednessOutput{Response: "r"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &GroundednessTraceContext{input: tt.input, output: tt.output}
+			err := ctx.ValidateForEvaluation()
+			if tt.expectError && err == nil {
+				t.Error("expected error, got nil")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestGroundednessTraceContext_UpdateGroundednessScoreRange(t *testing.T) {
+	ctx := &GroundednessTraceContext{}
+	for _, score := range []float64{-0.1, 1.5} {
+		if err := ctx.UpdateGroundednessScore(context.Background(), score, ""); err == nil {
+			t.Errorf("expected error for score %v", score)
+		}
+	}
+}
+
+func TestGroundednessTrace_CreateRecordsCitationCoverage(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Batch []struct {
+				Body map[string]any `json:"body"`
+			} `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		for _, e := range req.Batch {
+			bodies = append(bodies, e.Body)
+		}
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(langfuse.IngestionResult{})
+	}))
+	defer server.Close()
+
+	client, err := langfuse.New("pk-lf-test-key", "sk-lf-test-key",
+		langfuse.WithBaseURL(server.URL),
+		langfuse.WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	trace, err := NewGroundednessTrace(client, "grounded").
+		Query("q").
+		SourceDocuments([]SourceDocument{{ID: "a", Content: "A"}, {ID: "b", Content: "B"}}).
+		Response("r").
+		CitedDocumentIDs([]string{"a", "z"}).
+		Metadata(map[string]any{"model": "gpt-4o"}).
+		Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := trace.UpdateGroundednessScore(ctx, 0.8, "one citation unsupported"); err != nil {
+		t.Fatalf("UpdateGroundednessScore failed: %v", err)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(bodies) != 2 {
+		t.Fatalf("received %d events, want 2", len(bodies))
+	}
+
+	metadata, _ := bodies[0]["metadata"].(map[string]any)
+	if metadata[CitationCoverageMetadataKey] != 0.5 {
+		t.Errorf("citation_coverage = %v, want 0.5", metadata[CitationCoverageMetadataKey])
+	}
+	if metadata["model"] != "gpt-4o" {
+		t.Errorf("user metadata not preserved: %v", metadata)
+	}
+
+	score := bodies[1]
+	if score["name"] != "groundedness" || score["value"] != 0.8 || score["comment"] != "one citation unsupported" {
+		t.Errorf("unexpected score body: %v", score)
+	}
+}
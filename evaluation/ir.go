@@ -0,0 +1,292 @@
+package evaluation
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	langfuse "github.com/jdziat/langfuse-go"
+)
+
+// IRTraceBuilder provides a fluent interface for creating information
+// retrieval traces.
+type IRTraceBuilder struct {
+	*langfuse.TraceBuilder
+	irInput         *IRInput
+	irOutput        *IROutput
+	metadata        map[string]any
+	evaluatorConfig *EvaluatorConfig
+}
+
+// NewIRTrace creates a new information retrieval trace builder.
+//
+// Example:
+//
+//	trace, err := evaluation.NewIRTrace(client, "search").
+//	    Query("golang context cancellation").
+//	    RetrievedDocIDs([]string{"doc-3", "doc-7", "doc-1"}).
+//	    RelevantDocIDs([]string{"doc-1", "doc-3"}).
+//	    Create(ctx)
+//	trace.UpdateWithMetrics(ctx, 3)
+func NewIRTrace(client *langfuse.Client, name string) *IRTraceBuilder {
+	return &IRTraceBuilder{
+		TraceBuilder: client.NewTrace().Name(name),
+		irInput:      &IRInput{},
+		irOutput:     &IROutput{},
+	}
+}
+
+// Query sets the search query.
+func (b *IRTraceBuilder) Query(query string) *IRTraceBuilder {
+	b.irInput.Query = query
+	return b
+}
+
+// RetrievedDocIDs sets the IDs of the retrieved documents in rank order.
+func (b *IRTraceBuilder) RetrievedDocIDs(ids []string) *IRTraceBuilder {
+	b.irInput.RetrievedDocIDs = ids
+	return b
+}
+
+// RetrievedDocs sets the content of the retrieved documents.
+func (b *IRTraceBuilder) RetrievedDocs(docs []string) *IRTraceBuilder {
+	b.irInput.RetrievedDocs = docs
+	return b
+}
+
+// RelevantDocIDs sets the ground truth IDs of the relevant documents.
+func (b *IRTraceBuilder) RelevantDocIDs(ids []string) *IRTraceBuilder {
+	b.irOutput.RelevantDocIDs = ids
+	return b
+}
+
+// ID sets the trace ID.
+func (b *IRTraceBuilder) ID(id string) *IRTraceBuilder {
+	b.TraceBuilder.ID(id)
+	return b
+}
+
+// UserID sets the user ID.
+func (b *IRTraceBuilder) UserID(userID string) *IRTraceBuilder {
+	b.TraceBuilder.UserID(userID)
+	return b
+}
+
+// SessionID sets the session ID.
+func (b *IRTraceBuilder) SessionID(sessionID string) *IRTraceBuilder {
+	b.TraceBuilder.SessionID(sessionID)
+	return b
+}
+
+// Tags sets the trace tags.
+func (b *IRTraceBuilder) Tags(tags []string) *IRTraceBuilder {
+	b.TraceBuilder.Tags(tags)
+	return b
+}
+
+// Metadata sets the trace metadata.
+func (b *IRTraceBuilder) Metadata(metadata map[string]any) *IRTraceBuilder {
+	b.metadata = metadata
+	b.TraceBuilder.Metadata(metadata)
+	return b
+}
+
+// WithEvaluatorConfig records the judge configuration under
+// EvaluatorConfigMetadataKey in the trace metadata.
+func (b *IRTraceBuilder) WithEvaluatorConfig(cfg EvaluatorConfig) *IRTraceBuilder {
+	b.evaluatorConfig = &cfg
+	return b
+}
+
+// Release sets the release version.
+func (b *IRTraceBuilder) Release(release string) *IRTraceBuilder {
+	b.TraceBuilder.Release(release)
+	return b
+}
+
+// Version sets the version.
+func (b *IRTraceBuilder) Version(version string) *IRTraceBuilder {
+	b.TraceBuilder.Version(version)
+	return b
+}
+
+// Environment sets the environment.
+func (b *IRTraceBuilder) Environment(env string) *IRTraceBuilder {
+	b.TraceBuilder.Environment(env)
+	return b
+}
+
+// Public sets whether the trace is public.
+func (b *IRTraceBuilder) Public(public bool) *IRTraceBuilder {
+	b.TraceBuilder.Public(public)
+	return b
+}
+
+// Validate validates the IR trace configuration.
+func (b *IRTraceBuilder) Validate() error {
+	if b.irInput.Query == "" {
+		return fmt.Errorf("query is required for IR traces")
+	}
+	return b.TraceBuilder.Validate()
+}
+
+// Create creates the IR trace and returns a context for updating it.
+// The query and retrieved documents are recorded as the trace input;
+// relevant document IDs, if set, are recorded as the trace output.
+func (b *IRTraceBuilder) Create(ctx context.Context) (*IRTraceContext, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	b.TraceBuilder.Input(b.irInput)
+	if len(b.irOutput.RelevantDocIDs) > 0 {
+		b.TraceBuilder.Output(b.irOutput)
+	}
+
+	if b.evaluatorConfig != nil {
+		b.TraceBuilder.Metadata(withEvaluatorConfig(b.metadata, b.evaluatorConfig))
+	}
+
+	traceCtx, err := b.TraceBuilder.Create(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IRTraceContext{
+		TraceContext: traceCtx,
+		input:        b.irInput,
+		output:       b.irOutput,
+	}, nil
+}
+
+// TryCreate is like Create but returns the trace and error as a single
+// BuildResult.
+func (b *IRTraceBuilder) TryCreate(ctx context.Context) langfuse.BuildResult[*IRTraceContext] {
+	return langfuse.NewBuildResult(b.Create(ctx))
+}
+
+// IRTraceContext provides context for an information retrieval trace with
+// typed methods.
+type IRTraceContext struct {
+	*langfuse.TraceContext
+	input  *IRInput
+	output *IROutput
+}
+
+// GetInput returns the IR input.
+func (r *IRTraceContext) GetInput() *IRInput {
+	return r.input
+}
+
+// GetOutput returns the IR output.
+func (r *IRTraceContext) GetOutput() *IROutput {
+	return r.output
+}
+
+// ValidateForEvaluation checks if the trace has all required fields for evaluation.
+func (r *IRTraceContext) ValidateForEvaluation() error {
+	return ValidateFor(r.input, r.output, IREvaluator)
+}
+
+// ComputePrecisionAtK returns the fraction of the top k retrieved documents
+// that are relevant. It returns 0 if k is not positive.
+func (r *IRTraceContext) ComputePrecisionAtK(k int) float64 {
+	if k <= 0 {
+		return 0
+	}
+	return float64(r.relevantInTopK(k)) / float64(k)
+}
+
+// ComputeRecallAtK returns the fraction of relevant documents found in the
+// top k retrieved documents. It returns 0 if k is not positive or there are
+// no relevant documents.
+func (r *IRTraceContext) ComputeRecallAtK(k int) float64 {
+	relevant := r.relevantSet()
+	if k <= 0 || len(relevant) == 0 {
+		return 0
+	}
+	return float64(r.relevantInTopK(k)) / float64(len(relevant))
+}
+
+// ComputeNDCG returns the normalized discounted cumulative gain of the top k
+// retrieved documents using binary relevance. It returns 0 if k is not
+// positive or there are no relevant documents.
+func (r *IRTraceContext) ComputeNDCG(k int) float64 {
+	relevant := r.relevantSet()
+	if k <= 0 || len(relevant) == 0 {
+		return 0
+	}
+
+	var dcg float64
+	seen := make(map[string]bool)
+	for i, id := range r.topK(k) {
+		if relevant[id] && !seen[id] {
+			dcg += 1 / math.Log2(float64(i+2))
+		}
+		seen[id] = true
+	}
+
+	var idcg float64
+	for i := 0; i < min(k, len(relevant)); i++ {
+		idcg += 1 / math.Log2(float64(i+2))
+	}
+	return dcg / idcg
+}
+
+// UpdateWithMetrics computes precision, recall, and NDCG at k and records
+// them as numeric scores named "precision@k", "recall@k", and "ndcg@k".
+func (r *IRTraceContext) UpdateWithMetrics(ctx context.Context, k int) error {
+	if k <= 0 {
+		return fmt.Errorf("k must be positive, got %d", k)
+	}
+	if r.output == nil || len(r.output.RelevantDocIDs) == 0 {
+		return fmt.Errorf("relevant document IDs are required to compute IR metrics")
+	}
+
+	scores := []struct {
+		name  string
+		value float64
+	}{
+		{fmt.Sprintf("precision@%d", k), r.ComputePrecisionAtK(k)},
+		{fmt.Sprintf("recall@%d", k), r.ComputeRecallAtK(k)},
+		{fmt.Sprintf("ndcg@%d", k), r.ComputeNDCG(k)},
+	}
+	for _, s := range scores {
+		if err := r.ScoreNumeric(ctx, s.name, s.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// topK returns the first k retrieved document IDs.
+func (r *IRTraceContext) topK(k int) []string {
+	ids := r.input.RetrievedDocIDs
+	if len(ids) > k {
+		ids = ids[:k]
+	}
+	return ids
+}
+
+// relevantSet returns the relevant document IDs as a set.
+func (r *IRTraceContext) relevantSet() map[string]bool {
+	set := make(map[string]bool)
+	if r.output != nil {
+		for _, id := range r.output.RelevantDocIDs {
+			set[id] = true
+		}
+	}
+	return set
+}
+
+// relevantInTopK counts the distinct relevant documents in the top k results.
+func (r *IRTraceContext) relevantInTopK(k int) int {
+	relevant := r.relevantSet()
+	found := make(map[string]bool)
+	for _, id := range r.topK(k) {
+		if relevant[id] {
+			found[id] = true
+		}
+	}
+	return len(found)
+}
@@ -0,0 +1,158 @@
+package evaluation
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestIRTraceBuilder_FluentAPI(t *testing.T) {
+	builder := &IRTraceBuilder{
+		irInput:  &IRInput{},
+		irOutput: &IROutput{},
+	}
+
+	result := builder.
+		Query("go generics").
+		RetrievedDocIDs([]string{"a", "b"}).
+		RetrievedDocs([]string{"doc a", "doc b"}).
+		RelevantDocIDs([]string{"b"})
+
+	if result != builder {
+		t.Error("fluent methods should return the same builder")
+	}
+	if builder.irInput.Query != "go generics" {
+		t.Errorf("Query not set correctly: got %s", builder.irInput.Query)
+	}
+	if len(builder.irInput.RetrievedDocIDs) != 2 || len(builder.irInput.RetrievedDocs) != 2 {
+		t.Errorf("retrieved docs not set correctly: %+v", builder.irInput)
+	}
+	if len(builder.irOutput.RelevantDocIDs) != 1 {
+		t.Errorf("RelevantDocIDs length = %d, want 1", len(builder.irOutput.RelevantDocIDs))
+	}
+}
+
+func TestIRInputJSON(t *testing.T) {
+	input := &IRInput{Query: "q", RetrievedDocIDs: []string{"a"}}
+
+	data, err := json.Marshal(input)
+	if err != nil {
+		t.Fatalf("failed to marshal IRInput: %v", err)
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("failed to unmarshal IRInput: %v", err)
+	}
+	if raw["query"] != "q" {
+		t.Errorf("query = %v", raw["query"])
+	}
+	if _, ok := raw["retrieved_docs"]; !ok {
+		t.Error("retrieved_docs missing from JSON")
+	}
+	if _, ok := raw["retrieved_doc_contents"]; ok {
+		t.Error("retrieved_doc_contents should be omitted when empty")
+	}
+}
+
+func TestIRTraceContext_ValidateForEvaluation(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       *IRInput
+		output      *IROutput
+		expectError bool
+	}{
+		{
+			name:   "valid",
+			input:  &IRInput{Query: "q", RetrievedDocIDs: []string{"a"}},
+			output: &IROutput{RelevantDocIDs: []string{"a"}},
+		},
+		{
+			name:        "missing relevant docs",
+			input:       &IRInput{Query: "q", RetrievedDocIDs: []string{"a"}},
+			output:      &IROutput{},
+			expectError: true,
+		},
+		{
+			name:        "missing retrieved docs",
+			input:       &IRInput{Query: "q"},
+			output:      &IROutput{RelevantDocIDs: []string{"a"}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &IRTraceContext{input: tt.input, output: tt.output}
+			err := ctx.ValidateForEvaluation()
+			if tt.expectError && err == nil {
+				t.Error("expected error, got nil")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestIRTraceContext_Metrics(t *testing.T) {
+	ctx := &IRTraceContext{
+		input:  &IRInput{RetrievedDocIDs: []string{"d1", "d2", "d3", "d4"}},
+		output: &IROutput{RelevantDocIDs: []string{"d1", "d3", "d9"}},
+	}
+
+	// Relevant at ranks 1 and 3.
+	ndcg3 := (1 + 1/math.Log2(4)) / (1 + 1/math.Log2(3) + 1/math.Log2(4))
+
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"precision@1", ctx.ComputePrecisionAtK(1), 1},
+		{"precision@2", ctx.ComputePrecisionAtK(2), 0.5},
+		{"precision@10", ctx.ComputePrecisionAtK(10), 0.2},
+		{"precision@0", ctx.ComputePrecisionAtK(0), 0},
+		{"recall@1", ctx.ComputeRecallAtK(1), 1.0 / 3.0},
+		{"recall@4", ctx.ComputeRecallAtK(4), 2.0 / 3.0},
+		{"ndcg@1", ctx.ComputeNDCG(1), 1},
+		{"ndcg@3", ctx.ComputeNDCG(3), ndcg3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if math.Abs(tt.got-tt.want) > 1e-9 {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIRTraceContext_MetricsIgnoreDuplicates(t *testing.T) {
+	ctx := &IRTraceContext{
+		input:  &IRInput{RetrievedDocIDs: []string{"d1", "d1"}},
+		output: &IROutput{RelevantDocIDs: []string{"d1"}},
+	}
+
+	if got := ctx.ComputePrecisionAtK(2); got != 0.5 {
+		t.Errorf("ComputePrecisionAtK(2) = %v, want 0.5", got)
+	}
+	if got := ctx.ComputeNDCG(2); math.Abs(got-1) > 1e-9 {
+		t.Errorf("ComputeNDCG(2) = %v, want 1", got)
+	}
+}
+
+func TestIRTraceContext_UpdateWithMetricsValidation(t *testing.T) {
+	ctx := &IRTraceContext{
+		input:  &IRInput{Query: "q", RetrievedDocIDs: []string{"a"}},
+		output: &IROutput{},
+	}
+	if err := ctx.UpdateWithMetrics(context.Background(), 5); err == nil {
+		t.Error("expected error without relevant document IDs")
+	}
+
+	ctx.output.RelevantDocIDs = []string{"a"}
+	if err := ctx.UpdateWithMetrics(context.Background(), 0); err == nil {
+		t.Error("expected error for non-positive k")
+	}
+}
@@ -0,0 +1,247 @@
+package evaluation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	langfuse "github.com/jdziat/langfuse-go"
+)
+
+// JudgeGenerationName is the name of the generation recorded for each
+// PromptEvaluator judge call.
+const JudgeGenerationName = "llm-judge"
+
+// JudgeCriteria describes one criterion scored by a PromptEvaluator.
+type JudgeCriteria struct {
+	// Name is the score name, e.g. "helpfulness"
+	Name string
+
+	// Prompt tells the judge what to assess. It may reference the trace
+	// input and output with {{input}} and {{output}}.
+	Prompt string
+
+	// ScaleMin and ScaleMax bound the score the judge may give
+	ScaleMin float64
+	ScaleMax float64
+}
+
+// JudgeFunc calls the judge model with prompt and returns its raw response.
+// It is supplied by the caller so PromptEvaluator works with any LLM
+// provider.
+type JudgeFunc func(ctx context.Context, model, prompt string) (string, error)
+
+// EvalTrace is a trace to be scored by a PromptEvaluator.
+type EvalTrace struct {
+	// Trace is the trace the judge generation and scores are added to
+	Trace *langfuse.TraceContext
+
+	// Input and Output are the values shown to the judge
+	Input  any
+	Output any
+}
+
+// judgeVerdict is the judge's response for a single criterion.
+type judgeVerdict struct {
+	Score     *float64 `json:"score"`
+	Reasoning string   `json:"reasoning"`
+}
+
+// PromptEvaluator scores traces with an LLM acting as a judge. Each
+// evaluation is recorded as a generation in the evaluated trace, and each
+// criterion as a score on the trace.
+type PromptEvaluator struct {
+	client     *langfuse.Client
+	judgeModel string
+	criteria   []JudgeCriteria
+	judge      JudgeFunc
+}
+
+// NewPromptEvaluator creates an LLM-as-judge evaluator that scores traces
+// against criteria using judgeModel. Set the function that calls the model
+// with WithJudge.
+//
+// Example:
+//
+//	evaluator := evaluation.NewPromptEvaluator(client, "gpt-4o", []evaluation.JudgeCriteria{{
+//	    Name:     "helpfulness",
+//	    Prompt:   "How well does the answer address the question?",
+//	    ScaleMin: 1,
+//	    ScaleMax: 5,
+//	}}).WithJudge(func(ctx context.Context, model, prompt string) (string, error) {
+//	    return callOpenAI(ctx, model, prompt)
+//	})
+//
+//	scores, err := evaluator.Evaluate(ctx, evaluation.EvalTrace{
+//	    Trace:  trace.TraceContext,
+//	    Input:  question,
+//	    Output: answer,
+//	})
+func NewPromptEvaluator(client *langfuse.Client, judgeModel string, criteria []JudgeCriteria) *PromptEvaluator {
+	return &PromptEvaluator{
+		client:     client,
+		judgeModel: judgeModel,
+		criteria:   criteria,
+	}
+}
+
+// WithJudge sets the function that calls the judge model.
+func (e *PromptEvaluator) WithJudge(fn JudgeFunc) *PromptEvaluator {
+	e.judge = fn
+	return e
+}
+
+// Validate checks that the evaluator is fully configured.
+func (e *PromptEvaluator) Validate() error {
+	if e.client == nil {
+		return fmt.Errorf("client is required for prompt evaluators")
+	}
+	if e.judgeModel == "" {
+		return fmt.Errorf("judge model is required for prompt evaluators")
+	}
+	if e.judge == nil {
+		return fmt.Errorf("judge function is required for prompt evaluators")
+	}
+	if len(e.criteria) == 0 {
+		return fmt.Errorf("at least one criterion is required for prompt evaluators")
+	}
+	for _, c := range e.criteria {
+		if c.Name == "" {
+			return fmt.Errorf("criterion name is required")
+		}
+		if c.ScaleMin >= c.ScaleMax {
+			return fmt.Errorf("criterion %q: scale min must be less than scale max", c.Name)
+		}
+	}
+	return nil
+}
+
+// Evaluate asks the judge to score trace against every criterion. The judge
+// call is recorded as a generation in the same trace, and each criterion is
+// queued as a numeric score on the trace with the judge's reasoning as its
+// comment. Evaluate fails, without queueing any score, if the judge call
+// fails or its response is not valid JSON with an in-range score for every
+// criterion.
+func (e *PromptEvaluator) Evaluate(ctx context.Context, trace EvalTrace) ([]langfuse.Score, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	if trace.Trace == nil {
+		return nil, fmt.Errorf("trace is required for evaluation")
+	}
+
+	names := make([]string, len(e.criteria))
+	for i, c := range e.criteria {
+		names[i] = c.Name
+	}
+
+	prompt := e.formatPrompt(trace.Input, trace.Output)
+	gen, err := trace.Trace.NewGeneration().
+		Name(JudgeGenerationName).
+		Model(e.judgeModel).
+		Input(prompt).
+		Metadata(langfuse.Metadata{"criteria": names}).
+		Create(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create judge generation: %w", err)
+	}
+
+	response, err := e.judge(ctx, e.judgeModel, prompt)
+	if err != nil {
+		gen.EndWith(ctx, langfuse.WithError(err))
+		return nil, fmt.Errorf("judge call failed: %w", err)
+	}
+
+	verdicts, err := e.parseResponse(response)
+	if err != nil {
+		gen.EndWith(ctx, langfuse.WithOutput(response), langfuse.WithError(err))
+		return nil, err
+	}
+	if err := gen.EndWithOutput(ctx, response); err != nil {
+		return nil, err
+	}
+
+	scores := make([]langfuse.Score, 0, len(e.criteria))
+	for _, c := range e.criteria {
+		v := verdicts[c.Name]
+		metadata := langfuse.Metadata{
+			"judge_model":         e.judgeModel,
+			"judge_generation_id": gen.ID(),
+			"scale_min":           c.ScaleMin,
+			"scale_max":           c.ScaleMax,
+		}
+		builder := trace.Trace.NewScore().Name(c.Name).NumericValue(*v.Score).Metadata(metadata)
+		if v.Reasoning != "" {
+			builder = builder.Comment(v.Reasoning)
+		}
+		if err := builder.Create(ctx); err != nil {
+			return scores, fmt.Errorf("failed to record score %q: %w", c.Name, err)
+		}
+
+		scores = append(scores, langfuse.Score{
+			TraceID:  trace.Trace.ID(),
+			Name:     c.Name,
+			Value:    *v.Score,
+			DataType: langfuse.ScoreDataTypeNumeric,
+			Source:   langfuse.ScoreSourceEval,
+			Comment:  v.Reasoning,
+			Metadata: metadata,
+		})
+	}
+	return scores, nil
+}
+
+// formatPrompt builds the judge prompt for input and output.
+func (e *PromptEvaluator) formatPrompt(input, output any) string {
+	in, out := formatJudgeValue(input), formatJudgeValue(output)
+	replacer := strings.NewReplacer("{{input}}", in, "{{output}}", out)
+
+	var b strings.Builder
+	b.WriteString("You are an impartial judge. Evaluate the output below against each criterion.\n\n")
+	fmt.Fprintf(&b, "Input:\n%s\n\nOutput:\n%s\n\nCriteria:\n", in, out)
+	for _, c := range e.criteria {
+		fmt.Fprintf(&b, "- %s (score from %g to %g): %s\n", c.Name, c.ScaleMin, c.ScaleMax, replacer.Replace(c.Prompt))
+	}
+	b.WriteString("\nRespond with only a JSON object mapping each criterion name to ")
+	b.WriteString(`{"score": <number>, "reasoning": "<short explanation>"}.`)
+	return b.String()
+}
+
+// parseResponse extracts a verdict for every criterion from the judge
+// response, ignoring any text around the JSON object.
+func (e *PromptEvaluator) parseResponse(response string) (map[string]judgeVerdict, error) {
+	start, end := strings.Index(response, "{"), strings.LastIndex(response, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("judge response does not contain a JSON object")
+	}
+
+	var verdicts map[string]judgeVerdict
+	if err := json.Unmarshal([]byte(response[start:end+1]), &verdicts); err != nil {
+		return nil, fmt.Errorf("failed to parse judge response: %w", err)
+	}
+
+	for _, c := range e.criteria {
+		v, ok := verdicts[c.Name]
+		if !ok || v.Score == nil {
+			return nil, fmt.Errorf("judge response has no score for %q", c.Name)
+		}
+		if *v.Score < c.ScaleMin || *v.Score > c.ScaleMax {
+			return nil, fmt.Errorf("judge score for %q must be between %g and %g, got %g", c.Name, c.ScaleMin, c.ScaleMax, *v.Score)
+		}
+	}
+	return verdicts, nil
+}
+
+// formatJudgeValue renders a trace input or output for the judge prompt.
+// Strings are used as is; other values are rendered as JSON.
+func formatJudgeValue(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
@@ -0,0 +1,211 @@
+package evaluation
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	langfuse "github.com/jdziat/langfuse-go"
+	"github.com/jdziat/langfuse-go/langfusetest"
+)
+
+var testJudgeCriteria = []JudgeCriteria{
+	{Name: "helpfulness", Prompt: "Does the answer to {{input}} help?", ScaleMin: 1, ScaleMax: 5},
+	{Name: "accuracy", Prompt: "Is the answer correct?", ScaleMin: 0, ScaleMax: 1},
+}
+
+func TestPromptEvaluator_Validate(t *testing.T) {
+	judge := func(ctx context.Context, model, prompt string) (string, error) { return "", nil }
+	client := &langfuse.Client{}
+
+	tests := []struct {
+		name        string
+		evaluator   *PromptEvaluator
+		expectError bool
+	}{
+		{
+			name:      "valid",
+			evaluator: NewPromptEvaluator(client, "gpt-4o", testJudgeCriteria).WithJudge(judge),
+		},
+		{
+			name:        "missing judge",
+			evaluator:   NewPromptEvaluator(client, "gpt-4o", testJudgeCriteria),
+			expectError: true,
+		},
+		{
+			name:        "missing model",
+			evaluator:   NewPromptEvaluator(client, "", testJudgeCriteria).WithJudge(judge),
+			expectError: true,
+		},
+		{
+			name:        "missing criteria",
+			evaluator:   NewPromptEvaluator(client, "gpt-4o", nil).WithJudge(judge),
+			expectError: true,
+		},
+		{
+			name: "invalid scale",
+			evaluator: NewPromptEvaluator(client, "gpt-4o", []JudgeCriteria{{Name: "x", ScaleMin: 5, ScaleMax: 1}}).
+				WithJudge(judge),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.evaluator.Validate()
+			if tt.expectError && err == nil {
+				t.Error("expected error, got nil")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestPromptEvaluator_ParseResponse(t *testing.T) {
+	e := NewPromptEvaluator(nil, "gpt-4o", testJudgeCriteria)
+
+	tests := []struct {
+		name        string
+		response    string
+		expectError bool
+	}{
+		{
+			name:     "plain JSON",
+			response: `{"helpfulness": {"score": 4, "reasoning": "mostly"}, "accuracy": {"score": 1}}`,
+		},
+		{
+			name:     "fenced JSON",
+			response: "```json\n{\"helpfulness\": {\"score\": 5}, \"accuracy\": {\"score\": 0}}\n```",
+		},
+		{
+			name:        "missing criterion",
+			response:    `{"helpfulness": {"score": 4}}`,
+			expectError: true,
+		},
+		{
+			name:        "out of range",
+			response:    `{"helpfulness": {"score": 9}, "accuracy": {"score": 1}}`,
+			expectError: true,
+		},
+		{
+			name:        "not JSON",
+			response:    "I think it is great",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := e.parseResponse(tt.response)
+			if tt.expectError && err == nil {
+				t.Error("expected error, got nil")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestPromptEvaluator_FormatPrompt(t *testing.T) {
+	e := NewPromptEvaluator(nil, "gpt-4o", testJudgeCriteria)
+	prompt := e.formatPrompt("What is Go?", map[string]any{"answer": "A language"})
+
+	for _, want := range []string{
+		"Input:\nWhat is Go?",
+		`"answer": "A language"`,
+		"- helpfulness (score from 1 to 5): Does the answer to What is Go? help?",
+		"- accuracy (score from 0 to 1)",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+}
+
+func TestPromptEvaluator_Evaluate(t *testing.T) {
+	server := langfusetest.NewMockServer()
+	defer server.Close()
+
+	client, err := langfuse.New("pk-lf-test-key", "sk-lf-test-key",
+		langfuse.WithBaseURL(server.URL),
+		langfuse.WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	trace, err := client.NewTrace().Name("qa").Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	var gotModel string
+	evaluator := NewPromptEvaluator(client, "gpt-4o", testJudgeCriteria).
+		WithJudge(func(ctx context.Context, model, prompt string) (string, error) {
+			gotModel = model
+			return `{"helpfulness": {"score": 4, "reasoning": "clear"}, "accuracy": {"score": 1, "reasoning": "correct"}}`, nil
+		})
+
+	scores, err := evaluator.Evaluate(ctx, EvalTrace{Trace: trace, Input: "What is Go?", Output: "A language"})
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if gotModel != "gpt-4o" {
+		t.Errorf("judge model = %q, want gpt-4o", gotModel)
+	}
+	if len(scores) != 2 || scores[0].Name != "helpfulness" || scores[0].Value != 4.0 || scores[0].TraceID != trace.ID() {
+		t.Fatalf("unexpected scores: %+v", scores)
+	}
+
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	gens := server.GenerationsCreated()
+	if len(gens) != 1 || len(server.RequestsByType("generation-update")) != 1 {
+		t.Fatalf("got %d judge generations, want 1 created and ended", len(gens))
+	}
+	gen := gens[0]
+	if gen["name"] != JudgeGenerationName || gen["model"] != "gpt-4o" {
+		t.Errorf("unexpected judge generation: %v", gen)
+	}
+	if gen["traceId"] != trace.ID() || gen["parentObservationId"] != nil {
+		t.Errorf("judge generation should be a top-level observation of the trace: %v", gen)
+	}
+	recorded := server.ScoresCreated()
+	if len(recorded) != 2 {
+		t.Fatalf("got %d scores, want 2", len(recorded))
+	}
+	if score := recorded[0]; score["name"] != "helpfulness" || score["comment"] != "clear" {
+		t.Errorf("unexpected score: %v", score)
+	}
+}
+
+func TestPromptEvaluator_EvaluateJudgeError(t *testing.T) {
+	client, err := langfuse.New("pk-lf-test-key", "sk-lf-test-key", langfuse.WithFlushInterval(1*time.Hour))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	trace, _ := client.NewTrace().Name("qa").Create(ctx)
+
+	evaluator := NewPromptEvaluator(client, "gpt-4o", testJudgeCriteria).
+		WithJudge(func(ctx context.Context, model, prompt string) (string, error) {
+			return "", errors.New("rate limited")
+		})
+	if _, err := evaluator.Evaluate(ctx, EvalTrace{Trace: trace}); err == nil {
+		t.Error("expected judge error")
+	}
+	if _, err := evaluator.Evaluate(ctx, EvalTrace{}); err == nil {
+		t.Error("expected error for missing trace")
+	}
+}
@@ -0,0 +1,156 @@
+package evaluation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	langfuse "github.com/jdziat/langfuse-go"
+)
+
+// LeaderboardEntry is the ranking of a single dataset run.
+type LeaderboardEntry struct {
+	// RunName is the name of the dataset run
+	RunName string
+
+	// MeanScore is the mean of the run's numeric scores for the metric
+	MeanScore float64
+
+	// ItemCount is the number of run items with at least one score for the
+	// metric
+	ItemCount int
+
+	// Timestamp is when the run was created
+	Timestamp time.Time
+}
+
+// Leaderboard ranks the runs of a dataset by their mean score for a metric.
+type Leaderboard struct {
+	// Metric is the score name the runs are ranked by
+	Metric string
+
+	// Runs is sorted by MeanScore in descending order. Runs without any
+	// score for the metric are omitted.
+	Runs []LeaderboardEntry
+}
+
+// BuildLeaderboard ranks the runs of a dataset by the mean of the scores
+// named metric on the traces linked to each run's items. Only numeric score
+// values are considered. Runs with equal mean scores are ordered from the
+// most recent.
+//
+// Example:
+//
+//	board, err := evaluation.BuildLeaderboard(ctx, client, "support-golden", "accuracy")
+//	if err != nil {
+//	    return err
+//	}
+//	fmt.Println("best run:", board.Winner().RunName)
+func BuildLeaderboard(ctx context.Context, client *langfuse.Client, datasetName, metric string) (*Leaderboard, error) {
+	if datasetName == "" {
+		return nil, fmt.Errorf("dataset name is required")
+	}
+	if metric == "" {
+		return nil, fmt.Errorf("metric is required")
+	}
+
+	board := &Leaderboard{Metric: metric}
+	for page := 1; ; page++ {
+		resp, err := client.Datasets().ListRuns(ctx, datasetName, &langfuse.PaginationParams{Page: page, Limit: runnerPageSize})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list dataset runs: %w", err)
+		}
+
+		for _, run := range resp.Data {
+			entry, err := leaderboardEntry(ctx, client, datasetName, run.Name, metric)
+			if err != nil {
+				return nil, err
+			}
+			if entry != nil {
+				board.Runs = append(board.Runs, *entry)
+			}
+		}
+
+		if len(resp.Data) == 0 || !resp.Meta.HasMore() {
+			break
+		}
+	}
+
+	sort.SliceStable(board.Runs, func(i, j int) bool {
+		if board.Runs[i].MeanScore != board.Runs[j].MeanScore {
+			return board.Runs[i].MeanScore > board.Runs[j].MeanScore
+		}
+		return board.Runs[i].Timestamp.After(board.Runs[j].Timestamp)
+	})
+	return board, nil
+}
+
+// Winner returns the entry with the highest mean score, or nil if the
+// leaderboard is empty.
+func (l *Leaderboard) Winner() *LeaderboardEntry {
+	if len(l.Runs) == 0 {
+		return nil
+	}
+	return &l.Runs[0]
+}
+
+// Rank returns the 1-based position of the named run, or 0 if the run is
+// not on the leaderboard.
+func (l *Leaderboard) Rank(runName string) int {
+	for i, entry := range l.Runs {
+		if entry.RunName == runName {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// leaderboardEntry fetches the run's items and averages the metric scores of
+// their traces. It returns nil if none of the traces has a score for metric.
+func leaderboardEntry(ctx context.Context, client *langfuse.Client, datasetName, runName, metric string) (*LeaderboardEntry, error) {
+	run, err := client.Datasets().GetRun(ctx, datasetName, runName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dataset run %s: %w", runName, err)
+	}
+
+	agg := NewScoreAggregator(metric)
+	var items int
+	for _, item := range run.DatasetRunItems {
+		if item.TraceID == "" {
+			continue
+		}
+		scores, err := client.Scores().List(ctx, &langfuse.ScoresListParams{
+			PaginationParams: langfuse.PaginationParams{Limit: runnerPageSize},
+			Name:             metric,
+			TraceID:          item.TraceID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list scores for trace %s: %w", item.TraceID, err)
+		}
+
+		var scored bool
+		for _, score := range scores.Data {
+			if score.Name != metric {
+				continue
+			}
+			if value, ok := score.Value.(float64); ok {
+				agg.Add(value)
+				scored = true
+			}
+		}
+		if scored {
+			items++
+		}
+	}
+
+	if agg.Count() == 0 {
+		return nil, nil
+	}
+	return &LeaderboardEntry{
+		RunName:   runName,
+		MeanScore: agg.Mean(),
+		ItemCount: items,
+		Timestamp: run.CreatedAt.Time,
+	}, nil
+}
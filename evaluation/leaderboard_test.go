@@ -0,0 +1,102 @@
+package evaluation
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	langfuse "github.com/jdziat/langfuse-go"
+)
+
+func TestBuildLeaderboard(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	runs := map[string]langfuse.DatasetRun{
+		"baseline": {Name: "baseline", CreatedAt: langfuse.Time{Time: created}, DatasetRunItems: []langfuse.DatasetRunItem{
+			{TraceID: "b1"}, {TraceID: "b2"},
+		}},
+		"tuned": {Name: "tuned", CreatedAt: langfuse.Time{Time: created.Add(time.Hour)}, DatasetRunItems: []langfuse.DatasetRunItem{
+			{TraceID: "t1"}, {TraceID: "t2"}, {TraceID: "t3"},
+		}},
+		"unscored": {Name: "unscored", DatasetRunItems: []langfuse.DatasetRunItem{{TraceID: "u1"}}},
+	}
+	scores := map[string][]langfuse.Score{
+		"b1": {{Name: "accuracy", Value: 0.5}},
+		"b2": {{Name: "accuracy", Value: 0.7}},
+		"t1": {{Name: "accuracy", Value: 0.9}, {Name: "accuracy", Value: 0.7}},
+		"t2": {{Name: "accuracy", Value: 0.8}},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/public/datasets/golden/runs":
+			json.NewEncoder(w).Encode(langfuse.DatasetRunsListResponse{Data: []langfuse.DatasetRun{
+				{Name: "baseline"}, {Name: "unscored"}, {Name: "tuned"},
+			}})
+		case strings.HasPrefix(r.URL.Path, "/api/public/datasets/golden/runs/"):
+			json.NewEncoder(w).Encode(runs[strings.TrimPrefix(r.URL.Path, "/api/public/datasets/golden/runs/")])
+		case r.URL.Path == "/api/public/scores":
+			if r.URL.Query().Get("name") != "accuracy" {
+				t.Errorf("scores name = %q, want accuracy", r.URL.Query().Get("name"))
+			}
+			json.NewEncoder(w).Encode(langfuse.ScoresListResponse{Data: scores[r.URL.Query().Get("traceId")]})
+		default:
+			json.NewEncoder(w).Encode(langfuse.IngestionResult{})
+		}
+	}))
+	defer server.Close()
+
+	client, err := langfuse.New("pk-lf-test-key", "sk-lf-test-key",
+		langfuse.WithBaseURL(server.URL),
+		langfuse.WithFlushInterval(1*time.Hour),
+		langfuse.WithMaxRetries(0),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	board, err := BuildLeaderboard(context.Background(), client, "golden", "accuracy")
+	if err != nil {
+		t.Fatalf("BuildLeaderboard failed: %v", err)
+	}
+	if len(board.Runs) != 2 {
+		t.Fatalf("len(Runs) = %d, want 2 (unscored run omitted)", len(board.Runs))
+	}
+
+	winner := board.Winner()
+	if winner == nil || winner.RunName != "tuned" {
+		t.Fatalf("Winner() = %+v, want tuned", winner)
+	}
+	if math.Abs(winner.MeanScore-0.8) > 1e-9 {
+		t.Errorf("tuned MeanScore = %v, want 0.8", winner.MeanScore)
+	}
+	if winner.ItemCount != 2 {
+		t.Errorf("tuned ItemCount = %d, want 2", winner.ItemCount)
+	}
+	if !winner.Timestamp.Equal(created.Add(time.Hour)) {
+		t.Errorf("tuned Timestamp = %v, want %v", winner.Timestamp, created.Add(time.Hour))
+	}
+	if math.Abs(board.Runs[1].MeanScore-0.6) > 1e-9 {
+		t.Errorf("baseline MeanScore = %v, want 0.6", board.Runs[1].MeanScore)
+	}
+
+	if board.Rank("tuned") != 1 || board.Rank("baseline") != 2 || board.Rank("unscored") != 0 {
+		t.Errorf("Rank() = %d, %d, %d, want 1, 2, 0",
+			board.Rank("tuned"), board.Rank("baseline"), board.Rank("unscored"))
+	}
+}
+
+func TestLeaderboard_WinnerEmpty(t *testing.T) {
+	if (&Leaderboard{}).Winner() != nil {
+		t.Error("Winner() of an empty leaderboard should be nil")
+	}
+	if _, err := BuildLeaderboard(context.Background(), nil, "golden", ""); err == nil {
+		t.Error("BuildLeaderboard should require a metric")
+	}
+}
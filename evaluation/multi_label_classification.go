@@ -0,0 +1,332 @@
+package evaluation
+
+import (
+	"context"
+	"fmt"
+
+	langfuse "github.com/jdziat/langfuse-go"
+)
+
+// Score names used by MultiLabelClassificationTraceContext.UpdateWithMetrics.
+const (
+	HammingLossScoreName  = "hamming_loss"
+	JaccardScoreScoreName = "jaccard_score"
+	F1MacroScoreName      = "f1_macro"
+)
+
+// MultiLabelClassificationTraceBuilder provides a fluent interface for
+// creating multi-label classification traces.
+type MultiLabelClassificationTraceBuilder struct {
+	*langfuse.TraceBuilder
+	mlInput         *MultiLabelClassificationInput
+	mlOutput        *MultiLabelClassificationOutput
+	metadata        map[string]any
+	evaluatorConfig *EvaluatorConfig
+}
+
+// NewMultiLabelClassificationTrace creates a new multi-label classification
+// trace builder. Unlike ClassificationTraceBuilder, an input may be assigned
+// any number of labels.
+//
+// Example:
+//
+//	trace, err := evaluation.NewMultiLabelClassificationTrace(client, "topics").
+//	    Input(article).
+//	    Classes([]string{"sports", "politics", "business"}).
+//	    PredictedLabels([]string{"sports", "politics"}).
+//	    GroundTruthLabels([]string{"politics"}).
+//	    Create(ctx)
+//	trace.UpdateWithMetrics(ctx)
+func NewMultiLabelClassificationTrace(client *langfuse.Client, name string) *MultiLabelClassificationTraceBuilder {
+	return &MultiLabelClassificationTraceBuilder{
+		TraceBuilder: client.NewTrace().Name(name),
+		mlInput:      &MultiLabelClassificationInput{},
+		mlOutput:     &MultiLabelClassificationOutput{},
+	}
+}
+
+// Input sets the text to classify.
+func (b *MultiLabelClassificationTraceBuilder) Input(text string) *MultiLabelClassificationTraceBuilder {
+	b.mlInput.Input = text
+	return b
+}
+
+// Classes sets all possible labels.
+func (b *MultiLabelClassificationTraceBuilder) Classes(classes []string) *MultiLabelClassificationTraceBuilder {
+	b.mlInput.Classes = classes
+	return b
+}
+
+// PredictedLabels sets the labels assigned by the model.
+func (b *MultiLabelClassificationTraceBuilder) PredictedLabels(labels []string) *MultiLabelClassificationTraceBuilder {
+	b.mlInput.PredictedLabels = labels
+	return b
+}
+
+// PredictedProbabilities sets the model's probability for each label.
+func (b *MultiLabelClassificationTraceBuilder) PredictedProbabilities(probs map[string]float64) *MultiLabelClassificationTraceBuilder {
+	b.mlInput.PredictedProbabilities = probs
+	return b
+}
+
+// GroundTruthLabels sets the expected labels for evaluation.
+func (b *MultiLabelClassificationTraceBuilder) GroundTruthLabels(labels []string) *MultiLabelClassificationTraceBuilder {
+	b.mlOutput.GroundTruthLabels = labels
+	return b
+}
+
+// ID sets the trace ID.
+func (b *MultiLabelClassificationTraceBuilder) ID(id string) *MultiLabelClassificationTraceBuilder {
+	b.TraceBuilder.ID(id)
+	return b
+}
+
+// UserID sets the user ID.
+func (b *MultiLabelClassificationTraceBuilder) UserID(userID string) *MultiLabelClassificationTraceBuilder {
+	b.TraceBuilder.UserID(userID)
+	return b
+}
+
+// SessionID sets the session ID.
+func (b *MultiLabelClassificationTraceBuilder) SessionID(sessionID string) *MultiLabelClassificationTraceBuilder {
+	b.TraceBuilder.SessionID(sessionID)
+	return b
+}
+
+// Tags sets the trace tags.
+func (b *MultiLabelClassificationTraceBuilder) Tags(tags []string) *MultiLabelClassificationTraceBuilder {
+	b.TraceBuilder.Tags(tags)
+	return b
+}
+
+// Metadata sets the trace metadata.
+func (b *MultiLabelClassificationTraceBuilder) Metadata(metadata map[string]any) *MultiLabelClassificationTraceBuilder {
+	b.metadata = metadata
+	b.TraceBuilder.Metadata(metadata)
+	return b
+}
+
+// WithEvaluatorConfig records the judge configuration under
+// EvaluatorConfigMetadataKey in the trace metadata.
+func (b *MultiLabelClassificationTraceBuilder) WithEvaluatorConfig(cfg EvaluatorConfig) *MultiLabelClassificationTraceBuilder {
+	b.evaluatorConfig = &cfg
+	return b
+}
+
+// Release sets the release version.
+func (b *MultiLabelClassificationTraceBuilder) Release(release string) *MultiLabelClassificationTraceBuilder {
+	b.TraceBuilder.Release(release)
+	return b
+}
+
+// Version sets the version.
+func (b *MultiLabelClassificationTraceBuilder) Version(version string) *MultiLabelClassificationTraceBuilder {
+	b.TraceBuilder.Version(version)
+	return b
+}
+
+// Environment sets the environment.
+func (b *MultiLabelClassificationTraceBuilder) Environment(env string) *MultiLabelClassificationTraceBuilder {
+	b.TraceBuilder.Environment(env)
+	return b
+}
+
+// Public sets whether the trace is public.
+func (b *MultiLabelClassificationTraceBuilder) Public(public bool) *MultiLabelClassificationTraceBuilder {
+	b.TraceBuilder.Public(public)
+	return b
+}
+
+// Validate validates the multi-label classification trace configuration.
+func (b *MultiLabelClassificationTraceBuilder) Validate() error {
+	if b.mlInput.Input == "" {
+		return fmt.Errorf("input text is required for multi-label classification traces")
+	}
+	return b.TraceBuilder.Validate()
+}
+
+// Create creates the multi-label classification trace and returns a context
+// for updating it. The input, classes and predictions are recorded as the
+// trace input; ground truth labels, if set, are recorded as the trace output.
+func (b *MultiLabelClassificationTraceBuilder) Create(ctx context.Context) (*MultiLabelClassificationTraceContext, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	b.TraceBuilder.Input(b.mlInput)
+	if len(b.mlOutput.GroundTruthLabels) > 0 {
+		b.TraceBuilder.Output(b.mlOutput)
+	}
+
+	if b.evaluatorConfig != nil {
+		b.TraceBuilder.Metadata(withEvaluatorConfig(b.metadata, b.evaluatorConfig))
+	}
+
+	traceCtx, err := b.TraceBuilder.Create(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MultiLabelClassificationTraceContext{
+		TraceContext: traceCtx,
+		input:        b.mlInput,
+		output:       b.mlOutput,
+	}, nil
+}
+
+// TryCreate is like Create but returns the trace and error as a single
+// BuildResult.
+func (b *MultiLabelClassificationTraceBuilder) TryCreate(ctx context.Context) langfuse.BuildResult[*MultiLabelClassificationTraceContext] {
+	return langfuse.NewBuildResult(b.Create(ctx))
+}
+
+// MultiLabelClassificationTraceContext provides context for a multi-label
+// classification trace with typed methods.
+type MultiLabelClassificationTraceContext struct {
+	*langfuse.TraceContext
+	input  *MultiLabelClassificationInput
+	output *MultiLabelClassificationOutput
+}
+
+// GetInput returns the multi-label classification input.
+func (m *MultiLabelClassificationTraceContext) GetInput() *MultiLabelClassificationInput {
+	return m.input
+}
+
+// GetOutput returns the multi-label classification output.
+func (m *MultiLabelClassificationTraceContext) GetOutput() *MultiLabelClassificationOutput {
+	return m.output
+}
+
+// ValidateForEvaluation checks if the trace has all required fields for evaluation.
+func (m *MultiLabelClassificationTraceContext) ValidateForEvaluation() error {
+	return ValidateFor(m.input, m.output, MultiLabelEvaluator)
+}
+
+// ComputeHammingLoss returns the fraction of labels that are wrongly
+// predicted, either assigned but not expected or expected but not assigned.
+// The label space is Classes, or the union of predicted and ground truth
+// labels if Classes is empty. It returns 0 for an empty label space.
+func (m *MultiLabelClassificationTraceContext) ComputeHammingLoss() float64 {
+	predicted, truth := m.labelSets()
+	classes := m.labelSpace(predicted, truth)
+	if len(classes) == 0 {
+		return 0
+	}
+
+	var wrong int
+	for _, c := range classes {
+		if predicted[c] != truth[c] {
+			wrong++
+		}
+	}
+	return float64(wrong) / float64(len(classes))
+}
+
+// ComputeJaccardScore returns the size of the intersection of predicted and
+// ground truth labels divided by the size of their union. If both are empty
+// the score is 1.
+func (m *MultiLabelClassificationTraceContext) ComputeJaccardScore() float64 {
+	predicted, truth := m.labelSets()
+
+	union := len(truth)
+	var intersection int
+	for label := range predicted {
+		if truth[label] {
+			intersection++
+		} else {
+			union++
+		}
+	}
+	if union == 0 {
+		return 1
+	}
+	return float64(intersection) / float64(union)
+}
+
+// ComputeF1Macro returns the unweighted mean of the per-label F1 scores over
+// the label space (see ComputeHammingLoss). A label that is neither
+// predicted nor expected scores 1, since the prediction for it is correct.
+// It returns 1 for an empty label space.
+func (m *MultiLabelClassificationTraceContext) ComputeF1Macro() float64 {
+	predicted, truth := m.labelSets()
+	classes := m.labelSpace(predicted, truth)
+	if len(classes) == 0 {
+		return 1
+	}
+
+	var sum float64
+	for _, c := range classes {
+		// With a single example, a label's F1 is 1 when the prediction
+		// agrees with the ground truth and 0 otherwise.
+		if predicted[c] == truth[c] {
+			sum++
+		}
+	}
+	return sum / float64(len(classes))
+}
+
+// UpdateWithMetrics computes Hamming loss, Jaccard score and macro F1 and
+// records them as numeric scores named "hamming_loss", "jaccard_score" and
+// "f1_macro" on the trace.
+func (m *MultiLabelClassificationTraceContext) UpdateWithMetrics(ctx context.Context) error {
+	if m.output == nil || len(m.output.GroundTruthLabels) == 0 {
+		return fmt.Errorf("ground truth labels are required to compute multi-label metrics")
+	}
+
+	scores := []struct {
+		name  string
+		value float64
+	}{
+		{HammingLossScoreName, m.ComputeHammingLoss()},
+		{JaccardScoreScoreName, m.ComputeJaccardScore()},
+		{F1MacroScoreName, m.ComputeF1Macro()},
+	}
+	for _, s := range scores {
+		if err := m.ScoreNumeric(ctx, s.name, s.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// labelSets returns the predicted and ground truth labels as sets.
+func (m *MultiLabelClassificationTraceContext) labelSets() (predicted, truth map[string]bool) {
+	predicted = make(map[string]bool)
+	for _, label := range m.input.PredictedLabels {
+		predicted[label] = true
+	}
+	truth = make(map[string]bool)
+	if m.output != nil {
+		for _, label := range m.output.GroundTruthLabels {
+			truth[label] = true
+		}
+	}
+	return predicted, truth
+}
+
+// labelSpace returns the distinct labels metrics are computed over: Classes,
+// or the union of predicted and truth if Classes is empty.
+func (m *MultiLabelClassificationTraceContext) labelSpace(predicted, truth map[string]bool) []string {
+	source := m.input.Classes
+	if len(source) == 0 {
+		for label := range predicted {
+			source = append(source, label)
+		}
+		for label := range truth {
+			if !predicted[label] {
+				source = append(source, label)
+			}
+		}
+	}
+
+	seen := make(map[string]bool, len(source))
+	classes := make([]string, 0, len(source))
+	for _, c := range source {
+		if !seen[c] {
+			seen[c] = true
+			classes = append(classes, c)
+		}
+	}
+	return classes
+}
@@ -0,0 +1,137 @@
+package evaluation
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestMultiLabelClassificationTraceBuilder_FluentAPI(t *testing.T) {
+	builder := &MultiLabelClassificationTraceBuilder{
+		mlInput:  &MultiLabelClassificationInput{},
+		mlOutput: &MultiLabelClassificationOutput{},
+	}
+
+	result := builder.
+		Input("The minister opened the stadium.").
+		Classes([]string{"sports", "politics", "business"}).
+		PredictedLabels([]string{"sports", "politics"}).
+		PredictedProbabilities(map[string]float64{"sports": 0.8, "politics": 0.7, "business": 0.1}).
+		GroundTruthLabels([]string{"politics"})
+
+	if result != builder {
+		t.Error("fluent methods should return the same builder")
+	}
+	if builder.mlInput.Input != "The minister opened the stadium." {
+		t.Errorf("Input not set correctly: got %s", builder.mlInput.Input)
+	}
+	if len(builder.mlInput.Classes) != 3 {
+		t.Errorf("Classes length = %d, want 3", len(builder.mlInput.Classes))
+	}
+	if len(builder.mlInput.PredictedLabels) != 2 {
+		t.Errorf("PredictedLabels length = %d, want 2", len(builder.mlInput.PredictedLabels))
+	}
+	if builder.mlInput.PredictedProbabilities["sports"] != 0.8 {
+		t.Errorf("PredictedProbabilities not set correctly: got %v", builder.mlInput.PredictedProbabilities)
+	}
+	if len(builder.mlOutput.GroundTruthLabels) != 1 {
+		t.Errorf("GroundTruthLabels length = %d, want 1", len(builder.mlOutput.GroundTruthLabels))
+	}
+}
+
+func TestMultiLabelClassificationInputJSON(t *testing.T) {
+	input := &MultiLabelClassificationInput{
+		Input:           "text",
+		Classes:         []string{"a", "b"},
+		PredictedLabels: []string{"a"},
+	}
+
+	data, err := json.Marshal(input)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	for _, field := range []string{"input", "classes", "predicted_labels"} {
+		if _, ok := decoded[field]; !ok {
+			t.Errorf("missing field %q in %s", field, data)
+		}
+	}
+	if _, ok := decoded["predicted_probabilities"]; ok {
+		t.Error("predicted_probabilities should be omitted when empty")
+	}
+}
+
+func TestMultiLabelClassificationTraceContext_ValidateForEvaluation(t *testing.T) {
+	valid := &MultiLabelClassificationTraceContext{
+		input: &MultiLabelClassificationInput{
+			Input:           "text",
+			Classes:         []string{"a", "b"},
+			PredictedLabels: []string{"a"},
+		},
+		output: &MultiLabelClassificationOutput{},
+	}
+	if err := valid.ValidateForEvaluation(); err != nil {
+		t.Errorf("ValidateForEvaluation() error = %v", err)
+	}
+
+	missing := &MultiLabelClassificationTraceContext{
+		input:  &MultiLabelClassificationInput{Input: "text"},
+		output: &MultiLabelClassificationOutput{},
+	}
+	if err := missing.ValidateForEvaluation(); err == nil {
+		t.Error("expected error without classes and predicted labels")
+	}
+}
+
+func TestMultiLabelClassificationTraceContext_Metrics(t *testing.T) {
+	classes := []string{"sports", "politics", "business", "tech"}
+
+	tests := []struct {
+		name        string
+		classes     []string
+		predicted   []string
+		truth       []string
+		wantHamming float64
+		wantJaccard float64
+		wantF1Macro float64
+	}{
+		{"perfect match", classes, []string{"sports", "politics"}, []string{"politics", "sports"}, 0, 1, 1},
+		{"one extra label", classes, []string{"sports", "politics"}, []string{"politics"}, 0.25, 0.5, 0.75},
+		{"disjoint", classes, []string{"tech"}, []string{"business"}, 0.5, 0, 0.5},
+		{"no classes uses union", nil, []string{"a", "b"}, []string{"b", "c"}, 2.0 / 3.0, 1.0 / 3.0, 1.0 / 3.0},
+		{"all empty", nil, nil, nil, 0, 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &MultiLabelClassificationTraceContext{
+				input:  &MultiLabelClassificationInput{Classes: tt.classes, PredictedLabels: tt.predicted},
+				output: &MultiLabelClassificationOutput{GroundTruthLabels: tt.truth},
+			}
+			if got := ctx.ComputeHammingLoss(); math.Abs(got-tt.wantHamming) > 1e-9 {
+				t.Errorf("ComputeHammingLoss() = %v, want %v", got, tt.wantHamming)
+			}
+			if got := ctx.ComputeJaccardScore(); math.Abs(got-tt.wantJaccard) > 1e-9 {
+				t.Errorf("ComputeJaccardScore() = %v, want %v", got, tt.wantJaccard)
+			}
+			if got := ctx.ComputeF1Macro(); math.Abs(got-tt.wantF1Macro) > 1e-9 {
+				t.Errorf("ComputeF1Macro() = %v, want %v", got, tt.wantF1Macro)
+			}
+		})
+	}
+}
+
+func TestMultiLabelClassificationTraceContext_UpdateWithMetricsRequiresGroundTruth(t *testing.T) {
+	ctx := &MultiLabelClassificationTraceContext{
+		input:  &MultiLabelClassificationInput{Input: "x", PredictedLabels: []string{"a"}},
+		output: &MultiLabelClassificationOutput{},
+	}
+	if err := ctx.UpdateWithMetrics(context.Background()); err == nil {
+		t.Error("expected error without ground truth labels")
+	}
+}
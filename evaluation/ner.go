@@ -0,0 +1,234 @@
+package evaluation
+
+import (
+	"context"
+	"fmt"
+
+	langfuse "github.com/jdziat/langfuse-go"
+)
+
+// NERF1ScoreName is the score name used by NERTraceContext.UpdateWithF1.
+const NERF1ScoreName = "ner_f1"
+
+// NERTraceBuilder provides a fluent interface for creating NER-ready traces.
+type NERTraceBuilder struct {
+	*langfuse.TraceBuilder
+	nerInput        *NERInput
+	nerOutput       *NEROutput
+	metadata        map[string]any
+	evaluatorConfig *EvaluatorConfig
+}
+
+// NewNERTrace creates a new named entity recognition trace builder.
+//
+// Example:
+//
+//	trace, err := evaluation.NewNERTrace(client, "extract-entities").
+//	    InputText("Ada Lovelace worked with Charles Babbage.").
+//	    PredictedEntities(predicted).
+//	    GroundTruthEntities(expected).
+//	    Create(ctx)
+//	trace.UpdateWithF1(ctx)
+func NewNERTrace(client *langfuse.Client, name string) *NERTraceBuilder {
+	return &NERTraceBuilder{
+		TraceBuilder: client.NewTrace().Name(name),
+		nerInput:     &NERInput{},
+		nerOutput:    &NEROutput{},
+	}
+}
+
+// InputText sets the text entities are extracted from.
+func (b *NERTraceBuilder) InputText(text string) *NERTraceBuilder {
+	b.nerInput.InputText = text
+	return b
+}
+
+// PredictedEntities sets the entities produced by the model.
+func (b *NERTraceBuilder) PredictedEntities(entities []Entity) *NERTraceBuilder {
+	b.nerInput.PredictedEntities = entities
+	return b
+}
+
+// GroundTruthEntities sets the expected entities for evaluation.
+func (b *NERTraceBuilder) GroundTruthEntities(entities []Entity) *NERTraceBuilder {
+	b.nerOutput.GroundTruthEntities = entities
+	return b
+}
+
+// ID sets the trace ID.
+func (b *NERTraceBuilder) ID(id string) *NERTraceBuilder {
+	b.TraceBuilder.ID(id)
+	return b
+}
+
+// UserID sets the user ID.
+func (b *NERTraceBuilder) UserID(userID string) *NERTraceBuilder {
+	b.TraceBuilder.UserID(userID)
+	return b
+}
+
+// SessionID sets the session ID.
+func (b *NERTraceBuilder) SessionID(sessionID string) *NERTraceBuilder {
+	b.TraceBuilder.SessionID(sessionID)
+	return b
+}
+
+// Tags sets the trace tags.
+func (b *NERTraceBuilder) Tags(tags []string) *NERTraceBuilder {
+	b.TraceBuilder.Tags(tags)
+	return b
+}
+
+// Metadata sets the trace metadata.
+func (b *NERTraceBuilder) Metadata(metadata map[string]any) *NERTraceBuilder {
+	b.metadata = metadata
+	b.TraceBuilder.Metadata(metadata)
+	return b
+}
+
+// WithEvaluatorConfig records the judge configuration under
+// EvaluatorConfigMetadataKey in the trace metadata.
+func (b *NERTraceBuilder) WithEvaluatorConfig(cfg EvaluatorConfig) *NERTraceBuilder {
+	b.evaluatorConfig = &cfg
+	return b
+}
+
+// Release sets the release version.
+func (b *NERTraceBuilder) Release(release string) *NERTraceBuilder {
+	b.TraceBuilder.Release(release)
+	return b
+}
+
+// Version sets the version.
+func (b *NERTraceBuilder) Version(version string) *NERTraceBuilder {
+	b.TraceBuilder.Version(version)
+	return b
+}
+
+// Environment sets the environment.
+func (b *NERTraceBuilder) Environment(env string) *NERTraceBuilder {
+	b.TraceBuilder.Environment(env)
+	return b
+}
+
+// Public sets whether the trace is public.
+func (b *NERTraceBuilder) Public(public bool) *NERTraceBuilder {
+	b.TraceBuilder.Public(public)
+	return b
+}
+
+// Validate validates the NER trace configuration.
+func (b *NERTraceBuilder) Validate() error {
+	if b.nerInput.InputText == "" {
+		return fmt.Errorf("input text is required for NER traces")
+	}
+	return b.TraceBuilder.Validate()
+}
+
+// Create creates the NER trace and returns a context for updating it.
+// The input text and predicted entities are recorded as the trace input;
+// ground truth entities, if set, are recorded as the trace output.
+func (b *NERTraceBuilder) Create(ctx context.Context) (*NERTraceContext, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	b.TraceBuilder.Input(b.nerInput)
+	if len(b.nerOutput.GroundTruthEntities) > 0 {
+		b.TraceBuilder.Output(b.nerOutput)
+	}
+
+	if b.evaluatorConfig != nil {
+		b.TraceBuilder.Metadata(withEvaluatorConfig(b.metadata, b.evaluatorConfig))
+	}
+
+	traceCtx, err := b.TraceBuilder.Create(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NERTraceContext{
+		TraceContext: traceCtx,
+		input:        b.nerInput,
+		output:       b.nerOutput,
+	}, nil
+}
+
+// TryCreate is like Create but returns the trace and error as a single
+// BuildResult.
+func (b *NERTraceBuilder) TryCreate(ctx context.Context) langfuse.BuildResult[*NERTraceContext] {
+	return langfuse.NewBuildResult(b.Create(ctx))
+}
+
+// NERTraceContext provides context for a NER trace with typed methods.
+type NERTraceContext struct {
+	*langfuse.TraceContext
+	input  *NERInput
+	output *NEROutput
+}
+
+// GetInput returns the NER input.
+func (n *NERTraceContext) GetInput() *NERInput {
+	return n.input
+}
+
+// GetOutput returns the NER output.
+func (n *NERTraceContext) GetOutput() *NEROutput {
+	return n.output
+}
+
+// ValidateForEvaluation checks if the trace has all required fields for evaluation.
+func (n *NERTraceContext) ValidateForEvaluation() error {
+	return ValidateFor(n.input, n.output, NEREvaluator)
+}
+
+// ComputeF1 returns the entity-level F1 score of the predicted entities
+// against the ground truth entities. An entity counts as a match only when
+// its text, label, and character offsets are all equal. If both sets are
+// empty the score is 1.
+func (n *NERTraceContext) ComputeF1() float64 {
+	var truth []Entity
+	if n.output != nil {
+		truth = n.output.GroundTruthEntities
+	}
+	return entityF1(n.input.PredictedEntities, truth)
+}
+
+// UpdateWithF1 computes the entity-level F1 score and records it as a
+// numeric score named "ner_f1" on the trace.
+func (n *NERTraceContext) UpdateWithF1(ctx context.Context) error {
+	if n.output == nil || len(n.output.GroundTruthEntities) == 0 {
+		return fmt.Errorf("ground truth entities are required to compute F1")
+	}
+	return n.ScoreNumeric(ctx, NERF1ScoreName, n.ComputeF1())
+}
+
+// entityF1 computes the F1 score between predicted and expected entities.
+func entityF1(predicted, expected []Entity) float64 {
+	if len(predicted) == 0 && len(expected) == 0 {
+		return 1
+	}
+	if len(predicted) == 0 || len(expected) == 0 {
+		return 0
+	}
+
+	remaining := make(map[Entity]int, len(expected))
+	for _, e := range expected {
+		remaining[e]++
+	}
+
+	var tp int
+	for _, p := range predicted {
+		if remaining[p] > 0 {
+			remaining[p]--
+			tp++
+		}
+	}
+	if tp == 0 {
+		return 0
+	}
+
+	precision := float64(tp) / float64(len(predicted))
+	recall := float64(tp) / float64(len(expected))
+	return 2 * precision * recall / (precision + recall)
+}
@@ -0,0 +1,142 @@
+package evaluation
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestNERTraceBuilder_FluentAPI(t *testing.T) {
+	builder := &NERTraceBuilder{
+		nerInput:  &NERInput{},
+		nerOutput: &NEROutput{},
+	}
+
+	predicted := []Entity{{Text: "Ada", Label: "PERSON", StartChar: 0, EndChar: 3}}
+	result := builder.
+		InputText("Ada wrote notes.").
+		PredictedEntities(predicted).
+		GroundTruthEntities(predicted)
+
+	if result != builder {
+		t.Error("fluent methods should return the same builder")
+	}
+	if builder.nerInput.InputText != "Ada wrote notes." {
+		t.Errorf("InputText not set correctly: got %s", builder.nerInput.InputText)
+	}
+	if len(builder.nerInput.PredictedEntities) != 1 {
+		t.Errorf("PredictedEntities length = %d, want 1", len(builder.nerInput.PredictedEntities))
+	}
+	if len(builder.nerOutput.GroundTruthEntities) != 1 {
+		t.Errorf("GroundTruthEntities length = %d, want 1", len(builder.nerOutput.GroundTruthEntities))
+	}
+}
+
+func TestNERInputJSON(t *testing.T) {
+	input := &NERInput{
+		InputText:         "Paris is in France.",
+		PredictedEntities: []Entity{{Text: "Paris", Label: "LOC", StartChar: 0, EndChar: 5}},
+	}
+
+	data, err := json.Marshal(input)
+	if err != nil {
+		t.Fatalf("failed to marshal NERInput: %v", err)
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("failed to unmarshal NERInput: %v", err)
+	}
+	if raw["input_text"] != "Paris is in France." {
+		t.Errorf("input_text = %v", raw["input_text"])
+	}
+	entities, ok := raw["predicted_entities"].([]any)
+	if !ok || len(entities) != 1 {
+		t.Fatalf("predicted_entities = %v", raw["predicted_entities"])
+	}
+	entity := entities[0].(map[string]any)
+	if entity["start_char"] != float64(0) || entity["end_char"] != float64(5) {
+		t.Errorf("entity offsets = %v", entity)
+	}
+}
+
+func TestNERTraceContext_ValidateForEvaluation(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       *NERInput
+		expectError bool
+	}{
+		{
+			name: "valid",
+			input: &NERInput{
+				InputText:         "Ada wrote notes.",
+				PredictedEntities: []Entity{{Text: "Ada", Label: "PERSON", EndChar: 3}},
+			},
+		},
+		{
+			name:        "missing predicted entities",
+			input:       &NERInput{InputText: "Ada wrote notes."},
+			expectError: true,
+		},
+		{
+			name:        "missing input text",
+			input:       &NERInput{PredictedEntities: []Entity{{Text: "Ada"}}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &NERTraceContext{input: tt.input, output: &NEROutput{}}
+			err := ctx.ValidateForEvaluation()
+			if tt.expectError && err == nil {
+				t.Error("expected error, got nil")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestNERTraceContext_ComputeF1(t *testing.T) {
+	ada := Entity{Text: "Ada", Label: "PERSON", StartChar: 0, EndChar: 3}
+	london := Entity{Text: "London", Label: "LOC", StartChar: 13, EndChar: 19}
+	wrongLabel := Entity{Text: "London", Label: "ORG", StartChar: 13, EndChar: 19}
+
+	tests := []struct {
+		name      string
+		predicted []Entity
+		truth     []Entity
+		want      float64
+	}{
+		{"perfect match", []Entity{ada, london}, []Entity{ada, london}, 1},
+		{"label mismatch", []Entity{ada, wrongLabel}, []Entity{ada, london}, 0.5},
+		{"partial recall", []Entity{ada}, []Entity{ada, london}, 2.0 / 3.0},
+		{"no predictions", nil, []Entity{ada}, 0},
+		{"both empty", nil, nil, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &NERTraceContext{
+				input:  &NERInput{PredictedEntities: tt.predicted},
+				output: &NEROutput{GroundTruthEntities: tt.truth},
+			}
+			if got := ctx.ComputeF1(); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("ComputeF1() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNERTraceContext_UpdateWithF1RequiresGroundTruth(t *testing.T) {
+	ctx := &NERTraceContext{
+		input:  &NERInput{InputText: "x", PredictedEntities: []Entity{{Text: "x"}}},
+		output: &NEROutput{},
+	}
+	if err := ctx.UpdateWithF1(context.Background()); err == nil {
+		t.Error("expected error without ground truth entities")
+	}
+}
@@ -10,7 +10,9 @@ import (
 // QATraceBuilder provides a fluent interface for creating Q&A-ready traces.
 type QATraceBuilder struct {
 	*langfuse.TraceBuilder
-	qaInput *QAInput
+	qaInput         *QAInput
+	metadata        map[string]any
+	evaluatorConfig *EvaluatorConfig
 }
 
 // NewQATrace creates a new Q&A trace builder.
@@ -65,10 +67,18 @@ func (b *QATraceBuilder) Tags(tags []string) *QATraceBuilder {
 
 // Metadata sets the trace metadata.
 func (b *QATraceBuilder) Metadata(metadata map[string]any) *QATraceBuilder {
+	b.metadata = metadata
 	b.TraceBuilder.Metadata(metadata)
 	return b
 }
 
+// WithEvaluatorConfig records the judge configuration under
+// EvaluatorConfigMetadataKey in the trace metadata.
+func (b *QATraceBuilder) WithEvaluatorConfig(cfg EvaluatorConfig) *QATraceBuilder {
+	b.evaluatorConfig = &cfg
+	return b
+}
+
 // Release sets the release version.
 func (b *QATraceBuilder) Release(release string) *QATraceBuilder {
 	b.TraceBuilder.Release(release)
@@ -109,6 +119,10 @@ func (b *QATraceBuilder) Create(ctx context.Context) (*QATraceContext, error) {
 
 	b.TraceBuilder.Input(b.qaInput)
 
+	if b.evaluatorConfig != nil {
+		b.TraceBuilder.Metadata(withEvaluatorConfig(b.metadata, b.evaluatorConfig))
+	}
+
 	traceCtx, err := b.TraceBuilder.Create(ctx)
 	if err != nil {
 		return nil, err
@@ -120,6 +134,12 @@ func (b *QATraceBuilder) Create(ctx context.Context) (*QATraceContext, error) {
 	}, nil
 }
 
+// TryCreate is like Create but returns the trace and error as a single
+// BuildResult.
+func (b *QATraceBuilder) TryCreate(ctx context.Context) langfuse.BuildResult[*QATraceContext] {
+	return langfuse.NewBuildResult(b.Create(ctx))
+}
+
 // QATraceContext provides context for a Q&A trace with typed methods.
 type QATraceContext struct {
 	*langfuse.TraceContext
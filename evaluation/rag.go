@@ -10,7 +10,9 @@ import (
 // RAGTraceBuilder provides a fluent interface for creating RAG-ready traces.
 type RAGTraceBuilder struct {
 	*langfuse.TraceBuilder
-	ragInput *RAGInput
+	ragInput        *RAGInput
+	metadata        map[string]any
+	evaluatorConfig *EvaluatorConfig
 }
 
 // NewRAGTrace creates a new RAG trace builder.
@@ -71,10 +73,18 @@ func (b *RAGTraceBuilder) Tags(tags []string) *RAGTraceBuilder {
 
 // Metadata sets the trace metadata.
 func (b *RAGTraceBuilder) Metadata(metadata map[string]any) *RAGTraceBuilder {
+	b.metadata = metadata
 	b.TraceBuilder.Metadata(metadata)
 	return b
 }
 
+// WithEvaluatorConfig records the judge configuration under
+// EvaluatorConfigMetadataKey in the trace metadata.
+func (b *RAGTraceBuilder) WithEvaluatorConfig(cfg EvaluatorConfig) *RAGTraceBuilder {
+	b.evaluatorConfig = &cfg
+	return b
+}
+
 // Release sets the release version.
 func (b *RAGTraceBuilder) Release(release string) *RAGTraceBuilder {
 	b.TraceBuilder.Release(release)
@@ -119,6 +129,10 @@ func (b *RAGTraceBuilder) Create(ctx context.Context) (*RAGTraceContext, error)
 	// Set the structured input
 	b.TraceBuilder.Input(b.ragInput)
 
+	if b.evaluatorConfig != nil {
+		b.TraceBuilder.Metadata(withEvaluatorConfig(b.metadata, b.evaluatorConfig))
+	}
+
 	traceCtx, err := b.TraceBuilder.Create(ctx)
 	if err != nil {
 		return nil, err
@@ -130,6 +144,12 @@ func (b *RAGTraceBuilder) Create(ctx context.Context) (*RAGTraceContext, error)
 	}, nil
 }
 
+// TryCreate is like Create but returns the trace and error as a single
+// BuildResult.
+func (b *RAGTraceBuilder) TryCreate(ctx context.Context) langfuse.BuildResult[*RAGTraceContext] {
+	return langfuse.NewBuildResult(b.Create(ctx))
+}
+
 // RAGTraceContext provides context for a RAG trace with typed methods.
 type RAGTraceContext struct {
 	*langfuse.TraceContext
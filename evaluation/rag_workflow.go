@@ -23,7 +23,7 @@ import (
 //	})
 //
 //	// Record generation
-//	response, err := rag.Generate(ctx, "gpt-4", func(prompt string) (string, int, int, error) {
+//	response, err := rag.Generate(ctx, "gpt-4", langfuse.ProviderOpenAI, func(prompt string) (string, int, int, error) {
 //	    resp := openai.Complete(prompt)
 //	    return resp.Content, resp.InputTokens, resp.OutputTokens, nil
 //	})
@@ -34,11 +34,21 @@ type RAGWorkflow struct {
 	*WorkflowBuilder
 
 	// RAG-specific state
-	retrievedDocs   []string
-	retrievalScores []float64
-	citations       []string
-	generatedAnswer string
-	confidence      float64
+	retrievedDocs     []string
+	retrievalScores   []float64
+	citations         []string
+	generatedAnswer   string
+	confidence        float64
+	reranker          RerankFunc
+	retrievedDocCount int
+	rerankedDocCount  int
+
+	// Retrieval step metadata recorded on the retrieval span
+	retrievalLatencyMs int
+	topK               int
+	vectorDBName       string
+	embeddingModel     string
+	embeddingLatencyMs int
 }
 
 // NewRAGWorkflow creates a new RAG workflow builder.
@@ -90,6 +100,88 @@ func (r *RAGWorkflow) GroundTruth(groundTruth string) *RAGWorkflow {
 	return r
 }
 
+// WithRetrievalMetadata sets details of the retrieval step that are recorded
+// in the retrieval span's metadata as "retrieval_latency_ms", "top_k" and
+// "vector_db". If latencyMs is 0, Retrieve records the measured duration of
+// the retrieval function instead.
+//
+// Example:
+//
+//	rag := evaluation.NewRAGWorkflow(client, "document-qa").
+//	    Query(query).
+//	    WithRetrievalMetadata(0, 5, "pgvector").
+//	    WithEmbeddingModel("text-embedding-3-small", 42)
+func (r *RAGWorkflow) WithRetrievalMetadata(latencyMs int, topK int, vectorDBName string) *RAGWorkflow {
+	r.retrievalLatencyMs = latencyMs
+	r.topK = topK
+	r.vectorDBName = vectorDBName
+	return r
+}
+
+// WithEmbeddingModel sets the model used to embed the query and how long
+// embedding took. They are recorded in the retrieval span's metadata as
+// "embedding_model" and "embedding_latency_ms".
+func (r *RAGWorkflow) WithEmbeddingModel(model string, embeddingLatencyMs int) *RAGWorkflow {
+	r.embeddingModel = model
+	r.embeddingLatencyMs = embeddingLatencyMs
+	return r
+}
+
+// RetrievalLatencyMs returns the retrieval latency set by
+// WithRetrievalMetadata, or the measured latency of the last retrieval if
+// none was set.
+func (r *RAGWorkflow) RetrievalLatencyMs() int {
+	return r.retrievalLatencyMs
+}
+
+// EmbeddingModel returns the embedding model set by WithEmbeddingModel.
+func (r *RAGWorkflow) EmbeddingModel() string {
+	return r.embeddingModel
+}
+
+// retrievalMetadata returns the retrieval span metadata for a retrieval that
+// took elapsed.
+func (r *RAGWorkflow) retrievalMetadata(elapsed time.Duration) langfuse.Metadata {
+	if r.retrievalLatencyMs == 0 {
+		r.retrievalLatencyMs = int(elapsed.Milliseconds())
+	}
+
+	metadata := langfuse.Metadata{
+		"retrieval_latency_ms": r.retrievalLatencyMs,
+	}
+	if r.topK > 0 {
+		metadata["top_k"] = r.topK
+	}
+	if r.vectorDBName != "" {
+		metadata["vector_db"] = r.vectorDBName
+	}
+	if r.embeddingModel != "" {
+		metadata["embedding_model"] = r.embeddingModel
+		metadata["embedding_latency_ms"] = r.embeddingLatencyMs
+	}
+	return metadata
+}
+
+// RerankFunc reorders or filters retrieved documents.
+type RerankFunc func(docs []string) []string
+
+// WithReranking sets a reranker that Retrieve applies to the retrieved
+// documents before generation. Each call is recorded as a "reranking" span
+// whose input is the retrieved documents and whose output is the reranked
+// documents. RetrieveWithScores does not rerank, since reordering would
+// separate documents from their scores.
+//
+// Example:
+//
+//	rag := evaluation.NewRAGWorkflow(client, "document-qa").
+//	    WithReranking(func(docs []string) []string {
+//	        return crossEncoder.Rerank(query, docs)[:3]
+//	    })
+func (r *RAGWorkflow) WithReranking(fn RerankFunc) *RAGWorkflow {
+	r.reranker = fn
+	return r
+}
+
 // RetrieveFunc is a function that performs document retrieval.
 type RetrieveFunc func() ([]string, error)
 
@@ -115,9 +207,11 @@ func (r *RAGWorkflow) Retrieve(ctx context.Context, retrieveFunc RetrieveFunc) (
 
 	// Execute retrieval
 	docs, err := retrieveFunc()
+	metadata := r.retrievalMetadata(time.Since(startTime))
 	if err != nil {
 		// End span with error
 		_ = span.Update().
+			Metadata(metadata).
 			Level(langfuse.ObservationLevelError).
 			StatusMessage(err.Error()).
 			EndTime(time.Now()).
@@ -125,10 +219,6 @@ func (r *RAGWorkflow) Retrieve(ctx context.Context, retrieveFunc RetrieveFunc) (
 		return nil, fmt.Errorf("retrieval failed: %w", err)
 	}
 
-	// Store retrieved docs
-	r.retrievedDocs = docs
-	r.WithContext(docs...)
-
 	// End span with context
 	output := &langfuse.RetrievalOutput{
 		Documents:    docs,
@@ -138,11 +228,47 @@ func (r *RAGWorkflow) Retrieve(ctx context.Context, retrieveFunc RetrieveFunc) (
 		},
 	}
 
-	_ = span.Update().Output(output).EndTime(time.Now()).Apply(ctx)
+	_ = span.Update().Output(output).Metadata(metadata).EndTime(time.Now()).Apply(ctx)
+
+	r.retrievedDocCount = len(docs)
+	if r.reranker != nil {
+		if docs, err = r.rerank(ctx, docs); err != nil {
+			return nil, err
+		}
+	}
+
+	// Store retrieved docs
+	r.retrievedDocs = docs
+	r.rerankedDocCount = len(docs)
+	r.WithContext(docs...)
 
 	return docs, nil
 }
 
+// rerank applies the reranker to docs and records it as a span.
+func (r *RAGWorkflow) rerank(ctx context.Context, docs []string) ([]string, error) {
+	span, err := r.trace.NewSpan().
+		Name("reranking").
+		Input(docs).
+		Create(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reranked := r.reranker(docs)
+
+	_ = span.Update().
+		Output(reranked).
+		Metadata(langfuse.Metadata{
+			"retrieved_count": len(docs),
+			"reranked_count":  len(reranked),
+		}).
+		EndTime(time.Now()).
+		Apply(ctx)
+
+	return reranked, nil
+}
+
 // RetrieveWithScores executes a retrieval function that also returns relevance scores.
 func (r *RAGWorkflow) RetrieveWithScores(ctx context.Context, retrieveFunc RetrieveWithScoresFunc) ([]string, []float64, error) {
 	if err := r.Start(ctx); err != nil {
@@ -162,8 +288,10 @@ func (r *RAGWorkflow) RetrieveWithScores(ctx context.Context, retrieveFunc Retri
 
 	// Execute retrieval
 	docs, scores, err := retrieveFunc()
+	metadata := r.retrievalMetadata(time.Since(startTime))
 	if err != nil {
 		_ = span.Update().
+			Metadata(metadata).
 			Level(langfuse.ObservationLevelError).
 			StatusMessage(err.Error()).
 			EndTime(time.Now()).
@@ -174,6 +302,8 @@ func (r *RAGWorkflow) RetrieveWithScores(ctx context.Context, retrieveFunc Retri
 	// Store retrieved docs
 	r.retrievedDocs = docs
 	r.retrievalScores = scores
+	r.retrievedDocCount = len(docs)
+	r.rerankedDocCount = len(docs)
 	r.WithContext(docs...)
 
 	// End span with context and scores
@@ -186,7 +316,7 @@ func (r *RAGWorkflow) RetrieveWithScores(ctx context.Context, retrieveFunc Retri
 		},
 	}
 
-	_ = span.Update().Output(output).EndTime(time.Now()).Apply(ctx)
+	_ = span.Update().Output(output).Metadata(metadata).EndTime(time.Now()).Apply(ctx)
 
 	return docs, scores, nil
 }
@@ -198,8 +328,10 @@ type GenerateFunc func(prompt string) (string, int, int, error)
 // GenerateWithPromptFunc receives the full prompt including context.
 type GenerateWithPromptFunc func(query string, context []string) (string, int, int, error)
 
-// Generate executes a generation function and records it.
-func (r *RAGWorkflow) Generate(ctx context.Context, model string, generateFunc GenerateWithPromptFunc) (string, error) {
+// Generate executes a generation function and records it. If provider is
+// not empty, it is recorded as the generation's LLM provider, such as
+// langfuse.ProviderOpenAI.
+func (r *RAGWorkflow) Generate(ctx context.Context, model, provider string, generateFunc GenerateWithPromptFunc) (string, error) {
 	if err := r.Start(ctx); err != nil {
 		return "", err
 	}
@@ -208,6 +340,7 @@ func (r *RAGWorkflow) Generate(ctx context.Context, model string, generateFunc G
 	gen, err := r.trace.NewEvalGeneration().
 		Name("llm-response").
 		Model(model).
+		LLMProvider(provider).
 		WithQuery(r.query).
 		WithContext(r.retrievedDocs...).
 		Create(ctx)
@@ -323,13 +456,15 @@ func (r *RAGWorkflow) Complete(ctx context.Context) (*RAGWorkflowResult, error)
 	}
 
 	return &RAGWorkflowResult{
-		WorkflowResult:  baseResult,
-		Query:           r.query,
-		RetrievedDocs:   r.retrievedDocs,
-		RetrievalScores: r.retrievalScores,
-		GeneratedAnswer: r.generatedAnswer,
-		Citations:       r.citations,
-		Confidence:      r.confidence,
+		WorkflowResult:    baseResult,
+		Query:             r.query,
+		RetrievedDocs:     r.retrievedDocs,
+		RetrievalScores:   r.retrievalScores,
+		GeneratedAnswer:   r.generatedAnswer,
+		Citations:         r.citations,
+		Confidence:        r.confidence,
+		RetrievedDocCount: r.retrievedDocCount,
+		RerankedDocCount:  r.rerankedDocCount,
 	}, nil
 }
 
@@ -340,9 +475,17 @@ type RAGWorkflowResult struct {
 	// Query is the user's question.
 	Query string
 
-	// RetrievedDocs are the retrieved context documents.
+	// RetrievedDocs are the context documents passed to generation,
+	// after reranking if a reranker is set.
 	RetrievedDocs []string
 
+	// RetrievedDocCount is the number of documents retrieval returned.
+	RetrievedDocCount int
+
+	// RerankedDocCount is the number of documents after reranking. It equals
+	// RetrievedDocCount when no reranker is set.
+	RerankedDocCount int
+
 	// RetrievalScores are the relevance scores for retrieved docs.
 	RetrievalScores []float64
 
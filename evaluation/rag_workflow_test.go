@@ -0,0 +1,170 @@
+package evaluation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	langfuse "github.com/jdziat/langfuse-go"
+	"github.com/jdziat/langfuse-go/langfusetest"
+)
+
+func TestRAGWorkflow_WithReranking(t *testing.T) {
+	client, server := langfusetest.NewTestClient(t)
+	ctx := context.Background()
+
+	rag := NewRAGWorkflow(client, "rerank-qa").
+		Query("What is Go?").
+		WithReranking(func(docs []string) []string {
+			return []string{docs[2], docs[0]}
+		})
+
+	docs, err := rag.Retrieve(ctx, func() ([]string, error) {
+		return []string{"doc-a", "doc-b", "doc-c"}, nil
+	})
+	if err != nil {
+		t.Fatalf("Retrieve() error = %v", err)
+	}
+	if len(docs) != 2 || docs[0] != "doc-c" || docs[1] != "doc-a" {
+		t.Errorf("Retrieve() = %v, want reranked docs", docs)
+	}
+
+	var generationContext []string
+	if _, err := rag.Generate(ctx, "gpt-4", langfuse.ProviderOpenAI, func(query string, context []string) (string, int, int, error) {
+		generationContext = context
+		return "Go is a language", 10, 5, nil
+	}); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if len(generationContext) != 2 {
+		t.Errorf("generation context = %v, want reranked docs", generationContext)
+	}
+
+	result, err := rag.Complete(ctx)
+	if err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if result.RetrievedDocCount != 3 || result.RerankedDocCount != 2 {
+		t.Errorf("doc counts = %d/%d, want 3/2", result.RetrievedDocCount, result.RerankedDocCount)
+	}
+
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	var rerankID string
+	for _, span := range server.RequestsByType("span-create") {
+		if span.Body["name"] == "reranking" {
+			rerankID, _ = span.Body["id"].(string)
+			if input, _ := span.Body["input"].([]any); len(input) != 3 {
+				t.Errorf("reranking span input = %v, want retrieved docs", span.Body["input"])
+			}
+		}
+	}
+	if rerankID == "" {
+		t.Fatal("no reranking span recorded")
+	}
+	for _, gen := range server.RequestsByType("generation-create") {
+		metadata, _ := gen.Body["metadata"].(map[string]any)
+		if metadata[langfuse.LLMProviderMetadataKey] != langfuse.ProviderOpenAI {
+			t.Errorf("generation metadata = %v, want llm_provider openai", metadata)
+		}
+	}
+	found := false
+	for _, update := range server.RequestsByType("span-update") {
+		if update.Body["id"] == rerankID {
+			found = true
+			if output, _ := update.Body["output"].([]any); len(output) != 2 {
+				t.Errorf("reranking span output = %v, want reranked docs", update.Body["output"])
+			}
+		}
+	}
+	if !found {
+		t.Error("reranking span was not ended")
+	}
+}
+
+func TestRAGWorkflow_WithoutReranking(t *testing.T) {
+	client, _ := langfusetest.NewTestClient(t)
+	ctx := context.Background()
+
+	rag := NewRAGWorkflow(client, "plain-qa").Query("What is Go?")
+	if _, err := rag.Retrieve(ctx, func() ([]string, error) {
+		return []string{"doc-a", "doc-b"}, nil
+	}); err != nil {
+		t.Fatalf("Retrieve() error = %v", err)
+	}
+
+	result, err := rag.Complete(ctx)
+	if err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if result.RetrievedDocCount != 2 || result.RerankedDocCount != 2 {
+		t.Errorf("doc counts = %d/%d, want 2/2", result.RetrievedDocCount, result.RerankedDocCount)
+	}
+}
+
+func TestRAGWorkflow_RetrievalMetadata(t *testing.T) {
+	client, server := langfusetest.NewTestClient(t)
+	ctx := context.Background()
+
+	rag := NewRAGWorkflow(client, "metadata-qa").
+		Query("What is Go?").
+		WithRetrievalMetadata(120, 5, "pgvector").
+		WithEmbeddingModel("text-embedding-3-small", 42)
+
+	if _, err := rag.Retrieve(ctx, func() ([]string, error) {
+		return []string{"doc-a"}, nil
+	}); err != nil {
+		t.Fatalf("Retrieve() error = %v", err)
+	}
+	if rag.RetrievalLatencyMs() != 120 {
+		t.Errorf("RetrievalLatencyMs() = %d, want 120", rag.RetrievalLatencyMs())
+	}
+	if rag.EmbeddingModel() != "text-embedding-3-small" {
+		t.Errorf("EmbeddingModel() = %q, want text-embedding-3-small", rag.EmbeddingModel())
+	}
+
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	updates := server.RequestsByType("span-update")
+	if len(updates) != 1 {
+		t.Fatalf("got %d span updates, want 1", len(updates))
+	}
+	metadata, _ := updates[0].Body["metadata"].(map[string]any)
+	want := map[string]any{
+		"retrieval_latency_ms": float64(120),
+		"top_k":                float64(5),
+		"vector_db":            "pgvector",
+		"embedding_model":      "text-embedding-3-small",
+		"embedding_latency_ms": float64(42),
+	}
+	for key, value := range want {
+		if metadata[key] != value {
+			t.Errorf("retrieval span metadata[%q] = %v, want %v", key, metadata[key], value)
+		}
+	}
+	for _, trace := range server.RequestsByType("trace-create") {
+		if traceMetadata, _ := trace.Body["metadata"].(map[string]any); traceMetadata["vector_db"] != nil {
+			t.Error("retrieval metadata should not be recorded on the trace")
+		}
+	}
+}
+
+func TestRAGWorkflow_RetrievalLatencyDefaultsToMeasured(t *testing.T) {
+	client, _ := langfusetest.NewTestClient(t)
+	ctx := context.Background()
+
+	rag := NewRAGWorkflow(client, "latency-qa").Query("What is Go?")
+	if _, err := rag.Retrieve(ctx, func() ([]string, error) {
+		time.Sleep(5 * time.Millisecond)
+		return []string{"doc-a"}, nil
+	}); err != nil {
+		t.Fatalf("Retrieve() error = %v", err)
+	}
+	if rag.RetrievalLatencyMs() < 5 {
+		t.Errorf("RetrievalLatencyMs() = %d, want measured latency", rag.RetrievalLatencyMs())
+	}
+}
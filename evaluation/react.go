@@ -0,0 +1,290 @@
+package evaluation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	langfuse "github.com/jdziat/langfuse-go"
+)
+
+// ReActTraceBuilder provides a fluent interface for creating ReAct
+// (Reasoning+Acting) agent traces.
+type ReActTraceBuilder struct {
+	*langfuse.TraceBuilder
+	reactInput      *ReActInput
+	reactOutput     *ReActOutput
+	metadata        map[string]any
+	evaluatorConfig *EvaluatorConfig
+}
+
+// NewReActTrace creates a new ReAct agent trace builder. Each step is
+// recorded as a child span of the trace.
+//
+// Example:
+//
+//	trace, err := evaluation.NewReActTrace(client, "weather-agent").
+//	    Task("What should I wear in Paris today?").
+//	    Steps([]evaluation.ReActStep{{
+//	        Thought:     "I need the current weather in Paris.",
+//	        Action:      "get_weather",
+//	        ActionInput: `{"city":"Paris"}`,
+//	        Observation: "18°C, light rain",
+//	    }}).
+//	    FinalAnswer("A light jacket and an umbrella.").
+//	    Create(ctx)
+func NewReActTrace(client *langfuse.Client, name string) *ReActTraceBuilder {
+	return &ReActTraceBuilder{
+		TraceBuilder: client.NewTrace().Name(name),
+		reactInput:   &ReActInput{},
+		reactOutput:  &ReActOutput{},
+	}
+}
+
+// Task sets the task given to the agent.
+func (b *ReActTraceBuilder) Task(task string) *ReActTraceBuilder {
+	b.reactInput.Task = task
+	return b
+}
+
+// Steps sets the agent's reasoning and acting steps.
+func (b *ReActTraceBuilder) Steps(steps []ReActStep) *ReActTraceBuilder {
+	b.reactOutput.Steps = steps
+	return b
+}
+
+// FinalAnswer sets the agent's final answer.
+func (b *ReActTraceBuilder) FinalAnswer(answer string) *ReActTraceBuilder {
+	b.reactOutput.FinalAnswer = answer
+	return b
+}
+
+// GroundTruth sets the expected final answer.
+func (b *ReActTraceBuilder) GroundTruth(groundTruth string) *ReActTraceBuilder {
+	b.reactInput.GroundTruth = groundTruth
+	return b
+}
+
+// ID sets the trace ID.
+func (b *ReActTraceBuilder) ID(id string) *ReActTraceBuilder {
+	b.TraceBuilder.ID(id)
+	return b
+}
+
+// UserID sets the user ID.
+func (b *ReActTraceBuilder) UserID(userID string) *ReActTraceBuilder {
+	b.TraceBuilder.UserID(userID)
+	return b
+}
+
+// SessionID sets the session ID.
+func (b *ReActTraceBuilder) SessionID(sessionID string) *ReActTraceBuilder {
+	b.TraceBuilder.SessionID(sessionID)
+	return b
+}
+
+// Tags sets the trace tags.
+func (b *ReActTraceBuilder) Tags(tags []string) *ReActTraceBuilder {
+	b.TraceBuilder.Tags(tags)
+	return b
+}
+
+// Metadata sets the trace metadata.
+func (b *ReActTraceBuilder) Metadata(metadata map[string]any) *ReActTraceBuilder {
+	b.metadata = metadata
+	b.TraceBuilder.Metadata(metadata)
+	return b
+}
+
+// WithEvaluatorConfig records the judge configuration under
+// EvaluatorConfigMetadataKey in the trace metadata.
+func (b *ReActTraceBuilder) WithEvaluatorConfig(cfg EvaluatorConfig) *ReActTraceBuilder {
+	b.evaluatorConfig = &cfg
+	return b
+}
+
+// Release sets the release version.
+func (b *ReActTraceBuilder) Release(release string) *ReActTraceBuilder {
+	b.TraceBuilder.Release(release)
+	return b
+}
+
+// Version sets the version.
+func (b *ReActTraceBuilder) Version(version string) *ReActTraceBuilder {
+	b.TraceBuilder.Version(version)
+	return b
+}
+
+// Environment sets the environment.
+func (b *ReActTraceBuilder) Environment(env string) *ReActTraceBuilder {
+	b.TraceBuilder.Environment(env)
+	return b
+}
+
+// Public sets whether the trace is public.
+func (b *ReActTraceBuilder) Public(public bool) *ReActTraceBuilder {
+	b.TraceBuilder.Public(public)
+	return b
+}
+
+// Validate validates the ReAct trace configuration.
+func (b *ReActTraceBuilder) Validate() error {
+	if b.reactInput.Task == "" {
+		return fmt.Errorf("task is required for ReAct traces")
+	}
+	return b.TraceBuilder.Validate()
+}
+
+// Create creates the ReAct trace and records each step as a child span.
+// The task is recorded as the trace input; the steps and final answer, if
+// set, are recorded as the trace output.
+func (b *ReActTraceBuilder) Create(ctx context.Context) (*ReActTraceContext, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	b.TraceBuilder.Input(b.reactInput)
+	if len(b.reactOutput.Steps) > 0 || b.reactOutput.FinalAnswer != "" {
+		b.TraceBuilder.Output(b.reactOutput)
+	}
+
+	if b.evaluatorConfig != nil {
+		b.TraceBuilder.Metadata(withEvaluatorConfig(b.metadata, b.evaluatorConfig))
+	}
+
+	traceCtx, err := b.TraceBuilder.Create(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &ReActTraceContext{
+		TraceContext: traceCtx,
+		input:        b.reactInput,
+		output:       &ReActOutput{FinalAnswer: b.reactOutput.FinalAnswer},
+	}
+	if err := r.recordSteps(ctx, b.reactOutput.Steps); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// TryCreate is like Create but returns the trace and error as a single
+// BuildResult.
+func (b *ReActTraceBuilder) TryCreate(ctx context.Context) langfuse.BuildResult[*ReActTraceContext] {
+	return langfuse.NewBuildResult(b.Create(ctx))
+}
+
+// ReActTraceContext provides context for a ReAct agent trace with typed methods.
+// It is safe for concurrent use.
+type ReActTraceContext struct {
+	*langfuse.TraceContext
+	input *ReActInput
+
+	mu     sync.Mutex
+	output *ReActOutput
+}
+
+// GetInput returns the ReAct input.
+func (r *ReActTraceContext) GetInput() *ReActInput {
+	return r.input
+}
+
+// GetOutput returns a copy of the ReAct output.
+func (r *ReActTraceContext) GetOutput() *ReActOutput {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.snapshot()
+}
+
+// UpdateWithSteps appends steps to the trajectory, records each as a child
+// span, and updates the trace output.
+func (r *ReActTraceContext) UpdateWithSteps(ctx context.Context, steps []ReActStep) error {
+	if len(steps) == 0 {
+		return nil
+	}
+	if err := r.recordSteps(ctx, steps); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	output := r.snapshot()
+	r.mu.Unlock()
+	return r.Update().Output(output).Apply(ctx)
+}
+
+// UpdateFinalAnswer sets the agent's final answer and updates the trace output.
+func (r *ReActTraceContext) UpdateFinalAnswer(ctx context.Context, answer string) error {
+	r.mu.Lock()
+	r.output.FinalAnswer = answer
+	output := r.snapshot()
+	r.mu.Unlock()
+	return r.Update().Output(output).Apply(ctx)
+}
+
+// StepCount returns the number of steps in the trajectory.
+func (r *ReActTraceContext) StepCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.output.Steps)
+}
+
+// TotalThinkingLength returns the total length, in characters, of the
+// thoughts in the trajectory.
+func (r *ReActTraceContext) TotalThinkingLength() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	total := 0
+	for _, step := range r.output.Steps {
+		total += utf8.RuneCountInString(step.Thought)
+	}
+	return total
+}
+
+// ValidateForEvaluation checks if the trace has all required fields for evaluation.
+func (r *ReActTraceContext) ValidateForEvaluation() error {
+	r.mu.Lock()
+	output := r.snapshot()
+	r.mu.Unlock()
+	return ValidateFor(r.input, output, ReActEvaluator)
+}
+
+// recordSteps creates a child span for each step and appends it to the
+// trajectory. Steps are numbered from 1 across the whole trajectory.
+func (r *ReActTraceContext) recordSteps(ctx context.Context, steps []ReActStep) error {
+	for _, step := range steps {
+		r.mu.Lock()
+		index := len(r.output.Steps) + 1
+		r.output.Steps = append(r.output.Steps, step)
+		r.mu.Unlock()
+
+		now := time.Now()
+		_, err := r.NewSpan().
+			Name(fmt.Sprintf("step-%d", index)).
+			StartTime(now).
+			EndTime(now).
+			Input(map[string]any{
+				"thought":      step.Thought,
+				"action":       step.Action,
+				"action_input": step.ActionInput,
+			}).
+			Output(step.Observation).
+			Metadata(langfuse.Metadata{"step_index": index, "action": step.Action}).
+			Create(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to record step %d: %w", index, err)
+		}
+	}
+	return nil
+}
+
+// snapshot returns a copy of the output. The caller must hold r.mu.
+func (r *ReActTraceContext) snapshot() *ReActOutput {
+	var steps []ReActStep
+	if len(r.output.Steps) > 0 {
+		steps = make([]ReActStep, len(r.output.Steps))
+		copy(steps, r.output.Steps)
+	}
+	return &ReActOutput{Steps: steps, FinalAnswer: r.output.FinalAnswer}
+}
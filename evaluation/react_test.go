@@ -0,0 +1,154 @@
+package evaluation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	langfuse "github.com/jdziat/langfuse-go"
+	"github.com/jdziat/langfuse-go/langfusetest"
+)
+
+func TestReActTraceBuilder_FluentAPI(t *testing.T) {
+	builder := &ReActTraceBuilder{
+		reactInput:  &ReActInput{},
+		reactOutput: &ReActOutput{},
+	}
+
+	result := builder.
+		Task("find the capital").
+		Steps([]ReActStep{{Thought: "look it up", Action: "search"}}).
+		FinalAnswer("Paris").
+		GroundTruth("Paris")
+
+	if result != builder {
+		t.Error("fluent methods should return the same builder")
+	}
+	if builder.reactInput.Task != "find the capital" || builder.reactInput.GroundTruth != "Paris" {
+		t.Errorf("input not set correctly: %+v", builder.reactInput)
+	}
+	if len(builder.reactOutput.Steps) != 1 || builder.reactOutput.FinalAnswer != "Paris" {
+		t.Errorf("output not set correctly: %+v", builder.reactOutput)
+	}
+}
+
+func TestReActTraceBuilder_ValidateRequiresTask(t *testing.T) {
+	builder := &ReActTraceBuilder{reactInput: &ReActInput{}, reactOutput: &ReActOutput{}}
+	if err := builder.Validate(); err == nil {
+		t.Error("expected error without a task")
+	}
+}
+
+func TestReActTraceContext_ValidateForEvaluation(t *testing.T) {
+	step := ReActStep{Thought: "t", Action: "a"}
+
+	tests := []struct {
+		name        string
+		input       *ReActInput
+		output      *ReActOutput
+		expectError bool
+	}{
+		{
+			name:   "valid",
+			input:  &ReActInput{Task: "task"},
+			output: &ReActOutput{Steps: []ReActStep{step}, FinalAnswer: "answer"},
+		},
+		{
+			name:        "missing steps",
+			input:       &ReActInput{Task: "task"},
+			output:      &ReActOutput{FinalAnswer: "answer"},
+			expectError: true,
+		},
+		{
+			name:        "missing final answer",
+			input:       &ReActInput{Task: "task"},
+			output:      &ReActOutput{Steps: []ReActStep{step}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &ReActTraceContext{input: tt.input, output: tt.output}
+			err := ctx.ValidateForEvaluation()
+			if tt.expectError && err == nil {
+				t.Error("expected error, got nil")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestReActTraceContext_TrajectoryStats(t *testing.T) {
+	ctx := &ReActTraceContext{output: &ReActOutput{Steps: []ReActStep{
+		{Thought: "abc"},
+		{Thought: "héllo"},
+		{},
+	}}}
+
+	if got := ctx.StepCount(); got != 3 {
+		t.Errorf("StepCount() = %d, want 3", got)
+	}
+	if got := ctx.TotalThinkingLength(); got != 8 {
+		t.Errorf("TotalThinkingLength() = %d, want 8", got)
+	}
+}
+
+func TestReActTrace_RecordsStepsAsSpans(t *testing.T) {
+	server := langfusetest.NewMockServer()
+	defer server.Close()
+
+	client, err := langfuse.New("pk-lf-test-key", "sk-lf-test-key",
+		langfuse.WithBaseURL(server.URL),
+		langfuse.WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	trace, err := NewReActTrace(client, "agent").
+		Task("weather in Paris").
+		Steps([]ReActStep{{Thought: "need weather", Action: "get_weather", ActionInput: `{"city":"Paris"}`, Observation: "18C"}}).
+		Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if err := trace.UpdateWithSteps(ctx, []ReActStep{{Thought: "done", Action: "finish"}}); err != nil {
+		t.Fatalf("UpdateWithSteps failed: %v", err)
+	}
+	if err := trace.UpdateFinalAnswer(ctx, "18C"); err != nil {
+		t.Fatalf("UpdateFinalAnswer failed: %v", err)
+	}
+	if trace.StepCount() != 2 {
+		t.Errorf("StepCount() = %d, want 2", trace.StepCount())
+	}
+	if err := trace.ValidateForEvaluation(); err != nil {
+		t.Errorf("ValidateForEvaluation failed: %v", err)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	var spanNames []string
+	for _, e := range server.RequestsByType("span-create") {
+		if e.Body["traceId"] != trace.ID() {
+			t.Errorf("span traceId = %v, want %s", e.Body["traceId"], trace.ID())
+		}
+		spanNames = append(spanNames, e.Body["name"].(string))
+	}
+	if len(spanNames) != 2 || spanNames[0] != "step-1" || spanNames[1] != "step-2" {
+		t.Errorf("span names = %v, want [step-1 step-2]", spanNames)
+	}
+
+	traces := server.TracesCreated()
+	last := traces[len(traces)-1]
+	output, _ := last["output"].(map[string]any)
+	if steps, _ := output["steps"].([]any); len(steps) != 2 || output["final_answer"] != "18C" {
+		t.Errorf("final trace output = %v", output)
+	}
+}
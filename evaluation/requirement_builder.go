@@ -0,0 +1,99 @@
+package evaluation
+
+// EvaluatorRequirementBuilder builds EvaluatorRequirements for custom,
+// project-specific evaluators.
+//
+// Example:
+//
+//	reqs := evaluation.NewEvaluatorRequirement("Brand Voice").
+//	    RequireInputField("prompt").
+//	    RequireOutputField("output").
+//	    OptionalInputField("style_guide").
+//	    WarnIfMissing("style_guide", "no style guide - tone scoring is disabled").
+//	    Build()
+//
+//	err := evaluation.ValidateFor(input, output, reqs)
+type EvaluatorRequirementBuilder struct {
+	reqs EvaluatorRequirements
+}
+
+// NewEvaluatorRequirement creates a builder for a custom evaluator requirement.
+func NewEvaluatorRequirement(name string) *EvaluatorRequirementBuilder {
+	return &EvaluatorRequirementBuilder{
+		reqs: EvaluatorRequirements{Name: name},
+	}
+}
+
+// Description sets the evaluator description.
+func (b *EvaluatorRequirementBuilder) Description(description string) *EvaluatorRequirementBuilder {
+	b.reqs.Description = description
+	return b
+}
+
+// RequireInputField adds a required field expected on the trace input.
+func (b *EvaluatorRequirementBuilder) RequireInputField(name string) *EvaluatorRequirementBuilder {
+	b.reqs.RequiredFields = appendUnique(b.reqs.RequiredFields, name)
+	b.reqs.InputFields = appendUnique(b.reqs.InputFields, name)
+	return b
+}
+
+// RequireOutputField adds a required field expected on the trace output.
+func (b *EvaluatorRequirementBuilder) RequireOutputField(name string) *EvaluatorRequirementBuilder {
+	b.reqs.RequiredFields = appendUnique(b.reqs.RequiredFields, name)
+	b.reqs.OutputFields = appendUnique(b.reqs.OutputFields, name)
+	return b
+}
+
+// OptionalInputField adds an optional field expected on the trace input.
+func (b *EvaluatorRequirementBuilder) OptionalInputField(name string) *EvaluatorRequirementBuilder {
+	b.reqs.OptionalFields = appendUnique(b.reqs.OptionalFields, name)
+	b.reqs.InputFields = appendUnique(b.reqs.InputFields, name)
+	return b
+}
+
+// OptionalOutputField adds an optional field expected on the trace output.
+func (b *EvaluatorRequirementBuilder) OptionalOutputField(name string) *EvaluatorRequirementBuilder {
+	b.reqs.OptionalFields = appendUnique(b.reqs.OptionalFields, name)
+	b.reqs.OutputFields = appendUnique(b.reqs.OutputFields, name)
+	return b
+}
+
+// WarnIfMissing sets the warning ValidateDetailed reports when field is absent.
+func (b *EvaluatorRequirementBuilder) WarnIfMissing(field, message string) *EvaluatorRequirementBuilder {
+	if b.reqs.MissingWarnings == nil {
+		b.reqs.MissingWarnings = make(map[string]string)
+	}
+	b.reqs.MissingWarnings[field] = message
+	return b
+}
+
+// WithEvaluatorConfig sets the judge configuration reported by ValidateDetailed.
+func (b *EvaluatorRequirementBuilder) WithEvaluatorConfig(cfg EvaluatorConfig) *EvaluatorRequirementBuilder {
+	b.reqs.EvaluatorConfig = &cfg
+	return b
+}
+
+// Build returns the configured requirements.
+// The result is independent of the builder and safe to reuse.
+func (b *EvaluatorRequirementBuilder) Build() EvaluatorRequirements {
+	reqs := b.reqs
+	reqs.RequiredFields = append([]string(nil), b.reqs.RequiredFields...)
+	reqs.OptionalFields = append([]string(nil), b.reqs.OptionalFields...)
+	reqs.InputFields = append([]string(nil), b.reqs.InputFields...)
+	reqs.OutputFields = append([]string(nil), b.reqs.OutputFields...)
+	if b.reqs.MissingWarnings != nil {
+		reqs.MissingWarnings = make(map[string]string, len(b.reqs.MissingWarnings))
+		for k, v := range b.reqs.MissingWarnings {
+			reqs.MissingWarnings[k] = v
+		}
+	}
+	return reqs
+}
+
+// appendUnique appends s to slice if it is not already present.
+func appendUnique(slice []string, s string) []string {
+	if containsField(slice, s) {
+		return slice
+	}
+	return append(slice, s)
+}
@@ -0,0 +1,109 @@
+package evaluation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEvaluatorRequirementBuilder_Build(t *testing.T) {
+	reqs := NewEvaluatorRequirement("Brand Voice").
+		Description("Checks tone").
+		RequireInputField("prompt").
+		RequireOutputField("output").
+		OptionalInputField("style_guide").
+		OptionalOutputField("tone").
+		RequireInputField("prompt").
+		Build()
+
+	if reqs.Name != "Brand Voice" {
+		t.Errorf("Name = %q", reqs.Name)
+	}
+	if len(reqs.RequiredFields) != 2 || reqs.RequiredFields[0] != "prompt" || reqs.RequiredFields[1] != "output" {
+		t.Errorf("RequiredFields = %v", reqs.RequiredFields)
+	}
+	if len(reqs.OptionalFields) != 2 {
+		t.Errorf("OptionalFields = %v", reqs.OptionalFields)
+	}
+	if !containsField(reqs.OutputFields, "output") || !containsField(reqs.OutputFields, "tone") {
+		t.Errorf("OutputFields = %v", reqs.OutputFields)
+	}
+}
+
+func TestEvaluatorRequirementBuilder_BuildIsIndependent(t *testing.T) {
+	b := NewEvaluatorRequirement("custom").RequireInputField("a").WarnIfMissing("b", "no b")
+	reqs := b.Build()
+	b.RequireInputField("c").WarnIfMissing("d", "no d")
+
+	if len(reqs.RequiredFields) != 1 {
+		t.Errorf("RequiredFields = %v, want [a]", reqs.RequiredFields)
+	}
+	if len(reqs.MissingWarnings) != 1 {
+		t.Errorf("MissingWarnings = %v, want 1 entry", reqs.MissingWarnings)
+	}
+}
+
+func TestEvaluatorRequirementBuilder_ValidateFor(t *testing.T) {
+	reqs := NewEvaluatorRequirement("custom").
+		RequireInputField("prompt").
+		RequireOutputField("verdict").
+		Build()
+
+	err := ValidateFor(map[string]any{"prompt": "hi"}, map[string]any{"verdict": "ok"}, reqs)
+	if err != nil {
+		t.Errorf("expected valid, got %v", err)
+	}
+
+	err = ValidateFor(map[string]any{"prompt": "hi"}, map[string]any{}, reqs)
+	if err == nil || !strings.Contains(err.Error(), "verdict") {
+		t.Errorf("expected error mentioning verdict, got %v", err)
+	}
+}
+
+func TestEvaluatorRequirementBuilder_ValidateInputOutput(t *testing.T) {
+	// "answer" is treated as an output field by default; explicit placement overrides it.
+	reqs := NewEvaluatorRequirement("custom").
+		RequireInputField("answer").
+		RequireOutputField("label").
+		Build()
+
+	if err := ValidateInput(map[string]any{}, reqs); err == nil || !strings.Contains(err.Error(), "answer") {
+		t.Errorf("expected input error mentioning answer, got %v", err)
+	}
+	if err := ValidateInput(map[string]any{"answer": "x"}, reqs); err != nil {
+		t.Errorf("expected valid input, got %v", err)
+	}
+	if err := ValidateOutput(map[string]any{}, reqs); err == nil || !strings.Contains(err.Error(), "label") {
+		t.Errorf("expected output error mentioning label, got %v", err)
+	}
+	if err := ValidateOutput(map[string]any{"label": "y"}, reqs); err != nil {
+		t.Errorf("expected valid output, got %v", err)
+	}
+}
+
+func TestEvaluatorRequirementBuilder_ValidateDetailedWarnings(t *testing.T) {
+	reqs := NewEvaluatorRequirement("custom").
+		RequireInputField("prompt").
+		OptionalInputField("style_guide").
+		OptionalOutputField("tone").
+		WarnIfMissing("style_guide", "no style guide provided").
+		WarnIfMissing("locale", "locale missing").
+		Build()
+
+	result := ValidateDetailed(map[string]any{"prompt": "hi"}, nil, reqs)
+	if !result.Valid {
+		t.Fatalf("expected valid, missing %v", result.MissingFields)
+	}
+	if result.EvaluatorName != "custom" {
+		t.Errorf("EvaluatorName = %q", result.EvaluatorName)
+	}
+
+	want := []string{"no style guide provided", "locale missing"}
+	for _, w := range want {
+		if !containsField(result.Warnings, w) {
+			t.Errorf("warnings %v missing %q", result.Warnings, w)
+		}
+	}
+	if len(result.Warnings) != 3 {
+		t.Errorf("expected 3 warnings, got %v", result.Warnings)
+	}
+}
@@ -0,0 +1,238 @@
+package evaluation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	langfuse "github.com/jdziat/langfuse-go"
+)
+
+const (
+	// DefaultRunnerConcurrency is the default number of dataset items an
+	// EvaluationRunner processes at once.
+	DefaultRunnerConcurrency = 4
+
+	// runnerPageSize is the number of dataset items fetched per page.
+	runnerPageSize = 50
+)
+
+// RunItemFunc is called by EvaluationRunner for each dataset item. It should
+// run the system under test, trace it, and return the ID of the trace.
+type RunItemFunc func(ctx context.Context, item *langfuse.DatasetItem) (*RunItemResult, error)
+
+// RunItemResult is the outcome of running a single dataset item.
+type RunItemResult struct {
+	// TraceID is the trace produced for the item (required)
+	TraceID string
+
+	// Output is the output produced for the item
+	Output any
+
+	// Score is an optional score for the item, aggregated in the run summary
+	Score *float64
+}
+
+// EvaluationRunResult pairs a dataset item with its successful result.
+type EvaluationRunResult struct {
+	ItemID string
+	Result *RunItemResult
+}
+
+// EvaluationRunFailure records a dataset item that could not be processed.
+type EvaluationRunFailure struct {
+	ItemID string
+	Err    error
+}
+
+// EvaluationRunSummary aggregates the results of an evaluation run.
+type EvaluationRunSummary struct {
+	DatasetName string
+	RunName     string
+
+	// TotalItems is the number of dataset items processed
+	TotalItems int
+
+	// Succeeded is the number of items whose run item was recorded
+	Succeeded int
+
+	// Results holds the successful items in completion order
+	Results []EvaluationRunResult
+
+	// Failures holds the items that failed, in completion order
+	Failures []EvaluationRunFailure
+
+	// Scores aggregates the scores returned in RunItemResult.Score
+	Scores *ScoreAggregator
+
+	// Duration is the wall-clock time of the run
+	Duration time.Duration
+}
+
+// EvaluationRunner runs a function over every item of a dataset and records
+// each result as a dataset run item.
+type EvaluationRunner struct {
+	client      *langfuse.Client
+	datasetName string
+	runName     string
+	description string
+	concurrency int
+}
+
+// NewEvaluationRunner creates a runner for the named dataset and run.
+//
+// Example:
+//
+//	summary, err := evaluation.NewEvaluationRunner(client, "qa-golden", "gpt-4o-2024-08").
+//	    WithConcurrency(8).
+//	    Run(ctx, func(ctx context.Context, item *langfuse.DatasetItem) (*evaluation.RunItemResult, error) {
+//	        trace, _ := client.NewTrace().Name("qa").Input(item.Input).Create(ctx)
+//	        answer, err := answer(ctx, item.Input)
+//	        if err != nil {
+//	            return nil, err
+//	        }
+//	        trace.Update().Output(answer).Apply(ctx)
+//	        return &evaluation.RunItemResult{TraceID: trace.ID(), Output: answer}, nil
+//	    })
+func NewEvaluationRunner(client *langfuse.Client, datasetName, runName string) *EvaluationRunner {
+	return &EvaluationRunner{
+		client:      client,
+		datasetName: datasetName,
+		runName:     runName,
+		concurrency: DefaultRunnerConcurrency,
+	}
+}
+
+// WithConcurrency sets the maximum number of items processed at once.
+// Values below 1 are treated as 1.
+func (r *EvaluationRunner) WithConcurrency(n int) *EvaluationRunner {
+	if n < 1 {
+		n = 1
+	}
+	r.concurrency = n
+	return r
+}
+
+// WithRunDescription sets the description recorded on the dataset run.
+func (r *EvaluationRunner) WithRunDescription(description string) *EvaluationRunner {
+	r.description = description
+	return r
+}
+
+// Run pages through all items of the dataset and calls fn for each of them,
+// at most WithConcurrency items at a time. Every successful result is
+// recorded with DatasetsClient.CreateRunItem. Items whose fn or run item
+// creation fails are collected in the summary's Failures without aborting
+// the run.
+//
+// Run returns an error only if the dataset items cannot be listed or ctx is
+// cancelled; the summary covers the items processed up to that point.
+func (r *EvaluationRunner) Run(ctx context.Context, fn RunItemFunc) (*EvaluationRunSummary, error) {
+	if fn == nil {
+		return nil, fmt.Errorf("run function is required")
+	}
+	if r.datasetName == "" {
+		return nil, fmt.Errorf("dataset name is required")
+	}
+	if r.runName == "" {
+		return nil, fmt.Errorf("run name is required")
+	}
+
+	start := time.Now()
+	summary := &EvaluationRunSummary{
+		DatasetName: r.datasetName,
+		RunName:     r.runName,
+		Scores:      NewScoreAggregator(r.runName),
+	}
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, r.concurrency)
+	)
+
+	err := r.forEachItem(ctx, func(item langfuse.DatasetItem) error {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result, err := r.runItem(ctx, &item, fn)
+
+			mu.Lock()
+			defer mu.Unlock()
+			summary.TotalItems++
+			if err != nil {
+				summary.Failures = append(summary.Failures, EvaluationRunFailure{ItemID: item.ID, Err: err})
+				return
+			}
+			summary.Succeeded++
+			summary.Results = append(summary.Results, EvaluationRunResult{ItemID: item.ID, Result: result})
+			if result.Score != nil {
+				summary.Scores.Add(*result.Score)
+			}
+		}()
+		return nil
+	})
+
+	wg.Wait()
+	summary.Duration = time.Since(start)
+
+	return summary, err
+}
+
+// runItem calls fn for a single item and records the run item.
+func (r *EvaluationRunner) runItem(ctx context.Context, item *langfuse.DatasetItem, fn RunItemFunc) (*RunItemResult, error) {
+	result, err := fn(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil || result.TraceID == "" {
+		return nil, fmt.Errorf("run function must return a trace ID")
+	}
+
+	_, err = r.client.Datasets().CreateRunItem(ctx, &langfuse.CreateDatasetRunItemRequest{
+		DatasetItemID:  item.ID,
+		RunName:        r.runName,
+		RunDescription: r.description,
+		TraceID:        result.TraceID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create run item: %w", err)
+	}
+	return result, nil
+}
+
+// forEachItem calls visit for every item of the dataset, one page at a time.
+func (r *EvaluationRunner) forEachItem(ctx context.Context, visit func(item langfuse.DatasetItem) error) error {
+	for page := 1; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		resp, err := r.client.Datasets().ListItems(ctx, &langfuse.DatasetItemsListParams{
+			PaginationParams: langfuse.PaginationParams{Page: page, Limit: runnerPageSize},
+			DatasetName:      r.datasetName,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list dataset items: %w", err)
+		}
+
+		for _, item := range resp.Data {
+			if err := visit(item); err != nil {
+				return err
+			}
+		}
+
+		if len(resp.Data) == 0 || !resp.Meta.HasMore() {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package evaluation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	langfuse "github.com/jdziat/langfuse-go"
+)
+
+func newRunnerTestServer(t *testing.T, items []langfuse.DatasetItem, pageSize int) (*httptest.Server, *[]langfuse.CreateDatasetRunItemRequest, *sync.Mutex) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var runItems []langfuse.CreateDatasetRunItemRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/public/dataset-items":
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			start := (page - 1) * pageSize
+			end := min(start+pageSize, len(items))
+			totalPages := (len(items) + pageSize - 1) / pageSize
+			json.NewEncoder(w).Encode(langfuse.DatasetItemsListResponse{
+				Data: items[min(start, len(items)):end],
+				Meta: langfuse.MetaResponse{Page: page, Limit: pageSize, TotalItems: len(items), TotalPages: totalPages},
+			})
+		case "/api/public/dataset-run-items":
+			var req langfuse.CreateDatasetRunItemRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			mu.Lock()
+			runItems = append(runItems, req)
+			mu.Unlock()
+			json.NewEncoder(w).Encode(langfuse.DatasetRunItem{ID: "run-item-" + req.DatasetItemID, DatasetItemID: req.DatasetItemID})
+		default:
+			json.NewEncoder(w).Encode(langfuse.IngestionResult{})
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &runItems, &mu
+}
+
+func TestEvaluationRunner_Run(t *testing.T) {
+	items := make([]langfuse.DatasetItem, 5)
+	for i := range items {
+		items[i] = langfuse.DatasetItem{ID: "item-" + strconv.Itoa(i), Input: i}
+	}
+	server, runItems, mu := newRunnerTestServer(t, items, 2)
+
+	client, err := langfuse.New("pk-lf-test-key", "sk-lf-test-key",
+		langfuse.WithBaseURL(server.URL),
+		langfuse.WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	summary, err := NewEvaluationRunner(client, "golden", "run-1").
+		WithConcurrency(2).
+		Run(context.Background(), func(ctx context.Context, item *langfuse.DatasetItem) (*RunItemResult, error) {
+			if item.ID == "item-3" {
+				return nil, errors.New("model unavailable")
+			}
+			score := 1.0
+			if item.ID == "item-0" {
+				score = 0
+			}
+			return &RunItemResult{TraceID: "trace-" + item.ID, Output: "ok", Score: &score}, nil
+		})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if summary.TotalItems != 5 {
+		t.Errorf("TotalItems = %d, want 5", summary.TotalItems)
+	}
+	if summary.Succeeded != 4 {
+		t.Errorf("Succeeded = %d, want 4", summary.Succeeded)
+	}
+	if len(summary.Failures) != 1 || summary.Failures[0].ItemID != "item-3" {
+		t.Errorf("Failures = %+v, want item-3", summary.Failures)
+	}
+	if summary.Scores.Count() != 4 || summary.Scores.Mean() != 0.75 {
+		t.Errorf("Scores count=%d mean=%v, want 4 and 0.75", summary.Scores.Count(), summary.Scores.Mean())
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(*runItems) != 4 {
+		t.Fatalf("created %d run items, want 4", len(*runItems))
+	}
+	for _, req := range *runItems {
+		if req.RunName != "run-1" || req.TraceID != "trace-"+req.DatasetItemID {
+			t.Errorf("unexpected run item request: %+v", req)
+		}
+	}
+}
+
+func TestEvaluationRunner_MissingTraceID(t *testing.T) {
+	server, runItems, mu := newRunnerTestServer(t, []langfuse.DatasetItem{{ID: "item-0"}}, 10)
+
+	client, err := langfuse.New("pk-lf-test-key", "sk-lf-test-key",
+		langfuse.WithBaseURL(server.URL),
+		langfuse.WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	summary, err := NewEvaluationRunner(client, "golden", "run-1").
+		Run(context.Background(), func(ctx context.Context, item *langfuse.DatasetItem) (*RunItemResult, error) {
+			return &RunItemResult{}, nil
+		})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(summary.Failures) != 1 {
+		t.Errorf("Failures = %d, want 1", len(summary.Failures))
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(*runItems) != 0 {
+		t.Errorf("created %d run items, want 0", len(*runItems))
+	}
+}
+
+func TestEvaluationRunner_Validation(t *testing.T) {
+	noop := func(ctx context.Context, item *langfuse.DatasetItem) (*RunItemResult, error) { return nil, nil }
+
+	if _, err := NewEvaluationRunner(nil, "", "run").Run(context.Background(), noop); err == nil {
+		t.Error("expected error for empty dataset name")
+	}
+	if _, err := NewEvaluationRunner(nil, "dataset", "").Run(context.Background(), noop); err == nil {
+		t.Error("expected error for empty run name")
+	}
+	if _, err := NewEvaluationRunner(nil, "dataset", "run").Run(context.Background(), nil); err == nil {
+		t.Error("expected error for nil run function")
+	}
+}
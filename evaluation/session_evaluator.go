@@ -0,0 +1,242 @@
+package evaluation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	langfuse "github.com/jdziat/langfuse-go"
+)
+
+// Score names recorded by SessionEvaluator.Evaluate.
+const (
+	GoalCompletionScoreName = "goal_completion"
+	CoherenceScoreName      = "coherence_score"
+	TurnCountScoreName      = "turn_count"
+)
+
+// SessionEvaluationTraceName is the name of the trace created by
+// SessionEvaluator.Evaluate.
+const SessionEvaluationTraceName = "session-evaluation"
+
+// GoalAchievedThreshold is the goal completion score at or above which a
+// session's goal is considered achieved.
+const GoalAchievedThreshold = 0.5
+
+// SessionEvaluation is the result of SessionEvaluator.Evaluate.
+type SessionEvaluation struct {
+	// TraceID is the ID of the evaluation trace
+	TraceID string
+
+	// SessionTraceCount is the number of traces in the evaluated session
+	SessionTraceCount int
+
+	// GoalAchieved is true if the goal completion score is at least
+	// GoalAchievedThreshold
+	GoalAchieved bool
+
+	// GoalCompletion and Coherence are the judge's scores, from 0 to 1
+	GoalCompletion float64
+	Coherence      float64
+
+	// OverallScore is the mean of GoalCompletion and Coherence
+	OverallScore float64
+}
+
+// sessionTurn is a session trace as shown to the judge.
+type sessionTurn struct {
+	Name   string `json:"name,omitempty"`
+	Input  any    `json:"input,omitempty"`
+	Output any    `json:"output,omitempty"`
+}
+
+// SessionEvaluator evaluates a user's session, made of multiple traces, as a
+// whole for goal completion and coherence. The scores are given by an LLM
+// judge and recorded on a new evaluation trace that references every
+// session trace.
+type SessionEvaluator struct {
+	client     *langfuse.Client
+	sessionID  string
+	goal       string
+	criteria   []string
+	judgeModel string
+	judge      JudgeFunc
+
+	traces []langfuse.Trace
+}
+
+// NewSessionEvaluator creates an evaluator for the session. Load its traces
+// with FetchSession and set the judge with WithJudge before calling
+// Evaluate.
+//
+// Example:
+//
+//	evaluator := evaluation.NewSessionEvaluator(client, sessionID).
+//	    GoalDescription("Book a flight from NYC to London").
+//	    EvaluationCriteria([]string{"The booking was confirmed"}).
+//	    WithJudge("gpt-4o", callOpenAI)
+//	if err := evaluator.FetchSession(ctx); err != nil {
+//	    return err
+//	}
+//	result, err := evaluator.Evaluate(ctx)
+//	fmt.Println(result.GoalAchieved, result.OverallScore)
+func NewSessionEvaluator(client *langfuse.Client, sessionID string) *SessionEvaluator {
+	return &SessionEvaluator{
+		client:    client,
+		sessionID: sessionID,
+	}
+}
+
+// GoalDescription sets the goal the user was trying to achieve.
+func (e *SessionEvaluator) GoalDescription(goal string) *SessionEvaluator {
+	e.goal = goal
+	return e
+}
+
+// EvaluationCriteria sets additional requirements the judge considers when
+// scoring goal completion.
+func (e *SessionEvaluator) EvaluationCriteria(criteria []string) *SessionEvaluator {
+	e.criteria = criteria
+	return e
+}
+
+// WithJudge sets the judge model and the function that calls it.
+func (e *SessionEvaluator) WithJudge(model string, fn JudgeFunc) *SessionEvaluator {
+	e.judgeModel = model
+	e.judge = fn
+	return e
+}
+
+// Traces returns the session traces loaded by FetchSession, oldest first.
+func (e *SessionEvaluator) Traces() []langfuse.Trace {
+	return e.traces
+}
+
+// FetchSession loads all traces of the session, oldest first.
+func (e *SessionEvaluator) FetchSession(ctx context.Context) error {
+	if e.sessionID == "" {
+		return fmt.Errorf("session ID is required")
+	}
+
+	var traces []langfuse.Trace
+	for page := 1; ; page++ {
+		resp, err := e.client.Traces().List(ctx, &langfuse.TracesListParams{
+			PaginationParams: langfuse.PaginationParams{Page: page, Limit: runnerPageSize},
+			FilterParams:     langfuse.FilterParams{SessionID: e.sessionID},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list traces of session %s: %w", e.sessionID, err)
+		}
+		traces = append(traces, resp.Data...)
+		if len(resp.Data) == 0 || !resp.Meta.HasMore() {
+			break
+		}
+	}
+
+	sort.SliceStable(traces, func(i, j int) bool {
+		return traces[i].Timestamp.Before(traces[j].Timestamp.Time)
+	})
+	e.traces = traces
+	return nil
+}
+
+// Validate checks that the evaluator is ready to evaluate.
+func (e *SessionEvaluator) Validate() error {
+	if e.client == nil {
+		return fmt.Errorf("client is required for session evaluators")
+	}
+	if e.goal == "" {
+		return fmt.Errorf("goal description is required for session evaluators")
+	}
+	if e.judgeModel == "" || e.judge == nil {
+		return fmt.Errorf("judge model and function are required for session evaluators")
+	}
+	if len(e.traces) == 0 {
+		return fmt.Errorf("session %s has no traces; call FetchSession first", e.sessionID)
+	}
+	return nil
+}
+
+// Evaluate creates an evaluation trace referencing every session trace in
+// its metadata and asks the judge to score the session's goal completion
+// and coherence. Both are recorded as scores on the evaluation trace, along
+// with the number of turns, and as the trace output.
+func (e *SessionEvaluator) Evaluate(ctx context.Context) (*SessionEvaluation, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+
+	traceIDs := make([]string, len(e.traces))
+	turns := make([]sessionTurn, len(e.traces))
+	for i, t := range e.traces {
+		traceIDs[i] = t.ID
+		turns[i] = sessionTurn{Name: t.Name, Input: t.Input, Output: t.Output}
+	}
+
+	trace, err := e.client.NewTrace().
+		Name(SessionEvaluationTraceName).
+		Input(turns).
+		Metadata(langfuse.Metadata{
+			"session_id":        e.sessionID,
+			"session_trace_ids": traceIDs,
+			"goal":              e.goal,
+			"criteria":          e.criteria,
+		}).
+		Create(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session evaluation trace: %w", err)
+	}
+
+	judge := NewPromptEvaluator(e.client, e.judgeModel, e.judgeCriteria()).WithJudge(e.judge)
+	scores, err := judge.Evaluate(ctx, EvalTrace{
+		Trace:  trace,
+		Input:  turns,
+		Output: e.traces[len(e.traces)-1].Output,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SessionEvaluation{
+		TraceID:           trace.ID(),
+		SessionTraceCount: len(e.traces),
+	}
+	for _, s := range scores {
+		switch s.Name {
+		case GoalCompletionScoreName:
+			result.GoalCompletion = s.Value.(float64)
+		case CoherenceScoreName:
+			result.Coherence = s.Value.(float64)
+		}
+	}
+	result.GoalAchieved = result.GoalCompletion >= GoalAchievedThreshold
+	result.OverallScore = (result.GoalCompletion + result.Coherence) / 2
+
+	if err := trace.ScoreNumeric(ctx, TurnCountScoreName, float64(len(e.traces))); err != nil {
+		return result, err
+	}
+	err = trace.Update().Output(map[string]any{
+		GoalCompletionScoreName: result.GoalCompletion,
+		CoherenceScoreName:      result.Coherence,
+		TurnCountScoreName:      len(e.traces),
+	}).Apply(ctx)
+	return result, err
+}
+
+// judgeCriteria returns the criteria the judge scores the session on.
+func (e *SessionEvaluator) judgeCriteria() []JudgeCriteria {
+	goal := "Over the whole session, to what degree did the user achieve this goal: " + e.goal
+	if len(e.criteria) > 0 {
+		goal += ". The goal is only complete if: " + strings.Join(e.criteria, "; ")
+	}
+	return []JudgeCriteria{
+		{Name: GoalCompletionScoreName, Prompt: goal, ScaleMin: 0, ScaleMax: 1},
+		{
+			Name:     CoherenceScoreName,
+			Prompt:   "How coherent and consistent are the responses across the turns of the session?",
+			ScaleMin: 0,
+			ScaleMax: 1,
+		},
+	}
+}
@@ -0,0 +1,117 @@
+package evaluation
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	langfuse "github.com/jdziat/langfuse-go"
+	"github.com/jdziat/langfuse-go/langfusetest"
+)
+
+func TestSessionEvaluator_Evaluate(t *testing.T) {
+	server := langfusetest.NewMockLangfuseServer()
+	defer server.Close()
+
+	// Session traces, ingested out of order, plus a trace of another session.
+	batch := `{"batch":[
+		{"id":"e1","type":"trace-create","body":{"id":"t2","sessionId":"session-1","timestamp":"2026-01-01T12:01:00Z","input":"London, Friday","output":"Booked"}},
+		{"id":"e2","type":"trace-create","body":{"id":"t1","sessionId":"session-1","timestamp":"2026-01-01T12:00:00Z","input":"I need a flight","output":"Where to?"}},
+		{"id":"e3","type":"trace-create","body":{"id":"other","sessionId":"session-2","timestamp":"2026-01-01T11:00:00Z"}}
+	]}`
+	resp, err := http.Post(server.URL+"/api/public/ingestion", "application/json", strings.NewReader(batch))
+	if err != nil {
+		t.Fatalf("seeding traces failed: %v", err)
+	}
+	resp.Body.Close()
+
+	client, err := langfuse.New("pk-lf-test-key", "sk-lf-test-key",
+		langfuse.WithBaseURL(server.URL),
+		langfuse.WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	var prompt string
+	evaluator := NewSessionEvaluator(client, "session-1").
+		GoalDescription("Book a flight to London").
+		EvaluationCriteria([]string{"The booking was confirmed"}).
+		WithJudge("gpt-4o", func(ctx context.Context, model, p string) (string, error) {
+			prompt = p
+			return `{"goal_completion": {"score": 0.9}, "coherence_score": {"score": 0.7}}`, nil
+		})
+
+	ctx := context.Background()
+	if _, err := evaluator.Evaluate(ctx); err == nil {
+		t.Error("Evaluate should fail before FetchSession")
+	}
+	if err := evaluator.FetchSession(ctx); err != nil {
+		t.Fatalf("FetchSession failed: %v", err)
+	}
+	if got := evaluator.Traces(); len(got) != 2 || got[0].ID != "t1" {
+		t.Fatalf("Traces() = %+v, want t1 first", got)
+	}
+
+	result, err := evaluator.Evaluate(ctx)
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if result.SessionTraceCount != 2 || !result.GoalAchieved {
+		t.Errorf("result = %+v, want 2 traces and goal achieved", result)
+	}
+	if result.GoalCompletion != 0.9 || result.Coherence != 0.7 {
+		t.Errorf("scores = %v, %v, want 0.9, 0.7", result.GoalCompletion, result.Coherence)
+	}
+	if d := result.OverallScore - 0.8; d > 1e-9 || d < -1e-9 {
+		t.Errorf("OverallScore = %v, want 0.8", result.OverallScore)
+	}
+	if !strings.Contains(prompt, "Book a flight to London") || !strings.Contains(prompt, "The booking was confirmed") {
+		t.Errorf("judge prompt should include the goal and criteria:\n%s", prompt)
+	}
+
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	var found bool
+	for _, body := range server.TracesCreated() {
+		metadata, ok := body["metadata"].(map[string]any)
+		if body["id"] != result.TraceID || !ok {
+			continue
+		}
+		found = true
+		ids, _ := metadata["session_trace_ids"].([]any)
+		if len(ids) != 2 || ids[0] != "t1" || ids[1] != "t2" {
+			t.Errorf("session_trace_ids = %v, want [t1 t2]", metadata["session_trace_ids"])
+		}
+	}
+	if !found {
+		t.Errorf("no trace-create with metadata for result trace %s", result.TraceID)
+	}
+	scores := make(map[string]any)
+	for _, body := range server.ScoresCreated() {
+		scores[body["name"].(string)] = body["value"]
+	}
+	if scores[GoalCompletionScoreName] != 0.9 || scores[CoherenceScoreName] != 0.7 || scores[TurnCountScoreName] != 2.0 {
+		t.Errorf("recorded scores = %v", scores)
+	}
+}
+
+func TestSessionEvaluator_Validate(t *testing.T) {
+	judge := func(ctx context.Context, model, prompt string) (string, error) { return "", nil }
+	e := NewSessionEvaluator(&langfuse.Client{}, "session-1").WithJudge("gpt-4o", judge)
+	e.traces = []langfuse.Trace{{ID: "t1"}}
+	if err := e.Validate(); err == nil {
+		t.Error("Validate should require a goal description")
+	}
+	if err := e.GoalDescription("goal").Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if err := NewSessionEvaluator(nil, "").FetchSession(context.Background()); err == nil {
+		t.Error("FetchSession should require a session ID")
+	}
+}
@@ -10,7 +10,9 @@ import (
 // SummarizationTraceBuilder provides a fluent interface for creating summarization-ready traces.
 type SummarizationTraceBuilder struct {
 	*langfuse.TraceBuilder
-	sumInput *SummarizationInput
+	sumInput        *SummarizationInput
+	metadata        map[string]any
+	evaluatorConfig *EvaluatorConfig
 }
 
 // NewSummarizationTrace creates a new summarization trace builder.
@@ -71,10 +73,18 @@ func (b *SummarizationTraceBuilder) Tags(tags []string) *SummarizationTraceBuild
 
 // Metadata sets the trace metadata.
 func (b *SummarizationTraceBuilder) Metadata(metadata map[string]any) *SummarizationTraceBuilder {
+	b.metadata = metadata
 	b.TraceBuilder.Metadata(metadata)
 	return b
 }
 
+// WithEvaluatorConfig records the judge configuration under
+// EvaluatorConfigMetadataKey in the trace metadata.
+func (b *SummarizationTraceBuilder) WithEvaluatorConfig(cfg EvaluatorConfig) *SummarizationTraceBuilder {
+	b.evaluatorConfig = &cfg
+	return b
+}
+
 // Release sets the release version.
 func (b *SummarizationTraceBuilder) Release(release string) *SummarizationTraceBuilder {
 	b.TraceBuilder.Release(release)
@@ -115,6 +125,10 @@ func (b *SummarizationTraceBuilder) Create(ctx context.Context) (*SummarizationT
 
 	b.TraceBuilder.Input(b.sumInput)
 
+	if b.evaluatorConfig != nil {
+		b.TraceBuilder.Metadata(withEvaluatorConfig(b.metadata, b.evaluatorConfig))
+	}
+
 	traceCtx, err := b.TraceBuilder.Create(ctx)
 	if err != nil {
 		return nil, err
@@ -126,6 +140,12 @@ func (b *SummarizationTraceBuilder) Create(ctx context.Context) (*SummarizationT
 	}, nil
 }
 
+// TryCreate is like Create but returns the trace and error as a single
+// BuildResult.
+func (b *SummarizationTraceBuilder) TryCreate(ctx context.Context) langfuse.BuildResult[*SummarizationTraceContext] {
+	return langfuse.NewBuildResult(b.Create(ctx))
+}
+
 // SummarizationTraceContext provides context for a summarization trace with typed methods.
 type SummarizationTraceContext struct {
 	*langfuse.TraceContext
@@ -0,0 +1,301 @@
+package evaluation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	langfuse "github.com/jdziat/langfuse-go"
+)
+
+const (
+	// SummarizationSpanName is the name of the child span recording the
+	// summarization step of a summary Q&A trace.
+	SummarizationSpanName = "summarization"
+
+	// QASpanName is the name of the child span recording the Q&A step of a
+	// summary Q&A trace.
+	QASpanName = "qa"
+)
+
+// SummaryQATraceBuilder provides a fluent interface for creating traces of
+// pipelines that summarize a document and then answer a question about the
+// summary.
+type SummaryQATraceBuilder struct {
+	*langfuse.TraceBuilder
+	summaryQAInput  *SummaryQAInput
+	summaryQAOutput *SummaryQAOutput
+	metadata        map[string]any
+	evaluatorConfig *EvaluatorConfig
+}
+
+// NewSummaryQATrace creates a new summary Q&A trace builder. The
+// summarization and the Q&A are each recorded as a child span of the trace.
+//
+// Example:
+//
+//	trace, err := evaluation.NewSummaryQATrace(client, "report-qa").
+//	    SourceDocument(report).
+//	    Summary(summary).
+//	    Question("What was Q3 revenue?").
+//	    Answer("$4.2M").
+//	    GroundTruth("$4.2M").
+//	    Create(ctx)
+func NewSummaryQATrace(client *langfuse.Client, name string) *SummaryQATraceBuilder {
+	return &SummaryQATraceBuilder{
+		TraceBuilder:    client.NewTrace().Name(name),
+		summaryQAInput:  &SummaryQAInput{},
+		summaryQAOutput: &SummaryQAOutput{},
+	}
+}
+
+// SourceDocument sets the document that is summarized.
+func (b *SummaryQATraceBuilder) SourceDocument(doc string) *SummaryQATraceBuilder {
+	b.summaryQAInput.SourceDocument = doc
+	return b
+}
+
+// Summary sets the generated summary.
+func (b *SummaryQATraceBuilder) Summary(summary string) *SummaryQATraceBuilder {
+	b.summaryQAOutput.Summary = summary
+	return b
+}
+
+// Question sets the question asked about the summary.
+func (b *SummaryQATraceBuilder) Question(question string) *SummaryQATraceBuilder {
+	b.summaryQAInput.Question = question
+	return b
+}
+
+// Answer sets the answer to the question.
+func (b *SummaryQATraceBuilder) Answer(answer string) *SummaryQATraceBuilder {
+	b.summaryQAOutput.Answer = answer
+	return b
+}
+
+// GroundTruth sets the expected answer for evaluation.
+func (b *SummaryQATraceBuilder) GroundTruth(truth string) *SummaryQATraceBuilder {
+	b.summaryQAInput.GroundTruth = truth
+	return b
+}
+
+// ID sets the trace ID.
+func (b *SummaryQATraceBuilder) ID(id string) *SummaryQATraceBuilder {
+	b.TraceBuilder.ID(id)
+	return b
+}
+
+// UserID sets the user ID.
+func (b *SummaryQATraceBuilder) UserID(userID string) *SummaryQATraceBuilder {
+	b.TraceBuilder.UserID(userID)
+	return b
+}
+
+// SessionID sets the session ID.
+func (b *SummaryQATraceBuilder) SessionID(sessionID string) *SummaryQATraceBuilder {
+	b.TraceBuilder.SessionID(sessionID)
+	return b
+}
+
+// Tags sets the trace tags.
+func (b *SummaryQATraceBuilder) Tags(tags []string) *SummaryQATraceBuilder {
+	b.TraceBuilder.Tags(tags)
+	return b
+}
+
+// Metadata sets the trace metadata.
+func (b *SummaryQATraceBuilder) Metadata(metadata map[string]any) *SummaryQATraceBuilder {
+	b.metadata = metadata
+	b.TraceBuilder.Metadata(metadata)
+	return b
+}
+
+// WithEvaluatorConfig records the judge configuration under
+// EvaluatorConfigMetadataKey in the trace metadata.
+func (b *SummaryQATraceBuilder) WithEvaluatorConfig(cfg EvaluatorConfig) *SummaryQATraceBuilder {
+	b.evaluatorConfig = &cfg
+	return b
+}
+
+// Release sets the release version.
+func (b *SummaryQATraceBuilder) Release(release string) *SummaryQATraceBuilder {
+	b.TraceBuilder.Release(release)
+	return b
+}
+
+// Version sets the version.
+func (b *SummaryQATraceBuilder) Version(version string) *SummaryQATraceBuilder {
+	b.TraceBuilder.Version(version)
+	return b
+}
+
+// Environment sets the environment.
+func (b *SummaryQATraceBuilder) Environment(env string) *SummaryQATraceBuilder {
+	b.TraceBuilder.Environment(env)
+	return b
+}
+
+// Public sets whether the trace is public.
+func (b *SummaryQATraceBuilder) Public(public bool) *SummaryQATraceBuilder {
+	b.TraceBuilder.Public(public)
+	return b
+}
+
+// Validate validates the summary Q&A trace configuration.
+func (b *SummaryQATraceBuilder) Validate() error {
+	if b.summaryQAInput.SourceDocument == "" {
+		return fmt.Errorf("source document is required for summary Q&A traces")
+	}
+	if b.summaryQAInput.Question == "" {
+		return fmt.Errorf("question is required for summary Q&A traces")
+	}
+	return b.TraceBuilder.Validate()
+}
+
+// Create creates the summary Q&A trace and returns a context for updating
+// it. The source document and question are recorded as the trace input. If
+// set, the summary is recorded as a "summarization" child span and the
+// answer as a "qa" child span, and both in the trace output.
+func (b *SummaryQATraceBuilder) Create(ctx context.Context) (*SummaryQATraceContext, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	b.TraceBuilder.Input(b.summaryQAInput)
+	if b.summaryQAOutput.Summary != "" || b.summaryQAOutput.Answer != "" {
+		b.TraceBuilder.Output(b.summaryQAOutput)
+	}
+
+	if b.evaluatorConfig != nil {
+		b.TraceBuilder.Metadata(withEvaluatorConfig(b.metadata, b.evaluatorConfig))
+	}
+
+	traceCtx, err := b.TraceBuilder.Create(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &SummaryQATraceContext{
+		TraceContext: traceCtx,
+		input:        b.summaryQAInput,
+		output:       &SummaryQAOutput{},
+	}
+	if b.summaryQAOutput.Summary != "" {
+		if err := s.recordSummary(ctx, b.summaryQAOutput.Summary); err != nil {
+			return nil, err
+		}
+	}
+	if b.summaryQAOutput.Answer != "" {
+		if err := s.recordAnswer(ctx, b.summaryQAOutput.Answer); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// TryCreate is like Create but returns the trace and error as a single
+// BuildResult.
+func (b *SummaryQATraceBuilder) TryCreate(ctx context.Context) langfuse.BuildResult[*SummaryQATraceContext] {
+	return langfuse.NewBuildResult(b.Create(ctx))
+}
+
+// SummaryQATraceContext provides context for a summary Q&A trace with typed
+// methods.
+type SummaryQATraceContext struct {
+	*langfuse.TraceContext
+	input  *SummaryQAInput
+	output *SummaryQAOutput
+}
+
+// GetInput returns the summary Q&A input.
+func (s *SummaryQATraceContext) GetInput() *SummaryQAInput {
+	return s.input
+}
+
+// GetOutput returns the summary Q&A output.
+func (s *SummaryQATraceContext) GetOutput() *SummaryQAOutput {
+	return s.output
+}
+
+// UpdateSummary records the summary as a "summarization" child span and
+// updates the trace output.
+func (s *SummaryQATraceContext) UpdateSummary(ctx context.Context, summary string) error {
+	if err := s.recordSummary(ctx, summary); err != nil {
+		return err
+	}
+	return s.Update().Output(s.output).Apply(ctx)
+}
+
+// UpdateAnswer records the answer as a "qa" child span and updates the
+// trace output.
+func (s *SummaryQATraceContext) UpdateAnswer(ctx context.Context, answer string) error {
+	if err := s.recordAnswer(ctx, answer); err != nil {
+		return err
+	}
+	return s.Update().Output(s.output).Apply(ctx)
+}
+
+// ValidateSummarizationStep checks if the summarization step has the fields
+// required by SummarizationEvaluator.
+func (s *SummaryQATraceContext) ValidateSummarizationStep() error {
+	input, output := s.summarizationStep()
+	return ValidateFor(input, output, SummarizationEvaluator)
+}
+
+// ValidateQAStep checks if the Q&A step has the fields required by
+// QAEvaluator.
+func (s *SummaryQATraceContext) ValidateQAStep() error {
+	input, output := s.qaStep()
+	return ValidateFor(input, output, QAEvaluator)
+}
+
+// ValidateForEvaluation checks if the trace has all required fields for evaluation.
+func (s *SummaryQATraceContext) ValidateForEvaluation() error {
+	return ValidateFor(s.input, s.output, SummaryQAEvaluator)
+}
+
+// summarizationStep returns the input and output of the summarization span.
+func (s *SummaryQATraceContext) summarizationStep() (*SummarizationInput, *SummarizationOutput) {
+	return &SummarizationInput{Input: s.input.SourceDocument},
+		&SummarizationOutput{Output: s.output.Summary}
+}
+
+// qaStep returns the input and output of the Q&A span. The summary is the
+// context the question is answered from.
+func (s *SummaryQATraceContext) qaStep() (*QAInput, *QAOutput) {
+	return &QAInput{Query: s.input.Question, GroundTruth: s.input.GroundTruth, Context: s.output.Summary},
+		&QAOutput{Output: s.output.Answer}
+}
+
+// recordSummary sets the summary and records the summarization span.
+func (s *SummaryQATraceContext) recordSummary(ctx context.Context, summary string) error {
+	s.output.Summary = summary
+	input, output := s.summarizationStep()
+	if err := s.recordStep(ctx, SummarizationSpanName, input, output); err != nil {
+		return fmt.Errorf("failed to record summarization step: %w", err)
+	}
+	return nil
+}
+
+// recordAnswer sets the answer and records the Q&A span.
+func (s *SummaryQATraceContext) recordAnswer(ctx context.Context, answer string) error {
+	s.output.Answer = answer
+	input, output := s.qaStep()
+	if err := s.recordStep(ctx, QASpanName, input, output); err != nil {
+		return fmt.Errorf("failed to record Q&A step: %w", err)
+	}
+	return nil
+}
+
+// recordStep creates an ended child span for a pipeline step.
+func (s *SummaryQATraceContext) recordStep(ctx context.Context, name string, input, output any) error {
+	now := time.Now()
+	_, err := s.NewSpan().
+		Name(name).
+		StartTime(now).
+		EndTime(now).
+		Input(input).
+		Output(output).
+		Create(ctx)
+	return err
+}
@@ -0,0 +1,170 @@
+package evaluation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	langfuse "github.com/jdziat/langfuse-go"
+	"github.com/jdziat/langfuse-go/langfusetest"
+)
+
+func TestSummaryQATraceBuilder_FluentAPI(t *testing.T) {
+	builder := &SummaryQATraceBuilder{
+		summaryQAInput:  &SummaryQAInput{},
+		summaryQAOutput: &SummaryQAOutput{},
+	}
+
+	result := builder.
+		SourceDocument("doc").
+		Summary("sum").
+		Question("q").
+		Answer("a").
+		GroundTruth("gt")
+
+	if result != builder {
+		t.Error("fluent methods should return the same builder")
+	}
+	if builder.summaryQAInput.SourceDocument != "doc" || builder.summaryQAInput.Question != "q" || builder.summaryQAInput.GroundTruth != "gt" {
+		t.Errorf("input not set correctly: %+v", builder.summaryQAInput)
+	}
+	if builder.summaryQAOutput.Summary != "sum" || builder.summaryQAOutput.Answer != "a" {
+		t.Errorf("output not set correctly: %+v", builder.summaryQAOutput)
+	}
+}
+
+func TestSummaryQATraceBuilder_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		input *SummaryQAInput
+	}{
+		{"missing source document", &SummaryQAInput{Question: "q"}},
+		{"missing question", &SummaryQAInput{SourceDocument: "doc"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := &SummaryQATraceBuilder{summaryQAInput: tt.input, summaryQAOutput: &SummaryQAOutput{}}
+			if err := builder.Validate(); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestSummaryQATraceContext_Validation(t *testing.T) {
+	input := &SummaryQAInput{SourceDocument: "doc", Question: "q", GroundTruth: "gt"}
+
+	tests := []struct {
+		name             string
+		output           *SummaryQAOutput
+		summarizationErr bool
+		qaErr            bool
+		evaluationErr    bool
+	}{
+		{
+			name:   "complete",
+			output: &SummaryQAOutput{Summary: "sum", Answer: "a"},
+		},
+		{
+			name:          "missing answer",
+			output:        &SummaryQAOutput{Summary: "sum"},
+			qaErr:         true,
+			evaluationErr: true,
+		},
+		{
+			name:             "missing summary",
+			output:           &SummaryQAOutput{Answer: "a"},
+			summarizationErr: true,
+			evaluationErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &SummaryQATraceContext{input: input, output: tt.output}
+			if err := ctx.ValidateSummarizationStep(); (err != nil) != tt.summarizationErr {
+				t.Errorf("ValidateSummarizationStep() error = %v, want error %v", err, tt.summarizationErr)
+			}
+			if err := ctx.ValidateQAStep(); (err != nil) != tt.qaErr {
+				t.Errorf("ValidateQAStep() error = %v, want error %v", err, tt.qaErr)
+			}
+			if err := ctx.ValidateForEvaluation(); (err != nil) != tt.evaluationErr {
+				t.Errorf("ValidateForEvaluation() error = %v, want error %v", err, tt.evaluationErr)
+			}
+		})
+	}
+
+	noTruth := &SummaryQATraceContext{
+		input:  &SummaryQAInput{SourceDocument: "doc", Question: "q"},
+		output: &SummaryQAOutput{Summary: "sum", Answer: "a"},
+	}
+	if err := noTruth.ValidateForEvaluation(); err == nil {
+		t.Error("expected error without ground truth")
+	}
+}
+
+func TestSummaryQATrace_CreateRecordsChildSpans(t *testing.T) {
+	server := langfusetest.NewMockServer()
+	defer server.Close()
+
+	client, err := langfuse.New("pk-lf-test-key", "sk-lf-test-key",
+		langfuse.WithBaseURL(server.URL),
+		langfuse.WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	trace, err := NewSummaryQATrace(client, "report-qa").
+		SourceDocument("Revenue was $4.2M in Q3.").
+		Summary("Q3 revenue: $4.2M.").
+		Question("What was Q3 revenue?").
+		GroundTruth("$4.2M").
+		Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := trace.UpdateAnswer(ctx, "$4.2M"); err != nil {
+		t.Fatalf("UpdateAnswer failed: %v", err)
+	}
+	if err := trace.ValidateForEvaluation(); err != nil {
+		t.Errorf("ValidateForEvaluation failed: %v", err)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	// trace-create, summarization span, qa span, trace output update
+	traces, spans := server.TracesCreated(), server.RequestsByType("span-create")
+	if len(traces) != 2 || len(spans) != 2 {
+		t.Fatalf("received %d traces and %d spans, want 2 and 2", len(traces), len(spans))
+	}
+
+	input := traces[0]["input"].(map[string]any)
+	if input["source_document"] != "Revenue was $4.2M in Q3." || input["question"] != "What was Q3 revenue?" {
+		t.Errorf("unexpected trace input: %v", input)
+	}
+
+	summarization := spans[0].Body
+	if summarization["name"] != SummarizationSpanName {
+		t.Errorf("unexpected summarization span: %v", summarization)
+	}
+	if out := summarization["output"].(map[string]any); out["output"] != "Q3 revenue: $4.2M." {
+		t.Errorf("unexpected summarization output: %v", out)
+	}
+
+	qa := spans[1].Body
+	if qa["name"] != QASpanName {
+		t.Errorf("unexpected Q&A span: %v", qa)
+	}
+	if in := qa["input"].(map[string]any); in["context"] != "Q3 revenue: $4.2M." || in["query"] != "What was Q3 revenue?" {
+		t.Errorf("Q&A span should use the summary as context: %v", in)
+	}
+
+	if out := traces[1]["output"].(map[string]any); out["answer"] != "$4.2M" || out["summary"] != "Q3 revenue: $4.2M." {
+		t.Errorf("unexpected trace output: %v", out)
+	}
+}
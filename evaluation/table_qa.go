@@ -0,0 +1,237 @@
+package evaluation
+
+import (
+	"context"
+	"fmt"
+
+	langfuse "github.com/jdziat/langfuse-go"
+)
+
+// Score names used by TableQATraceContext.UpdateWithExecutionResult.
+const (
+	SQLRowsReturnedScoreName = "sql_rows_returned"
+	SQLExecutionMsScoreName  = "sql_execution_ms"
+	SQLCorrectScoreName      = "sql_correct"
+)
+
+// TableQATraceBuilder provides a fluent interface for creating traces of
+// questions answered from tabular data.
+type TableQATraceBuilder struct {
+	*langfuse.TraceBuilder
+	tableInput      *TableQAInput
+	tableOutput     *TableQAOutput
+	metadata        map[string]any
+	evaluatorConfig *EvaluatorConfig
+}
+
+// NewTableQATrace creates a new table question answering trace builder.
+//
+// Example:
+//
+//	trace, err := evaluation.NewTableQATrace(client, "sales-qa").
+//	    TableData([][]string{{"EMEA", "4.2"}, {"APAC", "3.1"}}, []string{"region", "revenue"}).
+//	    Query("Which region had the highest revenue?").
+//	    GeneratedSQL("SELECT region FROM sales ORDER BY revenue DESC LIMIT 1").
+//	    Answer("EMEA").
+//	    GroundTruth("EMEA").
+//	    Create(ctx)
+//	trace.UpdateWithExecutionResult(ctx, 1, 12, true)
+func NewTableQATrace(client *langfuse.Client, name string) *TableQATraceBuilder {
+	return &TableQATraceBuilder{
+		TraceBuilder: client.NewTrace().Name(name),
+		tableInput:   &TableQAInput{},
+		tableOutput:  &TableQAOutput{},
+	}
+}
+
+// TableData sets the table the question is asked about.
+func (b *TableQATraceBuilder) TableData(rows [][]string, headers []string) *TableQATraceBuilder {
+	b.tableInput.TableData = &TableData{Headers: headers, Rows: rows}
+	return b
+}
+
+// Query sets the question about the table.
+func (b *TableQATraceBuilder) Query(query string) *TableQATraceBuilder {
+	b.tableInput.Query = query
+	return b
+}
+
+// GeneratedSQL sets the SQL generated to answer the query.
+func (b *TableQATraceBuilder) GeneratedSQL(sql string) *TableQATraceBuilder {
+	b.tableOutput.GeneratedSQL = sql
+	return b
+}
+
+// Answer sets the answer to the query.
+func (b *TableQATraceBuilder) Answer(answer string) *TableQATraceBuilder {
+	b.tableOutput.Answer = answer
+	return b
+}
+
+// GroundTruth sets the expected answer for evaluation.
+func (b *TableQATraceBuilder) GroundTruth(truth string) *TableQATraceBuilder {
+	b.tableInput.GroundTruth = truth
+	return b
+}
+
+// ID sets the trace ID.
+func (b *TableQATraceBuilder) ID(id string) *TableQATraceBuilder {
+	b.TraceBuilder.ID(id)
+	return b
+}
+
+// UserID sets the user ID.
+func (b *TableQATraceBuilder) UserID(userID string) *TableQATraceBuilder {
+	b.TraceBuilder.UserID(userID)
+	return b
+}
+
+// SessionID sets the session ID.
+func (b *TableQATraceBuilder) SessionID(sessionID string) *TableQATraceBuilder {
+	b.TraceBuilder.SessionID(sessionID)
+	return b
+}
+
+// Tags sets the trace tags.
+func (b *TableQATraceBuilder) Tags(tags []string) *TableQATraceBuilder {
+	b.TraceBuilder.Tags(tags)
+	return b
+}
+
+// Metadata sets the trace metadata.
+func (b *TableQATraceBuilder) Metadata(metadata map[string]any) *TableQATraceBuilder {
+	b.metadata = metadata
+	b.TraceBuilder.Metadata(metadata)
+	return b
+}
+
+// WithEvaluatorConfig records the judge configuration under
+// EvaluatorConfigMetadataKey in the trace metadata.
+func (b *TableQATraceBuilder) WithEvaluatorConfig(cfg EvaluatorConfig) *TableQATraceBuilder {
+	b.evaluatorConfig = &cfg
+	return b
+}
+
+// Release sets the release version.
+func (b *TableQATraceBuilder) Release(release string) *TableQATraceBuilder {
+	b.TraceBuilder.Release(release)
+	return b
+}
+
+// Version sets the version.
+func (b *TableQATraceBuilder) Version(version string) *TableQATraceBuilder {
+	b.TraceBuilder.Version(version)
+	return b
+}
+
+// Environment sets the environment.
+func (b *TableQATraceBuilder) Environment(env string) *TableQATraceBuilder {
+	b.TraceBuilder.Environment(env)
+	return b
+}
+
+// Public sets whether the trace is public.
+func (b *TableQATraceBuilder) Public(public bool) *TableQATraceBuilder {
+	b.TraceBuilder.Public(public)
+	return b
+}
+
+// Validate validates the table QA trace configuration.
+func (b *TableQATraceBuilder) Validate() error {
+	table := b.tableInput.TableData
+	if table == nil || len(table.Headers) == 0 {
+		return fmt.Errorf("table data with headers is required for table QA traces")
+	}
+	for i, row := range table.Rows {
+		if len(row) != len(table.Headers) {
+			return fmt.Errorf("table row %d has %d cells, want %d", i, len(row), len(table.Headers))
+		}
+	}
+	if b.tableInput.Query == "" {
+		return fmt.Errorf("query is required for table QA traces")
+	}
+	return b.TraceBuilder.Validate()
+}
+
+// Create creates the table QA trace and returns a context for updating it.
+// The table, query, and ground truth are recorded as the trace input; the
+// generated SQL and answer, if set, are recorded as the trace output.
+func (b *TableQATraceBuilder) Create(ctx context.Context) (*TableQATraceContext, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	b.TraceBuilder.Input(b.tableInput)
+	if b.tableOutput.GeneratedSQL != "" || b.tableOutput.Answer != "" {
+		b.TraceBuilder.Output(b.tableOutput)
+	}
+
+	if b.evaluatorConfig != nil {
+		b.TraceBuilder.Metadata(withEvaluatorConfig(b.metadata, b.evaluatorConfig))
+	}
+
+	traceCtx, err := b.TraceBuilder.Create(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TableQATraceContext{
+		TraceContext: traceCtx,
+		input:        b.tableInput,
+		output:       b.tableOutput,
+	}, nil
+}
+
+// TryCreate is like Create but returns the trace and error as a single
+// BuildResult.
+func (b *TableQATraceBuilder) TryCreate(ctx context.Context) langfuse.BuildResult[*TableQATraceContext] {
+	return langfuse.NewBuildResult(b.Create(ctx))
+}
+
+// TableQATraceContext provides context for a table QA trace with typed methods.
+type TableQATraceContext struct {
+	*langfuse.TraceContext
+	input  *TableQAInput
+	output *TableQAOutput
+}
+
+// GetInput returns the table QA input.
+func (t *TableQATraceContext) GetInput() *TableQAInput {
+	return t.input
+}
+
+// GetOutput returns the table QA output.
+func (t *TableQATraceContext) GetOutput() *TableQAOutput {
+	return t.output
+}
+
+// UpdateOutput sets the generated SQL and answer and updates the trace output.
+func (t *TableQATraceContext) UpdateOutput(ctx context.Context, generatedSQL, answer string) error {
+	t.output = &TableQAOutput{GeneratedSQL: generatedSQL, Answer: answer}
+	return t.Update().Output(t.output).Apply(ctx)
+}
+
+// ValidateForEvaluation checks if the trace has all required fields for evaluation.
+func (t *TableQATraceContext) ValidateForEvaluation() error {
+	return ValidateFor(t.input, t.output, TableQAEvaluator)
+}
+
+// UpdateWithExecutionResult records the result of executing the generated
+// SQL as scores on the trace: "sql_rows_returned" and "sql_execution_ms" as
+// numeric scores and "sql_correct" as a boolean score.
+func (t *TableQATraceContext) UpdateWithExecutionResult(ctx context.Context, rowsReturned int, executionMs int64, correct bool) error {
+	if rowsReturned < 0 {
+		return fmt.Errorf("rows returned cannot be negative, got %d", rowsReturned)
+	}
+	if executionMs < 0 {
+		return fmt.Errorf("execution time cannot be negative, got %d", executionMs)
+	}
+
+	if err := t.ScoreNumeric(ctx, SQLRowsReturnedScoreName, float64(rowsReturned)); err != nil {
+		return err
+	}
+	if err := t.ScoreNumeric(ctx, SQLExecutionMsScoreName, float64(executionMs)); err != nil {
+		return err
+	}
+	return t.ScoreBoolean(ctx, SQLCorrectScoreName, correct)
+}
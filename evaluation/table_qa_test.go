@@ -0,0 +1,157 @@
+package evaluation
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	langfuse "github.com/jdziat/langfuse-go"
+	"github.com/jdziat/langfuse-go/langfusetest"
+)
+
+func TestTableQATraceBuilder_FluentAPI(t *testing.T) {
+	builder := &TableQATraceBuilder{
+		tableInput:  &TableQAInput{},
+		tableOutput: &TableQAOutput{},
+	}
+
+	result := builder.
+		TableData([][]string{{"EMEA", "4.2"}}, []string{"region", "revenue"}).
+		Query("Top region?").
+		GeneratedSQL("SELECT region FROM sales").
+		Answer("EMEA").
+		GroundTruth("EMEA")
+
+	if result != builder {
+		t.Error("fluent methods should return the same builder")
+	}
+	table := builder.tableInput.TableData
+	if table == nil || len(table.Headers) != 2 || len(table.Rows) != 1 {
+		t.Fatalf("TableData not set correctly: %+v", table)
+	}
+	if builder.tableInput.Query != "Top region?" || builder.tableInput.GroundTruth != "EMEA" {
+		t.Errorf("input not set correctly: %+v", builder.tableInput)
+	}
+	if builder.tableOutput.GeneratedSQL != "SELECT region FROM sales" || builder.tableOutput.Answer != "EMEA" {
+		t.Errorf("output not set correctly: %+v", builder.tableOutput)
+	}
+}
+
+func TestTableQATraceBuilder_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		input *TableQAInput
+	}{
+		{"missing table", &TableQAInput{Query: "q"}},
+		{"missing headers", &TableQAInput{TableData: &TableData{Rows: [][]string{{"a"}}}, Query: "q"}},
+		{"ragged row", &TableQAInput{TableData: &TableData{Headers: []string{"a", "b"}, Rows: [][]string{{"1"}}}, Query: "q"}},
+		{"missing query", &TableQAInput{TableData: &TableData{Headers: []string{"a"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := &TableQATraceBuilder{tableInput: tt.input, tableOutput: &TableQAOutput{}}
+			if err := builder.Validate(); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestTableQAInputJSON(t *testing.T) {
+	input := &TableQAInput{
+		TableData: &TableData{Headers: []string{"region", "revenue"}, Rows: [][]string{{"EMEA", "4.2"}}},
+		Query:     "Top region?",
+	}
+
+	data, err := json.Marshal(input)
+	if err != nil {
+		t.Fatalf("failed to marshal TableQAInput: %v", err)
+	}
+	want := `{"table_data":{"headers":["region","revenue"],"rows":[["EMEA","4.2"]]},"query":"Top region?"}`
+	if string(data) != want {
+		t.Errorf("TableQAInput JSON = %s, want %s", data, want)
+	}
+}
+
+func TestTableQATraceContext_ValidateForEvaluation(t *testing.T) {
+	input := &TableQAInput{TableData: &TableData{Headers: []string{"a"}}, Query: "q"}
+
+	tests := []struct {
+		name        string
+		output      *TableQAOutput
+		expectError bool
+	}{
+		{name: "valid", output: &TableQAOutput{Answer: "a"}},
+		{name: "missing answer", output: &TableQAOutput{GeneratedSQL: "SELECT 1"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &TableQATraceContext{input: input, output: tt.output}
+			err := ctx.ValidateForEvaluation()
+			if tt.expectError && err == nil {
+				t.Error("expected error, got nil")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestTableQATraceContext_UpdateWithExecutionResult(t *testing.T) {
+	server := langfusetest.NewMockServer()
+	defer server.Close()
+
+	client, err := langfuse.New("pk-lf-test-key", "sk-lf-test-key",
+		langfuse.WithBaseURL(server.URL),
+		langfuse.WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	trace, err := NewTableQATrace(client, "sales-qa").
+		TableData([][]string{{"EMEA", "4.2"}, {"APAC", "3.1"}}, []string{"region", "revenue"}).
+		Query("Which region had the highest revenue?").
+		GeneratedSQL("SELECT region FROM sales ORDER BY revenue DESC LIMIT 1").
+		Answer("EMEA").
+		Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := trace.UpdateWithExecutionResult(ctx, -1, 0, true); err == nil {
+		t.Error("expected error for negative row count")
+	}
+	if err := trace.UpdateWithExecutionResult(ctx, 1, 12, true); err != nil {
+		t.Fatalf("UpdateWithExecutionResult failed: %v", err)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	traces, scores := server.TracesCreated(), server.ScoresCreated()
+	if len(traces) != 1 || len(scores) != 3 {
+		t.Fatalf("received %d traces and %d scores, want 1 and 3", len(traces), len(scores))
+	}
+	input := traces[0]["input"].(map[string]any)
+	table := input["table_data"].(map[string]any)
+	if rows := table["rows"].([]any); len(rows) != 2 {
+		t.Errorf("trace input table rows = %v", rows)
+	}
+
+	want := map[string]any{
+		SQLRowsReturnedScoreName: float64(1),
+		SQLExecutionMsScoreName:  float64(12),
+		SQLCorrectScoreName:      float64(1),
+	}
+	for _, score := range scores {
+		if v, ok := want[score["name"].(string)]; !ok || score["value"] != v {
+			t.Errorf("unexpected score: %v", score)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package evaluation
+
+import (
+	"context"
+	"fmt"
+
+	langfuse "github.com/jdziat/langfuse-go"
+)
+
+// TranslationTraceBuilder provides a fluent interface for creating machine translation traces.
+type TranslationTraceBuilder struct {
+	*langfuse.TraceBuilder
+	transInput      *TranslationInput
+	metadata        map[string]any
+	evaluatorConfig *EvaluatorConfig
+}
+
+// NewTranslationTrace creates a new translation trace builder.
+//
+// Example:
+//
+//	trace, err := evaluation.NewTranslationTrace(client, "fr-en-translator").
+//	    SourceText("Bonjour le monde").
+//	    SourceLanguage("fr").
+//	    TargetLanguage("en").
+//	    GroundTruth("Hello world").
+//	    Create(ctx)
+//	trace.UpdateOutput(ctx, "Hello world")
+func NewTranslationTrace(client *langfuse.Client, name string) *TranslationTraceBuilder {
+	return &TranslationTraceBuilder{
+		TraceBuilder: client.NewTrace().Name(name),
+		transInput:   &TranslationInput{},
+	}
+}
+
+// SourceText sets the text to translate.
+func (b *TranslationTraceBuilder) SourceText(text string) *TranslationTraceBuilder {
+	b.transInput.SourceText = text
+	return b
+}
+
+// SourceLanguage sets the language of the source text (e.g., "fr").
+func (b *TranslationTraceBuilder) SourceLanguage(language string) *TranslationTraceBuilder {
+	b.transInput.SourceLanguage = language
+	return b
+}
+
+// TargetLanguage sets the language to translate into (e.g., "en").
+func (b *TranslationTraceBuilder) TargetLanguage(language string) *TranslationTraceBuilder {
+	b.transInput.TargetLanguage = language
+	return b
+}
+
+// GroundTruth sets the reference translation for evaluation.
+func (b *TranslationTraceBuilder) GroundTruth(truth string) *TranslationTraceBuilder {
+	b.transInput.GroundTruth = truth
+	return b
+}
+
+// ID sets the trace ID.
+func (b *TranslationTraceBuilder) ID(id string) *TranslationTraceBuilder {
+	b.TraceBuilder.ID(id)
+	return b
+}
+
+// UserID sets the user ID.
+func (b *TranslationTraceBuilder) UserID(userID string) *TranslationTraceBuilder {
+	b.TraceBuilder.UserID(userID)
+	return b
+}
+
+// SessionID sets the session ID.
+func (b *TranslationTraceBuilder) SessionID(sessionID string) *TranslationTraceBuilder {
+	b.TraceBuilder.SessionID(sessionID)
+	return b
+}
+
+// Tags sets the trace tags.
+func (b *TranslationTraceBuilder) Tags(tags []string) *TranslationTraceBuilder {
+	b.TraceBuilder.Tags(tags)
+	return b
+}
+
+// Metadata sets the trace metadata.
+func (b *TranslationTraceBuilder) Metadata(metadata map[string]any) *TranslationTraceBuilder {
+	b.metadata = metadata
+	b.TraceBuilder.Metadata(metadata)
+	return b
+}
+
+// WithEvaluatorConfig records the judge configuration under
+// EvaluatorConfigMetadataKey in the trace metadata.
+func (b *TranslationTraceBuilder) WithEvaluatorConfig(cfg EvaluatorConfig) *TranslationTraceBuilder {
+	b.evaluatorConfig = &cfg
+	return b
+}
+
+// Release sets the release version.
+func (b *TranslationTraceBuilder) Release(release string) *TranslationTraceBuilder {
+	b.TraceBuilder.Release(release)
+	return b
+}
+
+// Version sets the version.
+func (b *TranslationTraceBuilder) Version(version string) *TranslationTraceBuilder {
+	b.TraceBuilder.Version(version)
+	return b
+}
+
+// Environment sets the environment.
+func (b *TranslationTraceBuilder) Environment(env string) *TranslationTraceBuilder {
+	b.TraceBuilder.Environment(env)
+	return b
+}
+
+// Public sets whether the trace is public.
+func (b *TranslationTraceBuilder) Public(public bool) *TranslationTraceBuilder {
+	b.TraceBuilder.Public(public)
+	return b
+}
+
+// Validate validates the translation trace configuration.
+func (b *TranslationTraceBuilder) Validate() error {
+	if b.transInput.SourceText == "" {
+		return fmt.Errorf("source text is required for translation traces")
+	}
+	if b.transInput.TargetLanguage == "" {
+		return fmt.Errorf("target language is required for translation traces")
+	}
+	return b.TraceBuilder.Validate()
+}
+
+// Create creates the translation trace and returns a context for updating it.
+func (b *TranslationTraceBuilder) Create(ctx context.Context) (*TranslationTraceContext, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	b.TraceBuilder.Input(b.transInput)
+
+	if b.evaluatorConfig != nil {
+		b.TraceBuilder.Metadata(withEvaluatorConfig(b.metadata, b.evaluatorConfig))
+	}
+
+	traceCtx, err := b.TraceBuilder.Create(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TranslationTraceContext{
+		TraceContext: traceCtx,
+		input:        b.transInput,
+	}, nil
+}
+
+// TryCreate is like Create but returns the trace and error as a single
+// BuildResult.
+func (b *TranslationTraceBuilder) TryCreate(ctx context.Context) langfuse.BuildResult[*TranslationTraceContext] {
+	return langfuse.NewBuildResult(b.Create(ctx))
+}
+
+// TranslationTraceContext provides context for a translation trace with typed methods.
+type TranslationTraceContext struct {
+	*langfuse.TraceContext
+	input  *TranslationInput
+	output *TranslationOutput
+}
+
+// GetInput returns the translation input.
+func (t *TranslationTraceContext) GetInput() *TranslationInput {
+	return t.input
+}
+
+// GetOutput returns the translation output.
+func (t *TranslationTraceContext) GetOutput() *TranslationOutput {
+	return t.output
+}
+
+// UpdateOutput updates the trace with the translated text.
+func (t *TranslationTraceContext) UpdateOutput(ctx context.Context, translatedText string) error {
+	t.output = &TranslationOutput{
+		Output: translatedText,
+	}
+	return t.Update().Output(t.output).Apply(ctx)
+}
+
+// ValidateForEvaluation checks if the trace has all required fields for evaluation.
+func (t *TranslationTraceContext) ValidateForEvaluation() error {
+	if t.output == nil {
+		return fmt.Errorf("output is required before evaluation")
+	}
+	return ValidateFor(t.input, t.output, TranslationEvaluator)
+}
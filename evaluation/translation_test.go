@@ -0,0 +1,106 @@
+package evaluation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	langfuse "github.com/jdziat/langfuse-go"
+	"github.com/jdziat/langfuse-go/langfusetest"
+)
+
+func TestTranslationTraceBuilder_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		input *TranslationInput
+	}{
+		{name: "missing source text", input: &TranslationInput{TargetLanguage: "en"}},
+		{name: "missing target language", input: &TranslationInput{SourceText: "Bonjour"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := &TranslationTraceBuilder{transInput: tt.input}
+			if err := builder.Validate(); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestTranslationEvaluator(t *testing.T) {
+	input := &TranslationInput{SourceText: "Bonjour le monde", SourceLanguage: "fr", TargetLanguage: "en"}
+
+	if err := ValidateFor(input, &TranslationOutput{Output: "Hello world"}, TranslationEvaluator); err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+	if err := ValidateFor(input, &TranslationOutput{}, TranslationEvaluator); err == nil {
+		t.Error("expected error for a missing translation")
+	}
+
+	result := ValidateDetailed(&TranslationInput{SourceText: "Bonjour"}, &TranslationOutput{Output: "Hello"}, TranslationEvaluator)
+	if result.Valid || len(result.MissingFields) != 1 || result.MissingFields[0] != "target_language" {
+		t.Errorf("ValidateDetailed() = %+v, want target_language missing", result)
+	}
+}
+
+func TestTranslationTraceContext_ValidateForEvaluation(t *testing.T) {
+	trace := &TranslationTraceContext{input: &TranslationInput{SourceText: "Bonjour", TargetLanguage: "en"}}
+	if err := trace.ValidateForEvaluation(); err == nil {
+		t.Error("expected error without output")
+	}
+
+	trace.output = &TranslationOutput{Output: "Hello"}
+	if err := trace.ValidateForEvaluation(); err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+}
+
+func TestTranslationTrace_Create(t *testing.T) {
+	server := langfusetest.NewMockLangfuseServer()
+	defer server.Close()
+
+	client, err := langfuse.New("pk-lf-test-key", "sk-lf-test-key",
+		langfuse.WithBaseURL(server.URL),
+		langfuse.WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	trace, err := NewTranslationTrace(client, "fr-en").
+		SourceText("Bonjour le monde").
+		SourceLanguage("fr").
+		TargetLanguage("en").
+		GroundTruth("Hello world").
+		Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := trace.UpdateOutput(ctx, "Hello world"); err != nil {
+		t.Fatalf("UpdateOutput failed: %v", err)
+	}
+	if err := trace.ValidateForEvaluation(); err != nil {
+		t.Errorf("ValidateForEvaluation failed: %v", err)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	got, err := client.Traces().Get(ctx, trace.ID())
+	if err != nil {
+		t.Fatalf("Traces().Get() failed: %v", err)
+	}
+	input, _ := got.Input.(map[string]any)
+	if input["source_text"] != "Bonjour le monde" || input["target_language"] != "en" || input["source_language"] != "fr" {
+		t.Errorf("input = %v", got.Input)
+	}
+	if output, _ := got.Output.(map[string]any); output["output"] != "Hello world" {
+		t.Errorf("output = %v, want the translation", got.Output)
+	}
+	if err := ValidateFor(got.Input, got.Output, TranslationEvaluator); err != nil {
+		t.Errorf("fetched trace is not valid for the translation evaluator: %v", err)
+	}
+}
@@ -7,7 +7,21 @@ const (
 	EvaluationTypeRAG            EvaluationType = "rag"
 	EvaluationTypeQA             EvaluationType = "qa"
 	EvaluationTypeSummarization  EvaluationType = "summarization"
+	EvaluationTypeTranslation    EvaluationType = "translation"
 	EvaluationTypeClassification EvaluationType = "classification"
+	EvaluationTypeNER            EvaluationType = "ner"
+	EvaluationTypeIR             EvaluationType = "ir"
+	EvaluationTypeGroundedness   EvaluationType = "groundedness"
+	EvaluationTypeReAct          EvaluationType = "react"
+	EvaluationTypeSummaryQA      EvaluationType = "summary_qa"
+	EvaluationTypeFactChecking   EvaluationType = "fact_checking"
+	EvaluationTypeTableQA        EvaluationType = "table_qa"
+	EvaluationTypeDocumentQA     EvaluationType = "document_qa"
+
+	EvaluationTypeConstitutionalAI EvaluationType = "constitutional_ai"
+	EvaluationTypeBiasDetection    EvaluationType = "bias_detection"
+
+	EvaluationTypeMultiLabelClassification EvaluationType = "multi_label_classification"
 )
 
 // RAGInput represents input for RAG (Retrieval-Augmented Generation) workflows.
@@ -135,6 +149,39 @@ type SummarizationOutput struct {
 	Metadata map[string]any `json:"metadata,omitempty"`
 }
 
+// TranslationInput represents input for machine translation workflows.
+//
+// Example:
+//
+//	input := &evaluation.TranslationInput{
+//	    SourceText:     "Bonjour le monde",
+//	    SourceLanguage: "fr",
+//	    TargetLanguage: "en",
+//	    GroundTruth:    "Hello world",
+//	}
+type TranslationInput struct {
+	// SourceText is the text to translate (required for evaluation)
+	SourceText string `json:"source_text"`
+
+	// SourceLanguage is the language of the source text (optional)
+	SourceLanguage string `json:"source_language,omitempty"`
+
+	// TargetLanguage is the language to translate into (required for evaluation)
+	TargetLanguage string `json:"target_language"`
+
+	// GroundTruth is a reference translation for evaluation (optional)
+	GroundTruth string `json:"ground_truth,omitempty"`
+}
+
+// TranslationOutput represents output from machine translation workflows.
+type TranslationOutput struct {
+	// Output is the translated text (required for evaluation)
+	Output string `json:"output"`
+
+	// Metadata allows passing additional metadata
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
 // ClassificationInput represents input for classification workflows.
 type ClassificationInput struct {
 	// Input is the text to classify (required for evaluation)
@@ -162,6 +209,29 @@ type ClassificationOutput struct {
 	Metadata map[string]any `json:"metadata,omitempty"`
 }
 
+// MultiLabelClassificationInput represents input for multi-label
+// classification workflows, where an input may belong to several classes.
+type MultiLabelClassificationInput struct {
+	// Input is the text to classify (required)
+	Input string `json:"input"`
+
+	// Classes lists all possible labels (required)
+	Classes []string `json:"classes"`
+
+	// PredictedLabels are the labels assigned by the model (required)
+	PredictedLabels []string `json:"predicted_labels"`
+
+	// PredictedProbabilities are the model's per-label probabilities (optional)
+	PredictedProbabilities map[string]float64 `json:"predicted_probabilities,omitempty"`
+}
+
+// MultiLabelClassificationOutput represents the reference output for
+// multi-label classification evaluation.
+type MultiLabelClassificationOutput struct {
+	// GroundTruthLabels are the expected labels (optional)
+	GroundTruthLabels []string `json:"ground_truth_labels,omitempty"`
+}
+
 // ToxicityInput represents input for toxicity evaluation.
 type ToxicityInput struct {
 	// Input is the text to evaluate for toxicity (required)
@@ -203,3 +273,290 @@ type HallucinationOutput struct {
 	// Metadata allows passing additional metadata
 	Metadata map[string]any `json:"metadata,omitempty"`
 }
+
+// Entity represents a named entity span within a text.
+type Entity struct {
+	// Text is the entity surface text
+	Text string `json:"text"`
+
+	// Label is the entity type (e.g., "PERSON", "ORG")
+	Label string `json:"label"`
+
+	// StartChar is the offset of the first character of the entity
+	StartChar int `json:"start_char"`
+
+	// EndChar is the offset just past the last character of the entity
+	EndChar int `json:"end_char"`
+}
+
+// NERInput represents input for named entity recognition evaluation.
+type NERInput struct {
+	// InputText is the text entities were extracted from (required)
+	InputText string `json:"input_text"`
+
+	// PredictedEntities are the entities produced by the model (required)
+	PredictedEntities []Entity `json:"predicted_entities"`
+}
+
+// NEROutput represents the reference output for NER evaluation.
+type NEROutput struct {
+	// GroundTruthEntities are the expected entities (optional)
+	GroundTruthEntities []Entity `json:"ground_truth_entities,omitempty"`
+}
+
+// IRInput represents input for information retrieval evaluation.
+type IRInput struct {
+	// Query is the search query (required)
+	Query string `json:"query"`
+
+	// RetrievedDocIDs are the IDs of the retrieved documents in rank order (required)
+	RetrievedDocIDs []string `json:"retrieved_docs"`
+
+	// RetrievedDocs is the content of the retrieved documents (optional)
+	RetrievedDocs []string `json:"retrieved_doc_contents,omitempty"`
+}
+
+// IROutput represents the reference output for information retrieval evaluation.
+type IROutput struct {
+	// RelevantDocIDs are the IDs of the documents relevant to the query (required)
+	RelevantDocIDs []string `json:"relevant_docs"`
+}
+
+// SourceDocument is a document a response may be grounded in.
+type SourceDocument struct {
+	// ID identifies the document; citations refer to it
+	ID string `json:"id"`
+
+	// Content is the document text
+	Content string `json:"content"`
+
+	// Metadata holds additional document attributes (optional)
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// GroundednessInput represents input for groundedness evaluation.
+type GroundednessInput struct {
+	// Query is the user's question (required)
+	Query string `json:"query"`
+
+	// SourceDocuments are the documents the response should be supported by (required)
+	SourceDocuments []SourceDocument `json:"source_documents"`
+}
+
+// GroundednessOutput represents output for groundedness evaluation.
+type GroundednessOutput struct {
+	// Response is the generated response (required)
+	Response string `json:"output"`
+
+	// CitedDocumentIDs are the IDs of the source documents the response cites (optional)
+	CitedDocumentIDs []string `json:"cited_document_ids,omitempty"`
+}
+
+// ReActStep is one thought, action, and observation cycle of a ReAct agent.
+type ReActStep struct {
+	// Thought is the agent's reasoning before acting
+	Thought string `json:"thought"`
+
+	// Action is the tool or action the agent chose
+	Action string `json:"action"`
+
+	// ActionInput is the input passed to the action (optional)
+	ActionInput string `json:"action_input,omitempty"`
+
+	// Observation is the result of the action (optional)
+	Observation string `json:"observation,omitempty"`
+}
+
+// ReActInput represents input for ReAct agent evaluation.
+type ReActInput struct {
+	// Task is the task given to the agent (required)
+	Task string `json:"task"`
+
+	// GroundTruth is the expected final answer (optional)
+	GroundTruth string `json:"ground_truth,omitempty"`
+}
+
+// ReActOutput represents the trajectory and result of a ReAct agent.
+type ReActOutput struct {
+	// Steps are the agent's reasoning and acting cycles in order (required)
+	Steps []ReActStep `json:"steps"`
+
+	// FinalAnswer is the agent's final answer (required)
+	FinalAnswer string `json:"final_answer"`
+}
+
+// SummaryQAInput represents input for summarize-then-answer workflows.
+type SummaryQAInput struct {
+	// SourceDocument is the document that is summarized (required)
+	SourceDocument string `json:"source_document"`
+
+	// Question is asked about the summary (required)
+	Question string `json:"question"`
+
+	// GroundTruth is the expected answer (optional)
+	GroundTruth string `json:"ground_truth,omitempty"`
+}
+
+// SummaryQAOutput represents the summary and answer of a summarize-then-answer
+// workflow.
+type SummaryQAOutput struct {
+	// Summary is the generated summary of the source document
+	Summary string `json:"summary,omitempty"`
+
+	// Answer is the answer to the question, based on the summary
+	Answer string `json:"answer"`
+}
+
+// Fact-checking verdicts.
+const (
+	FactVerdictSupported     = "supported"
+	FactVerdictRefuted       = "refuted"
+	FactVerdictNotEnoughInfo = "not_enough_info"
+)
+
+// FactVerdict is the verdict of a fact-checking model on a single claim.
+type FactVerdict struct {
+	// Claim is the claim being verified
+	Claim string `json:"claim"`
+
+	// Verdict is one of FactVerdictSupported, FactVerdictRefuted, or FactVerdictNotEnoughInfo
+	Verdict string `json:"verdict"`
+
+	// Evidence is the source text supporting the verdict (optional)
+	Evidence string `json:"evidence,omitempty"`
+}
+
+// FactCheckingInput represents input for claim verification evaluation.
+type FactCheckingInput struct {
+	// Claims are the claims to verify (required)
+	Claims []string `json:"claims"`
+
+	// SourceDocuments are the documents claims are verified against (required)
+	SourceDocuments []string `json:"source_documents"`
+
+	// GroundTruth holds the expected verdict for each claim, in the same order (optional)
+	GroundTruth []string `json:"ground_truth,omitempty"`
+}
+
+// FactCheckingOutput represents the verdicts of a fact-checking model.
+type FactCheckingOutput struct {
+	// Verdicts are the model's verdicts on the claims (required)
+	Verdicts []FactVerdict `json:"verdicts"`
+}
+
+// TableData is a table of string cells with named columns.
+type TableData struct {
+	// Headers are the column names
+	Headers []string `json:"headers"`
+
+	// Rows are the table rows; each row has one cell per header
+	Rows [][]string `json:"rows"`
+}
+
+// TableQAInput represents input for table question answering evaluation.
+type TableQAInput struct {
+	// TableData is the table the question is asked about (required)
+	TableData *TableData `json:"table_data"`
+
+	// Query is the question about the table (required)
+	Query string `json:"query"`
+
+	// GroundTruth is the expected answer (optional)
+	GroundTruth string `json:"ground_truth,omitempty"`
+}
+
+// TableQAOutput represents output for table question answering evaluation.
+type TableQAOutput struct {
+	// GeneratedSQL is the SQL generated to answer the query (optional, for text-to-SQL)
+	GeneratedSQL string `json:"generated_sql,omitempty"`
+
+	// Answer is the answer to the query (required)
+	Answer string `json:"answer"`
+}
+
+// Citation is a passage of a document that an answer is based on.
+type Citation struct {
+	// Page is the 1-based page number of the passage (required)
+	Page int `json:"page"`
+
+	// Section is the heading of the section containing the passage (optional)
+	Section string `json:"section,omitempty"`
+
+	// Excerpt is the cited text (optional)
+	Excerpt string `json:"excerpt,omitempty"`
+}
+
+// DocumentQAInput represents input for document question answering evaluation.
+type DocumentQAInput struct {
+	// Document is the document content (required)
+	Document string `json:"document"`
+
+	// DocumentLength is the length of the document in characters
+	DocumentLength int `json:"document_length"`
+
+	// PageCount is the number of pages in the document (required)
+	PageCount int `json:"page_count"`
+
+	// Question is the question about the document (required)
+	Question string `json:"question"`
+
+	// GroundTruth is the expected answer (optional)
+	GroundTruth string `json:"ground_truth,omitempty"`
+}
+
+// DocumentQAOutput represents output for document question answering evaluation.
+type DocumentQAOutput struct {
+	// Answer is the answer to the question (required)
+	Answer string `json:"answer"`
+
+	// Citations are the passages the answer is based on (required)
+	Citations []Citation `json:"citations"`
+}
+
+// ConstitutionalAIInput represents input for constitutional AI evaluation.
+type ConstitutionalAIInput struct {
+	// UserQuery is the user's request (required)
+	UserQuery string `json:"user_query"`
+
+	// Constitution is the list of principles responses are critiqued against (required)
+	Constitution []string `json:"constitution"`
+}
+
+// ConstitutionalAIOutput represents the stages of a constitutional AI
+// critique and revision.
+type ConstitutionalAIOutput struct {
+	// InitialResponse is the response before critique (required)
+	InitialResponse string `json:"initial_response"`
+
+	// Critique is the critique of the initial response against the constitution (optional)
+	Critique string `json:"critique,omitempty"`
+
+	// RevisedResponse is the response after revision (required)
+	RevisedResponse string `json:"revised_response"`
+}
+
+// BiasDetectionInput represents input for bias detection evaluation.
+type BiasDetectionInput struct {
+	// Prompt is the prompt given to the model (required)
+	Prompt string `json:"prompt"`
+
+	// BiasCategory is the kind of bias evaluated, e.g. "gender", "political" or "racial" (required)
+	BiasCategory string `json:"bias_category"`
+}
+
+// BiasDetectionOutput represents output for bias detection evaluation.
+type BiasDetectionOutput struct {
+	// Response is the model's response to the prompt (required)
+	Response string `json:"output"`
+}
+
+// ControlResponse is the model's response to a variant of the prompt, for
+// example with a different demographic attribute, to compare against the
+// evaluated response.
+type ControlResponse struct {
+	// VariantDescription describes how the prompt was varied (required)
+	VariantDescription string `json:"variant_description"`
+
+	// Response is the model's response to the varied prompt (required)
+	Response string `json:"response"`
+}
@@ -3,7 +3,10 @@ package evaluation
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
+
+	langfuse "github.com/jdziat/langfuse-go"
 )
 
 // EvaluatorRequirements defines what fields an evaluator expects.
@@ -19,6 +22,19 @@ type EvaluatorRequirements struct {
 
 	// Description explains what this evaluator does
 	Description string
+
+	// InputFields and OutputFields record which side of the trace each field
+	// is expected on. When empty, placement is inferred from the field name.
+	InputFields  []string
+	OutputFields []string
+
+	// MissingWarnings maps field names to custom warnings reported by
+	// ValidateDetailed when the field is absent.
+	MissingWarnings map[string]string
+
+	// EvaluatorConfig is the judge configuration of the evaluator, if known.
+	// ValidateDetailed includes it in its result.
+	EvaluatorConfig *EvaluatorConfig
 }
 
 var (
@@ -63,6 +79,14 @@ var (
 		Description:    "Evaluates summary quality and completeness",
 	}
 
+	// TranslationEvaluator defines requirements for machine translation evaluations.
+	TranslationEvaluator = EvaluatorRequirements{
+		Name:           "Translation",
+		RequiredFields: []string{"source_text", "target_language", "output"},
+		OptionalFields: []string{"source_language", "ground_truth"},
+		Description:    "Evaluates translation accuracy and fluency",
+	}
+
 	// ClassificationEvaluator defines requirements for classification evaluations.
 	ClassificationEvaluator = EvaluatorRequirements{
 		Name:           "Classification",
@@ -102,6 +126,92 @@ var (
 		OptionalFields: []string{"context"},
 		Description:    "Evaluates if the answer is relevant to the query",
 	}
+
+	// NEREvaluator defines requirements for named entity recognition evaluations.
+	NEREvaluator = EvaluatorRequirements{
+		Name:           "NER",
+		RequiredFields: []string{"input_text", "predicted_entities"},
+		OptionalFields: []string{"ground_truth_entities"},
+		Description:    "Evaluates named entity extraction quality",
+	}
+
+	// IREvaluator defines requirements for information retrieval evaluations.
+	IREvaluator = EvaluatorRequirements{
+		Name:           "IR",
+		RequiredFields: []string{"query", "retrieved_docs", "relevant_docs"},
+		OptionalFields: []string{"retrieved_doc_contents"},
+		Description:    "Evaluates retrieval ranking quality (precision, recall, NDCG)",
+	}
+
+	// GroundednessEvaluator checks if a response is supported by its source documents.
+	GroundednessEvaluator = EvaluatorRequirements{
+		Name:           "Groundedness",
+		RequiredFields: []string{"query", "source_documents", "output"},
+		OptionalFields: []string{"cited_document_ids"},
+		Description:    "Evaluates if the response is supported by the source documents",
+	}
+
+	// ReActEvaluator defines requirements for ReAct agent trajectory evaluations.
+	ReActEvaluator = EvaluatorRequirements{
+		Name:           "ReAct",
+		RequiredFields: []string{"task", "steps", "final_answer"},
+		OptionalFields: []string{"ground_truth"},
+		Description:    "Evaluates ReAct agent reasoning and acting trajectories",
+	}
+
+	// SummaryQAEvaluator defines requirements for summarize-then-answer evaluations.
+	SummaryQAEvaluator = EvaluatorRequirements{
+		Name:           "Summary Q&A",
+		RequiredFields: []string{"source_document", "summary", "question", "answer", "ground_truth"},
+		Description:    "Evaluates answers to questions about a generated summary",
+	}
+
+	// FactCheckingEvaluator defines requirements for claim verification evaluations.
+	FactCheckingEvaluator = EvaluatorRequirements{
+		Name:           "Fact Checking",
+		RequiredFields: []string{"claims", "source_documents", "verdicts"},
+		OptionalFields: []string{"ground_truth"},
+		Description:    "Evaluates claim verdicts against source documents",
+	}
+
+	// TableQAEvaluator defines requirements for table question answering evaluations.
+	TableQAEvaluator = EvaluatorRequirements{
+		Name:           "Table QA",
+		RequiredFields: []string{"table_data", "query", "answer"},
+		OptionalFields: []string{"generated_sql", "ground_truth"},
+		Description:    "Evaluates answers and generated SQL for questions about tabular data",
+	}
+
+	// DocumentQAEvaluator defines requirements for document question answering evaluations.
+	DocumentQAEvaluator = EvaluatorRequirements{
+		Name:           "Document QA",
+		RequiredFields: []string{"document", "question", "answer", "citations"},
+		OptionalFields: []string{"document_length", "page_count", "ground_truth"},
+		Description:    "Evaluates answers and page citations for questions about a document",
+	}
+
+	// MultiLabelEvaluator defines requirements for multi-label classification evaluations.
+	MultiLabelEvaluator = EvaluatorRequirements{
+		Name:           "Multi-Label Classification",
+		RequiredFields: []string{"input", "classes", "predicted_labels"},
+		OptionalFields: []string{"predicted_probabilities", "ground_truth_labels"},
+		Description:    "Evaluates multi-label classification accuracy",
+	}
+
+	// ConstitutionalAIEvaluator defines requirements for constitutional AI critique and revision evaluations.
+	ConstitutionalAIEvaluator = EvaluatorRequirements{
+		Name:           "Constitutional AI",
+		RequiredFields: []string{"user_query", "initial_response", "constitution", "revised_response"},
+		OptionalFields: []string{"critique"},
+		Description:    "Evaluates whether revisions bring responses in line with a constitution",
+	}
+
+	// BiasEvaluator defines requirements for bias detection evaluations.
+	BiasEvaluator = EvaluatorRequirements{
+		Name:           "Bias Detection",
+		RequiredFields: []string{"prompt", "bias_category", "output"},
+		Description:    "Evaluates responses for demographic, political, or factual bias",
+	}
 )
 
 // ValidateFor checks if input and output structures match evaluator requirements.
@@ -133,12 +243,38 @@ func ValidateFor(input, output any, reqs EvaluatorRequirements) error {
 	return nil
 }
 
+// ValidateForSchemaVersion is like ValidateFor but applies the requirements
+// registered in schemas for schemaVersion, for inputs whose schema changed
+// between deployments. An input recorded with TraceBuilder.VersionedInput
+// is unwrapped to its data first. It returns an error if schemas has no
+// requirements for schemaVersion.
+//
+// Example:
+//
+//	schemas := map[int]evaluation.EvaluatorRequirements{
+//	    1: evaluation.QAEvaluator,
+//	    2: evaluation.RAGEvaluator,
+//	}
+//	err := evaluation.ValidateForSchemaVersion(input, output, trace.InputSchemaVersion(), schemas)
+func ValidateForSchemaVersion(input, output any, schemaVersion int, schemas map[int]EvaluatorRequirements) error {
+	reqs, ok := schemas[schemaVersion]
+	if !ok {
+		return fmt.Errorf("no evaluator requirements for input schema version %d", schemaVersion)
+	}
+	if m, ok := input.(map[string]any); ok {
+		if data, ok := m["data"]; ok && m["schema_version"] != nil {
+			input = data
+		}
+	}
+	return ValidateFor(input, output, reqs)
+}
+
 // ValidateInput validates that an input structure has the required fields.
 func ValidateInput(input any, reqs EvaluatorRequirements) error {
 	fields := extractFields(input)
 
 	// Check which required fields should be in input (not output-specific)
-	inputRequiredFields := filterInputFields(reqs.RequiredFields)
+	inputRequiredFields := reqs.requiredInputFields()
 
 	var missing []string
 	for _, required := range inputRequiredFields {
@@ -161,6 +297,22 @@ func ValidateInput(input any, reqs EvaluatorRequirements) error {
 func ValidateOutput(output any, reqs EvaluatorRequirements) error {
 	fields := extractFields(output)
 
+	if len(reqs.OutputFields) > 0 {
+		var missing []string
+		for _, required := range reqs.RequiredFields {
+			if containsField(reqs.OutputFields, required) && !containsField(fields, required) {
+				missing = append(missing, required)
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf(
+				"output missing required fields for %s evaluator: %v (found: %v)",
+				reqs.Name, missing, fields,
+			)
+		}
+		return nil
+	}
+
 	// Check if output field is required and present
 	if containsField(reqs.RequiredFields, "output") && !containsField(fields, "output") {
 		return fmt.Errorf(
@@ -172,6 +324,21 @@ func ValidateOutput(output any, reqs EvaluatorRequirements) error {
 	return nil
 }
 
+// requiredInputFields returns the required fields expected on the input side.
+func (reqs EvaluatorRequirements) requiredInputFields() []string {
+	if len(reqs.InputFields) == 0 && len(reqs.OutputFields) == 0 {
+		return filterInputFields(reqs.RequiredFields)
+	}
+
+	var fields []string
+	for _, f := range reqs.RequiredFields {
+		if !containsField(reqs.OutputFields, f) {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
 // extractFields extracts field names from a struct or map.
 func extractFields(data any) []string {
 	if data == nil {
@@ -292,6 +459,16 @@ func mergeFields(a, b []string) []string {
 	return result
 }
 
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // filterInputFields returns fields that are typically in input (not output-specific).
 func filterInputFields(fields []string) []string {
 	outputFields := map[string]bool{
@@ -304,6 +481,17 @@ func filterInputFields(fields []string) []string {
 		"scores":              true,
 		"toxicity_score":      true,
 		"hallucination_score": true,
+		"relevant_docs":       true,
+		"cited_document_ids":  true,
+		"steps":               true,
+		"final_answer":        true,
+		"summary":             true,
+		"answer":              true,
+		"verdicts":            true,
+		"generated_sql":       true,
+		"initial_response":    true,
+		"critique":            true,
+		"revised_response":    true,
 	}
 
 	var inputFields []string
@@ -315,26 +503,44 @@ func filterInputFields(fields []string) []string {
 	return inputFields
 }
 
+// Usage types reported in ValidationResult.UsageType.
+const (
+	// UsageTypeStandard is reported for usage with input and output tokens only.
+	UsageTypeStandard = "standard"
+
+	// UsageTypeExtended is reported for usage with reasoning or cached input
+	// tokens, whose output token count excludes the reasoning tokens.
+	UsageTypeExtended = "extended"
+)
+
 // ValidationResult contains detailed validation results.
 type ValidationResult struct {
-	Valid         bool
-	MissingFields []string
-	PresentFields []string
-	Warnings      []string
-	EvaluatorName string
+	Valid           bool
+	MissingFields   []string
+	PresentFields   []string
+	Warnings        []string
+	EvaluatorName   string
+	EvaluatorConfig *EvaluatorConfig
+
+	// UsageType is UsageTypeStandard or UsageTypeExtended if the input or
+	// output holds a langfuse.Usage, directly or in a field or map value,
+	// and empty otherwise.
+	UsageType string
 }
 
 // ValidateDetailed performs detailed validation and returns a result struct.
 func ValidateDetailed(input, output any, reqs EvaluatorRequirements) *ValidationResult {
 	result := &ValidationResult{
-		Valid:         true,
-		EvaluatorName: reqs.Name,
+		Valid:           true,
+		EvaluatorName:   reqs.Name,
+		EvaluatorConfig: reqs.EvaluatorConfig,
 	}
 
 	inputFields := extractFields(input)
 	outputFields := extractFields(output)
 	allFields := mergeFields(inputFields, outputFields)
 	result.PresentFields = allFields
+	result.UsageType = usageType(input, output)
 
 	// Check required fields
 	for _, required := range reqs.RequiredFields {
@@ -347,14 +553,82 @@ func ValidateDetailed(input, output any, reqs EvaluatorRequirements) *Validation
 	// Check optional fields for warnings
 	for _, optional := range reqs.OptionalFields {
 		if !containsField(allFields, optional) {
+			if msg, ok := reqs.MissingWarnings[optional]; ok {
+				result.Warnings = append(result.Warnings, msg)
+				continue
+			}
 			result.Warnings = append(result.Warnings,
 				fmt.Sprintf("optional field '%s' not provided - evaluation may be less accurate", optional))
 		}
 	}
 
+	// Custom warnings for fields that are neither required nor optional
+	for _, field := range sortedKeys(reqs.MissingWarnings) {
+		if containsField(reqs.RequiredFields, field) || containsField(reqs.OptionalFields, field) {
+			continue
+		}
+		if !containsField(allFields, field) {
+			result.Warnings = append(result.Warnings, reqs.MissingWarnings[field])
+		}
+	}
+
 	return result
 }
 
+// usageType returns the usage type of a langfuse.Usage found in values,
+// looking at the values themselves and their fields or map values.
+func usageType(values ...any) string {
+	for _, data := range values {
+		if u := findUsage(reflect.ValueOf(data), true); u != nil {
+			if u.HasExtendedUsage() {
+				return UsageTypeExtended
+			}
+			return UsageTypeStandard
+		}
+	}
+	return ""
+}
+
+// findUsage returns the langfuse.Usage held by v, or by one of its fields or
+// map values if nested is true.
+func findUsage(v reflect.Value, nested bool) *langfuse.Usage {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+	if u, ok := v.Interface().(langfuse.Usage); ok {
+		return &u
+	}
+	if !nested {
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !v.Type().Field(i).IsExported() {
+				continue
+			}
+			if u := findUsage(v.Field(i), false); u != nil {
+				return u
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if u := findUsage(iter.Value(), false); u != nil {
+				return u
+			}
+		}
+	}
+	return nil
+}
+
 // Error returns an error if validation failed, nil otherwise.
 func (r *ValidationResult) Error() error {
 	if r.Valid {
@@ -394,6 +394,7 @@ func TestAllEvaluatorRequirements(t *testing.T) {
 		"ContextCorrectness": ContextCorrectnessEvaluator,
 		"QA":                 QAEvaluator,
 		"Summarization":      SummarizationEvaluator,
+		"Translation":        TranslationEvaluator,
 		"Classification":     ClassificationEvaluator,
 		"Toxicity":           ToxicityEvaluator,
 		"Hallucination":      HallucinationEvaluator,
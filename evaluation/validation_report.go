@@ -0,0 +1,139 @@
+package evaluation
+
+import (
+	"embed"
+	htmltemplate "html/template"
+	"io"
+	"net/url"
+	"strings"
+	"text/template"
+
+	langfuse "github.com/jdziat/langfuse-go"
+)
+
+//go:embed templates/validation_report.html.tmpl templates/validation_report.md.tmpl
+var reportTemplates embed.FS
+
+var (
+	htmlReportTemplate = htmltemplate.Must(htmltemplate.New("validation_report.html.tmpl").
+				Funcs(htmltemplate.FuncMap{"join": strings.Join}).
+				ParseFS(reportTemplates, "templates/validation_report.html.tmpl"))
+
+	markdownReportTemplate = template.Must(template.New("validation_report.md.tmpl").
+				Funcs(template.FuncMap{"join": strings.Join, "cell": markdownCell}).
+				ParseFS(reportTemplates, "templates/validation_report.md.tmpl"))
+)
+
+// defaultReportBaseURL is the Langfuse UI base URL used when a report is
+// built without a client.
+const defaultReportBaseURL = "https://cloud.langfuse.com"
+
+// ValidationReport holds the validation results of a set of traces against
+// an evaluator's requirements, for rendering as HTML or Markdown.
+type ValidationReport struct {
+	// Evaluator is the name of the evaluator the traces were validated for.
+	Evaluator string
+	// BaseURL is the Langfuse base URL used for the trace links.
+	BaseURL string
+	// Results holds one result per trace, in the order given.
+	Results []TraceValidationResult
+}
+
+// TraceValidationResult is the validation result of a single trace in a
+// ValidationReport.
+type TraceValidationResult struct {
+	TraceID       string
+	Name          string
+	Valid         bool
+	MissingFields []string
+	Warnings      []string
+	// URL links to the trace in the Langfuse UI. It is empty if the project
+	// of the trace is unknown.
+	URL string
+}
+
+// NewValidationReport validates the input and output of each trace against
+// reqs. Trace links use the base URL of the client; the project comes from
+// the trace, or from the client's WithProjectID if the trace has none. A nil
+// client links to Langfuse Cloud.
+//
+// Example:
+//
+//	trace, _ := client.Traces().Get(ctx, traceID)
+//	report := evaluation.NewValidationReport(client, []*langfuse.Trace{trace}, evaluation.RAGEvaluator)
+//	report.WriteMarkdown(os.Stdout)
+func NewValidationReport(client *langfuse.Client, traces []*langfuse.Trace, reqs EvaluatorRequirements) *ValidationReport {
+	baseURL, projectID := defaultReportBaseURL, ""
+	if client != nil {
+		cfg := client.Config()
+		if cfg.BaseURL != "" {
+			baseURL = cfg.BaseURL
+		}
+		projectID = cfg.ProjectID
+	}
+	baseURL = strings.TrimRight(baseURL, "/")
+
+	report := &ValidationReport{
+		Evaluator: reqs.Name,
+		BaseURL:   baseURL,
+		Results:   make([]TraceValidationResult, 0, len(traces)),
+	}
+	for _, trace := range traces {
+		if trace == nil {
+			continue
+		}
+		validation := ValidateDetailed(trace.Input, trace.Output, reqs)
+		result := TraceValidationResult{
+			TraceID:       trace.ID,
+			Name:          trace.Name,
+			Valid:         validation.Valid,
+			MissingFields: validation.MissingFields,
+			Warnings:      validation.Warnings,
+		}
+		if project := trace.ProjectID; project != "" || projectID != "" {
+			if project == "" {
+				project = projectID
+			}
+			result.URL = baseURL + "/project/" + url.PathEscape(project) + "/traces/" + url.PathEscape(trace.ID)
+		}
+		report.Results = append(report.Results, result)
+	}
+	return report
+}
+
+// GenerateValidationReport validates the traces against reqs and returns the
+// report as an HTML table. See NewValidationReport.
+func GenerateValidationReport(client *langfuse.Client, traces []*langfuse.Trace, reqs EvaluatorRequirements) (string, error) {
+	var b strings.Builder
+	if err := NewValidationReport(client, traces, reqs).WriteHTML(&b); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// ValidCount returns the number of valid traces in the report.
+func (r *ValidationReport) ValidCount() int {
+	count := 0
+	for _, result := range r.Results {
+		if result.Valid {
+			count++
+		}
+	}
+	return count
+}
+
+// WriteHTML writes the report to w as an HTML table.
+func (r *ValidationReport) WriteHTML(w io.Writer) error {
+	return htmlReportTemplate.Execute(w, r)
+}
+
+// WriteMarkdown writes the report to w as a Markdown table.
+func (r *ValidationReport) WriteMarkdown(w io.Writer) error {
+	return markdownReportTemplate.Execute(w, r)
+}
+
+// markdownCell escapes s for use in a Markdown table cell.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
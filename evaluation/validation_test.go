@@ -3,6 +3,8 @@ package evaluation
 import (
 	"strings"
 	"testing"
+
+	langfuse "github.com/jdziat/langfuse-go"
 )
 
 func TestValidateFor_RAG_Valid(t *testing.T) {
@@ -292,6 +294,7 @@ func TestEvaluatorRequirements(t *testing.T) {
 		RAGEvaluator,
 		QAEvaluator,
 		SummarizationEvaluator,
+		TranslationEvaluator,
 		ClassificationEvaluator,
 		ToxicityEvaluator,
 		HallucinationEvaluator,
@@ -352,3 +355,46 @@ func TestContextCorrectnessEvaluator_MissingGroundTruth(t *testing.T) {
 		t.Error("expected error for missing ground_truth")
 	}
 }
+
+func TestValidateDetailed_UsageType(t *testing.T) {
+	input := &RAGInput{Query: "q", Context: []string{"c"}}
+	tests := []struct {
+		name   string
+		output any
+		want   string
+	}{
+		{name: "no usage", output: &RAGOutput{Output: "a"}, want: ""},
+		{name: "standard usage", output: map[string]any{"output": "a", "usage": &langfuse.Usage{Input: 10, Output: 5}}, want: UsageTypeStandard},
+		{name: "extended usage", output: map[string]any{"output": "a", "usage": langfuse.Usage{Input: 10, Output: 5, ReasoningTokens: 20}}, want: UsageTypeExtended},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidateDetailed(input, tt.output, RAGEvaluator).UsageType; got != tt.want {
+				t.Errorf("UsageType = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateForSchemaVersion(t *testing.T) {
+	schemas := map[int]EvaluatorRequirements{
+		1: QAEvaluator,
+		2: RAGEvaluator,
+	}
+	input := map[string]any{
+		"schema_version": 2,
+		"data":           map[string]any{"query": "q"},
+	}
+	output := &RAGOutput{Output: "answer"}
+
+	if err := ValidateForSchemaVersion(input, output, 1, schemas); err != nil {
+		t.Errorf("version 1 should only require a query and output, got: %v", err)
+	}
+	if err := ValidateForSchemaVersion(input, output, 2, schemas); err == nil {
+		t.Error("version 2 should require context")
+	}
+	if err := ValidateForSchemaVersion(input, output, 3, schemas); err == nil {
+		t.Error("expected error for an unknown schema version")
+	}
+}
@@ -129,7 +129,37 @@ building scalable network services and distributed systems.`
 	}
 
 	// ============================================================
-	// Example 4: Classification Trace
+	// Example 4: Translation Trace
+	// ============================================================
+	fmt.Println("\n=== Translation Trace Example ===")
+
+	translationTrace, err := evaluation.NewTranslationTrace(client, "fr-en-translator").
+		SourceText("Go est un langage de programmation conçu chez Google.").
+		SourceLanguage("fr").
+		TargetLanguage("en").
+		GroundTruth("Go is a programming language designed at Google.").
+		UserID("user-789").
+		Create(ctx)
+	if err != nil {
+		log.Fatalf("Failed to create translation trace: %v", err)
+	}
+	fmt.Printf("Created Translation trace: %s\n", translationTrace.ID())
+
+	// Update with the translated text
+	err = translationTrace.UpdateOutput(ctx, "Go is a programming language designed at Google.")
+	if err != nil {
+		log.Printf("Failed to update translation output: %v", err)
+	}
+
+	// Validate
+	if err := translationTrace.ValidateForEvaluation(); err != nil {
+		log.Printf("Translation trace validation failed: %v", err)
+	} else {
+		fmt.Println("Translation trace is ready for evaluation!")
+	}
+
+	// ============================================================
+	// Example 5: Classification Trace
 	// ============================================================
 	fmt.Println("\n=== Classification Trace Example ===")
 
@@ -163,7 +193,7 @@ building scalable network services and distributed systems.`
 	}
 
 	// ============================================================
-	// Example 5: Using Validation Utilities Directly
+	// Example 6: Using Validation Utilities Directly
 	// ============================================================
 	fmt.Println("\n=== Direct Validation Example ===")
 
@@ -125,7 +125,7 @@ func ragWorkflowExample() {
 	fmt.Printf("Retrieved %d documents\n", len(docs))
 
 	// Simulate generation - automatically creates an evaluation-ready generation
-	response, err := rag.Generate(ctx, "gpt-4", func(query string, context []string) (string, int, int, error) {
+	response, err := rag.Generate(ctx, "gpt-4", langfuse.ProviderOpenAI, func(query string, context []string) (string, int, int, error) {
 		// In real code, this would call your LLM
 		return "Dependency injection in Go is typically implemented by passing dependencies as constructor parameters...",
 			150, // input tokens
@@ -5,7 +5,10 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"net/http/httptest"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -740,3 +743,69 @@ func TestClassifiedHookChain_WithMetrics(t *testing.T) {
 		t.Error("Hook failure should be counted in metrics")
 	}
 }
+
+// roundTripperFunc adapts a function to http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestWithHTTPMiddleware(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("X-Inner") != "outer" {
+			t.Errorf("X-Inner = %q, want the outer middleware to run first", r.Header.Get("X-Inner"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"OK"}`))
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var order []string
+	middleware := func(name string) func(http.RoundTripper) http.RoundTripper {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				mu.Lock()
+				order = append(order, name)
+				mu.Unlock()
+				if name == "outer" {
+					req = req.Clone(req.Context())
+					req.Header.Set("X-Outer", "set")
+				} else if req.Header.Get("X-Outer") == "set" {
+					req = req.Clone(req.Context())
+					req.Header.Set("X-Inner", "outer")
+				}
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	httpClient := &http.Client{Timeout: 5 * time.Second}
+	client, err := New("pk-lf-test-key", "sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithHTTPClient(httpClient),
+		WithHTTPMiddleware(middleware("outer"), middleware("inner")),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	if _, err := client.Health(context.Background()); err != nil {
+		t.Fatalf("Health failed: %v", err)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("server received %d requests, want 1", requests.Load())
+	}
+	mu.Lock()
+	if strings.Join(order, ",") != "outer,inner" {
+		t.Errorf("middleware order = %v, want outer,inner", order)
+	}
+	mu.Unlock()
+	if httpClient.Transport != nil {
+		t.Error("WithHTTPMiddleware should not modify the HTTP client passed with WithHTTPClient")
+	}
+}
@@ -3,8 +3,13 @@ package langfuse
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -744,3 +749,1913 @@ func TestScoreBuilderValidation(t *testing.T) {
 		t.Errorf("Expected field 'name', got %s", validErr.Field)
 	}
 }
+
+func TestGenerationContextForkForParallelCalls(t *testing.T) {
+	var mu sync.Mutex
+	var events []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Batch []map[string]any `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		events = append(events, req.Batch...)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	client, err := New(
+		"pk-lf-test-key",
+		"sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	trace, _ := client.NewTrace().Name("test").Create(ctx)
+	span, _ := trace.NewSpan().Name("parent").Create(ctx)
+	gen, _ := span.NewGeneration().
+		Name("tool-calls").
+		Model("gpt-4").
+		PromptName("agent").
+		PromptVersion(3).
+		Create(ctx)
+
+	if _, err := gen.ForkForParallelCalls(ctx, 0); err == nil {
+		t.Error("ForkForParallelCalls(0) should return an error")
+	}
+
+	forks, err := gen.ForkForParallelCalls(ctx, 3)
+	if err != nil {
+		t.Fatalf("ForkForParallelCalls failed: %v", err)
+	}
+	if len(forks) != 3 {
+		t.Fatalf("len(forks) = %d, want 3", len(forks))
+	}
+	for i, f := range forks {
+		if f.TraceID() != trace.ID() {
+			t.Errorf("forks[%d].TraceID() = %s, want %s", i, f.TraceID(), trace.ID())
+		}
+		if f.ID() == gen.ID() {
+			t.Errorf("forks[%d] reuses the original generation ID", i)
+		}
+		if err := f.EndWithUsage(ctx, "out", 10*(i+1), 5); err != nil {
+			t.Fatalf("EndWithUsage failed: %v", err)
+		}
+	}
+
+	if err := trace.MergeGenerations(ctx, forks, "combined"); err != nil {
+		t.Fatalf("MergeGenerations failed: %v", err)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	forkIDs := map[string]bool{}
+	for _, f := range forks {
+		forkIDs[f.ID()] = true
+	}
+	var created int
+	var merged map[string]any
+	for _, e := range events {
+		body, _ := e["body"].(map[string]any)
+		switch e["type"] {
+		case eventTypeGenerationCreate:
+			if !forkIDs[body["id"].(string)] {
+				continue
+			}
+			created++
+			if body["parentObservationId"] != span.ID() {
+				t.Errorf("parentObservationId = %v, want %s", body["parentObservationId"], span.ID())
+			}
+			if body["model"] != "gpt-4" || body["promptName"] != "agent" || body["promptVersion"] != float64(3) {
+				t.Errorf("fork not configured like the original: %v", body)
+			}
+		case eventTypeTraceCreate:
+			if body["output"] == "combined" {
+				merged = body
+			}
+		}
+	}
+	if created != 3 {
+		t.Errorf("created %d forked generations, want 3", created)
+	}
+	if merged == nil {
+		t.Fatal("merged trace update not sent")
+	}
+	usage := merged["metadata"].(map[string]any)["merged_usage"].(map[string]any)
+	if usage["input"] != float64(60) || usage["output"] != float64(15) || usage["total"] != float64(75) {
+		t.Errorf("merged_usage = %v, want input=60 output=15 total=75", usage)
+	}
+}
+
+func TestTraceContextMergeGenerationsRejectsForeignTrace(t *testing.T) {
+	client, err := New(
+		"pk-lf-test-key",
+		"sk-lf-test-key",
+		WithBaseURL("http://localhost:1"),
+		WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	trace1, _ := client.NewTrace().Name("one").Create(ctx)
+	trace2, _ := client.NewTrace().Name("two").Create(ctx)
+	gen, _ := trace2.NewGeneration().Name("gen").Create(ctx)
+
+	if err := trace1.MergeGenerations(ctx, []*GenerationContext{gen}, "out"); err == nil {
+		t.Error("MergeGenerations should reject generations from another trace")
+	}
+}
+
+func TestTraceContextSetReleaseAndVersion(t *testing.T) {
+	var mu sync.Mutex
+	var events []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Batch []map[string]any `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		events = append(events, req.Batch...)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	client, err := New(
+		"pk-lf-test-key",
+		"sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	trace, _ := client.NewTrace().Name("canary").Create(ctx)
+
+	if err := trace.SetRelease(ctx, ""); err == nil {
+		t.Error("SetRelease with empty release should return an error")
+	}
+	if err := trace.SetRelease(ctx, strings.Repeat("r", MaxReleaseLength+1)); err == nil {
+		t.Error("SetRelease with an overlong release should return an error")
+	}
+	if err := trace.SetRelease(ctx, "v2.3.0-canary"); err != nil {
+		t.Fatalf("SetRelease failed: %v", err)
+	}
+	if err := trace.SetVersion(ctx, "2"); err != nil {
+		t.Fatalf("SetVersion failed: %v", err)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(events) != 3 {
+		t.Fatalf("received %d events, want 3", len(events))
+	}
+	release, _ := events[1]["body"].(map[string]any)
+	if release["id"] != trace.ID() || release["release"] != "v2.3.0-canary" {
+		t.Errorf("release update body = %v", release)
+	}
+	if _, ok := release["name"]; ok {
+		t.Errorf("release update should be partial, got name in %v", release)
+	}
+	version, _ := events[2]["body"].(map[string]any)
+	if version["version"] != "2" {
+		t.Errorf("version update body = %v", version)
+	}
+	if _, ok := version["release"]; ok {
+		t.Errorf("version update should not include release, got %v", version)
+	}
+}
+
+func TestGenerationOpenAIRequestAndResponse(t *testing.T) {
+	var mu sync.Mutex
+	var events []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Batch []map[string]any `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		events = append(events, req.Batch...)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	client, err := New(
+		"pk-lf-test-key",
+		"sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	// Mirrors the JSON shape of the OpenAI SDK request and response types.
+	type message struct {
+		Role    string `json:"role"`
+		Content string `json:"content"`
+	}
+	request := struct {
+		Model       string    `json:"model"`
+		Messages    []message `json:"messages"`
+		MaxTokens   int       `json:"max_tokens"`
+		Temperature float64   `json:"temperature"`
+		User        string    `json:"user"`
+	}{
+		Model:       "gpt-4o",
+		Messages:    []message{{Role: "user", Content: "Hello"}},
+		MaxTokens:   256,
+		Temperature: 0.2,
+		User:        "u-1",
+	}
+	response := map[string]any{
+		"choices": []map[string]any{
+			{"message": map[string]any{"role": "assistant", "content": "Hi there"}},
+		},
+		"usage": map[string]any{"prompt_tokens": 12, "completion_tokens": 3, "total_tokens": 15},
+	}
+
+	ctx := context.Background()
+	trace, _ := client.NewTrace().Name("chat").Create(ctx)
+	gen, err := trace.NewGeneration().
+		Name("chat").
+		ModelParameters(Metadata{"top_p": 0.9}).
+		OpenAIRequest(request).
+		Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := gen.OpenAIResponse(ctx, response); err != nil {
+		t.Fatalf("OpenAIResponse failed: %v", err)
+	}
+	if err := gen.OpenAIResponse(ctx, make(chan int)); err == nil {
+		t.Error("OpenAIResponse should fail for a value that cannot be marshalled")
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	var created, updated map[string]any
+	for _, e := range events {
+		body, _ := e["body"].(map[string]any)
+		switch e["type"] {
+		case eventTypeGenerationCreate:
+			created = body
+		case eventTypeGenerationUpdate:
+			updated = body
+		}
+	}
+	if created == nil || updated == nil {
+		t.Fatalf("missing generation events: %v", events)
+	}
+
+	if created["model"] != "gpt-4o" {
+		t.Errorf("model = %v, want gpt-4o", created["model"])
+	}
+	input, _ := created["input"].([]any)
+	if len(input) != 1 || input[0].(map[string]any)["content"] != "Hello" {
+		t.Errorf("input = %v, want the request messages", created["input"])
+	}
+	params, _ := created["modelParameters"].(map[string]any)
+	if params["max_tokens"] != float64(256) || params["temperature"] != 0.2 || params["top_p"] != 0.9 {
+		t.Errorf("modelParameters = %v", params)
+	}
+
+	if updated["output"] != "Hi there" {
+		t.Errorf("output = %v, want Hi there", updated["output"])
+	}
+	usage, _ := updated["usage"].(map[string]any)
+	if usage["input"] != float64(12) || usage["output"] != float64(3) || usage["total"] != float64(15) {
+		t.Errorf("usage = %v, want input=12 output=3 total=15", usage)
+	}
+}
+
+func TestWithCompressMetadata(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Batch []struct {
+				Body map[string]any `json:"body"`
+			} `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		for _, e := range req.Batch {
+			bodies = append(bodies, e.Body)
+		}
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	client, err := New(
+		"pk-lf-test-key",
+		"sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(1*time.Hour),
+		WithCompressMetadata(1024),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	large := map[string]any{"document": strings.Repeat("lorem ipsum dolor sit amet ", 200)}
+
+	ctx := context.Background()
+	builder := client.NewTrace().Name("compress").Input(large).Output("short")
+	trace, err := builder.Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := trace.NewSpan().Name("span").Output(large).Create(ctx); err != nil {
+		t.Fatalf("span Create failed: %v", err)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(bodies) != 2 {
+		t.Fatalf("received %d events, want 2", len(bodies))
+	}
+
+	input, _ := bodies[0]["input"].(map[string]any)
+	if input["_compressed"] != true {
+		t.Fatalf("trace input not compressed: %v", bodies[0]["input"])
+	}
+	if input["compressedSize"].(float64) >= input["originalSize"].(float64) {
+		t.Errorf("compressedSize %v should be smaller than originalSize %v", input["compressedSize"], input["originalSize"])
+	}
+	if bodies[0]["output"] != "short" {
+		t.Errorf("small output should be sent as is, got %v", bodies[0]["output"])
+	}
+	if out, _ := bodies[1]["output"].(map[string]any); out["_compressed"] != true {
+		t.Errorf("span output not compressed: %v", bodies[1]["output"])
+	}
+
+	restored, err := DecompressField(bodies[0]["input"])
+	if err != nil {
+		t.Fatalf("DecompressField failed: %v", err)
+	}
+	if restored.(map[string]any)["document"] != large["document"] {
+		t.Error("decompressed input does not match the original")
+	}
+
+	// The builder's own body must not be replaced by the compressed form.
+	if _, ok := builder.trace.Input.(map[string]any); !ok {
+		t.Errorf("builder input was modified: %T", builder.trace.Input)
+	}
+}
+
+func TestDecompressFieldPassthrough(t *testing.T) {
+	for _, v := range []any{nil, "text", 42.0, map[string]any{"key": "value"}} {
+		got, err := DecompressField(v)
+		if err != nil {
+			t.Errorf("DecompressField(%v) returned error: %v", v, err)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(v) {
+			t.Errorf("DecompressField(%v) = %v, want unchanged", v, got)
+		}
+	}
+
+	if _, err := DecompressField(map[string]any{"_compressed": true, "data": "!!not-base64!!"}); err == nil {
+		t.Error("expected error for invalid compressed data")
+	}
+}
+
+func TestWithMaxMetadataSize(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Batch []struct {
+				Body map[string]any `json:"body"`
+			} `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		for _, e := range req.Batch {
+			bodies = append(bodies, e.Body)
+		}
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	logger := &formattingLogger{}
+	client, err := New(
+		"pk-lf-test-key",
+		"sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(1*time.Hour),
+		WithLogger(logger),
+		WithMaxMetadataSize(64),
+		WithMaxOutputSize(256),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	large := strings.Repeat("x", 200)
+	metadata := Metadata{"prompt": large, "model": "gpt-4o"}
+
+	ctx := context.Background()
+	trace, err := client.NewTrace().Name("truncate").Input(large).Output(large).Metadata(metadata).Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := trace.NewScore().Name("quality").NumericValue(1).Metadata(Metadata{"notes": large}).Create(ctx); err != nil {
+		t.Fatalf("score Create failed: %v", err)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(bodies) != 2 {
+		t.Fatalf("received %d events, want 2", len(bodies))
+	}
+
+	input, _ := bodies[0]["input"].(map[string]any)
+	if input["_truncated"] != true || input["originalSize"] != float64(202) {
+		t.Fatalf("trace input not truncated: %v", bodies[0]["input"])
+	}
+	if preview := input["preview"].(string); len(preview) != 64 {
+		t.Errorf("preview length = %d, want 64", len(preview))
+	}
+	if bodies[0]["output"] != large {
+		t.Errorf("output under WithMaxOutputSize should be sent as is, got %v", bodies[0]["output"])
+	}
+
+	meta, _ := bodies[0]["metadata"].(map[string]any)
+	if prompt, _ := meta["prompt"].(map[string]any); prompt["_truncated"] != true {
+		t.Errorf("metadata value not truncated: %v", meta["prompt"])
+	}
+	if meta["model"] != "gpt-4o" {
+		t.Errorf("small metadata value should be sent as is, got %v", meta["model"])
+	}
+	if _, ok := metadata["prompt"].(string); !ok {
+		t.Error("caller metadata was modified")
+	}
+
+	scoreMeta, _ := bodies[1]["metadata"].(map[string]any)
+	if notes, _ := scoreMeta["notes"].(map[string]any); notes["_truncated"] != true {
+		t.Errorf("score metadata not truncated: %v", scoreMeta["notes"])
+	}
+
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+	var logged bool
+	for _, m := range logger.messages {
+		if strings.Contains(m, "truncated metadata.prompt from 202 to 64 bytes") {
+			logged = true
+		}
+	}
+	if !logged {
+		t.Errorf("truncation not logged: %v", logger.messages)
+	}
+}
+
+func TestTruncateFieldPreviewRuneBoundary(t *testing.T) {
+	client := &Client{rootConfig: &Config{}}
+	got, ok := client.truncateField("input", strings.Repeat("é", 10), 4).(*truncatedField)
+	if !ok {
+		t.Fatal("expected value to be truncated")
+	}
+	// `"é` is 3 bytes; the 4th byte would split the second rune.
+	if got.Preview != `"é` {
+		t.Errorf("preview = %q, want %q", got.Preview, `"é`)
+	}
+}
+
+func TestSpanContextRecordPanic(t *testing.T) {
+	var mu sync.Mutex
+	var events []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Batch []map[string]any `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		events = append(events, req.Batch...)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	client, err := New("pk-lf-test-key", "sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	trace, err := client.NewTrace().Name("panics").Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	run := func(value any) error {
+		span, err := trace.NewSpan().Name("work").Create(ctx)
+		if err != nil {
+			t.Fatalf("span Create failed: %v", err)
+		}
+		var recordErr error
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					recordErr = span.RecordPanic(ctx, r)
+				}
+			}()
+			panic(value)
+		}()
+		return recordErr
+	}
+
+	if err := run("boom"); err != nil {
+		t.Fatalf("RecordPanic failed: %v", err)
+	}
+	if err := run(errors.New("bad state")); err != nil {
+		t.Fatalf("RecordPanic failed: %v", err)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	var statuses []string
+	var ended int
+	for _, event := range events {
+		if event["type"] != "span-update" {
+			continue
+		}
+		body := event["body"].(map[string]any)
+		if msg, ok := body["statusMessage"].(string); ok {
+			if body["level"] != "ERROR" {
+				t.Errorf("level = %v, want ERROR", body["level"])
+			}
+			statuses = append(statuses, msg)
+		}
+		if body["endTime"] != nil {
+			ended++
+			metadata, _ := body["metadata"].(map[string]any)
+			stack, _ := metadata["panic_stack"].(string)
+			if !strings.Contains(stack, "TestSpanContextRecordPanic") {
+				t.Errorf("panic_stack = %q, want the panicking goroutine's stack", stack)
+			}
+		}
+	}
+	if strings.Join(statuses, "|") != "panic: boom|panic: bad state" {
+		t.Errorf("status messages = %q", statuses)
+	}
+	if ended != 2 {
+		t.Errorf("ended %d spans, want 2", ended)
+	}
+}
+
+func TestSpanContextEnrichFromError(t *testing.T) {
+	var mu sync.Mutex
+	var events []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Batch []map[string]any `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		events = append(events, req.Batch...)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	client, err := New("pk-lf-test-key", "sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(1*time.Hour),
+		WithObservationLevelMapping(func(error) ObservationLevel { return ObservationLevelWarning }),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	trace, err := client.NewTrace().Name("enrich").Tags([]string{"api"}).Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	span, err := trace.NewSpan().Name("call").Create(ctx)
+	if err != nil {
+		t.Fatalf("span Create failed: %v", err)
+	}
+	orphan, err := client.NewOrphanSpan().TraceID("external-trace").Name("orphan").Create(ctx)
+	if err != nil {
+		t.Fatalf("orphan span Create failed: %v", err)
+	}
+
+	if err := span.EnrichFromError(ctx, nil); err != nil {
+		t.Errorf("EnrichFromError(nil) = %v, want nil", err)
+	}
+
+	apiErr := &APIError{StatusCode: 429, Message: "slow down", RequestID: "req-123"}
+	if err := span.EnrichFromError(ctx, fmt.Errorf("list prompts: %w", apiErr)); err != nil {
+		t.Fatalf("EnrichFromError failed: %v", err)
+	}
+	if err := span.EnrichFromError(ctx, ErrClientClosed); err != nil {
+		t.Fatalf("second EnrichFromError failed: %v", err)
+	}
+	if err := orphan.EnrichFromError(ctx, apiErr); err != nil {
+		t.Fatalf("EnrichFromError on orphan span failed: %v", err)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	// trace-create, two span-creates, then one span-update per error; nil
+	// adds nothing and the trace is never updated.
+	if len(events) != 6 {
+		t.Fatalf("received %d events, want 6", len(events))
+	}
+	for _, event := range events[1:3] {
+		if event["type"] != "span-create" {
+			t.Fatalf("unexpected event: %v", event)
+		}
+	}
+	updates := events[3:]
+	for _, event := range updates {
+		body := event["body"].(map[string]any)
+		if event["type"] != "span-update" || body["level"] != "WARNING" {
+			t.Fatalf("unexpected span update: %v", event)
+		}
+	}
+
+	first := updates[0]["body"].(map[string]any)
+	if !strings.Contains(first["statusMessage"].(string), "slow down") {
+		t.Errorf("statusMessage = %v", first["statusMessage"])
+	}
+	metadata := first["metadata"].(map[string]any)
+	if metadata["error_code"] != string(apiErr.Code()) ||
+		metadata["request_id"] != "req-123" ||
+		metadata["retryable"] != true ||
+		metadata["status_code"] != float64(429) {
+		t.Errorf("unexpected metadata: %v", metadata)
+	}
+
+	second := updates[1]["body"].(map[string]any)
+	if metadata := second["metadata"].(map[string]any); metadata["error_code"] != string(ErrCodeShutdown) {
+		t.Errorf("second update metadata = %v, want error_code %s", metadata, ErrCodeShutdown)
+	}
+
+	orphanUpdate := updates[2]["body"].(map[string]any)
+	if orphanUpdate["id"] != orphan.ID() || orphanUpdate["traceId"] != "external-trace" {
+		t.Errorf("orphan update = %v", orphanUpdate)
+	}
+	if metadata := orphanUpdate["metadata"].(map[string]any); metadata["error_code"] != string(apiErr.Code()) {
+		t.Errorf("orphan update metadata = %v", metadata)
+	}
+}
+
+func TestSpanTimerLaps(t *testing.T) {
+	timer := newSpanTimer(time.Now())
+
+	var cumulative []time.Duration
+	var total time.Duration
+	for _, name := range []string{"a", "b", "c", "d"} {
+		time.Sleep(time.Millisecond)
+		lap := timer.Lap(name)
+		if lap <= 0 {
+			t.Errorf("Lap(%q) = %v, want positive", name, lap)
+		}
+		total += lap
+		cumulative = append(cumulative, total)
+	}
+	for i := 1; i < len(cumulative); i++ {
+		if cumulative[i] < cumulative[i-1] {
+			t.Errorf("cumulative lap time decreased: %v -> %v", cumulative[i-1], cumulative[i])
+		}
+	}
+	if total > timer.Elapsed() {
+		t.Errorf("sum of laps %v exceeds elapsed %v", total, timer.Elapsed())
+	}
+
+	laps := timer.Finish()
+	if len(laps) != 4 {
+		t.Errorf("Finish() returned %d laps, want 4", len(laps))
+	}
+
+	// Concurrent laps must not race.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			timer.Lap("parallel")
+		}()
+	}
+	wg.Wait()
+	if _, ok := timer.Finish()["parallel"]; !ok {
+		t.Error("parallel lap not recorded")
+	}
+}
+
+func TestSpanContextEndRecordsTiming(t *testing.T) {
+	var mu sync.Mutex
+	var events []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Batch []map[string]any `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		events = append(events, req.Batch...)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	client, err := New(
+		"pk-lf-test-key",
+		"sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	trace, _ := client.NewTrace().Name("timed").Create(ctx)
+	timed, _ := trace.NewSpan().Name("timed").Create(ctx)
+	untimed, _ := trace.NewSpan().Name("untimed").Create(ctx)
+
+	if timed.Timer() != timed.Timer() {
+		t.Error("Timer() should return the same timer on every call")
+	}
+	timed.Timer().Lap("retrieve")
+	timed.Timer().Lap("generate")
+
+	if err := timed.End(ctx); err != nil {
+		t.Fatalf("End failed: %v", err)
+	}
+	if err := untimed.End(ctx); err != nil {
+		t.Fatalf("End failed: %v", err)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	var checked int
+	for _, e := range events {
+		if e["type"] != eventTypeSpanUpdate {
+			continue
+		}
+		body := e["body"].(map[string]any)
+		metadata, _ := body["metadata"].(map[string]any)
+		switch body["id"] {
+		case timed.ID():
+			timing, _ := metadata["timing"].(map[string]any)
+			if _, ok := timing["retrieve"]; !ok {
+				t.Errorf("timing missing retrieve lap: %v", metadata)
+			}
+			if _, ok := timing["generate"]; !ok {
+				t.Errorf("timing missing generate lap: %v", metadata)
+			}
+			checked++
+		case untimed.ID():
+			if _, ok := metadata["timing"]; ok {
+				t.Errorf("span without laps should not record timing: %v", metadata)
+			}
+			checked++
+		}
+	}
+	if checked != 2 {
+		t.Errorf("checked %d span updates, want 2", checked)
+	}
+}
+
+func TestTraceBuilderFromContext(t *testing.T) {
+	var mu sync.Mutex
+	var events []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Batch []map[string]any `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		events = append(events, req.Batch...)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	client, err := New(
+		"pk-lf-test-key",
+		"sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(1*time.Hour),
+		WithDefaultRelease("v1.2.3"),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	trace, err := client.NewTrace().
+		Name("request").
+		UserID("user-1").
+		SessionID("session-1").
+		Tags([]string{"api"}).
+		Environment("staging").
+		Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	ctx = ContextWithTrace(ctx, trace)
+
+	continued, err := client.NewTrace().FromContext(ctx).Output("done").Create(ctx)
+	if err != nil {
+		t.Fatalf("Create from context failed: %v", err)
+	}
+	if continued.ID() != trace.ID() {
+		t.Errorf("continued trace ID = %q, want %q", continued.ID(), trace.ID())
+	}
+
+	if _, err := client.NewTrace().FromContext(context.Background()).Name("fresh").Create(ctx); err != nil {
+		t.Fatalf("Create without trace in context failed: %v", err)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(events) != 3 {
+		t.Fatalf("received %d events, want 3", len(events))
+	}
+
+	update := events[1]
+	if update["type"] != EventTypeTraceCreateForTest {
+		t.Errorf("event type = %v, want %s", update["type"], EventTypeTraceCreateForTest)
+	}
+	body := update["body"].(map[string]any)
+	if body["id"] != trace.ID() {
+		t.Errorf("body id = %v, want %s", body["id"], trace.ID())
+	}
+	if body["userId"] != "user-1" || body["sessionId"] != "session-1" || body["environment"] != "staging" {
+		t.Errorf("body did not carry trace settings: %v", body)
+	}
+	if tags, _ := body["tags"].([]any); len(tags) != 1 || tags[0] != "api" {
+		t.Errorf("tags = %v, want [api]", body["tags"])
+	}
+	for _, field := range []string{"timestamp", "release"} {
+		if _, ok := body[field]; ok {
+			t.Errorf("continuation sent default %s, which would overwrite the original trace: %v", field, body)
+		}
+	}
+
+	fresh := events[2]
+	if fresh["type"] != EventTypeTraceCreateForTest {
+		t.Errorf("event type = %v, want %s", fresh["type"], EventTypeTraceCreateForTest)
+	}
+	if fresh["body"].(map[string]any)["id"] == trace.ID() {
+		t.Error("builder without trace in context reused the existing trace ID")
+	}
+}
+
+func TestGenerationToolCalls(t *testing.T) {
+	var mu sync.Mutex
+	var events []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Batch []map[string]any `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		events = append(events, req.Batch...)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	client, err := New(
+		"pk-lf-test-key",
+		"sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	trace, _ := client.NewTrace().Name("agent").Create(ctx)
+	gen, err := trace.NewGeneration().
+		Name("step").
+		ExpectedTools([]ToolDefinition{{Name: "get_weather", Description: "Current weather"}}).
+		Metadata(Metadata{"step": 1}).
+		Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	calls := []ToolCall{{ID: "call_1", Name: "get_weather", Arguments: `{"city":"Paris"}`, Result: `{"temp_c":18}`}}
+	if err := gen.EndWithToolCalls(ctx, calls, 40, 12); err != nil {
+		t.Fatalf("EndWithToolCalls failed: %v", err)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(events) != 3 {
+		t.Fatalf("received %d events, want 3", len(events))
+	}
+
+	metadata := events[1]["body"].(map[string]any)["metadata"].(map[string]any)
+	tools, _ := metadata[ToolsMetadataKey].([]any)
+	if len(tools) != 1 || tools[0].(map[string]any)["name"] != "get_weather" {
+		t.Errorf("tools metadata = %v", metadata[ToolsMetadataKey])
+	}
+	if metadata["step"] != float64(1) {
+		t.Errorf("user metadata not preserved: %v", metadata)
+	}
+
+	update := events[2]["body"].(map[string]any)
+	output := update["output"].(map[string]any)
+	if output["text"] != "" {
+		t.Errorf("output text = %v, want empty string", output["text"])
+	}
+	toolCalls, _ := output["tool_calls"].([]any)
+	if len(toolCalls) != 1 || toolCalls[0].(map[string]any)["arguments"] != `{"city":"Paris"}` {
+		t.Errorf("output tool_calls = %v", output["tool_calls"])
+	}
+	if update["usage"] == nil {
+		t.Error("usage was not recorded")
+	}
+}
+
+func TestGenerationBuilderLLMProvider(t *testing.T) {
+	var mu sync.Mutex
+	var events []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Batch []map[string]any `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		events = append(events, req.Batch...)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	client, err := New("pk-lf-test-key", "sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	trace, _ := client.NewTrace().Name("chat").Create(ctx)
+	template := trace.NewGeneration().
+		Name("completion").
+		LLMProvider(ProviderAnthropic).
+		Metadata(Metadata{"step": 1})
+	if _, err := template.Create(ctx); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := template.Clone().Create(ctx); err != nil {
+		t.Fatalf("Create clone failed: %v", err)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	var generations int
+	for _, event := range events {
+		if event["type"] != "generation-create" {
+			continue
+		}
+		generations++
+		metadata := event["body"].(map[string]any)["metadata"].(map[string]any)
+		if metadata[LLMProviderMetadataKey] != "anthropic" || metadata["step"] != float64(1) {
+			t.Errorf("metadata = %v, want llm_provider and user metadata", metadata)
+		}
+	}
+	if generations != 2 {
+		t.Errorf("received %d generations, want 2", generations)
+	}
+}
+
+func TestGenerationEndWithExtendedUsage(t *testing.T) {
+	var mu sync.Mutex
+	var events []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Batch []map[string]any `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		events = append(events, req.Batch...)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	client, err := New("pk-lf-test-key", "sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	trace, _ := client.NewTrace().Name("reasoning").Create(ctx)
+	gen, _ := trace.NewGeneration().Name("o1").Model("o1").Create(ctx)
+	if err := gen.EndWithExtendedUsage(ctx, "42", 1000, 200, 800, 600); err != nil {
+		t.Fatalf("EndWithExtendedUsage failed: %v", err)
+	}
+	if u := gen.TrackedUsage(); u == nil || u.ReasoningTokens != 800 || u.CachedInputTokens != 600 {
+		t.Errorf("TrackedUsage() = %+v, want reasoning and cached tokens", u)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	var details map[string]any
+	for _, event := range events {
+		if event["type"] == "generation-update" {
+			details, _ = event["body"].(map[string]any)["usageDetails"].(map[string]any)
+		}
+	}
+	want := map[string]float64{
+		UsageDetailsInput:           1000,
+		UsageDetailsOutput:          200,
+		UsageDetailsTotal:           1200,
+		UsageDetailsReasoningTokens: 800,
+		UsageDetailsCachedTokens:    600,
+	}
+	if len(details) != len(want) {
+		t.Fatalf("usageDetails = %v, want %v", details, want)
+	}
+	for k, v := range want {
+		if details[k] != v {
+			t.Errorf("usageDetails[%q] = %v, want %v", k, details[k], v)
+		}
+	}
+}
+
+func TestUsageEffectiveInputCost(t *testing.T) {
+	u := &Usage{Input: 1000, CachedInputTokens: 600}
+	if got := u.EffectiveInputCost(0.001, 0.0005); math.Abs(got-0.7) > 1e-9 {
+		t.Errorf("EffectiveInputCost() = %v, want 0.7", got)
+	}
+	if (&Usage{Input: 10, Output: 5}).Details() != nil {
+		t.Error("Details() should be nil without extended usage")
+	}
+}
+
+func TestCheckpointTrace(t *testing.T) {
+	var mu sync.Mutex
+	var events []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Batch []map[string]any `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		events = append(events, req.Batch...)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	client, err := New("pk-lf-test-key", "sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	trace, err := client.NewTrace().Name("pipeline").WithCheckpoints().Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	if err := trace.Checkpoint(ctx, "parsed"); err != nil {
+		t.Fatalf("Checkpoint failed: %v", err)
+	}
+	span, done := trace.CheckpointSpan(ctx, "embed")
+	if span == nil {
+		t.Fatal("CheckpointSpan returned a nil span")
+	}
+	time.Sleep(5 * time.Millisecond)
+	done()
+	done()
+
+	summary := trace.Summary()
+	if len(summary) != 2 || summary[0].Stage != "parsed" || summary[1].Stage != "embed" {
+		t.Fatalf("Summary() = %+v, want parsed then embed", summary)
+	}
+	if summary[0].Elapsed < 5*time.Millisecond || summary[0].Duration != 0 {
+		t.Errorf("parsed checkpoint = %+v", summary[0])
+	}
+	if summary[1].Elapsed < summary[0].Elapsed || summary[1].Duration < 5*time.Millisecond {
+		t.Errorf("embed checkpoint = %+v", summary[1])
+	}
+
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	var types []string
+	for _, event := range events {
+		types = append(types, event["type"].(string))
+		if event["type"] != "event-create" {
+			continue
+		}
+		body := event["body"].(map[string]any)
+		metadata := body["metadata"].(map[string]any)
+		if metadata["stage"] != body["name"] || metadata["elapsed_ms"] == nil {
+			t.Errorf("checkpoint event = %v", body)
+		}
+		if _, ok := metadata["duration_ms"]; ok != (body["name"] == "embed") {
+			t.Errorf("duration_ms should only be recorded for the span checkpoint: %v", metadata)
+		}
+	}
+	want := "trace-create,event-create,span-create,span-update,event-create"
+	if got := strings.Join(types, ","); got != want {
+		t.Errorf("events = %s, want %s", got, want)
+	}
+}
+
+func TestToolCallParseArguments(t *testing.T) {
+	call := ToolCall{Name: "get_weather", Arguments: `{"city":"Paris","days":3}`}
+
+	var args struct {
+		City string `json:"city"`
+		Days int    `json:"days"`
+	}
+	if err := call.ParseArguments(&args); err != nil {
+		t.Fatalf("ParseArguments failed: %v", err)
+	}
+	if args.City != "Paris" || args.Days != 3 {
+		t.Errorf("args = %+v", args)
+	}
+
+	bad := ToolCall{Name: "get_weather", Arguments: `{"city":`}
+	if err := bad.ParseArguments(&args); err == nil || !strings.Contains(err.Error(), "get_weather") {
+		t.Errorf("expected error naming the tool, got %v", err)
+	}
+}
+
+func TestNewOrphanSpan(t *testing.T) {
+	var mu sync.Mutex
+	var events []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Batch []map[string]any `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		events = append(events, req.Batch...)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	client, err := New("pk-lf-test-key", "sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	if _, err := client.NewOrphanSpan().Name("no-trace").Create(ctx); err == nil {
+		t.Error("expected error for orphan span without trace ID")
+	}
+
+	span, err := client.NewOrphanSpan().TraceID("upstream-trace").Name("downstream").Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if span.TraceID() != "upstream-trace" {
+		t.Errorf("TraceID() = %q, want upstream-trace", span.TraceID())
+	}
+	if _, err := span.NewGeneration().Name("llm").Create(ctx); err != nil {
+		t.Fatalf("child generation Create failed: %v", err)
+	}
+
+	// TraceID also moves a span built from another trace.
+	trace, err := client.NewTrace().Name("local").Create(ctx)
+	if err != nil {
+		t.Fatalf("trace Create failed: %v", err)
+	}
+	moved, err := trace.NewSpan().TraceID("other-trace").Name("moved").Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if moved.TraceID() != "other-trace" || trace.TraceID() == "other-trace" {
+		t.Errorf("moved span trace = %q, trace = %q", moved.TraceID(), trace.TraceID())
+	}
+
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	// span-create, generation-create, trace-create, span-create
+	if len(events) != 4 {
+		t.Fatalf("received %d events, want 4", len(events))
+	}
+	body := events[0]["body"].(map[string]any)
+	if events[0]["type"] != "span-create" || body["traceId"] != "upstream-trace" || body["name"] != "downstream" {
+		t.Errorf("unexpected orphan span event: %v", events[0])
+	}
+	gen := events[1]["body"].(map[string]any)
+	if gen["traceId"] != "upstream-trace" || gen["parentObservationId"] != span.ID() {
+		t.Errorf("child generation not attached to orphan span: %v", gen)
+	}
+	if body := events[3]["body"].(map[string]any); body["traceId"] != "other-trace" {
+		t.Errorf("moved span traceId = %v, want other-trace", body["traceId"])
+	}
+}
+
+func TestNewRootGeneration(t *testing.T) {
+	var mu sync.Mutex
+	var events []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Batch []map[string]any `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		events = append(events, req.Batch...)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	client, err := New("pk-lf-test-key", "sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	trace, gen, err := client.NewRootGeneration().
+		Name("complete").
+		Model("gpt-4").
+		Input("hello").
+		UsageTokens(3, 5).
+		UserID("user-1").
+		SessionID("session-1").
+		Tags([]string{"simple"}).
+		Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if gen.TraceID() != trace.ID() {
+		t.Errorf("generation trace = %q, want %q", gen.TraceID(), trace.ID())
+	}
+
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(events) != 2 {
+		t.Fatalf("received %d events, want 2", len(events))
+	}
+	traceBody := events[0]["body"].(map[string]any)
+	if events[0]["type"] != "trace-create" || traceBody["name"] != "complete" {
+		t.Errorf("unexpected trace event: %v", events[0])
+	}
+	if traceBody["userId"] != "user-1" || traceBody["sessionId"] != "session-1" {
+		t.Errorf("trace did not inherit user/session: %v", traceBody)
+	}
+	if tags, _ := traceBody["tags"].([]any); len(tags) != 1 || tags[0] != "simple" {
+		t.Errorf("trace tags = %v", traceBody["tags"])
+	}
+	genBody := events[1]["body"].(map[string]any)
+	if events[1]["type"] != "generation-create" || genBody["traceId"] != trace.ID() || genBody["model"] != "gpt-4" {
+		t.Errorf("unexpected generation event: %v", events[1])
+	}
+	if _, ok := genBody["parentObservationId"]; ok {
+		t.Errorf("root generation should have no parent: %v", genBody)
+	}
+}
+
+func TestTraceContextReplay(t *testing.T) {
+	var mu sync.Mutex
+	var events []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Batch []map[string]any `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		events = append(events, req.Batch...)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	client, err := New("pk-lf-test-key", "sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	trace, err := client.NewTrace().
+		Name("ab-test").
+		Input("question").
+		UserID("user-1").
+		SessionID("session-1").
+		Tags([]string{"eval"}).
+		Metadata(Metadata{"dataset": "v1"}).
+		Replayable().
+		Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	span, err := trace.NewSpan().Name("step").Create(ctx)
+	if err != nil {
+		t.Fatalf("span Create failed: %v", err)
+	}
+	if _, err := span.NewGeneration().Name("llm").Model("gpt-3.5").Input("prompt").Output("old answer").Create(ctx); err != nil {
+		t.Fatalf("generation Create failed: %v", err)
+	}
+
+	plain, err := trace.Replay(ctx)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	replay, err := trace.Replay(ctx, WithReplayGenerations("gpt-4o"))
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if replay.ID() == trace.ID() || plain.ID() == replay.ID() {
+		t.Error("replayed traces should have new IDs")
+	}
+
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	// trace, span, generation, plain replay, replay, replayed generation
+	if len(events) != 6 {
+		t.Fatalf("received %d events, want 6", len(events))
+	}
+	for _, i := range []int{3, 4} {
+		body := events[i]["body"].(map[string]any)
+		if events[i]["type"] != "trace-create" || body["name"] != "ab-test" || body["input"] != "question" {
+			t.Errorf("unexpected replay trace: %v", body)
+		}
+		if body["userId"] != "user-1" || body["sessionId"] != "session-1" {
+			t.Errorf("replay did not copy user/session: %v", body)
+		}
+		metadata := body["metadata"].(map[string]any)
+		if metadata[ReplayMetadataKey] != trace.ID() || metadata["dataset"] != "v1" {
+			t.Errorf("replay metadata = %v", metadata)
+		}
+	}
+	gen := events[5]["body"].(map[string]any)
+	if events[5]["type"] != "generation-create" || gen["traceId"] != replay.ID() {
+		t.Errorf("unexpected replayed generation: %v", events[5])
+	}
+	if gen["model"] != "gpt-4o" || gen["input"] != "prompt" || gen["name"] != "llm" {
+		t.Errorf("replayed generation = %v", gen)
+	}
+	if _, ok := gen["output"]; ok {
+		t.Errorf("replayed generation should have no output: %v", gen)
+	}
+	if _, ok := gen["parentObservationId"]; ok {
+		t.Errorf("replayed generation should be attached to the trace: %v", gen)
+	}
+
+	untracked, err := client.NewTrace().Name("untracked").Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := untracked.Replay(ctx); !errors.Is(err, ErrTraceNotReplayable) {
+		t.Errorf("Replay of a trace not created Replayable error = %v, want ErrTraceNotReplayable", err)
+	}
+}
+
+func TestBaggagePropagation(t *testing.T) {
+	var mu sync.Mutex
+	var events []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Batch []map[string]any `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		events = append(events, req.Batch...)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	client, err := New("pk-lf-test-key", "sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	trace, err := client.NewTrace().Name("baggage").Create(ctx)
+	if err != nil {
+		t.Fatalf("trace Create failed: %v", err)
+	}
+	trace.SetBaggage("env", "prod")
+
+	parent, err := trace.NewSpan().Name("parent").Create(ctx)
+	if err != nil {
+		t.Fatalf("span Create failed: %v", err)
+	}
+	parent.SetBaggage("tenant", "acme")
+	parent.SetBaggage("env", "staging")
+	if parent.GetBaggage("tenant") != "acme" || parent.GetBaggage("env") != "staging" {
+		t.Errorf("parent baggage = %v", parent.Baggage().Entries())
+	}
+	if trace.GetBaggage("tenant") != "" {
+		t.Error("span baggage leaked into the trace")
+	}
+
+	snapshot := parent.Baggage()
+	child, err := parent.NewSpan().Name("child").Create(ctx)
+	if err != nil {
+		t.Fatalf("child Create failed: %v", err)
+	}
+	child.SetBaggage("step", "1")
+	if snapshot.Get("step") != "" || parent.GetBaggage("step") != "" {
+		t.Error("child baggage modified the parent or an earlier snapshot")
+	}
+	if _, err := child.NewGeneration().Name("llm").Metadata(Metadata{"k": "v"}).Create(ctx); err != nil {
+		t.Fatalf("generation Create failed: %v", err)
+	}
+	if _, err := trace.NewSpan().Name("sibling").Create(ctx); err != nil {
+		t.Fatalf("sibling Create failed: %v", err)
+	}
+
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	baggageOf := func(name string) map[string]any {
+		for _, e := range events {
+			body := e["body"].(map[string]any)
+			if body["name"] == name {
+				metadata, _ := body["metadata"].(map[string]any)
+				baggage, _ := metadata[BaggageMetadataKey].(map[string]any)
+				return baggage
+			}
+		}
+		t.Fatalf("no event named %q", name)
+		return nil
+	}
+
+	if b := baggageOf("parent"); len(b) != 1 || b["env"] != "prod" {
+		t.Errorf("parent baggage = %v, want trace baggage only", b)
+	}
+	if b := baggageOf("child"); len(b) != 2 || b["env"] != "staging" || b["tenant"] != "acme" {
+		t.Errorf("child baggage = %v", b)
+	}
+	if b := baggageOf("llm"); len(b) != 3 || b["step"] != "1" {
+		t.Errorf("generation baggage = %v", b)
+	}
+	if b := baggageOf("sibling"); len(b) != 1 || b["env"] != "prod" {
+		t.Errorf("sibling baggage = %v, want trace baggage only", b)
+	}
+}
+
+func TestBaggageConcurrent(t *testing.T) {
+	trace := &TraceContext{traceID: "trace"}
+	span := &SpanContext{TraceContext: trace, spanID: "span"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := fmt.Sprintf("k%d", i)
+			trace.SetBaggage(key, "trace")
+			span.SetBaggage(key, "span")
+			_ = span.Baggage().Entries()
+		}(i)
+	}
+	wg.Wait()
+
+	if n := span.Baggage().Len(); n != 10 {
+		t.Errorf("Baggage().Len() = %d, want 10", n)
+	}
+	if v := span.GetBaggage("k3"); v != "span" {
+		t.Errorf("GetBaggage(k3) = %q, want span entry to take precedence", v)
+	}
+}
+
+func TestTraceBuilderUpsert(t *testing.T) {
+	var mu sync.Mutex
+	var events []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Batch []map[string]any `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		events = append(events, req.Batch...)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	client, err := New("pk-lf-test-key", "sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(1*time.Hour),
+		WithDefaultRelease("v1.2.3"),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	create := client.NewTrace().Name("new")
+	if create.IsUpsert() {
+		t.Error("IsUpsert() = true without an ID")
+	}
+	created, err := create.Upsert(ctx)
+	if err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	if created.ID() == "" {
+		t.Error("Upsert without an ID should generate one")
+	}
+
+	update := client.NewTrace().ID("existing-trace").Metadata(Metadata{"region": "eu"})
+	if !update.IsUpsert() {
+		t.Error("IsUpsert() = false with an ID")
+	}
+	updated, err := update.Upsert(ctx)
+	if err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	if updated.ID() != "existing-trace" {
+		t.Errorf("ID() = %q, want existing-trace", updated.ID())
+	}
+
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(events) != 2 {
+		t.Fatalf("received %d events, want 2", len(events))
+	}
+	if events[0]["type"] != "trace-create" {
+		t.Errorf("first event type = %v, want trace-create", events[0]["type"])
+	}
+	body := events[1]["body"].(map[string]any)
+	if events[1]["type"] != "trace-create" || body["id"] != "existing-trace" {
+		t.Errorf("unexpected upsert event: %v", events[1])
+	}
+	for _, field := range []string{"timestamp", "release", "name"} {
+		if _, ok := body[field]; ok {
+			t.Errorf("upsert sent unset field %q: %v", field, body)
+		}
+	}
+	if metadata, _ := body["metadata"].(map[string]any); metadata["region"] != "eu" {
+		t.Errorf("upsert metadata = %v", body["metadata"])
+	}
+}
+
+func TestGenerationChainTo(t *testing.T) {
+	var mu sync.Mutex
+	var events []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Batch []map[string]any `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		events = append(events, req.Batch...)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestionResult{})
+	}))
+	defer server.Close()
+
+	client, err := New("pk-lf-test-key", "sk-lf-test-key",
+		WithBaseURL(server.URL),
+		WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	trace, err := client.NewTrace().Name("chain").Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	span, err := trace.NewSpan().Name("pipeline").Create(ctx)
+	if err != nil {
+		t.Fatalf("span Create failed: %v", err)
+	}
+	router, err := span.NewGeneration().Name("router").Model("gpt-4o-mini").Input("question").Create(ctx)
+	if err != nil {
+		t.Fatalf("generation Create failed: %v", err)
+	}
+
+	if _, err := router.ChainTo(ctx, "gpt-4o"); err == nil {
+		t.Error("ChainTo before End should fail")
+	}
+	if trace.GenerationChain() != nil {
+		t.Error("GenerationChain should be nil before ChainTo")
+	}
+
+	if err := router.EndWithOutput(ctx, "route:code"); err != nil {
+		t.Fatalf("EndWithOutput failed: %v", err)
+	}
+	specialist, err := router.ChainTo(ctx, "gpt-4o", WithGenerationMetadata(Metadata{"step": 2}))
+	if err != nil {
+		t.Fatalf("ChainTo failed: %v", err)
+	}
+	if err := specialist.EndWithOutput(ctx, "answer"); err != nil {
+		t.Fatalf("EndWithOutput failed: %v", err)
+	}
+	reviewer, err := specialist.ChainTo(ctx, "claude-reviewer", WithGenerationInput("override"))
+	if err != nil {
+		t.Fatalf("ChainTo failed: %v", err)
+	}
+
+	chain := trace.GenerationChain()
+	if len(chain) != 3 || chain[0] != router || chain[1] != specialist || chain[2] != reviewer {
+		t.Fatalf("GenerationChain = %v, want [router specialist reviewer]", chain)
+	}
+
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	created := make(map[string]map[string]any)
+	for _, e := range events {
+		if e["type"] == "generation-create" {
+			body := e["body"].(map[string]any)
+			created[body["id"].(string)] = body
+		}
+	}
+
+	spec := created[specialist.ID()]
+	if spec == nil {
+		t.Fatal("specialist generation not sent")
+	}
+	if spec["parentObservationId"] != span.ID() {
+		t.Errorf("parentObservationId = %v, want %s", spec["parentObservationId"], span.ID())
+	}
+	if spec["model"] != "gpt-4o" || spec["name"] != "gpt-4o" {
+		t.Errorf("model/name = %v/%v, want gpt-4o", spec["model"], spec["name"])
+	}
+	if spec["input"] != "route:code" {
+		t.Errorf("input = %v, want previous output", spec["input"])
+	}
+	if spec["metadata"].(map[string]any)["step"] != float64(2) {
+		t.Errorf("metadata = %v, want step=2", spec["metadata"])
+	}
+	if created[reviewer.ID()]["input"] != "override" {
+		t.Errorf("reviewer input = %v, want override", created[reviewer.ID()]["input"])
+	}
+
+	var prev time.Time
+	for i, g := range chain {
+		start, err := time.Parse(time.RFC3339Nano, created[g.ID()]["startTime"].(string))
+		if err != nil {
+			t.Fatalf("parse startTime: %v", err)
+		}
+		if i > 0 && !start.After(prev) {
+			t.Errorf("chain[%d] startTime %v is not after %v", i, start, prev)
+		}
+		prev = start
+	}
+}
+
+func TestObservationLevelMapping(t *testing.T) {
+	newClient := func(t *testing.T, opts ...ConfigOption) (*Client, func() []map[string]any) {
+		var mu sync.Mutex
+		var events []map[string]any
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req struct {
+				Batch []map[string]any `json:"batch"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			mu.Lock()
+			events = append(events, req.Batch...)
+			mu.Unlock()
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(IngestionResult{})
+		}))
+		t.Cleanup(server.Close)
+
+		client, err := New("pk-lf-test-key", "sk-lf-test-key",
+			append([]ConfigOption{WithBaseURL(server.URL), WithFlushInterval(1 * time.Hour)}, opts...)...)
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		t.Cleanup(func() { client.Shutdown(context.Background()) })
+		return client, func() []map[string]any {
+			if err := client.Flush(context.Background()); err != nil {
+				t.Fatalf("Flush failed: %v", err)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			return append([]map[string]any(nil), events...)
+		}
+	}
+
+	// levels returns the level sent in each span and generation update, keyed by observation ID.
+	levels := func(events []map[string]any) map[string]any {
+		got := make(map[string]any)
+		for _, e := range events {
+			if e["type"] == "span-update" || e["type"] == "generation-update" {
+				body := e["body"].(map[string]any)
+				got[body["id"].(string)] = body["level"]
+			}
+		}
+		return got
+	}
+
+	validationErr := NewValidationError("field", "bad value")
+	retryableErr := &APIError{StatusCode: 503, Message: "unavailable"}
+
+	t.Run("default", func(t *testing.T) {
+		client, flush := newClient(t)
+		ctx := context.Background()
+		trace, _ := client.NewTrace().Name("t").Create(ctx)
+		warn, _ := trace.NewSpan().Name("validation").Create(ctx)
+		fail, _ := trace.NewSpan().Name("api").Create(ctx)
+		gen, _ := trace.NewGeneration().Name("gen").Create(ctx)
+
+		warn.SetStatusError(ctx, validationErr)
+		fail.SetStatusError(ctx, retryableErr)
+		gen.EndWith(ctx, WithError(validationErr))
+
+		got := levels(flush())
+		if got[warn.ID()] != "WARNING" {
+			t.Errorf("validation error level = %v, want WARNING", got[warn.ID()])
+		}
+		if got[fail.ID()] != "ERROR" {
+			t.Errorf("API error level = %v, want ERROR", got[fail.ID()])
+		}
+		if got[gen.ID()] != "WARNING" {
+			t.Errorf("generation WithError level = %v, want WARNING", got[gen.ID()])
+		}
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		client, flush := newClient(t, WithObservationLevelMapping(StrictLevelMapping()))
+		ctx := context.Background()
+		trace, _ := client.NewTrace().Name("t").Create(ctx)
+		span, _ := trace.NewSpan().Name("validation").Create(ctx)
+		span.EndWith(ctx, WithError(validationErr))
+
+		if got := levels(flush())[span.ID()]; got != "ERROR" {
+			t.Errorf("level = %v, want ERROR", got)
+		}
+	})
+
+	t.Run("lenient", func(t *testing.T) {
+		client, flush := newClient(t, WithObservationLevelMapping(LenientLevelMapping()))
+		ctx := context.Background()
+		trace, _ := client.NewTrace().Name("t").Create(ctx)
+		retry, _ := trace.NewSpan().Name("retry").Create(ctx)
+		fatal, _ := trace.NewSpan().Name("fatal").Create(ctx)
+		explicit, _ := trace.NewSpan().Name("explicit").Create(ctx)
+
+		retry.SetStatusError(ctx, retryableErr)
+		fatal.SetStatusError(ctx, errors.New("boom"))
+		explicit.EndWith(ctx, WithError(retryableErr), WithEndLevel(ObservationLevelDebug))
+
+		got := levels(flush())
+		if got[retry.ID()] != "WARNING" {
+			t.Errorf("retryable level = %v, want WARNING", got[retry.ID()])
+		}
+		if got[fatal.ID()] != "ERROR" {
+			t.Errorf("non-retryable level = %v, want ERROR", got[fatal.ID()])
+		}
+		if got[explicit.ID()] != "DEBUG" {
+			t.Errorf("explicit level = %v, want DEBUG", got[explicit.ID()])
+		}
+	})
+
+	t.Run("mapping functions", func(t *testing.T) {
+		for name, mapping := range map[string]func(error) ObservationLevel{
+			"default": DefaultLevelMapping(),
+			"strict":  StrictLevelMapping(),
+			"lenient": LenientLevelMapping(),
+		} {
+			if got := mapping(nil); got != ObservationLevelDefault {
+				t.Errorf("%s(nil) = %v, want DEFAULT", name, got)
+			}
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package langfusetest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+)
+
+// benchmarkResponse is the minimal body returned for every request.
+var benchmarkResponse = []byte(`{"successes":[],"errors":[]}`)
+
+// BenchmarkServer is a lightweight test HTTP server for throughput benchmarks.
+// Unlike MockServer it does not record requests; it only keeps atomic counters,
+// so it adds negligible overhead to the code under test.
+type BenchmarkServer struct {
+	*httptest.Server
+
+	requests atomic.Int64
+	events   atomic.Int64
+	bytes    atomic.Int64
+}
+
+// NewBenchmarkServer creates a new benchmark server.
+// Every request is acknowledged with 200 OK and a minimal ingestion response.
+// Options such as WithLatency simulate a slow API.
+func NewBenchmarkServer(opts ...ServerOption) *BenchmarkServer {
+	cfg := newServerConfig(opts)
+	bs := &BenchmarkServer{}
+
+	bs.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bs.requests.Add(1)
+
+		if r.Body != nil {
+			cr := &countingReader{r: r.Body}
+			if strings.HasSuffix(r.URL.Path, "/ingestion") {
+				var batch struct {
+					Batch []json.RawMessage `json:"batch"`
+				}
+				if err := json.NewDecoder(cr).Decode(&batch); err == nil {
+					bs.events.Add(int64(len(batch.Batch)))
+				}
+			}
+			io.Copy(io.Discard, cr)
+			bs.bytes.Add(cr.n)
+		}
+
+		cfg.delay(r.Context(), r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(benchmarkResponse)
+	}))
+
+	return bs
+}
+
+// RequestCount returns the number of requests received.
+func (bs *BenchmarkServer) RequestCount() int64 {
+	return bs.requests.Load()
+}
+
+// TotalEventsReceived returns the number of ingestion events received across all batches.
+func (bs *BenchmarkServer) TotalEventsReceived() int64 {
+	return bs.events.Load()
+}
+
+// BytesReceived returns the total number of request body bytes received.
+func (bs *BenchmarkServer) BytesReceived() int64 {
+	return bs.bytes.Load()
+}
+
+// Reset zeroes all counters.
+func (bs *BenchmarkServer) Reset() {
+	bs.requests.Store(0)
+	bs.events.Store(0)
+	bs.bytes.Store(0)
+}
+
+// countingReader counts bytes read from the wrapped reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,114 @@
+package langfusetest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jdziat/langfuse-go"
+)
+
+func TestBenchmarkServer_Counters(t *testing.T) {
+	bs := NewBenchmarkServer()
+	defer bs.Close()
+
+	body := []byte(`{"batch":[{"id":"1"},{"id":"2"},{"id":"3"}]}`)
+	resp, err := http.Post(bs.URL+"/api/public/ingestion", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to make request: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want 200", resp.StatusCode)
+	}
+	if bs.RequestCount() != 1 {
+		t.Errorf("RequestCount() = %d, want 1", bs.RequestCount())
+	}
+	if bs.TotalEventsReceived() != 3 {
+		t.Errorf("TotalEventsReceived() = %d, want 3", bs.TotalEventsReceived())
+	}
+	if bs.BytesReceived() != int64(len(body)) {
+		t.Errorf("BytesReceived() = %d, want %d", bs.BytesReceived(), len(body))
+	}
+
+	bs.Reset()
+	if bs.RequestCount() != 0 || bs.TotalEventsReceived() != 0 || bs.BytesReceived() != 0 {
+		t.Error("Reset() did not zero counters")
+	}
+}
+
+func TestBenchmarkServer_WithClient(t *testing.T) {
+	bs := NewBenchmarkServer()
+	defer bs.Close()
+
+	client, err := langfuse.New(TestPublicKey, TestSecretKey,
+		langfuse.WithBaseURL(bs.URL),
+		langfuse.WithBatchSize(1000),
+		langfuse.WithFlushInterval(time.Minute),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	for i := 0; i < 10; i++ {
+		if _, err := client.NewTrace().Name("trace").Create(ctx); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	if bs.TotalEventsReceived() != 10 {
+		t.Errorf("TotalEventsReceived() = %d, want 10", bs.TotalEventsReceived())
+	}
+}
+
+// BenchmarkClientThroughput measures SDK ingestion throughput against a
+// BenchmarkServer for several batching configurations.
+func BenchmarkClientThroughput(b *testing.B) {
+	configs := []struct {
+		batchSize     int
+		flushInterval time.Duration
+	}{
+		{10, 100 * time.Millisecond},
+		{100, 100 * time.Millisecond},
+		{100, time.Second},
+		{1000, time.Second},
+	}
+
+	for _, cfg := range configs {
+		b.Run(fmt.Sprintf("batch=%d/interval=%s", cfg.batchSize, cfg.flushInterval), func(b *testing.B) {
+			bs := NewBenchmarkServer()
+			defer bs.Close()
+
+			client, err := langfuse.New(TestPublicKey, TestSecretKey,
+				langfuse.WithBaseURL(bs.URL),
+				langfuse.WithBatchSize(cfg.batchSize),
+				langfuse.WithFlushInterval(cfg.flushInterval),
+			)
+			if err != nil {
+				b.Fatalf("Failed to create client: %v", err)
+			}
+
+			ctx := context.Background()
+			b.ResetTimer()
+			start := time.Now()
+			for i := 0; i < b.N; i++ {
+				_, _ = client.NewTrace().Name("bench").Create(ctx)
+			}
+			// Shutdown drains queued batches before the counters are read.
+			client.Shutdown(ctx)
+			elapsed := time.Since(start)
+			b.StopTimer()
+
+			b.ReportMetric(float64(bs.TotalEventsReceived())/elapsed.Seconds(), "events/s")
+		})
+	}
+}
@@ -45,6 +45,19 @@ func NewTestClient(t TestingT) (*langfuse.Client, *MockServer) {
 	return client, server
 }
 
+// NewTestClientForProject creates a test client configured with
+// WithProjectID, for tests of code that serves several Langfuse projects.
+// Each call uses its own mock server.
+//
+// Example:
+//
+//	checkout, checkoutServer := langfusetest.NewTestClientForProject(t, "checkout")
+//	search, searchServer := langfusetest.NewTestClientForProject(t, "search")
+func NewTestClientForProject(t TestingT, projectID string, opts ...langfuse.ConfigOption) (*langfuse.Client, *MockServer) {
+	t.Helper()
+	return NewTestClientWithConfig(t, append([]langfuse.ConfigOption{langfuse.WithProjectID(projectID)}, opts...)...)
+}
+
 // NewTestClientWithConfig creates a client with custom configuration for testing.
 // Base options (mock server URL, large batch size, long flush interval) are applied first,
 // then the provided options are applied on top.
@@ -121,3 +121,56 @@ func TestTestConstants(t *testing.T) {
 		t.Error("TestSecretKey should be at least 8 characters")
 	}
 }
+
+func TestMockServer_RequestsByType(t *testing.T) {
+	client, server := NewTestClient(t)
+	ctx := context.Background()
+
+	trace, err := client.NewTrace().Name("typed").Create(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create trace: %v", err)
+	}
+	gen, err := trace.NewGeneration().Name("llm").Model("gpt-4o").Create(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create generation: %v", err)
+	}
+	if err := gen.End(ctx); err != nil {
+		t.Fatalf("Failed to end generation: %v", err)
+	}
+	if err := trace.ScoreNumeric(ctx, "quality", 0.9); err != nil {
+		t.Fatalf("Failed to create score: %v", err)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Failed to flush: %v", err)
+	}
+
+	updates := server.RequestsByType("generation-update")
+	if len(updates) != 1 || updates[0].Body["id"] != gen.ID() {
+		t.Errorf("RequestsByType(generation-update) = %+v, want one update for %s", updates, gen.ID())
+	}
+	if len(server.RequestsByType("span-create")) != 0 {
+		t.Error("RequestsByType(span-create) should be empty")
+	}
+
+	if traces := server.TracesCreated(); len(traces) != 1 || traces[0]["name"] != "typed" {
+		t.Errorf("TracesCreated() = %v", traces)
+	}
+	if gens := server.GenerationsCreated(); len(gens) != 1 || gens[0]["model"] != "gpt-4o" {
+		t.Errorf("GenerationsCreated() = %v", gens)
+	}
+	if scores := server.ScoresCreated(); len(scores) != 1 || scores[0]["name"] != "quality" {
+		t.Errorf("ScoresCreated() = %v", scores)
+	}
+}
+
+func TestNewTestClientForProject(t *testing.T) {
+	checkout, checkoutServer := NewTestClientForProject(t, "checkout")
+	search, searchServer := NewTestClientForProject(t, "search", langfuse.WithBatchSize(5))
+
+	if checkout.Stats().ProjectID != "checkout" || search.Stats().ProjectID != "search" {
+		t.Errorf("project IDs = %q, %q", checkout.Stats().ProjectID, search.Stats().ProjectID)
+	}
+	if checkoutServer == searchServer {
+		t.Error("each project should have its own mock server")
+	}
+}
@@ -16,6 +16,21 @@
 //	requests := server.Requests()
 //	// assert on requests
 //
+// # Mock Langfuse Server
+//
+// Use MockLangfuseServer for end-to-end tests that read data back. It serves
+// ingested traces, observations and scores, and prompts and datasets seeded
+// in advance:
+//
+//	server := langfusetest.NewMockLangfuseServer()
+//	defer server.Close()
+//	server.AddPrompt(&langfuse.Prompt{Name: "greeting", Version: 1, Prompt: "Hello {{name}}", Labels: []string{"production"}})
+//
+//	client, _ := langfuse.New("pk", "sk", langfuse.WithBaseURL(server.URL))
+//	trace, _ := client.NewTrace().Name("test").Create(ctx)
+//	client.Flush(ctx)
+//	got, _ := client.Traces().Get(ctx, trace.ID())
+//
 // # Test Client
 //
 // Use NewTestClient for a pre-configured client with a mock server:
@@ -53,4 +68,38 @@
 //	// ... use client ...
 //
 //	messages := logger.GetMessages()
+//
+// # Simulated Latency
+//
+// Server options simulate slow API responses on both MockServer and
+// BenchmarkServer:
+//
+//	server := langfusetest.NewMockServer(
+//	    langfusetest.WithLatency(50*time.Millisecond, 200*time.Millisecond),
+//	    langfusetest.WithLatencyForEndpoint("/ingestion", time.Second),
+//	)
+//
+// # Benchmark Server
+//
+// Use BenchmarkServer for throughput benchmarks. It discards requests and
+// only keeps atomic counters:
+//
+//	server := langfusetest.NewBenchmarkServer()
+//	defer server.Close()
+//	client, _ := langfuse.New("pk", "sk", langfuse.WithBaseURL(server.URL))
+//	// ... create events, then client.Shutdown(ctx) ...
+//
+//	b.ReportMetric(float64(server.TotalEventsReceived())/elapsed.Seconds(), "events/s")
+//
+// # Goroutine Leaks
+//
+// CheckForGoroutineLeaks fails the test if SDK goroutines started by fn are
+// still running after it returns, for example because Shutdown was not
+// called:
+//
+//	langfusetest.CheckForGoroutineLeaks(t, func() {
+//	    client, _ := langfuse.New("pk", "sk")
+//	    defer client.Shutdown(context.Background())
+//	    // ...
+//	})
 package langfusetest
@@ -0,0 +1,383 @@
+package langfusetest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/jdziat/langfuse-go"
+	pkgingestion "github.com/jdziat/langfuse-go/pkg/ingestion"
+)
+
+// apiPathPrefix is the path prefix of the Langfuse public API.
+const apiPathPrefix = "/api/public"
+
+// observationTypes maps observation ingestion event types to the
+// observation type returned by the API.
+var observationTypes = map[string]langfuse.ObservationType{
+	pkgingestion.EventTypeSpanCreate:       langfuse.ObservationTypeSpan,
+	pkgingestion.EventTypeSpanUpdate:       langfuse.ObservationTypeSpan,
+	pkgingestion.EventTypeGenerationCreate: langfuse.ObservationTypeGeneration,
+	pkgingestion.EventTypeGenerationUpdate: langfuse.ObservationTypeGeneration,
+	pkgingestion.EventTypeEventCreate:      langfuse.ObservationTypeEvent,
+}
+
+// ingestionEventTypes are the event types accepted by the ingestion API.
+// Langfuse has no trace-update event; traces are updated by sending another
+// trace-create with the same ID.
+var ingestionEventTypes = map[string]bool{
+	pkgingestion.EventTypeTraceCreate:      true,
+	pkgingestion.EventTypeSpanCreate:       true,
+	pkgingestion.EventTypeSpanUpdate:       true,
+	pkgingestion.EventTypeGenerationCreate: true,
+	pkgingestion.EventTypeGenerationUpdate: true,
+	pkgingestion.EventTypeEventCreate:      true,
+	pkgingestion.EventTypeScoreCreate:      true,
+	pkgingestion.EventTypeSDKLog:           true,
+}
+
+// MockLangfuseServer is a MockServer that also serves the read endpoints of
+// the Langfuse API from in-memory state, for end-to-end tests without a
+// live Langfuse instance. Traces, observations and scores are built from
+// the ingestion events it receives; prompts and datasets are seeded with
+// AddPrompt, AddDataset and AddDatasetItem.
+//
+// Setting ResponseFunc, for example with RespondWithError, replaces the
+// API responses while requests are still recorded.
+type MockLangfuseServer struct {
+	*MockServer
+
+	stateMu      sync.Mutex
+	prompts      map[string][]langfuse.Prompt
+	datasets     []langfuse.Dataset
+	datasetItems []langfuse.DatasetItem
+}
+
+// NewMockLangfuseServer creates a new mock Langfuse API server.
+// Options such as WithLatency simulate a slow API.
+//
+// Example:
+//
+//	server := langfusetest.NewMockLangfuseServer()
+//	defer server.Close()
+//	server.AddPrompt(&langfuse.Prompt{Name: "greeting", Version: 1, Prompt: "Hello {{name}}"})
+//
+//	client, _ := langfuse.New("pk", "sk", langfuse.WithBaseURL(server.URL))
+//	prompt, _ := client.Prompts().GetLatest(ctx, "greeting")
+func NewMockLangfuseServer(opts ...ServerOption) *MockLangfuseServer {
+	s := &MockLangfuseServer{
+		MockServer: NewMockServer(opts...),
+		prompts:    make(map[string][]langfuse.Prompt),
+	}
+	s.ResponseFunc = s.respond
+	return s
+}
+
+// AddPrompt seeds a prompt version. Fetching a prompt by name returns the
+// version requested by version or label, or otherwise the version labelled
+// "production". Like Langfuse, it returns 404 if no version matches.
+func (s *MockLangfuseServer) AddPrompt(p *langfuse.Prompt) {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+	s.prompts[p.Name] = append(s.prompts[p.Name], *p)
+}
+
+// AddDataset seeds a dataset.
+func (s *MockLangfuseServer) AddDataset(d *langfuse.Dataset) {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+	s.datasets = append(s.datasets, *d)
+}
+
+// AddDatasetItem seeds a dataset item. Its DatasetName determines the
+// dataset it is listed under.
+func (s *MockLangfuseServer) AddDatasetItem(item *langfuse.DatasetItem) {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+	s.datasetItems = append(s.datasetItems, *item)
+}
+
+// respond serves r from the server state. It is the default ResponseFunc.
+func (s *MockLangfuseServer) respond(r *http.Request) (int, any) {
+	path := strings.TrimPrefix(r.URL.Path, apiPathPrefix)
+	if r.Method == http.MethodPost && path == "/ingestion" {
+		return s.ingestionResult(r)
+	}
+	if r.Method != http.MethodGet {
+		return notFound("endpoint")
+	}
+
+	query := r.URL.Query()
+	switch {
+	case path == "/health":
+		return http.StatusOK, langfuse.HealthStatus{Status: "OK"}
+	case path == "/traces":
+		return http.StatusOK, listResponse(filterBodies(s.traces(), query, "userId", "sessionId", "name"), query)
+	case strings.HasPrefix(path, "/traces/"):
+		return findBody(s.traces(), strings.TrimPrefix(path, "/traces/"), "trace")
+	case path == "/observations":
+		return http.StatusOK, listResponse(filterBodies(s.observations(), query, "traceId", "type", "name"), query)
+	case strings.HasPrefix(path, "/observations/"):
+		return findBody(s.observations(), strings.TrimPrefix(path, "/observations/"), "observation")
+	case path == "/scores":
+		return http.StatusOK, listResponse(filterBodies(s.scores(), query, "traceId", "name"), query)
+	case strings.HasPrefix(path, "/scores/"):
+		return findBody(s.scores(), strings.TrimPrefix(path, "/scores/"), "score")
+	case path == "/v2/prompts":
+		return http.StatusOK, listResponse(s.latestPrompts(), query)
+	case strings.HasPrefix(path, "/v2/prompts/"):
+		return s.prompt(strings.TrimPrefix(path, "/v2/prompts/"), query.Get("version"), query.Get("label"))
+	case path == "/v2/datasets":
+		return http.StatusOK, listResponse(s.datasetList(), query)
+	case strings.HasPrefix(path, "/v2/datasets/"):
+		return s.dataset(strings.TrimPrefix(path, "/v2/datasets/"))
+	case path == "/dataset-items":
+		return http.StatusOK, listResponse(s.items(query.Get("datasetName")), query)
+	case strings.HasPrefix(path, "/dataset-items/"):
+		return s.item(strings.TrimPrefix(path, "/dataset-items/"))
+	default:
+		return notFound("endpoint")
+	}
+}
+
+// ingestionResult acknowledges the events of the ingestion batch in r and
+// reports an ingestion error for each event of an unknown type, as Langfuse
+// does. The events themselves are read back from the recorded requests.
+func (s *MockLangfuseServer) ingestionResult(r *http.Request) (int, any) {
+	var batch struct {
+		Batch []RecordedIngestionEvent `json:"batch"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+		return http.StatusBadRequest, map[string]string{"message": "invalid ingestion batch"}
+	}
+
+	result := langfuse.IngestionResult{Successes: make([]langfuse.IngestionSuccess, 0, len(batch.Batch))}
+	for _, event := range batch.Batch {
+		if !ingestionEventTypes[event.Type] {
+			result.Errors = append(result.Errors, langfuse.IngestionError{
+				ID:      event.ID,
+				Status:  http.StatusBadRequest,
+				Message: "Invalid event type: " + event.Type,
+			})
+			continue
+		}
+		result.Successes = append(result.Successes, langfuse.IngestionSuccess{ID: event.ID, Status: http.StatusCreated})
+	}
+	return http.StatusOK, result
+}
+
+// traces returns the ingested traces in the order they were first received.
+func (s *MockLangfuseServer) traces() []map[string]any {
+	return s.mergeBodies(func(event RecordedIngestionEvent) bool {
+		return event.Type == pkgingestion.EventTypeTraceCreate
+	})
+}
+
+// observations returns the ingested spans, generations and events in the
+// order they were first received, with their observation type set.
+func (s *MockLangfuseServer) observations() []map[string]any {
+	observations := s.mergeBodies(func(event RecordedIngestionEvent) bool {
+		_, ok := observationTypes[event.Type]
+		return ok
+	})
+
+	types := make(map[any]langfuse.ObservationType)
+	for _, event := range s.ingestionEvents() {
+		if obsType, ok := observationTypes[event.Type]; ok {
+			types[event.Body["id"]] = obsType
+		}
+	}
+	for _, observation := range observations {
+		observation["type"] = string(types[observation["id"]])
+	}
+	return observations
+}
+
+// scores returns the ingested scores in the order they were first received.
+func (s *MockLangfuseServer) scores() []map[string]any {
+	return s.mergeBodies(func(event RecordedIngestionEvent) bool {
+		return event.Type == pkgingestion.EventTypeScoreCreate
+	})
+}
+
+// mergeBodies merges the bodies of the recorded events matched by match by
+// their ID, in the order they were received, so later updates override
+// earlier fields.
+func (s *MockLangfuseServer) mergeBodies(match func(RecordedIngestionEvent) bool) []map[string]any {
+	var merged []map[string]any
+	byID := make(map[any]map[string]any)
+	for _, event := range s.ingestionEvents() {
+		if !match(event) {
+			continue
+		}
+		id := event.Body["id"]
+		body, ok := byID[id]
+		if !ok {
+			body = make(map[string]any)
+			byID[id] = body
+			merged = append(merged, body)
+		}
+		for k, v := range event.Body {
+			body[k] = v
+		}
+	}
+	return merged
+}
+
+// prompt returns the prompt version selected by version or label.
+func (s *MockLangfuseServer) prompt(name, version, label string) (int, any) {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+
+	versions := s.prompts[name]
+	for i := range versions {
+		p := &versions[i]
+		switch {
+		case version != "":
+			if strconv.Itoa(p.Version) == version {
+				return http.StatusOK, p
+			}
+		case label != "":
+			if hasLabel(p, label) {
+				return http.StatusOK, p
+			}
+		default:
+			if hasLabel(p, "production") {
+				return http.StatusOK, p
+			}
+		}
+	}
+	return notFound("prompt")
+}
+
+// latestPrompts returns the latest version of every seeded prompt.
+func (s *MockLangfuseServer) latestPrompts() []langfuse.Prompt {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+
+	prompts := make([]langfuse.Prompt, 0, len(s.prompts))
+	for _, versions := range s.prompts {
+		latest := versions[0]
+		for _, p := range versions[1:] {
+			if p.Version > latest.Version {
+				latest = p
+			}
+		}
+		prompts = append(prompts, latest)
+	}
+	return prompts
+}
+
+// datasetList returns the seeded datasets.
+func (s *MockLangfuseServer) datasetList() []langfuse.Dataset {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+	return append([]langfuse.Dataset{}, s.datasets...)
+}
+
+// dataset returns the seeded dataset with the given name.
+func (s *MockLangfuseServer) dataset(name string) (int, any) {
+	for _, d := range s.datasetList() {
+		if d.Name == name {
+			return http.StatusOK, d
+		}
+	}
+	return notFound("dataset")
+}
+
+// items returns the seeded dataset items, restricted to datasetName if set.
+func (s *MockLangfuseServer) items(datasetName string) []langfuse.DatasetItem {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+
+	items := make([]langfuse.DatasetItem, 0, len(s.datasetItems))
+	for _, item := range s.datasetItems {
+		if datasetName == "" || item.DatasetName == datasetName {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// item returns the seeded dataset item with the given ID.
+func (s *MockLangfuseServer) item(id string) (int, any) {
+	for _, item := range s.items("") {
+		if item.ID == id {
+			return http.StatusOK, item
+		}
+	}
+	return notFound("dataset item")
+}
+
+// hasLabel reports whether p has label.
+func hasLabel(p *langfuse.Prompt, label string) bool {
+	for _, l := range p.Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+// filterBodies returns the bodies whose fields match the values of the
+// given query parameters, ignoring parameters that are not set.
+func filterBodies(bodies []map[string]any, query url.Values, fields ...string) []map[string]any {
+	filtered := make([]map[string]any, 0, len(bodies))
+	for _, body := range bodies {
+		matches := true
+		for _, field := range fields {
+			if value := query.Get(field); value != "" && body[field] != value {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			filtered = append(filtered, body)
+		}
+	}
+	return filtered
+}
+
+// findBody returns the body with the given ID, or 404 if there is none.
+func findBody(bodies []map[string]any, id, kind string) (int, any) {
+	for _, body := range bodies {
+		if body["id"] == id {
+			return http.StatusOK, body
+		}
+	}
+	return notFound(kind)
+}
+
+// listResponse returns the page of data selected by the page and limit
+// query parameters as a list response. Without a limit, all data is
+// returned as a single page.
+func listResponse[T any](data []T, query url.Values) map[string]any {
+	page, _ := strconv.Atoi(query.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	if limit < 1 {
+		limit = max(len(data), 1)
+	}
+
+	start := min((page-1)*limit, len(data))
+	end := min(start+limit, len(data))
+	return map[string]any{
+		"data": data[start:end],
+		"meta": langfuse.MetaResponse{
+			Page:       page,
+			Limit:      limit,
+			TotalItems: len(data),
+			TotalPages: (len(data) + limit - 1) / limit,
+		},
+	}
+}
+
+// notFound returns a 404 response for the given kind of resource.
+func notFound(kind string) (int, any) {
+	return http.StatusNotFound, map[string]string{"message": kind + " not found"}
+}
@@ -0,0 +1,182 @@
+package langfusetest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jdziat/langfuse-go"
+)
+
+func TestMockLangfuseServer_IngestedData(t *testing.T) {
+	server := NewMockLangfuseServer()
+	defer server.Close()
+
+	client, err := langfuse.New(TestPublicKey, TestSecretKey,
+		langfuse.WithBaseURL(server.URL),
+		langfuse.WithFlushInterval(time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	trace, err := client.NewTrace().Name("e2e").UserID("user-1").Create(ctx)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	span, err := trace.NewSpan().Name("step").Create(ctx)
+	if err != nil {
+		t.Fatalf("NewSpan() error = %v", err)
+	}
+	if err := span.EndWithOutput(ctx, "done"); err != nil {
+		t.Fatalf("EndWithOutput() error = %v", err)
+	}
+	if err := trace.ScoreNumeric(ctx, "quality", 0.9); err != nil {
+		t.Fatalf("ScoreNumeric() error = %v", err)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	got, err := client.Traces().Get(ctx, trace.ID())
+	if err != nil {
+		t.Fatalf("Traces().Get() error = %v", err)
+	}
+	if got.Name != "e2e" || got.UserID != "user-1" {
+		t.Errorf("trace = %+v, want name e2e and user user-1", got)
+	}
+
+	traces, err := client.Traces().List(ctx, &langfuse.TracesListParams{FilterParams: langfuse.FilterParams{UserID: "user-1"}})
+	if err != nil {
+		t.Fatalf("Traces().List() error = %v", err)
+	}
+	if len(traces.Data) != 1 || traces.Meta.TotalItems != 1 {
+		t.Errorf("Traces().List() = %+v, want 1 trace", traces)
+	}
+
+	full, err := client.Traces().GetWithObservations(ctx, trace.ID(), langfuse.WithFetchScores(true))
+	if err != nil {
+		t.Fatalf("GetWithObservations() error = %v", err)
+	}
+	if len(full.Observations) != 1 {
+		t.Fatalf("got %d observations, want 1", len(full.Observations))
+	}
+	if obs := full.Observations[0]; obs.Type != langfuse.ObservationTypeSpan || obs.Output != "done" {
+		t.Errorf("observation = %+v, want an ended span", obs)
+	}
+	if len(full.Scores) != 1 || full.Scores[0].Name != "quality" {
+		t.Errorf("scores = %+v, want the quality score", full.Scores)
+	}
+
+	var apiErr *langfuse.APIError
+	if _, err := client.Traces().Get(ctx, "missing"); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Get() of an unknown trace error = %v, want 404", err)
+	}
+}
+
+func TestMockLangfuseServer_UnknownEventType(t *testing.T) {
+	server := NewMockLangfuseServer()
+	defer server.Close()
+
+	batch := `{"batch":[
+		{"id":"evt-1","type":"trace-create","body":{"id":"trace-1","name":"original"}},
+		{"id":"evt-2","type":"trace-update","body":{"id":"trace-1","name":"updated"}}
+	]}`
+	resp, err := http.Post(server.URL+"/api/public/ingestion", "application/json", strings.NewReader(batch))
+	if err != nil {
+		t.Fatalf("POST ingestion error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	var result langfuse.IngestionResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("decode ingestion result error = %v", err)
+	}
+	if len(result.Successes) != 1 || result.Successes[0].ID != "evt-1" {
+		t.Errorf("successes = %+v, want evt-1", result.Successes)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].ID != "evt-2" || result.Errors[0].Status != http.StatusBadRequest {
+		t.Errorf("errors = %+v, want a 400 for evt-2", result.Errors)
+	}
+
+	client, err := langfuse.New(TestPublicKey, TestSecretKey, langfuse.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	trace, err := client.Traces().Get(context.Background(), "trace-1")
+	if err != nil {
+		t.Fatalf("Traces().Get() error = %v", err)
+	}
+	if trace.Name != "original" {
+		t.Errorf("trace name = %q, want the trace-update ignored", trace.Name)
+	}
+}
+
+func TestMockLangfuseServer_SeededData(t *testing.T) {
+	server := NewMockLangfuseServer()
+	defer server.Close()
+
+	server.AddPrompt(&langfuse.Prompt{Name: "greeting", Version: 1, Prompt: "Hi", Labels: []string{"production"}})
+	server.AddPrompt(&langfuse.Prompt{Name: "greeting", Version: 2, Prompt: "Hello", Labels: []string{"staging"}})
+	server.AddPrompt(&langfuse.Prompt{Name: "draft", Version: 1, Prompt: "Hey", Labels: []string{"staging"}})
+	server.AddDataset(&langfuse.Dataset{ID: "ds-1", Name: "golden"})
+	server.AddDatasetItem(&langfuse.DatasetItem{ID: "item-1", DatasetName: "golden", Input: "q"})
+
+	client, err := langfuse.New(TestPublicKey, TestSecretKey, langfuse.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	health, err := client.Health(ctx)
+	if err != nil || health.Status != "OK" {
+		t.Errorf("Health() = %+v, %v, want OK", health, err)
+	}
+
+	tests := []struct {
+		name   string
+		params *langfuse.GetPromptParams
+		want   int
+	}{
+		{name: "default", params: nil, want: 1},
+		{name: "version", params: &langfuse.GetPromptParams{Version: 2}, want: 2},
+		{name: "label", params: &langfuse.GetPromptParams{Label: "staging"}, want: 2},
+	}
+	for _, tt := range tests {
+		prompt, err := client.Prompts().Get(ctx, "greeting", tt.params)
+		if err != nil {
+			t.Fatalf("%s: Prompts().Get() error = %v", tt.name, err)
+		}
+		if prompt.Version != tt.want {
+			t.Errorf("%s: prompt version = %d, want %d", tt.name, prompt.Version, tt.want)
+		}
+	}
+	if _, err := client.Prompts().Get(ctx, "missing", nil); err == nil {
+		t.Error("Prompts().Get() of an unknown prompt should fail")
+	}
+	var apiErr *langfuse.APIError
+	if _, err := client.Prompts().Get(ctx, "draft", nil); !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+		t.Errorf("Prompts().Get() of a prompt without a production version error = %v, want 404", err)
+	}
+
+	dataset, err := client.Datasets().Get(ctx, "golden")
+	if err != nil || dataset.ID != "ds-1" {
+		t.Errorf("Datasets().Get() = %+v, %v, want ds-1", dataset, err)
+	}
+	items, err := client.Datasets().ListItems(ctx, &langfuse.DatasetItemsListParams{DatasetName: "golden"})
+	if err != nil {
+		t.Fatalf("ListItems() error = %v", err)
+	}
+	if len(items.Data) != 1 || items.Data[0].ID != "item-1" {
+		t.Errorf("ListItems() = %+v, want item-1", items.Data)
+	}
+}
@@ -0,0 +1,111 @@
+package langfusetest
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// ServerOption configures a MockServer or BenchmarkServer.
+type ServerOption func(*serverConfig)
+
+// serverConfig holds options shared by the test servers.
+type serverConfig struct {
+	latency          func() time.Duration
+	endpointLatency  map[string]time.Duration
+	endpointPatterns []string
+}
+
+// WithLatency delays every response by a uniformly random duration between
+// min and max, to simulate a slow API.
+//
+// Example:
+//
+//	server := langfusetest.NewMockServer(
+//	    langfusetest.WithLatency(50*time.Millisecond, 200*time.Millisecond),
+//	)
+func WithLatency(min, max time.Duration) ServerOption {
+	return WithLatencyDistribution(func() time.Duration {
+		if max <= min {
+			return min
+		}
+		return min + time.Duration(rand.Int63n(int64(max-min)+1))
+	})
+}
+
+// WithLatencyDistribution delays every response by the duration returned
+// from dist, for custom distributions such as exponential or Pareto.
+// dist may be called concurrently.
+//
+// Example:
+//
+//	server := langfusetest.NewMockServer(
+//	    langfusetest.WithLatencyDistribution(func() time.Duration {
+//	        return time.Duration(rand.ExpFloat64() * float64(20*time.Millisecond))
+//	    }),
+//	)
+func WithLatencyDistribution(dist func() time.Duration) ServerOption {
+	return func(c *serverConfig) {
+		c.latency = dist
+	}
+}
+
+// WithLatencyForEndpoint delays responses to requests whose path ends with
+// path by latency, for example "/ingestion" or "/api/public/traces". It
+// takes precedence over WithLatency and WithLatencyDistribution. The option
+// may be given several times for different endpoints.
+//
+// Example:
+//
+//	server := langfusetest.NewMockServer(
+//	    langfusetest.WithLatencyForEndpoint("/ingestion", time.Second),
+//	)
+func WithLatencyForEndpoint(path string, latency time.Duration) ServerOption {
+	return func(c *serverConfig) {
+		if c.endpointLatency == nil {
+			c.endpointLatency = make(map[string]time.Duration)
+		}
+		if _, ok := c.endpointLatency[path]; !ok {
+			c.endpointPatterns = append(c.endpointPatterns, path)
+		}
+		c.endpointLatency[path] = latency
+	}
+}
+
+// newServerConfig applies opts to a new serverConfig.
+func newServerConfig(opts []ServerOption) *serverConfig {
+	c := &serverConfig{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// latencyFor returns the simulated latency for a request path.
+func (c *serverConfig) latencyFor(path string) time.Duration {
+	for _, p := range c.endpointPatterns {
+		if strings.HasSuffix(path, p) {
+			return c.endpointLatency[p]
+		}
+	}
+	if c.latency != nil {
+		return c.latency()
+	}
+	return 0
+}
+
+// delay sleeps for the simulated latency of path, returning early if ctx is
+// done (for example because the client gave up on the request).
+func (c *serverConfig) delay(ctx context.Context, path string) {
+	d := c.latencyFor(path)
+	if d <= 0 {
+		return
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
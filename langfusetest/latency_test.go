@@ -0,0 +1,139 @@
+package langfusetest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jdziat/langfuse-go"
+)
+
+func TestWithLatency(t *testing.T) {
+	ms := NewMockServer(WithLatency(40*time.Millisecond, 60*time.Millisecond))
+	defer ms.Close()
+
+	start := time.Now()
+	resp, err := http.Get(ms.URL + "/api/public/health")
+	if err != nil {
+		t.Fatalf("Failed to make request: %v", err)
+	}
+	resp.Body.Close()
+
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("response took %v, want at least 40ms", elapsed)
+	}
+}
+
+func TestWithLatencyDistribution(t *testing.T) {
+	var calls atomic.Int32
+	bs := NewBenchmarkServer(WithLatencyDistribution(func() time.Duration {
+		calls.Add(1)
+		return 30 * time.Millisecond
+	}))
+	defer bs.Close()
+
+	start := time.Now()
+	resp, err := http.Get(bs.URL + "/api/public/health")
+	if err != nil {
+		t.Fatalf("Failed to make request: %v", err)
+	}
+	resp.Body.Close()
+
+	if calls.Load() != 1 {
+		t.Errorf("distribution called %d times, want 1", calls.Load())
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("response took %v, want at least 30ms", elapsed)
+	}
+}
+
+func TestWithLatencyForEndpoint(t *testing.T) {
+	cfg := newServerConfig([]ServerOption{
+		WithLatency(time.Millisecond, time.Millisecond),
+		WithLatencyForEndpoint("/ingestion", time.Second),
+	})
+
+	if d := cfg.latencyFor("/api/public/ingestion"); d != time.Second {
+		t.Errorf("ingestion latency = %v, want 1s", d)
+	}
+	if d := cfg.latencyFor("/api/public/traces"); d != time.Millisecond {
+		t.Errorf("traces latency = %v, want 1ms", d)
+	}
+	if d := newServerConfig(nil).latencyFor("/api/public/ingestion"); d != 0 {
+		t.Errorf("default latency = %v, want 0", d)
+	}
+}
+
+func TestSimulatedLatency_RetriesTimedOutRequest(t *testing.T) {
+	// Only the first request is slow enough to exceed the client timeout.
+	var calls atomic.Int32
+	ms := NewMockServer(WithLatencyDistribution(func() time.Duration {
+		if calls.Add(1) == 1 {
+			return 500 * time.Millisecond
+		}
+		return 0
+	}))
+	defer ms.Close()
+
+	client, err := langfuse.New(TestPublicKey, TestSecretKey,
+		langfuse.WithBaseURL(ms.URL),
+		langfuse.WithTimeout(50*time.Millisecond),
+		langfuse.WithMaxRetries(2),
+		langfuse.WithRetryDelay(10*time.Millisecond),
+		langfuse.WithFlushInterval(time.Minute),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	if _, err := client.NewTrace().Name("slow").Create(ctx); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush() error = %v, want retry to succeed", err)
+	}
+
+	if n := len(ms.RequestsWithPath("/api/public/ingestion")); n != 2 {
+		t.Errorf("ingestion requests = %d, want 2 (timed out + retry)", n)
+	}
+}
+
+func TestSimulatedLatency_ContextDeadlineExceeded(t *testing.T) {
+	ms := NewMockServer(WithLatencyForEndpoint("/ingestion", 2*time.Second))
+	defer ms.Close()
+
+	client, err := langfuse.New(TestPublicKey, TestSecretKey,
+		langfuse.WithBaseURL(ms.URL),
+		langfuse.WithMaxRetries(3),
+		langfuse.WithRetryDelay(10*time.Millisecond),
+		langfuse.WithFlushInterval(time.Minute),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	if _, err := client.NewTrace().Name("slow").Create(context.Background()); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = client.Flush(ctx)
+	if err == nil {
+		t.Fatal("Flush() succeeded, want deadline error")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Flush() error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Flush() took %v, want it to stop retrying at the deadline", elapsed)
+	}
+}
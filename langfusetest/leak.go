@@ -0,0 +1,45 @@
+package langfusetest
+
+import (
+	"strings"
+	"time"
+
+	langfuse "github.com/jdziat/langfuse-go"
+)
+
+// LeakCheckTimeout is how long CheckForGoroutineLeaks waits for SDK
+// goroutines to exit after fn returns.
+const LeakCheckTimeout = 2 * time.Second
+
+// CheckForGoroutineLeaks runs fn and fails the test if goroutines running
+// SDK code that were started during fn are still running LeakCheckTimeout
+// after it returns. It is typically used to verify that every client
+// created by fn is shut down. Do not use it in parallel tests; their
+// goroutines may be reported as leaks.
+//
+// Example:
+//
+//	langfusetest.CheckForGoroutineLeaks(t, func() {
+//	    client, _ := langfuse.New(pk, sk)
+//	    defer client.Shutdown(context.Background())
+//	    // ... use client ...
+//	})
+func CheckForGoroutineLeaks(t TestingT, fn func()) {
+	t.Helper()
+
+	leaked := langfuse.TrackGoroutines()
+	fn()
+
+	deadline := time.Now().Add(LeakCheckTimeout)
+	for {
+		stacks := leaked()
+		if len(stacks) == 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("langfusetest: %d leaked goroutine(s):\n\n%s", len(stacks), strings.Join(stacks, "\n\n"))
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
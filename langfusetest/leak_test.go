@@ -0,0 +1,65 @@
+package langfusetest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	langfuse "github.com/jdziat/langfuse-go"
+)
+
+// recordingT records Fatalf calls instead of failing the test.
+type recordingT struct {
+	failed  bool
+	message string
+}
+
+func (r *recordingT) Fatalf(format string, args ...any) {
+	r.failed = true
+	r.message = fmt.Sprintf(format, args...)
+}
+func (r *recordingT) Cleanup(func()) {}
+func (r *recordingT) Helper()        {}
+
+func TestCheckForGoroutineLeaks(t *testing.T) {
+	server := NewMockServer()
+	defer server.Close()
+
+	t.Run("client shut down", func(t *testing.T) {
+		CheckForGoroutineLeaks(t, func() {
+			client, err := langfuse.New(TestPublicKey, TestSecretKey, langfuse.WithBaseURL(server.URL))
+			if err != nil {
+				t.Fatalf("New failed: %v", err)
+			}
+			if _, err := client.NewTrace().Name("trace").Create(context.Background()); err != nil {
+				t.Fatalf("Create failed: %v", err)
+			}
+			client.Shutdown(context.Background())
+		})
+	})
+
+	t.Run("client leaked", func(t *testing.T) {
+		var client *langfuse.Client
+		rt := &recordingT{}
+		CheckForGoroutineLeaks(rt, func() {
+			var err error
+			client, err = langfuse.New(TestPublicKey, TestSecretKey, langfuse.WithBaseURL(server.URL))
+			if err != nil {
+				t.Fatalf("New failed: %v", err)
+			}
+			// Start the background goroutines without shutting down.
+			if _, err := client.NewTrace().Name("trace").Create(context.Background()); err != nil {
+				t.Fatalf("Create failed: %v", err)
+			}
+		})
+		client.Shutdown(context.Background())
+
+		if !rt.failed {
+			t.Fatal("CheckForGoroutineLeaks should fail when a client is not shut down")
+		}
+		if !strings.Contains(rt.message, "langfuse") {
+			t.Errorf("failure message should include the leaked stacks, got %q", rt.message)
+		}
+	})
+}
@@ -1,12 +1,16 @@
 package langfusetest
 
 import (
+	"bytes"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync"
 
 	"github.com/jdziat/langfuse-go"
+	pkgingestion "github.com/jdziat/langfuse-go/pkg/ingestion"
 )
 
 // MockServer is a test HTTP server that records requests for verification.
@@ -28,8 +32,22 @@ type RecordedRequest struct {
 	ContentType string
 }
 
+// RecordedIngestionEvent is a single event from a recorded ingestion batch.
+type RecordedIngestionEvent struct {
+	ID        string         `json:"id"`
+	Type      string         `json:"type"`
+	Timestamp string         `json:"timestamp"`
+	Body      map[string]any `json:"body"`
+}
+
 // NewMockServer creates a new mock server for testing.
-func NewMockServer() *MockServer {
+// Options such as WithLatency simulate a slow API.
+//
+// Unless ResponseFunc is set, fetching a single trace returns the trace
+// recorded from trace-create events, or 404 if none was received, so
+// Client.FlushAndWait works against the mock server.
+func NewMockServer(opts ...ServerOption) *MockServer {
+	cfg := newServerConfig(opts)
 	ms := &MockServer{
 		requests: make([]*RecordedRequest, 0),
 	}
@@ -38,8 +56,8 @@ func NewMockServer() *MockServer {
 		// Record the request
 		var body []byte
 		if r.Body != nil {
-			body = make([]byte, r.ContentLength)
-			r.Body.Read(body)
+			body, _ = io.ReadAll(r.Body)
+			r.Body = io.NopCloser(bytes.NewReader(body))
 		}
 
 		ms.mu.Lock()
@@ -51,12 +69,21 @@ func NewMockServer() *MockServer {
 		})
 		ms.mu.Unlock()
 
-		// Generate response
+		cfg.delay(r.Context(), r.URL.Path)
+
+		// Generate response. ResponseFunc can read the request body again.
 		status := http.StatusOK
 		var response any
 
 		if ms.ResponseFunc != nil {
 			status, response = ms.ResponseFunc(r)
+		} else if traceID, ok := traceGetPath(r); ok {
+			if trace, found := ms.trace(traceID); found {
+				response = trace
+			} else {
+				status = http.StatusNotFound
+				response = map[string]string{"message": "trace not found"}
+			}
 		} else {
 			response = langfuse.IngestionResult{
 				Successes: []langfuse.IngestionSuccess{{ID: "test", Status: 200}},
@@ -218,3 +245,93 @@ func (ms *MockServer) RequestsWithPath(path string) []*RecordedRequest {
 	}
 	return matched
 }
+
+// ingestionEvents returns the events of all recorded ingestion batches, in
+// the order they were received. Requests that are not ingestion batches, or
+// that cannot be decoded, are skipped.
+func (ms *MockServer) ingestionEvents() []RecordedIngestionEvent {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	var events []RecordedIngestionEvent
+	for _, req := range ms.requests {
+		if !strings.HasSuffix(req.Path, "/ingestion") {
+			continue
+		}
+		var batch struct {
+			Batch []RecordedIngestionEvent `json:"batch"`
+		}
+		if err := json.Unmarshal(req.Body, &batch); err != nil {
+			continue
+		}
+		events = append(events, batch.Batch...)
+	}
+	return events
+}
+
+// traceGetPath reports whether r fetches a single trace, and its ID.
+func traceGetPath(r *http.Request) (string, bool) {
+	if r.Method != http.MethodGet {
+		return "", false
+	}
+	_, id, ok := strings.Cut(r.URL.Path, "/traces/")
+	if !ok || id == "" || strings.Contains(id, "/") {
+		return "", false
+	}
+	return id, true
+}
+
+// trace returns the trace with the given ID as built from the recorded
+// trace-create events, merged in the order they were received, so a trace is
+// queryable as soon as its ingestion batch arrives.
+func (ms *MockServer) trace(id string) (map[string]any, bool) {
+	var trace map[string]any
+	for _, event := range ms.RequestsByType(pkgingestion.EventTypeTraceCreate) {
+		if event.Body["id"] != id {
+			continue
+		}
+		if trace == nil {
+			trace = make(map[string]any)
+		}
+		for k, v := range event.Body {
+			trace[k] = v
+		}
+	}
+	return trace, trace != nil
+}
+
+// RequestsByType returns the recorded ingestion events with the given type,
+// such as "trace-create" or "generation-update", across all batches.
+func (ms *MockServer) RequestsByType(eventType string) []RecordedIngestionEvent {
+	var matched []RecordedIngestionEvent
+	for _, event := range ms.ingestionEvents() {
+		if event.Type == eventType {
+			matched = append(matched, event)
+		}
+	}
+	return matched
+}
+
+// TracesCreated returns the bodies of all recorded trace-create events.
+func (ms *MockServer) TracesCreated() []map[string]any {
+	return ms.bodiesByType(pkgingestion.EventTypeTraceCreate)
+}
+
+// GenerationsCreated returns the bodies of all recorded generation-create events.
+func (ms *MockServer) GenerationsCreated() []map[string]any {
+	return ms.bodiesByType(pkgingestion.EventTypeGenerationCreate)
+}
+
+// ScoresCreated returns the bodies of all recorded score-create events.
+func (ms *MockServer) ScoresCreated() []map[string]any {
+	return ms.bodiesByType(pkgingestion.EventTypeScoreCreate)
+}
+
+// bodiesByType returns the bodies of the recorded events with the given type.
+func (ms *MockServer) bodiesByType(eventType string) []map[string]any {
+	var bodies []map[string]any
+	for _, event := range ms.RequestsByType(eventType) {
+		bodies = append(bodies, event.Body)
+	}
+	return bodies
+}
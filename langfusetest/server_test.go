@@ -2,10 +2,12 @@ package langfusetest
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/jdziat/langfuse-go"
 )
@@ -391,3 +393,55 @@ func TestMockServer_DefaultResponse(t *testing.T) {
 		t.Error("Default response should have successes")
 	}
 }
+
+func TestMockServer_GetTrace(t *testing.T) {
+	client, server := NewTestClient(t)
+	ctx := context.Background()
+
+	trace, err := client.NewTrace().Name("queryable").Create(ctx)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if _, err := client.Traces().Get(ctx, trace.ID()); err == nil {
+		t.Error("Get() before flush should fail with not found")
+	}
+
+	if err := client.FlushAndWait(ctx, 10*time.Millisecond); err != nil {
+		t.Fatalf("FlushAndWait() error = %v", err)
+	}
+
+	got, err := client.Traces().Get(ctx, trace.ID())
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.ID != trace.ID() || got.Name != "queryable" {
+		t.Errorf("Get() = %+v, want recorded trace", got)
+	}
+	if n := len(server.RequestsWithPath("/api/public/traces/" + trace.ID())); n != 3 {
+		t.Errorf("got %d trace requests, want 3", n)
+	}
+}
+
+func TestFlushAndWait_GivesUp(t *testing.T) {
+	client, server := NewTestClientWithConfig(t, langfuse.WithFlushWaitMaxPolls(3))
+	server.SetResponseFunc(func(r *http.Request) (int, any) {
+		if r.Method == http.MethodGet {
+			return http.StatusNotFound, map[string]string{"message": "trace not found"}
+		}
+		return http.StatusOK, langfuse.IngestionResult{}
+	})
+	ctx := context.Background()
+
+	trace, err := client.NewTrace().Name("never-queryable").Create(ctx)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if err := client.FlushAndWait(ctx, time.Millisecond); err == nil {
+		t.Fatal("FlushAndWait() should fail when the trace never becomes queryable")
+	}
+	if n := len(server.RequestsWithPath("/api/public/traces/" + trace.ID())); n != 3 {
+		t.Errorf("got %d polls, want 3", n)
+	}
+}
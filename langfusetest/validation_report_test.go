@@ -0,0 +1,80 @@
+package langfusetest
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jdziat/langfuse-go"
+	"github.com/jdziat/langfuse-go/evaluation"
+)
+
+func TestValidationReport_Renders(t *testing.T) {
+	server := NewMockLangfuseServer()
+	defer server.Close()
+
+	client, err := langfuse.New(TestPublicKey, TestSecretKey,
+		langfuse.WithBaseURL(server.URL),
+		langfuse.WithProjectID("proj-1"),
+		langfuse.WithFlushInterval(time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	valid, err := client.NewTrace().Name("qa").
+		Input(map[string]any{"query": "What is Go?"}).
+		Output(map[string]any{"output": "A language."}).
+		Create(ctx)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	invalid, err := client.NewTrace().Name("<b>qa|broken</b>").
+		Input(map[string]any{"question": "What is Go?"}).
+		Create(ctx)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	var traces []*langfuse.Trace
+	for _, id := range []string{valid.ID(), invalid.ID()} {
+		trace, err := client.Traces().Get(ctx, id)
+		if err != nil {
+			t.Fatalf("Traces().Get() error = %v", err)
+		}
+		traces = append(traces, trace)
+	}
+
+	html, err := evaluation.GenerateValidationReport(client, traces, evaluation.QAEvaluator)
+	if err != nil {
+		t.Fatalf("GenerateValidationReport() error = %v", err)
+	}
+	wantLink := server.URL + "/project/proj-1/traces/" + valid.ID()
+	for _, want := range []string{"1 of 2 traces valid", "✓", "✗", "query", wantLink, "&lt;b&gt;qa|broken&lt;/b&gt;"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("HTML report missing %q:\n%s", want, html)
+		}
+	}
+
+	var md strings.Builder
+	report := evaluation.NewValidationReport(client, traces, evaluation.QAEvaluator)
+	if err := report.WriteMarkdown(&md); err != nil {
+		t.Fatalf("WriteMarkdown() error = %v", err)
+	}
+	for _, want := range []string{"| Trace ID |", `qa\|broken`, "[View in Langfuse](" + wantLink + ")"} {
+		if !strings.Contains(md.String(), want) {
+			t.Errorf("Markdown report missing %q:\n%s", want, md.String())
+		}
+	}
+
+	empty, err := evaluation.GenerateValidationReport(nil, nil, evaluation.QAEvaluator)
+	if err != nil || !strings.Contains(empty, "0 of 0 traces valid") {
+		t.Errorf("GenerateValidationReport() of no traces = %q, %v", empty, err)
+	}
+}
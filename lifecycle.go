@@ -1,9 +1,22 @@
 package langfuse
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
 
 	pkgclient "github.com/jdziat/langfuse-go/pkg/client"
 	pkgingestion "github.com/jdziat/langfuse-go/pkg/ingestion"
@@ -55,6 +68,8 @@ var ErrAlreadyClosed = pkglifecycle.ErrAlreadyClosed
 
 // Shutdown gracefully shuts down the client, flushing any pending events.
 // Returns ErrClientClosed if already closed (for backward compatibility).
+// Pending auto-end timeouts and stats history recording are stopped by
+// shutdown hooks, so they also stop when IdleTimeout shuts the client down.
 func (c *Client) Shutdown(ctx context.Context) error {
 	err := c.Client.Shutdown(ctx)
 	// Convert ErrAlreadyClosed to ErrClientClosed for backward compatibility
@@ -64,6 +79,114 @@ func (c *Client) Shutdown(ctx context.Context) error {
 	return err
 }
 
+// FlushSyncResult is the outcome of a Client.FlushSync call.
+type FlushSyncResult struct {
+	// SentEvents is the number of events sent
+	SentEvents int
+
+	// Successes is the number of events Langfuse accepted
+	Successes int
+
+	// Errors are the events Langfuse rejected
+	Errors []IngestionError
+
+	// Duration is how long the flush took
+	Duration time.Duration
+}
+
+// FlushSync sends all pending and queued events and waits, for at most
+// timeout, for Langfuse to confirm them. Unlike Flush, whose per-batch
+// results only reach OnBatchFlushed, it returns what was accepted and
+// rejected directly. Batches are sent on the calling goroutine, bypassing
+// the background workers; a batch a worker is already sending is not
+// waited for. A timeout of 0 relies on ctx alone.
+//
+// This is useful in tests to assert only after events are confirmed:
+//
+//	result, err := client.FlushSync(ctx, 5*time.Second)
+//	if err != nil || len(result.Errors) > 0 {
+//	    t.Fatalf("flush failed: %v, %+v", err, result.Errors)
+//	}
+func (c *Client) FlushSync(ctx context.Context, timeout time.Duration) (*FlushSyncResult, error) {
+	res, err := c.Client.FlushSync(ctx, timeout)
+	if res == nil {
+		return nil, err
+	}
+
+	result := &FlushSyncResult{
+		SentEvents: res.SentEvents,
+		Successes:  res.Successes,
+		Duration:   res.Duration,
+	}
+	for _, e := range res.Errors {
+		result.Errors = append(result.Errors, IngestionError{
+			ID:           e.ID,
+			Status:       e.Status,
+			Message:      e.Message,
+			ErrorMessage: e.Error,
+		})
+	}
+	return result, err
+}
+
+// DefaultFlushWaitMaxPolls is the default number of times FlushAndWait
+// checks whether the last trace is queryable.
+const DefaultFlushWaitMaxPolls = 30
+
+// FlushAndWait flushes pending events, then polls Traces().Get for the most
+// recently queued trace every pollInterval until it stops returning 404,
+// confirming the data is queryable and not just accepted. It gives up with
+// an error after Config.FlushWaitMaxPolls attempts. If no trace was queued,
+// it only flushes.
+//
+// FlushAndWait is a test utility, for example to make sure traces exist
+// before asserting on them through the API in test teardown. Langfuse
+// ingests events asynchronously, so production code should use Flush.
+//
+// Example:
+//
+//	if err := client.FlushAndWait(ctx, 500*time.Millisecond); err != nil {
+//	    t.Fatalf("traces not queryable: %v", err)
+//	}
+func (c *Client) FlushAndWait(ctx context.Context, pollInterval time.Duration) error {
+	if err := c.Flush(ctx); err != nil {
+		return err
+	}
+
+	traceID, _ := c.lastTraceID.Load().(string)
+	if traceID == "" {
+		return nil
+	}
+
+	maxPolls := c.rootConfig.FlushWaitMaxPolls
+	if maxPolls == 0 {
+		maxPolls = DefaultFlushWaitMaxPolls
+	}
+
+	for i := 0; i < maxPolls; i++ {
+		_, err := c.Traces().Get(ctx, traceID)
+		if err == nil {
+			return nil
+		}
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+			return err
+		}
+		if i == maxPolls-1 {
+			break
+		}
+
+		timer := time.NewTimer(pollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return fmt.Errorf("langfuse: trace %s not queryable after %d polls", traceID, maxPolls)
+}
+
 // ============================================================================
 // Client Statistics
 // ============================================================================
@@ -71,6 +194,9 @@ func (c *Client) Shutdown(ctx context.Context) error {
 // ClientStats contains a snapshot of all client metrics.
 // Use this for monitoring and observability.
 type ClientStats struct {
+	// ProjectID is the project ID set with WithProjectID
+	ProjectID string `json:"project_id,omitempty"`
+
 	// Client state
 	State       ClientState `json:"state"`
 	Uptime      string      `json:"uptime"`
@@ -93,6 +219,10 @@ type ClientStats struct {
 
 	// Batch processing metrics
 	Batch BatchStats `json:"batch"`
+
+	// InitialConnectivityTestPassed is true if the connection test enabled
+	// by WithConnectionTest succeeded when the client was created
+	InitialConnectivityTestPassed bool `json:"initial_connectivity_test_passed"`
 }
 
 // BackpressureInfo contains backpressure-related metrics.
@@ -116,8 +246,10 @@ type CircuitBreakerInfo struct {
 
 // BatchStats contains batch processing metrics.
 type BatchStats struct {
-	PendingEvents int `json:"pending_events"`
-	QueuedBatches int `json:"queued_batches"`
+	PendingEvents int   `json:"pending_events"`
+	QueuedBatches int   `json:"queued_batches"`
+	EventsSent    int64 `json:"events_sent"`
+	EventsDropped int64 `json:"events_dropped"`
 }
 
 // Stats returns a snapshot of all client metrics.
@@ -130,11 +262,14 @@ type BatchStats struct {
 //	log.Printf("State: %s, Uptime: %s", stats.State, stats.Uptime)
 func (c *Client) Stats() ClientStats {
 	stats := ClientStats{
+		ProjectID:    c.rootConfig.ProjectID,
 		State:        c.State(),
 		Uptime:       c.Uptime().String(),
 		UptimeNanos:  c.Uptime().Nanoseconds(),
 		Lifecycle:    c.LifecycleStats(),
 		IDGeneration: c.IDStats(),
+
+		InitialConnectivityTestPassed: c.connectionTestPassed.Load(),
 	}
 
 	// Queue/batch metrics from rootConfig
@@ -153,6 +288,14 @@ func (c *Client) Stats() ClientStats {
 		IsUnderPressure: c.IsUnderBackpressure(),
 	}
 
+	queue := c.QueueStats()
+	stats.Batch = BatchStats{
+		PendingEvents: queue.PendingEvents,
+		QueuedBatches: queue.BatchQueueLen,
+		EventsSent:    queue.TotalSent,
+		EventsDropped: queue.TotalDropped,
+	}
+
 	// Circuit breaker metrics using exported method
 	cbState := c.CircuitBreakerState()
 	stats.CircuitBreaker = CircuitBreakerInfo{
@@ -164,6 +307,366 @@ func (c *Client) Stats() ClientStats {
 	return stats
 }
 
+// QueueStats contains a snapshot of the client's queue and delivery counters.
+type QueueStats = pkgclient.QueueStats
+
+// ============================================================================
+// Stats History
+// ============================================================================
+
+// Stats history defaults, used for unset StatsHistoryConfig fields.
+const (
+	DefaultStatsHistoryInterval   = 30 * time.Second
+	DefaultStatsHistoryRetention  = 5 * time.Minute
+	DefaultStatsHistoryMaxEntries = 60
+)
+
+// ClientStatsSnapshot is a ClientStats recorded at Timestamp.
+type ClientStatsSnapshot struct {
+	ClientStats
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ClientStatsDelta is the change in the client's counters between two
+// snapshots.
+type ClientStatsDelta struct {
+	// From and To are the timestamps of the snapshots compared
+	From time.Time
+	To   time.Time
+
+	// Duration is To minus From
+	Duration time.Duration
+
+	// EventsSent, EventsDropped and EventsBlocked are the number of events
+	// sent, dropped and blocked between the snapshots
+	EventsSent    int64
+	EventsDropped int64
+	EventsBlocked int64
+
+	// EventsPerSecond is EventsSent divided by Duration, or 0 if Duration
+	// is 0
+	EventsPerSecond float64
+}
+
+// statsHistory is a ring buffer of stats snapshots recorded by a
+// background goroutine.
+type statsHistory struct {
+	cfg  StatsHistoryConfig
+	stop chan struct{}
+	once sync.Once
+
+	mu      sync.Mutex
+	entries []ClientStatsSnapshot
+	next    int
+	full    bool
+}
+
+// newStatsHistory creates a history for cfg, filling in defaults.
+func newStatsHistory(cfg StatsHistoryConfig) *statsHistory {
+	if cfg.Interval == 0 {
+		cfg.Interval = DefaultStatsHistoryInterval
+	}
+	if cfg.Retention == 0 {
+		cfg.Retention = DefaultStatsHistoryRetention
+	}
+	if cfg.MaxEntries == 0 {
+		cfg.MaxEntries = DefaultStatsHistoryMaxEntries
+	}
+	return &statsHistory{
+		cfg:     cfg,
+		stop:    make(chan struct{}),
+		entries: make([]ClientStatsSnapshot, cfg.MaxEntries),
+	}
+}
+
+// add records snap, overwriting the oldest entry when the buffer is full.
+func (h *statsHistory) add(snap ClientStatsSnapshot) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries[h.next] = snap
+	h.next = (h.next + 1) % len(h.entries)
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+// since returns the snapshots taken at or after start that are within the
+// retention period, oldest first.
+func (h *statsHistory) since(start time.Time) []ClientStatsSnapshot {
+	if cutoff := time.Now().Add(-h.cfg.Retention); start.Before(cutoff) {
+		start = cutoff
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	n, first := h.next, 0
+	if h.full {
+		n, first = len(h.entries), h.next
+	}
+
+	var snaps []ClientStatsSnapshot
+	for i := 0; i < n; i++ {
+		snap := h.entries[(first+i)%len(h.entries)]
+		if !snap.Timestamp.Before(start) {
+			snaps = append(snaps, snap)
+		}
+	}
+	return snaps
+}
+
+// close stops the recording goroutine.
+func (h *statsHistory) close() {
+	h.once.Do(func() { close(h.stop) })
+}
+
+// recordStats records a snapshot every interval until the history is
+// closed or the client shuts down.
+func (c *Client) recordStats() {
+	h := c.statsHistory
+	ticker := time.NewTicker(h.cfg.Interval)
+	defer ticker.Stop()
+
+	h.add(ClientStatsSnapshot{ClientStats: c.Stats(), Timestamp: time.Now()})
+	for {
+		select {
+		case <-h.stop:
+			return
+		case <-ticker.C:
+			if c.State() == ClientStateClosed {
+				return
+			}
+			h.add(ClientStatsSnapshot{ClientStats: c.Stats(), Timestamp: time.Now()})
+		}
+	}
+}
+
+// StatsHistory returns the snapshots recorded within window of now, oldest
+// first. A window of 0 or less returns every retained snapshot. It returns
+// nil unless stats history is enabled with WithStatsHistoryConfig.
+//
+// Example:
+//
+//	for _, snap := range client.StatsHistory(time.Minute) {
+//	    log.Printf("%s: %d pending", snap.Timestamp, snap.Batch.PendingEvents)
+//	}
+func (c *Client) StatsHistory(window time.Duration) []ClientStatsSnapshot {
+	if c.statsHistory == nil {
+		return nil
+	}
+	var start time.Time
+	if window > 0 {
+		start = time.Now().Add(-window)
+	}
+	return c.statsHistory.since(start)
+}
+
+// StatsDelta returns the change in the client's counters between the
+// snapshots closest to from and to: for each, the last snapshot taken at or
+// before it, or the first retained snapshot if it is earlier than all of
+// them. It returns a zero delta if no snapshot is retained.
+//
+// Example:
+//
+//	now := time.Now()
+//	delta := client.StatsDelta(now.Add(-time.Minute), now)
+//	log.Printf("%.1f events/s", delta.EventsPerSecond)
+func (c *Client) StatsDelta(from, to time.Time) ClientStatsDelta {
+	snaps := c.StatsHistory(0)
+	if len(snaps) == 0 {
+		return ClientStatsDelta{}
+	}
+
+	at := func(t time.Time) ClientStatsSnapshot {
+		snap := snaps[0]
+		for _, s := range snaps[1:] {
+			if s.Timestamp.After(t) {
+				break
+			}
+			snap = s
+		}
+		return snap
+	}
+	start, end := at(from), at(to)
+
+	delta := ClientStatsDelta{
+		From:          start.Timestamp,
+		To:            end.Timestamp,
+		Duration:      end.Timestamp.Sub(start.Timestamp),
+		EventsSent:    end.Batch.EventsSent - start.Batch.EventsSent,
+		EventsDropped: end.Batch.EventsDropped - start.Batch.EventsDropped,
+		EventsBlocked: end.BackpressureInfo.BlockedCount - start.BackpressureInfo.BlockedCount,
+	}
+	if delta.Duration > 0 {
+		delta.EventsPerSecond = float64(delta.EventsSent) / delta.Duration.Seconds()
+	}
+	return delta
+}
+
+// ============================================================================
+// Debug Information
+// ============================================================================
+
+// ClientConfig is a redacted view of the client configuration.
+type ClientConfig struct {
+	PublicKey       string        `json:"public_key"`
+	SecretKey       string        `json:"secret_key"`
+	BaseURL         string        `json:"base_url"`
+	APIPathPrefix   string        `json:"api_path_prefix"`
+	Region          Region        `json:"region"`
+	Timeout         time.Duration `json:"timeout"`
+	MaxRetries      int           `json:"max_retries"`
+	BatchSize       int           `json:"batch_size"`
+	FlushInterval   time.Duration `json:"flush_interval"`
+	BatchQueueSize  int           `json:"batch_queue_size"`
+	ShutdownTimeout time.Duration `json:"shutdown_timeout"`
+	Debug           bool          `json:"debug"`
+}
+
+// DebugInfo is a dump of the client's internal state for troubleshooting.
+type DebugInfo struct {
+	Config              ClientConfig     `json:"config"`
+	PendingEvents       int              `json:"pending_events"`
+	BatchQueueLen       int              `json:"batch_queue_len"`
+	BatchQueueCap       int              `json:"batch_queue_cap"`
+	CircuitBreakerState string           `json:"circuit_breaker_state"`
+	BackpressureLevel   string           `json:"backpressure_level"`
+	LastFlushTime       time.Time        `json:"last_flush_time"`
+	LastBatchSentAt     time.Time        `json:"last_batch_sent_at"`
+	TotalSent           int64            `json:"total_sent"`
+	TotalDropped        int64            `json:"total_dropped"`
+	GoRoutineCount      int              `json:"goroutine_count"`
+	MemStats            runtime.MemStats `json:"-"`
+}
+
+// Debug returns a dump of the client's internal state, useful when
+// investigating why events are not appearing in Langfuse. Credentials in
+// the returned config are masked.
+//
+// Debug is potentially expensive: it calls runtime.ReadMemStats, which
+// stops the world. Avoid calling it on hot paths.
+//
+// Example:
+//
+//	log.Println(client.Debug())
+func (c *Client) Debug() DebugInfo {
+	cfg := c.rootConfig
+	q := c.QueueStats()
+
+	info := DebugInfo{
+		Config: ClientConfig{
+			PublicKey:       MaskCredential(cfg.PublicKey),
+			SecretKey:       MaskCredential(cfg.SecretKey),
+			BaseURL:         cfg.BaseURL,
+			APIPathPrefix:   cfg.APIPathPrefix,
+			Region:          cfg.Region,
+			Timeout:         cfg.Timeout,
+			MaxRetries:      cfg.MaxRetries,
+			BatchSize:       cfg.BatchSize,
+			FlushInterval:   cfg.FlushInterval,
+			BatchQueueSize:  cfg.BatchQueueSize,
+			ShutdownTimeout: cfg.ShutdownTimeout,
+			Debug:           cfg.Debug,
+		},
+		PendingEvents:       q.PendingEvents,
+		BatchQueueLen:       q.BatchQueueLen,
+		BatchQueueCap:       q.BatchQueueCap,
+		CircuitBreakerState: c.CircuitBreakerState().String(),
+		BackpressureLevel:   c.BackpressureLevel().String(),
+		LastFlushTime:       q.LastFlushTime,
+		LastBatchSentAt:     q.LastBatchSentAt,
+		TotalSent:           q.TotalSent,
+		TotalDropped:        q.TotalDropped,
+		GoRoutineCount:      runtime.NumGoroutine(),
+	}
+	runtime.ReadMemStats(&info.MemStats)
+
+	return info
+}
+
+// String formats the debug information as a readable summary.
+func (d DebugInfo) String() string {
+	formatTime := func(t time.Time) string {
+		if t.IsZero() {
+			return "never"
+		}
+		return t.Format(time.RFC3339Nano)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("langfuse client debug info\n")
+	fmt.Fprintf(&sb, "  config:          base_url=%s prefix=%s region=%s public_key=%s secret_key=%s\n",
+		d.Config.BaseURL, d.Config.APIPathPrefix, d.Config.Region, d.Config.PublicKey, d.Config.SecretKey)
+	fmt.Fprintf(&sb, "                   batch_size=%d flush_interval=%s queue_size=%d timeout=%s max_retries=%d shutdown_timeout=%s debug=%t\n",
+		d.Config.BatchSize, d.Config.FlushInterval, d.Config.BatchQueueSize, d.Config.Timeout,
+		d.Config.MaxRetries, d.Config.ShutdownTimeout, d.Config.Debug)
+	fmt.Fprintf(&sb, "  pending events:  %d\n", d.PendingEvents)
+	fmt.Fprintf(&sb, "  batch queue:     %d/%d\n", d.BatchQueueLen, d.BatchQueueCap)
+	fmt.Fprintf(&sb, "  circuit breaker: %s\n", d.CircuitBreakerState)
+	fmt.Fprintf(&sb, "  backpressure:    %s\n", d.BackpressureLevel)
+	fmt.Fprintf(&sb, "  last flush:      %s\n", formatTime(d.LastFlushTime))
+	fmt.Fprintf(&sb, "  last batch sent: %s\n", formatTime(d.LastBatchSentAt))
+	fmt.Fprintf(&sb, "  events sent:     %d\n", d.TotalSent)
+	fmt.Fprintf(&sb, "  events dropped:  %d\n", d.TotalDropped)
+	fmt.Fprintf(&sb, "  goroutines:      %d\n", d.GoRoutineCount)
+	fmt.Fprintf(&sb, "  heap alloc:      %d bytes (%d objects)\n", d.MemStats.HeapAlloc, d.MemStats.HeapObjects)
+	return sb.String()
+}
+
+// TrackGoroutines records the goroutines that are currently running SDK
+// code and returns a function that reports the stack traces of SDK
+// goroutines started since. Call it before creating a client and call the
+// returned function after Shutdown; a non-empty result indicates a leak.
+//
+// Goroutines are matched by "langfuse" appearing in their stack trace, so
+// goroutines of other tests running in parallel may be reported.
+//
+// Example:
+//
+//	leaked := langfuse.TrackGoroutines()
+//	client, _ := langfuse.New(pk, sk)
+//	// ... use client ...
+//	client.Shutdown(ctx)
+//	if stacks := leaked(); len(stacks) > 0 {
+//	    t.Errorf("leaked goroutines:\n%s", strings.Join(stacks, "\n\n"))
+//	}
+func TrackGoroutines() func() []string {
+	before := langfuseGoroutines()
+	return func() []string {
+		var leaked []string
+		for id, stack := range langfuseGoroutines() {
+			if _, ok := before[id]; !ok {
+				leaked = append(leaked, stack)
+			}
+		}
+		return leaked
+	}
+}
+
+// langfuseGoroutines returns the stack traces of all goroutines whose stack
+// mentions langfuse, keyed by goroutine ID.
+func langfuseGoroutines() map[string]string {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	goroutines := make(map[string]string)
+	for _, stack := range strings.Split(string(buf), "\n\n") {
+		// Each stack starts with "goroutine <id> [<state>]:".
+		fields := strings.Fields(stack)
+		if len(fields) < 2 || fields[0] != "goroutine" || !strings.Contains(stack, "langfuse") {
+			continue
+		}
+		goroutines[fields[1]] = stack
+	}
+	return goroutines
+}
+
 // StatsHandler returns an http.Handler that serves client statistics as JSON.
 // This is useful for monitoring and debugging.
 //
@@ -262,6 +765,112 @@ func (c *Client) ReadyHandler() http.Handler {
 	})
 }
 
+// ============================================================================
+// Connectivity Test
+// ============================================================================
+
+// Connectivity check names used as keys in ConnectivityReport.
+const (
+	ConnectivityCheckHealth = "health"
+	ConnectivityCheckRead   = "read"
+	ConnectivityCheckWrite  = "write"
+)
+
+// ConnectivityReport is the result of Client.TestConnectivity.
+type ConnectivityReport struct {
+	// APIReachable is true if the health endpoint responded successfully
+	APIReachable bool
+
+	// ReadPermission is true if the credentials can list traces
+	ReadPermission bool
+
+	// WritePermission is true if a test event was accepted by the ingestion API
+	WritePermission bool
+
+	// Latency is the duration of each check, keyed by check name
+	Latency map[string]time.Duration
+
+	// Errors holds the error of each failed check, keyed by check name
+	Errors map[string]error
+}
+
+// OK returns true if every check passed.
+func (r *ConnectivityReport) OK() bool {
+	return r.APIReachable && r.ReadPermission && r.WritePermission
+}
+
+// TestConnectivity verifies that the client can reach the Langfuse API and
+// that its credentials have read and write access. It runs three checks in
+// order: a health check, listing a single trace, and sending a test trace
+// with metadata {"_test": true} directly to the ingestion API. All checks
+// run even if an earlier one fails.
+//
+// The report is always returned; the error is non-nil if any check failed.
+//
+// Example:
+//
+//	report, err := client.TestConnectivity(ctx)
+//	if err != nil {
+//	    for check, checkErr := range report.Errors {
+//	        log.Printf("%s: %v", check, checkErr)
+//	    }
+//	}
+func (c *Client) TestConnectivity(ctx context.Context) (*ConnectivityReport, error) {
+	report := &ConnectivityReport{
+		Latency: make(map[string]time.Duration),
+		Errors:  make(map[string]error),
+	}
+
+	run := func(name string, check func() error) bool {
+		start := time.Now()
+		err := check()
+		report.Latency[name] = time.Since(start)
+		if err != nil {
+			report.Errors[name] = err
+			return false
+		}
+		return true
+	}
+
+	report.APIReachable = run(ConnectivityCheckHealth, func() error {
+		_, err := c.Health(ctx)
+		return err
+	})
+
+	report.ReadPermission = run(ConnectivityCheckRead, func() error {
+		_, err := c.Traces().List(ctx, &TracesListParams{
+			PaginationParams: PaginationParams{Limit: 1},
+		})
+		return err
+	})
+
+	report.WritePermission = run(ConnectivityCheckWrite, func() error {
+		result, err := c.Client.Ingest(ctx, []pkgclient.IngestionEvent{{
+			ID:        generateID(),
+			Type:      eventTypeTraceCreate,
+			Timestamp: pkgclient.Now(),
+			Body: &traceEvent{
+				ID:       generateID(),
+				Name:     "langfuse-connectivity-test",
+				Metadata: Metadata{"_test": true},
+			},
+		}})
+		if err != nil {
+			return err
+		}
+		if result.HasErrors() {
+			e := result.Errors[0]
+			return fmt.Errorf("langfuse: test event rejected (status %d): %s", e.Status, e.Message)
+		}
+		return nil
+	})
+
+	if len(report.Errors) > 0 {
+		return report, fmt.Errorf("langfuse: connectivity test failed %d of 3 checks", len(report.Errors))
+	}
+	return report, nil
+}
+
 // ============================================================================
 // Internal Metrics - Re-exported from pkg/lifecycle
 // ============================================================================
@@ -311,6 +920,32 @@ const (
 	eventTypeSDKLog           = pkgingestion.EventTypeSDKLog
 )
 
+// Ingestion event types, for use with WithEventTypeFilter and
+// WithEventTypeAllowlist.
+const (
+	EventTypeTraceCreate      = eventTypeTraceCreate
+	EventTypeTraceUpdate      = eventTypeTraceUpdate
+	EventTypeSpanCreate       = eventTypeSpanCreate
+	EventTypeSpanUpdate       = eventTypeSpanUpdate
+	EventTypeGenerationCreate = eventTypeGenerationCreate
+	EventTypeGenerationUpdate = eventTypeGenerationUpdate
+	EventTypeEventCreate      = eventTypeEventCreate
+	EventTypeScoreCreate      = eventTypeScoreCreate
+	EventTypeSDKLog           = eventTypeSDKLog
+)
+
+// isKnownEventType reports whether eventType is one of the EventType constants.
+func isKnownEventType(eventType string) bool {
+	switch eventType {
+	case EventTypeTraceCreate, EventTypeTraceUpdate,
+		EventTypeSpanCreate, EventTypeSpanUpdate,
+		EventTypeGenerationCreate, EventTypeGenerationUpdate,
+		EventTypeEventCreate, EventTypeScoreCreate, EventTypeSDKLog:
+		return true
+	}
+	return false
+}
+
 // ============================================================================
 // UUID Functions - Re-exported from pkg/ingestion
 // ============================================================================
@@ -460,12 +1095,13 @@ type observationEvent struct {
 	Environment         string           `json:"environment,omitempty"`
 
 	// Generation-specific fields (ignored for spans/events)
-	Model               string   `json:"model,omitempty"`
-	ModelParameters     Metadata `json:"modelParameters,omitempty"`
-	Usage               *Usage   `json:"usage,omitempty"`
-	PromptName          string   `json:"promptName,omitempty"`
-	PromptVersion       int      `json:"promptVersion,omitempty"`
-	CompletionStartTime *Time    `json:"completionStartTime,omitempty"`
+	Model               string         `json:"model,omitempty"`
+	ModelParameters     Metadata       `json:"modelParameters,omitempty"`
+	Usage               *Usage         `json:"usage,omitempty"`
+	UsageDetails        map[string]int `json:"usageDetails,omitempty"`
+	PromptName          string         `json:"promptName,omitempty"`
+	PromptVersion       int            `json:"promptVersion,omitempty"`
+	CompletionStartTime *Time          `json:"completionStartTime,omitempty"`
 }
 
 // scoreEvent represents the body of a score-create event.
@@ -514,12 +1150,639 @@ var ErrBatchDropped = pkgclient.ErrBatchDropped
 //
 // queueEvent is a wrapper that converts root's ingestionEvent to pkgclient.IngestionEvent.
 func (c *Client) queueEvent(ctx context.Context, event ingestionEvent) error {
+	if !c.eventTypeEnabled(event.Type) {
+		if c.rootConfig.Metrics != nil {
+			c.rootConfig.Metrics.IncrementCounter("langfuse.events.type_filtered", 1)
+		}
+		return nil
+	}
+	if len(c.rootConfig.TraceProcessors) > 0 {
+		processed, ok := c.processEvent(event)
+		if !ok {
+			return nil
+		}
+		event = processed
+	}
+
+	body := c.truncateEventBody(event.Body)
+	if threshold := c.rootConfig.CompressThreshold; threshold > 0 {
+		body = compressEventBody(body, threshold)
+	}
+
 	// Convert root ingestionEvent to pkgclient.IngestionEvent
 	pkgEvent := pkgclient.IngestionEvent{
 		ID:        event.ID,
 		Type:      event.Type,
 		Timestamp: pkgclient.Time{Time: event.Timestamp.Time},
-		Body:      event.Body,
+		Body:      body,
+	}
+	if err := c.Client.QueueEvent(ctx, pkgEvent); err != nil {
+		return err
+	}
+	if event.Type == eventTypeTraceCreate {
+		if traceID := eventTraceID(event.Body); traceID != "" {
+			c.lastTraceID.Store(traceID)
+		}
+	}
+	c.publishEvent(event, body)
+	return nil
+}
+
+// eventTypeEnabled reports whether events of the given type pass the
+// configured event type filter and allowlist.
+func (c *Client) eventTypeEnabled(eventType string) bool {
+	if allow := c.rootConfig.EventTypeAllowlist; len(allow) > 0 && !slices.Contains(allow, eventType) {
+		return false
+	}
+	return !slices.Contains(c.rootConfig.EventTypeFilter, eventType)
+}
+
+// ============================================================================
+// Trace Auto-End
+// ============================================================================
+
+// autoEndTimer is the pending TraceBuilder.AutoEnd timeout of a trace.
+type autoEndTimer struct {
+	stop chan struct{}
+	once sync.Once
+}
+
+// cancel stops the timeout. It is safe to call on a nil timer and more
+// than once.
+func (a *autoEndTimer) cancel() {
+	if a == nil {
+		return
+	}
+	a.once.Do(func() { close(a.stop) })
+}
+
+// startAutoEnd starts a goroutine, tracked in autoEndWG, that ends trace
+// after d unless its timer is cancelled or the client shuts down first.
+func (c *Client) startAutoEnd(trace *TraceContext, d time.Duration) {
+	c.autoEndMu.Lock()
+	defer c.autoEndMu.Unlock()
+	if c.autoEndStopped {
+		return
+	}
+	if c.autoEndStop == nil {
+		c.autoEndStop = make(chan struct{})
+	}
+
+	timer := &autoEndTimer{stop: make(chan struct{})}
+	trace.autoEnd = timer
+	stop := c.autoEndStop
+
+	c.autoEndWG.Add(1)
+	go func() {
+		defer c.autoEndWG.Done()
+
+		t := time.NewTimer(d)
+		defer t.Stop()
+		select {
+		case <-t.C:
+		case <-timer.stop:
+			return
+		case <-stop:
+			return
+		}
+
+		timer.cancel()
+		err := trace.Update().
+			Metadata(Metadata{StatusMessageMetadataKey: AutoEndStatusMessage}).
+			Apply(context.Background())
+		if err != nil {
+			c.handleRootError(NewAsyncError(AsyncOpQueue, err).WithContext("trace_id", trace.ID()))
+		}
+	}()
+}
+
+// stopAutoEnds cancels pending auto-end timeouts and waits for any that are
+// ending their trace, so the update is queued before the final flush.
+func (c *Client) stopAutoEnds() {
+	c.autoEndMu.Lock()
+	if !c.autoEndStopped {
+		c.autoEndStopped = true
+		if c.autoEndStop != nil {
+			close(c.autoEndStop)
+		}
+	}
+	c.autoEndMu.Unlock()
+	c.autoEndWG.Wait()
+}
+
+// ============================================================================
+// Trace Processors
+// ============================================================================
+
+// TraceEvent is an ingestion event as seen by trace processors, before it is
+// truncated, compressed and queued.
+type TraceEvent struct {
+	// ID is the ingestion event ID
+	ID string
+
+	// Type is the ingestion event type, e.g. "trace-create"
+	Type string
+
+	// Timestamp is the time the event was created
+	Timestamp time.Time
+
+	// Body is the event body in its wire form, keyed by the JSON field
+	// names of the Langfuse API, e.g. "userId" or "input"
+	Body map[string]any
+}
+
+// ProcessorFn transforms an event before it is queued. It may modify the
+// event in place or return a different one; returning nil drops the event.
+type ProcessorFn func(event *TraceEvent) *TraceEvent
+
+// AddFieldProcessor returns a processor that sets field of every event body
+// to value, replacing any existing value.
+//
+// Example:
+//
+//	client, _ := langfuse.New(pk, sk,
+//	    langfuse.WithTraceProcessor(langfuse.AddFieldProcessor("environment", "staging")),
+//	)
+func AddFieldProcessor(field string, value any) ProcessorFn {
+	return func(event *TraceEvent) *TraceEvent {
+		if event.Body == nil {
+			event.Body = make(map[string]any)
+		}
+		event.Body[field] = value
+		return event
+	}
+}
+
+// RemoveFieldProcessor returns a processor that removes field from every
+// event body.
+func RemoveFieldProcessor(field string) ProcessorFn {
+	return func(event *TraceEvent) *TraceEvent {
+		delete(event.Body, field)
+		return event
+	}
+}
+
+// TransformInputProcessor returns a processor that replaces the input of
+// every event that has one with fn(input), for example to redact sensitive
+// values. The input is given in its JSON-decoded form.
+func TransformInputProcessor(fn func(any) any) ProcessorFn {
+	return func(event *TraceEvent) *TraceEvent {
+		if input, ok := event.Body["input"]; ok && input != nil {
+			event.Body["input"] = fn(input)
+		}
+		return event
+	}
+}
+
+// processEvent runs the configured trace processors on event in order. The
+// body is converted to its wire form first, so the returned event always
+// has a map[string]any body. It returns false if a processor dropped the
+// event. If the body cannot be converted or a processor panics, the
+// original event is returned unchanged.
+func (c *Client) processEvent(event ingestionEvent) (ingestionEvent, bool) {
+	data, err := json.Marshal(event.Body)
+	if err != nil {
+		return event, true
+	}
+	current := &TraceEvent{
+		ID:        event.ID,
+		Type:      event.Type,
+		Timestamp: event.Timestamp.Time,
+	}
+	if err := json.Unmarshal(data, &current.Body); err != nil {
+		return event, true
+	}
+
+	for _, fn := range c.rootConfig.TraceProcessors {
+		next, ok := c.runTraceProcessor(fn, current)
+		if !ok {
+			return event, true
+		}
+		if next == nil {
+			if c.rootConfig.Metrics != nil {
+				c.rootConfig.Metrics.IncrementCounter("langfuse.events.processor_dropped", 1)
+			}
+			return ingestionEvent{}, false
+		}
+		current = next
+	}
+
+	return ingestionEvent{
+		ID:        current.ID,
+		Type:      current.Type,
+		Timestamp: Time{Time: current.Timestamp},
+		Body:      current.Body,
+	}, true
+}
+
+// runTraceProcessor calls fn, recovering a panic unless DisablePanicRecovery
+// is set. A recovered panic is reported as an AsyncOpInternal error and
+// ok is false.
+func (c *Client) runTraceProcessor(fn ProcessorFn, event *TraceEvent) (result *TraceEvent, ok bool) {
+	if c.rootConfig.DisablePanicRecovery {
+		return fn(event), true
+	}
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		if c.rootConfig.Metrics != nil {
+			c.rootConfig.Metrics.IncrementCounter("langfuse.panics.recovered", 1)
+		}
+		c.handleRootError(NewAsyncError(AsyncOpInternal, fmt.Errorf("langfuse: panic in trace processor: %v", r)).
+			WithContext("panic", r).
+			WithContext("stack", string(debug.Stack())))
+		result, ok = nil, false
+	}()
+	return fn(event), true
+}
+
+// ============================================================================
+// Local Event Subscriptions
+// ============================================================================
+
+// subscriberBufferSize is the number of events buffered for each Subscribe
+// channel before further events are dropped.
+const subscriberBufferSize = 256
+
+// ObservedEvent is a copy of a queued ingestion event delivered to Subscribe
+// channels.
+type ObservedEvent struct {
+	// Type is the ingestion event type, e.g. "trace-create"
+	Type string
+
+	// ID is the ingestion event ID
+	ID string
+
+	// TraceID is the ID of the trace the event belongs to, if any
+	TraceID string
+
+	// Body is the event body as it is sent to Langfuse
+	Body map[string]any
+
+	// Timestamp is the time the event was created
+	Timestamp time.Time
+}
+
+// Subscribe returns a channel that receives a copy of every event of
+// eventType queued by the client, for in-process consumers such as live
+// dashboards. Use "*" to receive every event. The channel is closed when
+// ctx is done, so it can be consumed with range; callers must cancel ctx to
+// release the subscription.
+//
+// Sends never block event queuing: if a subscriber falls more than 256
+// events behind, further events are dropped for that subscriber.
+//
+// Example:
+//
+//	ctx, cancel := context.WithCancel(context.Background())
+//	defer cancel()
+//	events, err := client.Subscribe(ctx, "generation-create")
+//	if err != nil {
+//	    return err
+//	}
+//	for event := range events {
+//	    dashboard.Add(event.TraceID, event.Body["model"])
+//	}
+func (c *Client) Subscribe(ctx context.Context, eventType string) (<-chan ObservedEvent, error) {
+	if eventType == "" {
+		return nil, fmt.Errorf("langfuse: event type is required")
+	}
+	if c.State() != ClientStateActive {
+		return nil, ErrClientClosed
+	}
+
+	ch := make(chan ObservedEvent, subscriberBufferSize)
+
+	c.subscribersMu.Lock()
+	if c.subscribers == nil {
+		c.subscribers = make(map[chan ObservedEvent]string)
+	}
+	c.subscribers[ch] = eventType
+	c.subscribersMu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		c.subscribersMu.Lock()
+		delete(c.subscribers, ch)
+		close(ch)
+		c.subscribersMu.Unlock()
+	}()
+
+	return ch, nil
+}
+
+// SubscriberCount returns the number of active Subscribe channels.
+func (c *Client) SubscriberCount() int {
+	c.subscribersMu.Lock()
+	defer c.subscribersMu.Unlock()
+	return len(c.subscribers)
+}
+
+// publishEvent sends a copy of a queued event to every matching subscriber.
+// The body is only converted when at least one subscriber matches, and each
+// subscriber receives its own copy of it.
+func (c *Client) publishEvent(event ingestionEvent, body any) {
+	c.subscribersMu.Lock()
+	defer c.subscribersMu.Unlock()
+
+	var data []byte
+	for ch, eventType := range c.subscribers {
+		if eventType != "*" && eventType != event.Type {
+			continue
+		}
+		if data == nil {
+			var err error
+			if data, err = json.Marshal(body); err != nil {
+				return
+			}
+		}
+
+		observed := ObservedEvent{
+			Type:      event.Type,
+			ID:        event.ID,
+			TraceID:   eventTraceID(event.Body),
+			Timestamp: event.Timestamp.Time,
+		}
+		if err := json.Unmarshal(data, &observed.Body); err != nil {
+			return
+		}
+
+		select {
+		case ch <- observed:
+		default:
+			if c.rootConfig.Metrics != nil {
+				c.rootConfig.Metrics.IncrementCounter("langfuse.events.subscriber_dropped", 1)
+			}
+		}
+	}
+}
+
+// eventTraceID returns the ID of the trace an event body belongs to.
+func eventTraceID(body any) string {
+	switch b := body.(type) {
+	case *traceEvent:
+		return b.ID
+	case *observationEvent:
+		return b.TraceID
+	case *scoreEvent:
+		return b.TraceID
+	case map[string]any:
+		if traceID, _ := b["traceId"].(string); traceID != "" {
+			return traceID
+		}
+		traceID, _ := b["id"].(string)
+		return traceID
+	default:
+		return ""
+	}
+}
+
+// ============================================================================
+// Payload Truncation
+// ============================================================================
+
+// truncatedField is the wire form of a metadata value, input or output
+// truncated by WithMaxMetadataSize, WithMaxInputSize or WithMaxOutputSize.
+type truncatedField struct {
+	Truncated    bool   `json:"_truncated"`
+	Preview      string `json:"preview"`
+	OriginalSize int    `json:"originalSize"`
+}
+
+// truncateEventBody returns a copy of the body with oversized inputs,
+// outputs and metadata values truncated. Bodies are returned unchanged when
+// no limit is configured. Like compressEventBody, the original body is
+// never modified.
+func (c *Client) truncateEventBody(body any) any {
+	metaLimit := c.rootConfig.MaxMetadataSize
+	inputLimit := c.rootConfig.MaxInputSize
+	if inputLimit == 0 {
+		inputLimit = metaLimit
+	}
+	outputLimit := c.rootConfig.MaxOutputSize
+	if outputLimit == 0 {
+		outputLimit = metaLimit
+	}
+	if metaLimit == 0 && inputLimit == 0 && outputLimit == 0 {
+		return body
+	}
+
+	switch b := body.(type) {
+	case *traceEvent:
+		cp := *b
+		cp.Input = c.truncateField("input", cp.Input, inputLimit)
+		cp.Output = c.truncateField("output", cp.Output, outputLimit)
+		cp.Metadata = c.truncateMetadata(cp.Metadata, metaLimit)
+		return &cp
+	case *observationEvent:
+		cp := *b
+		cp.Input = c.truncateField("input", cp.Input, inputLimit)
+		cp.Output = c.truncateField("output", cp.Output, outputLimit)
+		cp.Metadata = c.truncateMetadata(cp.Metadata, metaLimit)
+		return &cp
+	case *scoreEvent:
+		cp := *b
+		cp.Metadata = c.truncateMetadata(cp.Metadata, metaLimit)
+		return &cp
+	case map[string]any:
+		// Bodies rewritten by trace processors.
+		cp := make(map[string]any, len(b))
+		for k, v := range b {
+			cp[k] = v
+		}
+		if input, ok := cp["input"]; ok {
+			cp["input"] = c.truncateField("input", input, inputLimit)
+		}
+		if output, ok := cp["output"]; ok {
+			cp["output"] = c.truncateField("output", output, outputLimit)
+		}
+		if metadata, ok := cp["metadata"].(map[string]any); ok {
+			cp["metadata"] = c.truncateMetadata(metadata, metaLimit)
+		}
+		return cp
+	default:
+		return body
+	}
+}
+
+// truncateMetadata returns metadata with each oversized value truncated.
+// The map is copied only if a value is truncated.
+func (c *Client) truncateMetadata(metadata Metadata, limit int) Metadata {
+	if limit <= 0 || len(metadata) == 0 {
+		return metadata
+	}
+	var out Metadata
+	for k, v := range metadata {
+		tv := c.truncateField("metadata."+k, v, limit)
+		if _, ok := tv.(*truncatedField); !ok {
+			continue
+		}
+		if out == nil {
+			out = make(Metadata, len(metadata))
+			for k2, v2 := range metadata {
+				out[k2] = v2
+			}
+		}
+		out[k] = tv
+	}
+	if out == nil {
+		return metadata
+	}
+	return out
+}
+
+// truncateField replaces value with a truncatedField if its JSON form
+// exceeds limit bytes. The preview holds the first limit bytes of the JSON,
+// cut back to a rune boundary. Values that cannot be marshalled are returned
+// unchanged.
+func (c *Client) truncateField(name string, value any, limit int) any {
+	if value == nil || limit <= 0 {
+		return value
+	}
+	data, err := json.Marshal(value)
+	if err != nil || len(data) <= limit {
+		return value
+	}
+
+	preview := data[:limit]
+	for len(preview) > 0 && !utf8.Valid(preview) {
+		preview = preview[:len(preview)-1]
+	}
+
+	if c.rootConfig.StructuredLogger != nil {
+		c.rootConfig.StructuredLogger.Debug("truncated field", "field", name, "originalSize", len(data), "limit", limit)
+	} else if c.rootConfig.Logger != nil && IsDebugEnabled(c.rootConfig.Logger) {
+		c.rootConfig.Logger.Printf("truncated %s from %d to %d bytes", name, len(data), limit)
+	}
+
+	return &truncatedField{
+		Truncated:    true,
+		Preview:      string(preview),
+		OriginalSize: len(data),
+	}
+}
+
+// ============================================================================
+// Payload Compression
+// ============================================================================
+
+// compressedField is the wire form of an input or output compressed by
+// WithCompressMetadata.
+type compressedField struct {
+	Compressed     bool   `json:"_compressed"`
+	Data           string `json:"data"`
+	OriginalSize   int    `json:"originalSize"`
+	CompressedSize int    `json:"compressedSize"`
+}
+
+// compressEventBody returns a copy of the trace or observation body with
+// its input and output compressed when they exceed threshold bytes. Other
+// bodies are returned unchanged. The original body is never modified, so
+// builders can be reused after Create.
+func compressEventBody(body any, threshold int) any {
+	switch b := body.(type) {
+	case *traceEvent:
+		cp := *b
+		cp.Input = compressField(cp.Input, threshold)
+		cp.Output = compressField(cp.Output, threshold)
+		return &cp
+	case *observationEvent:
+		cp := *b
+		cp.Input = compressField(cp.Input, threshold)
+		cp.Output = compressField(cp.Output, threshold)
+		return &cp
+	case map[string]any:
+		cp := make(map[string]any, len(b))
+		for k, v := range b {
+			cp[k] = v
+		}
+		if input, ok := cp["input"]; ok {
+			cp["input"] = compressField(input, threshold)
+		}
+		if output, ok := cp["output"]; ok {
+			cp["output"] = compressField(output, threshold)
+		}
+		return cp
+	default:
+		return body
+	}
+}
+
+// compressField gzip-compresses value if its JSON form exceeds threshold
+// bytes. The value is returned unchanged if it is small, cannot be
+// marshalled, or does not shrink when compressed.
+func compressField(value any, threshold int) any {
+	if value == nil {
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil || len(data) <= threshold {
+		return value
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return value
+	}
+	if err := zw.Close(); err != nil {
+		return value
+	}
+	if buf.Len() >= len(data) {
+		return value
+	}
+
+	return &compressedField{
+		Compressed:     true,
+		Data:           base64.StdEncoding.EncodeToString(buf.Bytes()),
+		OriginalSize:   len(data),
+		CompressedSize: buf.Len(),
+	}
+}
+
+// DecompressField restores an input or output that was compressed by
+// WithCompressMetadata, such as the Input of a trace fetched with
+// TracesClient.Get. Values that are not compressed are returned unchanged.
+//
+// Example:
+//
+//	trace, _ := client.Traces().Get(ctx, traceID)
+//	input, err := langfuse.DecompressField(trace.Input)
+func DecompressField(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value, nil
+	}
+	var field compressedField
+	if err := json.Unmarshal(data, &field); err != nil || !field.Compressed {
+		return value, nil
+	}
+
+	compressed, err := base64.StdEncoding.DecodeString(field.Data)
+	if err != nil {
+		return nil, fmt.Errorf("langfuse: invalid compressed field encoding: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("langfuse: invalid compressed field data: %w", err)
+	}
+	defer zr.Close()
+
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("langfuse: failed to decompress field: %w", err)
+	}
+
+	var result any
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, fmt.Errorf("langfuse: failed to decode decompressed field: %w", err)
 	}
-	return c.Client.QueueEvent(ctx, pkgEvent)
+	return result, nil
 }
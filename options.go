@@ -2,6 +2,7 @@ package langfuse
 
 import (
 	"context"
+	"log/slog"
 	"net/http"
 	"strconv"
 	"sync"
@@ -83,6 +84,37 @@ func WithDebug(debug bool) ConfigOption {
 	}
 }
 
+// WithDebugPrettyPrint indents JSON request and response bodies in debug
+// logs. Bodies are only formatted when the logger would write debug output
+// (see DebugEnabler); the transmitted payload is never changed.
+//
+// Example:
+//
+//	client, _ := langfuse.New(pk, sk,
+//	    langfuse.WithDebug(true),
+//	    langfuse.WithDebugPrettyPrint(true),
+//	)
+func WithDebugPrettyPrint(enabled bool) ConfigOption {
+	return func(c *Config) {
+		c.DebugPrettyPrint = enabled
+	}
+}
+
+// WithDebugMaxBodySize truncates request and response bodies in debug logs
+// to the given number of bytes. Zero means no limit.
+//
+// Example:
+//
+//	client, _ := langfuse.New(pk, sk,
+//	    langfuse.WithDebug(true),
+//	    langfuse.WithDebugMaxBodySize(4096),
+//	)
+func WithDebugMaxBodySize(bytes int) ConfigOption {
+	return func(c *Config) {
+		c.DebugMaxBodySize = bytes
+	}
+}
+
 // WithErrorHandler sets an error callback for async failures.
 func WithErrorHandler(handler func(error)) ConfigOption {
 	return func(c *Config) {
@@ -123,6 +155,22 @@ func WithStructuredLogger(logger StructuredLogger) ConfigOption {
 	}
 }
 
+// WithSlogLogger sets a *slog.Logger as the structured logger. SDK log
+// calls are emitted at the matching slog level, with their key-value pairs
+// as attributes. If logger is nil, slog.Default() is used. To pass a
+// context to the slog handler, for example one carrying an OpenTelemetry
+// span, use WithStructuredLogger(NewSlogAdapter(logger).WithContext(ctx)).
+//
+// Example:
+//
+//	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+//	client, _ := langfuse.New(pk, sk,
+//	    langfuse.WithSlogLogger(logger),
+//	)
+func WithSlogLogger(logger *slog.Logger) ConfigOption {
+	return WithStructuredLogger(NewSlogAdapter(logger))
+}
+
 // WithMetrics sets a metrics collector.
 func WithMetrics(metrics Metrics) ConfigOption {
 	return func(c *Config) {
@@ -200,7 +248,8 @@ func WithDefaultCircuitBreaker() ConfigOption {
 }
 
 // WithOnBatchFlushed sets a callback that is called after each batch is sent.
-// This is useful for monitoring, logging, or custom error handling.
+// This is useful for monitoring, logging, or custom error handling. Events
+// Langfuse rejected are listed in BatchResult.IngestionErrors.
 //
 // Example:
 //
@@ -211,6 +260,9 @@ func WithDefaultCircuitBreaker() ConfigOption {
 //	        } else {
 //	            log.Printf("Sent %d events in %v", result.EventCount, result.Duration)
 //	        }
+//	        for _, id := range result.FailedEventIDs() {
+//	            log.Printf("Langfuse rejected event %s", id)
+//	        }
 //	    }),
 //	)
 func WithOnBatchFlushed(callback func(BatchResult)) ConfigOption {
@@ -219,8 +271,54 @@ func WithOnBatchFlushed(callback func(BatchResult)) ConfigOption {
 	}
 }
 
+// WithShutdownCallback sets a callback that is called once at the end of
+// Shutdown, even if it timed out. The summary reports how many events and
+// batches were sent, how many events were dropped, and whether the queue
+// was fully drained.
+//
+// Example:
+//
+//	client, _ := langfuse.New(pk, sk,
+//	    langfuse.WithShutdownCallback(func(s langfuse.ShutdownSummary) {
+//	        log.Printf("langfuse: sent %d events, dropped %d, drained=%v",
+//	            s.TotalEventsSent, s.EventsDropped, s.DrainedSuccessfully)
+//	    }),
+//	)
+func WithShutdownCallback(fn func(result ShutdownSummary)) ConfigOption {
+	return func(c *Config) {
+		c.OnShutdown = fn
+	}
+}
+
+// WithPreSendHook sets a hook that transforms every batch just before it is
+// sent, for example to add fields computed from the final event. The hook
+// receives the JSON form of the batch, one map per event with "id", "type",
+// "timestamp" and "body" keys, and returns the batch to send; events left out
+// are dropped. It runs on the goroutine sending the batch. If the hook
+// panics, the panic is logged and the batch is dropped. Hook latency is
+// recorded as the "langfuse.presend_hook.duration" metric.
+//
+// Example:
+//
+//	client, _ := langfuse.New(pk, sk,
+//	    langfuse.WithPreSendHook(func(batch []map[string]any) []map[string]any {
+//	        for _, event := range batch {
+//	            if body, ok := event["body"].(map[string]any); ok && event["type"] == "generation-create" {
+//	                body["costDetails"] = pricing.Cost(body["model"], body["usageDetails"])
+//	            }
+//	        }
+//	        return batch
+//	    }),
+//	)
+func WithPreSendHook(hook PreSendHook) ConfigOption {
+	return func(c *Config) {
+		c.PreSendHook = hook
+	}
+}
+
 // WithHTTPHooks sets HTTP hooks for request/response customization.
 // Hooks are called in order before requests and in reverse order after responses.
+// To wrap the underlying http.RoundTripper instead, use WithHTTPMiddleware.
 //
 // Use hooks for:
 //   - Adding custom headers to all requests
@@ -242,6 +340,36 @@ func WithHTTPHooks(hooks ...HTTPHook) ConfigOption {
 	}
 }
 
+// WithHTTPMiddleware wraps the HTTP transport with standard
+// http.RoundTripper middleware, such as OpenTelemetry instrumentation, rate
+// limiters or caches. The functions are applied in order from outer to
+// inner: the first one sees each request first. They wrap the transport
+// configured by the SDK or passed with WithHTTPClient, after the timeout,
+// proxy and TLS settings, and are called once when the client is created.
+//
+// WithHTTPHooks are high-level SDK hooks: they observe each request and its
+// duration and may reject a request before it is sent. Middleware instead
+// wraps the transport itself, so it can modify, replace or short-circuit
+// the round trip and works with any third-party RoundTripper.
+// A client passed with WithHTTPClient is not modified; the SDK uses a copy
+// with the wrapped transport.
+//
+// Example:
+//
+//	client, _ := langfuse.New(pk, sk,
+//	    langfuse.WithHTTPMiddleware(
+//	        func(next http.RoundTripper) http.RoundTripper {
+//	            return otelhttp.NewTransport(next)
+//	        },
+//	        rateLimitTransport,
+//	    ),
+//	)
+func WithHTTPMiddleware(fns ...func(http.RoundTripper) http.RoundTripper) ConfigOption {
+	return func(c *Config) {
+		c.HTTPMiddleware = append(c.HTTPMiddleware, fns...)
+	}
+}
+
 // WithIdleWarning enables warnings when the client is idle without shutdown.
 // This helps detect goroutine leaks in development and testing.
 //
@@ -265,6 +393,55 @@ func WithIdleWarning(duration time.Duration) ConfigOption {
 	}
 }
 
+// WithIdleTimeout shuts the client down automatically once no event has been
+// queued for d. Unlike WithIdleWarning, which only logs, the client flushes
+// pending events and stops its background goroutines as if Shutdown had been
+// called. Calling Shutdown first stops the idle monitor.
+//
+// This suits short-lived jobs and serverless invocations that may never
+// reach their own Shutdown call. Events queued after an idle shutdown are
+// rejected with ErrClientClosed.
+//
+// Example:
+//
+//	client, _ := langfuse.New(pk, sk,
+//	    langfuse.WithIdleTimeout(30*time.Second),
+//	    langfuse.WithOnIdleShutdown(func() {
+//	        log.Println("langfuse client idle, shutting down")
+//	    }),
+//	)
+func WithIdleTimeout(d time.Duration) ConfigOption {
+	return func(c *Config) {
+		c.IdleTimeout = d
+	}
+}
+
+// WithOnIdleShutdown sets a callback that is called when WithIdleTimeout
+// triggers a shutdown.
+func WithOnIdleShutdown(fn func()) ConfigOption {
+	return func(c *Config) {
+		c.OnIdleShutdown = fn
+	}
+}
+
+// WithPanicRecovery sets whether panics in user callbacks and background
+// batch processing are recovered. It is enabled by default, so a panicking
+// OnBatchFlushed callback cannot stop event delivery. A recovered panic is
+// reported as an *AsyncError with Operation AsyncOpInternal, whose Context
+// holds the panic value under "panic" and the stack trace under "stack".
+// Disable it to let such panics crash the process, for example in tests.
+//
+// Example:
+//
+//	client, _ := langfuse.New(pk, sk,
+//	    langfuse.WithPanicRecovery(false),
+//	)
+func WithPanicRecovery(enabled bool) ConfigOption {
+	return func(c *Config) {
+		c.DisablePanicRecovery = !enabled
+	}
+}
+
 // WithIDGenerationMode sets the ID generation failure mode.
 //
 // IDModeFallback (default): Uses an atomic counter fallback when crypto/rand fails.
@@ -453,6 +630,75 @@ func WithMaxBackgroundSenders(n int) ConfigOption {
 	}
 }
 
+// WithConcurrentFlush starts workers goroutines that send queued batches in
+// parallel instead of one at a time. This helps keep the queue from filling
+// up when API responses are slow. Shutdown waits for every worker to finish
+// its in-flight send, and OnBatchFlushed calls are serialized across
+// workers. Default is 1.
+//
+// Example:
+//
+//	client, _ := langfuse.New(pk, sk,
+//	    langfuse.WithConcurrentFlush(4),
+//	)
+func WithConcurrentFlush(workers int) ConfigOption {
+	return func(c *Config) {
+		c.FlushWorkers = workers
+	}
+}
+
+// WithMaxConcurrentFlushes limits the number of batch requests in flight at
+// once across the batch processors, background senders and Flush. When the
+// batch queue is full and the limit is reached, event submission blocks
+// until a request completes or the caller's context is done, instead of
+// spawning a background sender. Waits are counted in the
+// langfuse.flush.throttled metric.
+//
+// Example:
+//
+//	client, _ := langfuse.New(pk, sk,
+//	    langfuse.WithMaxConcurrentFlushes(4),
+//	)
+func WithMaxConcurrentFlushes(n int) ConfigOption {
+	return func(c *Config) {
+		c.MaxConcurrentFlushes = n
+	}
+}
+
+// WithMaxFlushesPerSecond limits how many batch requests are sent per second,
+// smoothing bursts that could overwhelm the API. Bursts of up to rps
+// requests are allowed. Waits are counted in the langfuse.flush.throttled
+// metric.
+//
+// Example:
+//
+//	client, _ := langfuse.New(pk, sk,
+//	    langfuse.WithMaxFlushesPerSecond(5),
+//	)
+func WithMaxFlushesPerSecond(rps float64) ConfigOption {
+	return func(c *Config) {
+		c.MaxFlushesPerSecond = rps
+	}
+}
+
+// WithLazyInitialization defers starting the client's background goroutines
+// until the first event is queued, so New only validates the configuration.
+// A client that never queues an event has nothing to stop, and Shutdown
+// returns immediately. This is useful for test suites that create many
+// clients but send events from only a few. Use IsInitialized to check
+// whether the goroutines have started.
+//
+// Example:
+//
+//	client, _ := langfuse.New(pk, sk,
+//	    langfuse.WithLazyInitialization(),
+//	)
+func WithLazyInitialization() ConfigOption {
+	return func(c *Config) {
+		c.LazyInitialization = true
+	}
+}
+
 // WithStrictValidation enables strict validation mode.
 // When enabled, validated builders accumulate errors and force explicit
 // error handling via BuildResult types.
@@ -609,6 +855,378 @@ func WithRAGASEvaluation() ConfigOption {
 	}
 }
 
+// WithEnvironmentVariableOverrides lets operators override selected settings
+// at runtime through environment variables, without code changes. The
+// following variables are read and, when set, take precedence over the
+// values configured in code:
+//
+//   - LANGFUSE_BATCH_SIZE
+//   - LANGFUSE_FLUSH_INTERVAL_MS
+//   - LANGFUSE_DEBUG
+//   - LANGFUSE_MAX_RETRIES
+//   - LANGFUSE_SHUTDOWN_TIMEOUT_MS
+//   - LANGFUSE_QUEUE_SIZE
+//
+// Each variable that replaces a code-configured value is logged. Invalid
+// values cause client construction to fail.
+//
+// Example:
+//
+//	// LANGFUSE_BATCH_SIZE=500 raises the batch size without a rebuild.
+//	client, _ := langfuse.New(pk, sk,
+//	    langfuse.WithBatchSize(100),
+//	    langfuse.WithEnvironmentVariableOverrides(),
+//	)
+func WithEnvironmentVariableOverrides() ConfigOption {
+	return func(c *Config) {
+		c.EnvironmentOverrides = true
+	}
+}
+
+// WithFallbackBaseURL sets a secondary Langfuse endpoint for high-availability
+// setups. When a batch fails against the primary BaseURL with a 5xx or network
+// error after retries are exhausted, or while the circuit breaker is open, the
+// same batch is resent to the fallback using the same credentials. Each use is
+// recorded as the "langfuse.fallback.used" counter. Read operations such as
+// Health never use the fallback.
+//
+// Example:
+//
+//	client, _ := langfuse.New(pk, sk,
+//	    langfuse.WithBaseURL("https://langfuse-primary.internal"),
+//	    langfuse.WithFallbackBaseURL("https://langfuse-backup.internal"),
+//	)
+func WithFallbackBaseURL(url string) ConfigOption {
+	return func(c *Config) {
+		c.FallbackBaseURL = url
+	}
+}
+
+// WithFallbackOptions configures the fallback endpoint set by
+// WithFallbackBaseURL. The options are applied on top of a copy of the
+// primary config, so only the settings that differ need to be given.
+//
+// Example:
+//
+//	client, _ := langfuse.New(pk, sk,
+//	    langfuse.WithFallbackBaseURL("https://langfuse-backup.internal"),
+//	    langfuse.WithFallbackOptions(
+//	        func(c *langfuse.Config) { c.PublicKey, c.SecretKey = backupPK, backupSK },
+//	        langfuse.WithTimeout(5*time.Second),
+//	    ),
+//	)
+func WithFallbackOptions(opts ...ConfigOption) ConfigOption {
+	return func(c *Config) {
+		c.FallbackOptions = append(c.FallbackOptions, opts...)
+	}
+}
+
+// WithCompressMetadata compresses large inputs and outputs to reduce payload
+// size and storage costs. When the JSON form of a trace or observation input
+// or output exceeds threshold bytes, it is gzip-compressed, base64-encoded,
+// and sent as:
+//
+//	{"_compressed": true, "data": "<base64>", "originalSize": N, "compressedSize": M}
+//
+// The Langfuse UI does not render compressed values; use DecompressField to
+// read them programmatically. A threshold of 0 disables compression.
+//
+// Example:
+//
+//	client, _ := langfuse.New(pk, sk,
+//	    langfuse.WithCompressMetadata(64*1024), // compress fields over 64KB
+//	)
+func WithCompressMetadata(threshold int) ConfigOption {
+	return func(c *Config) {
+		c.CompressThreshold = threshold
+	}
+}
+
+// WithStatsHistoryConfig enables a rolling history of Stats snapshots for
+// trend analysis. A snapshot is recorded every cfg.Interval and kept for
+// cfg.Retention, in a ring buffer of cfg.MaxEntries snapshots; by default
+// every 30 seconds for 5 minutes, in at most 60 entries.
+//
+// Example:
+//
+//	client, _ := langfuse.New(pk, sk,
+//	    langfuse.WithStatsHistoryConfig(langfuse.StatsHistoryConfig{Interval: 10 * time.Second}),
+//	)
+//	// later
+//	now := time.Now()
+//	rate := client.StatsDelta(now.Add(-time.Minute), now).EventsPerSecond
+func WithStatsHistoryConfig(cfg StatsHistoryConfig) ConfigOption {
+	return func(c *Config) {
+		c.StatsHistory = &cfg
+	}
+}
+
+// WithFlushWaitMaxPolls sets how many times FlushAndWait checks whether the
+// last trace is queryable before giving up. The default is
+// DefaultFlushWaitMaxPolls.
+func WithFlushWaitMaxPolls(n int) ConfigOption {
+	return func(c *Config) {
+		c.FlushWaitMaxPolls = n
+	}
+}
+
+// WithProjectID records the ID of the Langfuse project the client's keys
+// belong to, for processes that serve several projects. The ID is reported
+// in ClientStats, added to log output as "project_id" and included in the
+// User-Agent header. It does not change which project events are sent to;
+// that is determined by the keys.
+//
+// Example:
+//
+//	client, _ := langfuse.New(keys.Public, keys.Secret,
+//	    langfuse.WithProjectID("proj-checkout"),
+//	)
+func WithProjectID(id string) ConfigOption {
+	return func(c *Config) {
+		c.ProjectID = id
+	}
+}
+
+// WithDefaultRelease sets the release applied to every trace that does not
+// set its own release.
+//
+// Example:
+//
+//	client, _ := langfuse.New(pk, sk,
+//	    langfuse.WithDefaultRelease("v1.4.2"),
+//	)
+func WithDefaultRelease(release string) ConfigOption {
+	return func(c *Config) {
+		c.Release = release
+	}
+}
+
+// WithObservationLevelMapping sets the function that determines the
+// observation level recorded for an error by SpanContext.SetStatusError and
+// the WithError end option. Use StrictLevelMapping, LenientLevelMapping or a
+// custom function, for example to record cache misses as warnings.
+//
+// Example:
+//
+//	client, _ := langfuse.New(pk, sk,
+//	    langfuse.WithObservationLevelMapping(langfuse.LenientLevelMapping()),
+//	)
+func WithObservationLevelMapping(fn func(error) ObservationLevel) ConfigOption {
+	return func(c *Config) {
+		c.ObservationLevelMapping = fn
+	}
+}
+
+// WithConnectionTest checks connectivity and credentials when the client is
+// created, so misconfigured keys are reported by New instead of by the first
+// flush. If the API rejects the credentials, New returns an error. If the API
+// cannot be reached, a warning is logged and the client is created anyway,
+// so offline-first deployments keep working. The result is reported by
+// ClientStats.InitialConnectivityTestPassed.
+//
+// Example:
+//
+//	client, err := langfuse.New(pk, sk,
+//	    langfuse.WithConnectionTest(true),
+//	    langfuse.WithConnectionTestTimeout(2*time.Second),
+//	)
+//	if err != nil {
+//	    log.Fatal(err) // invalid credentials
+//	}
+func WithConnectionTest(testOnConnect bool) ConfigOption {
+	return func(c *Config) {
+		c.ConnectionTest = testOnConnect
+	}
+}
+
+// WithConnectionTestTimeout sets the deadline of the connection test run by
+// WithConnectionTest. The default is DefaultConnectionTestTimeout.
+func WithConnectionTestTimeout(d time.Duration) ConfigOption {
+	return func(c *Config) {
+		c.ConnectionTestTimeout = d
+	}
+}
+
+// WithGitRelease sets the client release to "branch@sha" of the current Git
+// checkout at initialization, as returned by GitRelease. If git is not
+// available or the working directory is not a repository, the release is
+// left unset and a debug message is logged.
+//
+// Example:
+//
+//	client, _ := langfuse.New(pk, sk,
+//	    langfuse.WithGitRelease(),
+//	)
+func WithGitRelease() ConfigOption {
+	return func(c *Config) {
+		c.GitRelease = true
+	}
+}
+
+// WithMetricsPrefix namespaces all SDK metric names by prepending prefix and
+// a dot, so "langfuse.batch.sent" becomes "myapp.langfuse.batch.sent". The
+// prefix may contain only letters, digits, underscores and dots.
+//
+// Example:
+//
+//	client, _ := langfuse.New(pk, sk,
+//	    langfuse.WithMetrics(metrics),
+//	    langfuse.WithMetricsPrefix("checkout_service"),
+//	)
+func WithMetricsPrefix(prefix string) ConfigOption {
+	return func(c *Config) {
+		c.MetricsPrefix = prefix
+	}
+}
+
+// WithMetricsLabels attaches constant labels to every metric call. Labels
+// are passed to Metrics implementations that satisfy LabeledMetrics;
+// other implementations receive the unlabeled calls.
+// Calling it more than once merges the labels.
+//
+// Example:
+//
+//	client, _ := langfuse.New(pk, sk,
+//	    langfuse.WithMetrics(metrics),
+//	    langfuse.WithMetricsLabels(map[string]string{"service": "checkout", "region": "eu"}),
+//	)
+func WithMetricsLabels(labels map[string]string) ConfigOption {
+	return func(c *Config) {
+		if c.MetricsLabels == nil {
+			c.MetricsLabels = make(map[string]string, len(labels))
+		}
+		for k, v := range labels {
+			c.MetricsLabels[k] = v
+		}
+	}
+}
+
+// WithMaxMetadataSize truncates oversized fields before they are sent. When
+// the JSON form of a metadata value, input or output exceeds bytes, it is
+// replaced with:
+//
+//	{"_truncated": true, "preview": "<first bytes of the JSON>", "originalSize": N}
+//
+// The limit applies to traces, observations and scores. Use WithMaxInputSize
+// and WithMaxOutputSize to set different limits for inputs and outputs.
+// Truncation is logged at debug level. A size of 0 disables truncation.
+//
+// Example:
+//
+//	client, _ := langfuse.New(pk, sk,
+//	    langfuse.WithMaxMetadataSize(8*1024), // truncate fields over 8KB
+//	)
+func WithMaxMetadataSize(bytes int) ConfigOption {
+	return func(c *Config) {
+		c.MaxMetadataSize = bytes
+	}
+}
+
+// WithMaxInputSize truncates trace and observation inputs whose JSON form
+// exceeds bytes, overriding WithMaxMetadataSize for inputs.
+//
+// Example:
+//
+//	client, _ := langfuse.New(pk, sk,
+//	    langfuse.WithMaxInputSize(32*1024),
+//	)
+func WithMaxInputSize(bytes int) ConfigOption {
+	return func(c *Config) {
+		c.MaxInputSize = bytes
+	}
+}
+
+// WithMaxOutputSize truncates trace and observation outputs whose JSON form
+// exceeds bytes, overriding WithMaxMetadataSize for outputs.
+//
+// Example:
+//
+//	client, _ := langfuse.New(pk, sk,
+//	    langfuse.WithMaxOutputSize(32*1024),
+//	)
+func WithMaxOutputSize(bytes int) ConfigOption {
+	return func(c *Config) {
+		c.MaxOutputSize = bytes
+	}
+}
+
+// WithTraceProcessor adds a processor that transforms every event before it
+// is queued, for example to normalize user IDs or redact inputs. The
+// processor receives the event body in its wire form and may modify it or
+// return nil to drop the event. Processors added by repeated calls run in
+// order, before truncation and compression.
+//
+// If a processor panics, the panic is reported to the error handler and the
+// original event is queued unchanged, unless panic recovery is disabled
+// with WithPanicRecovery(false).
+//
+// Example:
+//
+//	client, _ := langfuse.New(pk, sk,
+//	    langfuse.WithTraceProcessor(func(e *langfuse.TraceEvent) *langfuse.TraceEvent {
+//	        if userID, ok := e.Body["userId"].(string); ok {
+//	            e.Body["userId"] = strings.ToLower(userID)
+//	        }
+//	        return e
+//	    }),
+//	)
+func WithTraceProcessor(fn ProcessorFn) ConfigOption {
+	return func(c *Config) {
+		c.TraceProcessors = append(c.TraceProcessors, fn)
+	}
+}
+
+// WithTraceProcessors adds several processors that run in the given order.
+// See WithTraceProcessor.
+//
+// Example:
+//
+//	client, _ := langfuse.New(pk, sk,
+//	    langfuse.WithTraceProcessors(
+//	        langfuse.RemoveFieldProcessor("metadata"),
+//	        langfuse.AddFieldProcessor("environment", "staging"),
+//	    ),
+//	)
+func WithTraceProcessors(fns ...ProcessorFn) ConfigOption {
+	return func(c *Config) {
+		c.TraceProcessors = append(c.TraceProcessors, fns...)
+	}
+}
+
+// WithEventTypeFilter drops events of the given ingestion event types
+// instead of queuing them, for example to handle scores through a separate
+// pipeline. Types must be one of the EventType constants; New returns an
+// error otherwise. Repeated calls add to the filter.
+//
+// Example:
+//
+//	client, _ := langfuse.New(pk, sk,
+//	    langfuse.WithEventTypeFilter(langfuse.EventTypeScoreCreate),
+//	)
+func WithEventTypeFilter(types ...string) ConfigOption {
+	return func(c *Config) {
+		c.EventTypeFilter = append(c.EventTypeFilter, types...)
+	}
+}
+
+// WithEventTypeAllowlist queues only events of the given ingestion event
+// types and drops all others. Types must be one of the EventType constants;
+// New returns an error otherwise. Repeated calls add to the allowlist.
+//
+// Note that an observation is usually sent as a create and an update event,
+// so allow both to keep complete observations.
+//
+// Example:
+//
+//	client, _ := langfuse.New(pk, sk,
+//	    langfuse.WithEventTypeAllowlist(langfuse.EventTypeTraceCreate, langfuse.EventTypeTraceUpdate),
+//	)
+func WithEventTypeAllowlist(types ...string) ConfigOption {
+	return func(c *Config) {
+		c.EventTypeAllowlist = append(c.EventTypeAllowlist, types...)
+	}
+}
+
 // ============================================================================
 // Sub-Client Options
 // ============================================================================
@@ -627,8 +1245,9 @@ type promptsConfig struct {
 	cacheEnabled   bool
 }
 
-// WithDefaultLabel sets a default label for all prompt lookups.
-// This is applied when no label is explicitly provided to Get methods.
+// WithDefaultLabel sets a default label for prompt lookups.
+// This is applied when neither a label nor a version is explicitly provided
+// to Get methods.
 //
 // Example:
 //
@@ -644,7 +1263,8 @@ func WithDefaultLabel(label string) PromptsOption {
 }
 
 // WithDefaultVersion sets a default version for prompt lookups.
-// This is applied when no version is explicitly provided.
+// This is applied when neither a label nor a version is explicitly
+// provided. GetLatest never uses the default version.
 //
 // Example:
 //
@@ -809,6 +1429,7 @@ type ConfiguredPromptsClient struct {
 }
 
 type cachedPrompt struct {
+	name      string
 	prompt    *Prompt
 	expiresAt time.Time
 }
@@ -816,9 +1437,11 @@ type cachedPrompt struct {
 // Get retrieves a prompt by name, applying configured defaults.
 // If caching is enabled, cached prompts are returned when available.
 func (c *ConfiguredPromptsClient) Get(ctx context.Context, name string, params *GetPromptParams) (*Prompt, error) {
-	// Apply defaults
-	effectiveParams := c.applyDefaults(params)
+	return c.get(ctx, name, c.applyDefaults(params))
+}
 
+// get retrieves a prompt with params as given, using the cache if enabled.
+func (c *ConfiguredPromptsClient) get(ctx context.Context, name string, effectiveParams *GetPromptParams) (*Prompt, error) {
 	// Check cache if enabled
 	if c.config.cacheEnabled {
 		if prompt := c.getFromCache(name, effectiveParams); prompt != nil {
@@ -840,16 +1463,40 @@ func (c *ConfiguredPromptsClient) Get(ctx context.Context, name string, params *
 	return prompt, nil
 }
 
+// GetLatest retrieves the latest version of a prompt, using the cache if
+// enabled. The default label applies, but never the default version.
+func (c *ConfiguredPromptsClient) GetLatest(ctx context.Context, name string) (*Prompt, error) {
+	return c.get(ctx, name, &GetPromptParams{Label: c.config.defaultLabel})
+}
+
+// GetByVersion retrieves a specific version of a prompt, using the cache
+// if enabled. The default label and version do not apply.
+func (c *ConfiguredPromptsClient) GetByVersion(ctx context.Context, name string, version int) (*Prompt, error) {
+	return c.get(ctx, name, &GetPromptParams{Version: version})
+}
+
+// GetByLabel retrieves a prompt by name and label, using the cache if
+// enabled. The default label and version do not apply.
+func (c *ConfiguredPromptsClient) GetByLabel(ctx context.Context, name string, label string) (*Prompt, error) {
+	return c.get(ctx, name, &GetPromptParams{Label: label})
+}
+
+// applyDefaults returns a copy of params with the default label and version
+// filled in if neither a label nor a version was set explicitly.
 func (c *ConfiguredPromptsClient) applyDefaults(params *GetPromptParams) *GetPromptParams {
+	// Copy so defaults are not written into the caller's params
 	if params == nil {
 		params = &GetPromptParams{}
+	} else {
+		copied := *params
+		params = &copied
 	}
 
-	// Only apply defaults if not explicitly set
-	if params.Label == "" && c.config.defaultLabel != "" {
-		params.Label = c.config.defaultLabel
+	if params.Label != "" || params.Version != 0 {
+		return params
 	}
-	if params.Version == 0 && c.config.defaultVersion > 0 {
+	params.Label = c.config.defaultLabel
+	if c.config.defaultVersion > 0 {
 		params.Version = c.config.defaultVersion
 	}
 
@@ -906,6 +1553,7 @@ func (c *ConfiguredPromptsClient) addToCache(name string, params *GetPromptParam
 	}
 
 	c.cache[key] = cachedPrompt{
+		name:      name,
 		prompt:    prompt,
 		expiresAt: expiresAt,
 	}
@@ -918,6 +1566,23 @@ func (c *ConfiguredPromptsClient) ClearCache() {
 	c.cache = nil
 }
 
+// Invalidate evicts every cached version and label of the named prompt,
+// so the next lookup fetches it again. Use it after updating a prompt.
+func (c *ConfiguredPromptsClient) Invalidate(name string) {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+	for key, cached := range c.cache {
+		if cached.name == name {
+			delete(c.cache, key)
+		}
+	}
+}
+
+// InvalidateAll evicts every cached prompt. It is equivalent to ClearCache.
+func (c *ConfiguredPromptsClient) InvalidateAll() {
+	c.ClearCache()
+}
+
 // CacheSize returns the number of cached prompts.
 func (c *ConfiguredPromptsClient) CacheSize() int {
 	c.cacheMu.RLock()
@@ -936,6 +1601,17 @@ func (c *ConfiguredTracesClient) Get(ctx context.Context, id string) (*Trace, er
 	return c.TracesClient.Get(ctx, id)
 }
 
+// ListByUserID lists the traces of a user. See TracesClient.ListByUserID.
+func (c *ConfiguredTracesClient) ListByUserID(ctx context.Context, userID string, page *PaginationParams) (*TracesListResponse, error) {
+	return c.TracesClient.ListByUserID(ctx, userID, page)
+}
+
+// ListBySessionID lists the traces of a session. See
+// TracesClient.ListBySessionID.
+func (c *ConfiguredTracesClient) ListBySessionID(ctx context.Context, sessionID string, page *PaginationParams) (*TracesListResponse, error) {
+	return c.TracesClient.ListBySessionID(ctx, sessionID, page)
+}
+
 // DefaultMetadata returns the configured default metadata.
 func (c *ConfiguredTracesClient) DefaultMetadata() Metadata {
 	return c.config.defaultMetadata
@@ -12,6 +12,9 @@ import (
 // Endpoint for the scores API.
 const Endpoint = "/scores"
 
+// ConfigsEndpoint for the score configs API.
+const ConfigsEndpoint = "/score-configs"
+
 // Client handles score-related API operations.
 // It uses generic result types to avoid circular dependencies with the root package.
 type Client struct {
@@ -45,3 +48,37 @@ func (c *Client) Create(ctx context.Context, body any, result any) error {
 func (c *Client) Delete(ctx context.Context, scoreID string) error {
 	return c.http.Delete(ctx, fmt.Sprintf("%s/%s", Endpoint, scoreID), nil)
 }
+
+// ListConfigs retrieves a list of score configs.
+// The result parameter should be a pointer to the response type (e.g., *ScoreConfigsListResponse).
+func (c *Client) ListConfigs(ctx context.Context, query url.Values, result any) error {
+	return c.http.Get(ctx, ConfigsEndpoint, query, result)
+}
+
+// GetConfig retrieves a single score config by ID.
+// The result parameter should be a pointer to the score config type (e.g., *ScoreConfig).
+func (c *Client) GetConfig(ctx context.Context, configID string, result any) error {
+	return c.http.Get(ctx, fmt.Sprintf("%s/%s", ConfigsEndpoint, configID), nil, result)
+}
+
+// CreateConfig creates a new score config.
+// The body should be the request struct, result should be a pointer to the score config type.
+func (c *Client) CreateConfig(ctx context.Context, body any, result any) error {
+	return c.http.Post(ctx, ConfigsEndpoint, body, result)
+}
+
+// UpdateConfig updates a score config.
+// The body should be the request struct, result should be a pointer to the score config type.
+// It returns http.ErrPatchNotSupported if the doer is not an http.PatchDoer.
+func (c *Client) UpdateConfig(ctx context.Context, configID string, body any, result any) error {
+	patcher, ok := c.http.(http.PatchDoer)
+	if !ok {
+		return http.ErrPatchNotSupported
+	}
+	return patcher.Patch(ctx, fmt.Sprintf("%s/%s", ConfigsEndpoint, configID), body, result)
+}
+
+// DeleteConfig deletes a score config by ID.
+func (c *Client) DeleteConfig(ctx context.Context, configID string) error {
+	return c.http.Delete(ctx, fmt.Sprintf("%s/%s", ConfigsEndpoint, configID), nil)
+}
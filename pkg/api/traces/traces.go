@@ -39,6 +39,17 @@ func (c *Client) Get(ctx context.Context, traceID string, result any) error {
 	return c.http.Get(ctx, fmt.Sprintf("%s/%s", Endpoint, traceID), nil, result)
 }
 
+// Update applies a partial update to a trace by ID.
+// The result parameter may be nil if the response body is not needed.
+// It returns http.ErrPatchNotSupported if the doer is not an http.PatchDoer.
+func (c *Client) Update(ctx context.Context, traceID string, body, result any) error {
+	patcher, ok := c.http.(http.PatchDoer)
+	if !ok {
+		return http.ErrPatchNotSupported
+	}
+	return patcher.Patch(ctx, fmt.Sprintf("%s/%s", Endpoint, traceID), body, result)
+}
+
 // Delete deletes a trace by ID.
 func (c *Client) Delete(ctx context.Context, traceID string) error {
 	return c.http.Delete(ctx, fmt.Sprintf("%s/%s", Endpoint, traceID), nil)
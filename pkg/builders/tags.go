@@ -1,5 +1,17 @@
 package builders
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxLabelTagLength is the maximum length of a "key:value" tag produced
+// from a label or annotation.
+const MaxLabelTagLength = 64
+
 // TagsBuilder provides a type-safe way to build tags.
 //
 // Example:
@@ -12,7 +24,8 @@ package builders
 //
 //	trace.Tags(tags).Create(ctx)
 type TagsBuilder struct {
-	tags []string
+	tags      []string
+	validator Validator
 }
 
 // NewTags creates a new TagsBuilder.
@@ -58,6 +71,84 @@ func (t *TagsBuilder) Version(version string) *TagsBuilder {
 	return t
 }
 
+// FromLabels adds a "key:value" tag for each entry in a Kubernetes-style
+// label map. Entries are added in key order. Entries whose key or value
+// contains whitespace, or whose combined tag exceeds MaxLabelTagLength
+// characters, are skipped and reported through Errors.
+//
+// Example:
+//
+//	tags := NewTags().
+//	    FromLabels(map[string]string{"app": "chatbot", "env": "prod"}).
+//	    Build()
+//	// ["app:chatbot", "env:prod"]
+func (t *TagsBuilder) FromLabels(labels map[string]string) *TagsBuilder {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		t.addLabel(k, labels[k])
+	}
+	return t
+}
+
+// FromAnnotations adds a "key:value" tag for each annotation whose key
+// starts with prefix. The prefix is stripped from the key before the tag is
+// formatted. The same validation rules as FromLabels apply.
+//
+// Example:
+//
+//	annotations := map[string]string{
+//	    "langfuse.com/team": "search",
+//	    "kubectl.kubernetes.io/restartedAt": "2024-01-01T00:00:00Z",
+//	}
+//	tags := NewTags().FromAnnotations(annotations, "langfuse.com/").Build()
+//	// ["team:search"]
+func (t *TagsBuilder) FromAnnotations(annotations map[string]string, prefix string) *TagsBuilder {
+	filtered := make(map[string]string)
+	for k, v := range annotations {
+		if strings.HasPrefix(k, prefix) {
+			filtered[strings.TrimPrefix(k, prefix)] = v
+		}
+	}
+	return t.FromLabels(filtered)
+}
+
+// addLabel validates and adds a single "key:value" tag.
+func (t *TagsBuilder) addLabel(key, value string) {
+	switch {
+	case key == "":
+		t.validator.AddFieldError("labels", "label key cannot be empty")
+		return
+	case strings.IndexFunc(key, unicode.IsSpace) >= 0:
+		t.validator.AddFieldError("labels", fmt.Sprintf("label key %q contains whitespace", key))
+		return
+	case strings.IndexFunc(value, unicode.IsSpace) >= 0:
+		t.validator.AddFieldError("labels", fmt.Sprintf("label %q value contains whitespace", key))
+		return
+	}
+
+	tag := key + ":" + value
+	if utf8.RuneCountInString(tag) > MaxLabelTagLength {
+		t.validator.AddFieldError("labels", fmt.Sprintf("tag %q exceeds %d characters", tag, MaxLabelTagLength))
+		return
+	}
+	t.tags = append(t.tags, tag)
+}
+
+// HasErrors returns true if any label or annotation was rejected.
+func (t *TagsBuilder) HasErrors() bool {
+	return t.validator.HasErrors()
+}
+
+// Errors returns the validation errors for rejected labels or annotations.
+func (t *TagsBuilder) Errors() []error {
+	return t.validator.Errors()
+}
+
 // Build returns the constructed tags slice.
 func (t *TagsBuilder) Build() []string {
 	return t.tags
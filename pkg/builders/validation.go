@@ -2,7 +2,6 @@ package builders
 
 import (
 	"fmt"
-	"strings"
 	"unicode/utf8"
 
 	pkgerrors "github.com/jdziat/langfuse-go/pkg/errors"
@@ -25,18 +24,32 @@ const (
 
 // Validator provides validation methods for builder types.
 // Builders can embed this to gain validation capabilities.
+//
+// Methods that record errors return the Validator so calls can be chained:
+//
+//	v.AddError(err).
+//	    AddFieldError("name", "too long").
+//	    AddFieldErrorf("count", "must be >= %d, got %d", 1, n)
 type Validator struct {
 	errors []error
 }
 
 // AddError adds a validation error.
-func (v *Validator) AddError(err error) {
+func (v *Validator) AddError(err error) *Validator {
 	v.errors = append(v.errors, err)
+	return v
 }
 
 // AddFieldError adds a validation error for a specific field.
-func (v *Validator) AddFieldError(field, message string) {
+func (v *Validator) AddFieldError(field, message string) *Validator {
 	v.errors = append(v.errors, pkgerrors.NewValidationError(field, message))
+	return v
+}
+
+// AddFieldErrorf adds a validation error for a specific field with a
+// formatted message.
+func (v *Validator) AddFieldErrorf(field, format string, args ...any) *Validator {
+	return v.AddFieldError(field, fmt.Sprintf(format, args...))
 }
 
 // HasErrors returns true if there are any validation errors.
@@ -49,13 +62,29 @@ func (v *Validator) Errors() []error {
 	return v.errors
 }
 
+// FieldErrors returns the messages of all field validation errors grouped
+// by field name. Errors not associated with a field are grouped under "".
+func (v *Validator) FieldErrors() map[string][]string {
+	fields := make(map[string][]string)
+	for _, err := range v.errors {
+		if valErr, ok := pkgerrors.AsValidationError(err); ok {
+			fields[valErr.Field] = append(fields[valErr.Field], valErr.Message)
+			continue
+		}
+		fields[""] = append(fields[""], err.Error())
+	}
+	return fields
+}
+
 // ClearErrors clears all validation errors.
-func (v *Validator) ClearErrors() {
+func (v *Validator) ClearErrors() *Validator {
 	v.errors = nil
+	return v
 }
 
-// CombinedError returns a single error combining all validation errors,
-// or nil if there are no errors.
+// CombinedError returns nil if there are no errors, the error itself if
+// there is exactly one, and a *CompilationError holding all errors if there
+// are several.
 func (v *Validator) CombinedError() error {
 	if len(v.errors) == 0 {
 		return nil
@@ -64,11 +93,9 @@ func (v *Validator) CombinedError() error {
 		return v.errors[0]
 	}
 
-	msgs := make([]string, len(v.errors))
-	for i, err := range v.errors {
-		msgs[i] = err.Error()
-	}
-	return fmt.Errorf("langfuse: multiple validation errors: %s", strings.Join(msgs, "; "))
+	errs := make([]error, len(v.errors))
+	copy(errs, v.errors)
+	return &pkgerrors.CompilationError{Errors: errs}
 }
 
 // Validation rules
@@ -192,5 +219,8 @@ const MaxNameLength = 500
 // MaxTagLength is the maximum allowed length for individual tags.
 const MaxTagLength = 100
 
+// MaxReleaseLength is the maximum allowed length for release and version fields.
+const MaxReleaseLength = 255
+
 // MaxTagCount is the maximum number of tags allowed.
 const MaxTagCount = 50
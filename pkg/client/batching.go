@@ -3,9 +3,13 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
 	"time"
 
 	pkgerrors "github.com/jdziat/langfuse-go/pkg/errors"
+	pkghttp "github.com/jdziat/langfuse-go/pkg/http"
 	pkgingestion "github.com/jdziat/langfuse-go/pkg/ingestion"
 	pkgtypes "github.com/jdziat/langfuse-go/pkg/types"
 )
@@ -57,12 +61,13 @@ var ErrBatchDropped = &pkgerrors.APIError{
 	Message:    "batch dropped: background sender limit reached",
 }
 
-// batchProcessor processes batch requests from the queue.
-// It handles graceful shutdown by listening for drainSignal and processing
-// all remaining events before signaling completion via drainComplete.
+// batchProcessor processes batch requests from the queue. FlushWorkers
+// instances share the queue. Each handles graceful shutdown by listening for
+// drainSignal and draining remaining events; drainComplete is closed once
+// all of them have returned.
 func (c *Client) batchProcessor() {
 	defer c.wg.Done()
-	defer close(c.drainComplete) // Signal that drain is complete
+	defer c.processorWG.Done()
 
 	for {
 		select {
@@ -84,7 +89,10 @@ func (c *Client) batchProcessor() {
 }
 
 // processBatchRequest handles sending a single batch request.
+// A panic while sending is recovered so the processor goes on with the next
+// batch.
 func (c *Client) processBatchRequest(req batchRequest) {
+	defer c.recoverPanic("batch processing")
 	start := time.Now()
 
 	// Check if request context is already cancelled before sending
@@ -93,7 +101,7 @@ func (c *Client) processBatchRequest(req batchRequest) {
 		// Use a timeout context instead of the cancelled one
 		sendCtx, cancel := context.WithTimeout(context.Background(), DefaultBackgroundSendTimeout)
 		if err := c.sendBatch(sendCtx, req.events); err != nil {
-			c.handleError(err)
+			c.handleError(pkgerrors.AsyncOpBatchSend, err)
 		}
 		cancel()
 		if c.config.Metrics != nil {
@@ -101,7 +109,7 @@ func (c *Client) processBatchRequest(req batchRequest) {
 		}
 	} else {
 		if err := c.sendBatch(req.ctx, req.events); err != nil {
-			c.handleError(err)
+			c.handleError(pkgerrors.AsyncOpBatchSend, err)
 		}
 	}
 
@@ -116,8 +124,15 @@ func (c *Client) processBatchRequest(req batchRequest) {
 // Always signals space availability on return (via defer) since the batch was
 // removed from the queue regardless of send success or failure.
 func (c *Client) sendBatch(ctx context.Context, events []IngestionEvent) error {
+	_, _, err := c.sendBatchResult(ctx, events)
+	return err
+}
+
+// sendBatchResult sends a batch of events like sendBatch and also returns
+// the number of events sent after the PreSendHook and the API's result.
+func (c *Client) sendBatchResult(ctx context.Context, events []IngestionEvent) (int, *IngestionResult, error) {
 	if len(events) == 0 {
-		return nil
+		return 0, &IngestionResult{}, nil
 	}
 
 	// Always signal space availability when done - the batch was already removed
@@ -125,6 +140,25 @@ func (c *Client) sendBatch(ctx context.Context, events []IngestionEvent) error {
 	// This prevents waiters from blocking unnecessarily on persistent errors.
 	defer c.signalSpaceAvailable()
 
+	if c.config.PreSendHook != nil {
+		var err error
+		if events, err = c.applyPreSendHook(events); err != nil {
+			return 0, nil, err
+		}
+		if len(events) == 0 {
+			return 0, &IngestionResult{}, nil
+		}
+	}
+
+	release, throttled, err := c.flushControl.Acquire(ctx)
+	if throttled && c.config.Metrics != nil {
+		c.config.Metrics.IncrementCounter("langfuse.flush.throttled", 1)
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	defer release()
+
 	start := time.Now()
 	req := &IngestionRequest{
 		Batch: events,
@@ -133,7 +167,15 @@ func (c *Client) sendBatch(ctx context.Context, events []IngestionEvent) error {
 	var result IngestionResult
 
 	// Circuit breaker is now handled by httpClient.do() automatically
-	err := c.http.post(ctx, endpoints.Ingestion, req, &result)
+	err = c.http.post(ctx, endpoints.Ingestion, req, &result)
+	if err != nil && c.fallback != nil && shouldUseFallback(ctx, err) {
+		c.log("primary endpoint failed, sending batch of %d events to fallback: %v", len(events), err)
+		if c.config.Metrics != nil {
+			c.config.Metrics.IncrementCounter("langfuse.fallback.used", 1)
+		}
+		result = IngestionResult{}
+		err = c.fallback.post(ctx, endpoints.Ingestion, req, &result)
+	}
 	duration := time.Since(start)
 
 	// Prepare batch result for callback
@@ -147,17 +189,23 @@ func (c *Client) sendBatch(ctx context.Context, events []IngestionEvent) error {
 	if err == nil {
 		batchResult.Successes = len(result.Successes)
 		batchResult.Errors = len(result.Errors)
+		batchResult.IngestionErrors = result.Errors
 	}
 
-	// Call the batch callback if configured
+	// Call the batch callback if configured. Calls are serialized because
+	// batches may be sent from several goroutines at once.
 	if c.config.OnBatchFlushed != nil {
-		c.config.OnBatchFlushed(batchResult)
+		c.notifyBatchFlushed(batchResult)
 	}
 
 	if err != nil {
-		return err
+		return len(events), nil, err
 	}
 
+	c.lastBatchSentNanos.Store(time.Now().UnixNano())
+	c.totalSent.Add(int64(len(events)))
+	c.batchesSent.Add(1)
+
 	// Log errors if any
 	if result.HasErrors() {
 		// Build an index of event ID -> event for correlation
@@ -181,7 +229,74 @@ func (c *Client) sendBatch(ctx context.Context, events []IngestionEvent) error {
 		}
 	}
 
-	return nil
+	return len(events), &result, nil
+}
+
+// notifyBatchFlushed calls the OnBatchFlushed callback, serialized with
+// other senders.
+func (c *Client) notifyBatchFlushed(result BatchResult) {
+	c.batchCallbackMu.Lock()
+	defer c.batchCallbackMu.Unlock()
+	defer c.recoverPanic("OnBatchFlushed callback")
+	c.config.OnBatchFlushed(result)
+}
+
+// applyPreSendHook passes the JSON form of events through the PreSendHook
+// and decodes the result. If the hook panics, the panic is logged and the
+// batch is dropped.
+func (c *Client) applyPreSendHook(events []IngestionEvent) (result []IngestionEvent, err error) {
+	data, err := json.Marshal(events)
+	if err != nil {
+		return nil, fmt.Errorf("langfuse: failed to encode batch for pre-send hook: %w", err)
+	}
+	var batch []map[string]any
+	if err := json.Unmarshal(data, &batch); err != nil {
+		return nil, fmt.Errorf("langfuse: failed to decode batch for pre-send hook: %w", err)
+	}
+
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			c.logError("pre-send hook panicked, dropping batch", "panic", r, "events", len(events))
+			if c.config.Metrics != nil {
+				c.config.Metrics.IncrementCounter("langfuse.presend_hook.panics", 1)
+				c.config.Metrics.IncrementCounter("langfuse.presend_hook.dropped", int64(len(events)))
+			}
+			result, err = nil, nil
+		}
+	}()
+	batch = c.config.PreSendHook(batch)
+	if c.config.Metrics != nil {
+		c.config.Metrics.RecordDuration("langfuse.presend_hook.duration", time.Since(start))
+		if dropped := len(events) - len(batch); dropped > 0 {
+			c.config.Metrics.IncrementCounter("langfuse.presend_hook.dropped", int64(dropped))
+		}
+	}
+
+	if data, err = json.Marshal(batch); err != nil {
+		return nil, fmt.Errorf("langfuse: failed to encode batch from pre-send hook: %w", err)
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("langfuse: failed to decode batch from pre-send hook: %w", err)
+	}
+	return result, nil
+}
+
+// shouldUseFallback reports whether a failed batch should be resent to the
+// fallback endpoint. Only an open circuit breaker, server errors (5xx), and
+// network errors qualify; client errors would fail against any endpoint.
+func shouldUseFallback(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if errors.Is(err, pkghttp.ErrCircuitOpen) {
+		return true
+	}
+	if apiErr, ok := pkgerrors.AsAPIError(err); ok {
+		return apiErr.StatusCode >= 500
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
 }
 
 // signalSpaceAvailable broadcasts to ALL waiters that queue space may be available.
@@ -218,12 +333,29 @@ func (c *Client) flushLoop() {
 			return
 		case <-ticker.C:
 			if err := c.Flush(c.ctx); err != nil && err != ErrClientClosed {
-				c.handleError(err)
+				c.handleError(pkgerrors.AsyncOpFlush, err)
 			}
+			c.reportBatchGauges()
 		}
 	}
 }
 
+// reportBatchGauges exports the batch pipeline state as gauges.
+// It is called after every periodic flush when Metrics is configured.
+func (c *Client) reportBatchGauges() {
+	if c.config.Metrics == nil {
+		return
+	}
+
+	c.mu.Lock()
+	pending := len(c.pendingEvents)
+	c.mu.Unlock()
+
+	c.config.Metrics.SetGauge("langfuse.batch.size_config", float64(c.config.BatchSize))
+	c.config.Metrics.SetGauge("langfuse.queue.pending_events", float64(pending))
+	c.config.Metrics.SetGauge("langfuse.queue.batch_queue_len", float64(len(c.batchQueue)))
+}
+
 // QueueEvent adds an event to the pending queue.
 // The provided context is used for immediate batch sends when the batch is full.
 //
@@ -232,6 +364,8 @@ func (c *Client) flushLoop() {
 //   - If configured with DropOnQueueFull, events are silently dropped when full
 //   - Otherwise, events are queued normally (may overflow)
 func (c *Client) QueueEvent(ctx context.Context, event IngestionEvent) error {
+	c.ensureStarted()
+
 	// Record activity for idle detection
 	if c.lifecycle != nil {
 		c.lifecycle.RecordActivity()
@@ -245,6 +379,7 @@ func (c *Client) QueueEvent(ctx context.Context, event IngestionEvent) error {
 		switch decision {
 		case DecisionDrop:
 			// Drop the event silently (already logged/metriced by handler)
+			c.totalDropped.Add(1)
 			return nil
 		case DecisionBlock:
 			// Block until space is available or context is cancelled
@@ -268,7 +403,7 @@ func (c *Client) QueueEvent(ctx context.Context, event IngestionEvent) error {
 		default:
 			// Queue is full, spawn tracked goroutine
 			// Return error if batch was dropped so caller knows about data loss
-			if err := c.handleQueueFull(events); err != nil {
+			if err := c.handleQueueFull(ctx, events); err != nil {
 				return err
 			}
 		}
@@ -363,7 +498,15 @@ func (c *Client) addEventToQueue(event IngestionEvent) ([]IngestionEvent, error)
 //
 // Returns ErrBatchDropped if all background sender slots are occupied.
 // The error is returned so callers can be informed of data loss.
-func (c *Client) handleQueueFull(events []IngestionEvent) error {
+//
+// With MaxConcurrentFlushes set, the batch is instead sent on the calling
+// goroutine, which blocks until an in-flight slot frees up or ctx is done.
+func (c *Client) handleQueueFull(ctx context.Context, events []IngestionEvent) error {
+	if c.flushControl.limitsConcurrency() {
+		c.log("batch queue full, sending on caller goroutine")
+		return c.sendBatch(ctx, events)
+	}
+
 	// Try to acquire the semaphore without blocking
 	select {
 	case c.backgroundSendSem <- struct{}{}:
@@ -382,7 +525,7 @@ func (c *Client) handleQueueFull(events []IngestionEvent) error {
 			defer cancel()
 
 			if err := c.sendBatch(sendCtx, events); err != nil {
-				c.handleError(err)
+				c.handleError(pkgerrors.AsyncOpBatchSend, err)
 			}
 		}()
 		return nil
@@ -394,6 +537,7 @@ func (c *Client) handleQueueFull(events []IngestionEvent) error {
 		if c.config.Metrics != nil {
 			c.config.Metrics.IncrementCounter("langfuse.batches_dropped", 1)
 		}
+		c.totalDropped.Add(int64(len(events)))
 		return ErrBatchDropped
 	}
 }
@@ -404,12 +548,76 @@ func (c *Client) Flush(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	c.lastFlushNanos.Store(time.Now().UnixNano())
 	if len(events) == 0 {
 		return nil
 	}
 	return c.sendBatch(ctx, events)
 }
 
+// FlushSyncResult is the outcome of a FlushSync call.
+type FlushSyncResult struct {
+	// SentEvents is the number of events sent
+	SentEvents int
+
+	// Successes is the number of events the API accepted
+	Successes int
+
+	// Errors are the events the API rejected
+	Errors []IngestionError
+
+	// Duration is how long the flush took
+	Duration time.Duration
+}
+
+// FlushSync sends all pending and queued events directly, bypassing the
+// background workers, and waits for the API's response. Unlike Flush, it
+// reports which events the API accepted or rejected. If timeout is
+// positive, it bounds the whole call. Batches already being sent by a
+// worker are not waited for.
+func (c *Client) FlushSync(ctx context.Context, timeout time.Duration) (*FlushSyncResult, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	start := time.Now()
+
+	events, err := c.extractPendingEvents()
+	if err != nil {
+		return nil, err
+	}
+	c.lastFlushNanos.Store(time.Now().UnixNano())
+
+	batches := [][]IngestionEvent{events}
+drain:
+	for {
+		select {
+		case req := <-c.batchQueue:
+			batches = append(batches, req.events)
+		default:
+			break drain
+		}
+	}
+
+	// Every batch is attempted even if an earlier one fails, since they
+	// have all been taken off the queue.
+	result := &FlushSyncResult{}
+	var errs []error
+	for _, batch := range batches {
+		sent, ingestion, err := c.sendBatchResult(ctx, batch)
+		result.SentEvents += sent
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		result.Successes += len(ingestion.Successes)
+		result.Errors = append(result.Errors, ingestion.Errors...)
+	}
+	result.Duration = time.Since(start)
+	return result, errors.Join(errs...)
+}
+
 // extractPendingEvents atomically extracts and clears pending events.
 // Uses defer for safe mutex handling.
 func (c *Client) extractPendingEvents() ([]IngestionEvent, error) {
@@ -462,7 +670,7 @@ func (c *Client) drainAllEvents() {
 	if len(pendingEvents) > 0 {
 		c.log("draining %d pending events during shutdown", len(pendingEvents))
 		if err := c.sendBatch(drainCtx, pendingEvents); err != nil {
-			c.handleError(err)
+			c.handleError(pkgerrors.AsyncOpShutdown, err)
 		}
 	}
 
@@ -472,7 +680,7 @@ func (c *Client) drainAllEvents() {
 		select {
 		case req := <-c.batchQueue:
 			if err := c.sendBatch(drainCtx, req.events); err != nil {
-				c.handleError(err)
+				c.handleError(pkgerrors.AsyncOpShutdown, err)
 			}
 			drained++
 		case <-drainCtx.Done():
@@ -488,9 +696,39 @@ func (c *Client) drainAllEvents() {
 	}
 }
 
+// AddShutdownHook registers fn to run when the client shuts down, whether
+// by Shutdown or by IdleTimeout. Hooks run once, in the order they were
+// added, before the client stops accepting events, so a hook can stop
+// goroutines that still queue events. A hook added after shutdown has begun
+// is not run.
+func (c *Client) AddShutdownHook(fn func()) {
+	c.shutdownHooksMu.Lock()
+	defer c.shutdownHooksMu.Unlock()
+	c.shutdownHooks = append(c.shutdownHooks, fn)
+}
+
+// runShutdownHooks runs and clears the hooks added with AddShutdownHook.
+func (c *Client) runShutdownHooks() {
+	c.shutdownHooksMu.Lock()
+	hooks := c.shutdownHooks
+	c.shutdownHooks = nil
+	c.shutdownHooksMu.Unlock()
+
+	for _, hook := range hooks {
+		c.runShutdownHook(hook)
+	}
+}
+
+// runShutdownHook runs hook, recovering a panic so the remaining shutdown
+// steps still run.
+func (c *Client) runShutdownHook(hook func()) {
+	defer c.recoverPanic("shutdown hook")
+	hook()
+}
+
 // Shutdown flushes pending events and closes the client gracefully.
 //
-// The shutdown process:
+// The shutdown process, after running the hooks added with AddShutdownHook:
 //  1. Stop accepting new events (mark closed)
 //  2. Stop the flush loop
 //  3. Signal batch processor to drain all pending and queued events
@@ -500,7 +738,10 @@ func (c *Client) drainAllEvents() {
 //
 // Returns a ShutdownError if the shutdown times out, which includes
 // information about how many events may have been lost.
-func (c *Client) Shutdown(ctx context.Context) error {
+//
+// If Config.OnShutdown is set, it is called with a ShutdownSummary once
+// shutdown completes, whether or not it timed out.
+func (c *Client) Shutdown(ctx context.Context) (err error) {
 	// Use lifecycle manager to begin shutdown
 	if c.lifecycle != nil {
 		if err := c.lifecycle.BeginShutdown(); err != nil {
@@ -508,6 +749,8 @@ func (c *Client) Shutdown(ctx context.Context) error {
 		}
 	}
 
+	c.runShutdownHooks()
+
 	// Step 1: Stop accepting new events
 	if err := c.markClosed(); err != nil {
 		if c.lifecycle != nil {
@@ -516,6 +759,34 @@ func (c *Client) Shutdown(ctx context.Context) error {
 		return err
 	}
 
+	start := time.Now()
+	drainedSuccessfully := true
+	if c.config.OnShutdown != nil {
+		defer func() {
+			defer c.recoverPanic("OnShutdown callback")
+			c.config.OnShutdown(ShutdownSummary{
+				TotalEventsSent:     c.totalSent.Load(),
+				EventsDropped:       c.totalDropped.Load(),
+				BatchesSent:         c.batchesSent.Load(),
+				ShutdownDuration:    time.Since(start),
+				DrainedSuccessfully: drainedSuccessfully,
+				Error:               err,
+			})
+		}()
+	}
+
+	// A lazily initialized client that never queued an event has no
+	// goroutines to stop. Running the once here also keeps them from
+	// starting after shutdown.
+	c.startOnce.Do(func() {})
+	if !c.started.Load() {
+		c.cancel()
+		if c.lifecycle != nil {
+			c.lifecycle.CompleteShutdown()
+		}
+		return nil
+	}
+
 	// Step 2: Stop the flush loop
 	close(c.stopFlush)
 
@@ -527,7 +798,7 @@ func (c *Client) Shutdown(ctx context.Context) error {
 	shutdownCtx, shutdownCancel := context.WithTimeout(ctx, c.config.ShutdownTimeout)
 	defer shutdownCancel()
 
-	drainedSuccessfully := false
+	drainedSuccessfully = false
 	select {
 	case <-c.drainComplete:
 		// Batch processor finished draining all events
@@ -636,6 +907,51 @@ func (c *Client) LifecycleStats() LifecycleStats {
 	return c.lifecycle.Stats()
 }
 
+// QueueStats contains a snapshot of the client's queue and delivery counters.
+type QueueStats struct {
+	// PendingEvents is the number of events not yet assigned to a batch.
+	PendingEvents int
+	// BatchQueueLen is the number of batches waiting to be sent.
+	BatchQueueLen int
+	// BatchQueueCap is the capacity of the batch queue.
+	BatchQueueCap int
+	// LastFlushTime is when Flush last ran; zero if it never ran.
+	LastFlushTime time.Time
+	// LastBatchSentAt is when a batch was last accepted by the API; zero if none was.
+	LastBatchSentAt time.Time
+	// TotalSent is the number of events sent successfully.
+	TotalSent int64
+	// TotalDropped is the number of events dropped due to backpressure or
+	// background sender exhaustion.
+	TotalDropped int64
+}
+
+// QueueStats returns a snapshot of the queue and delivery counters.
+func (c *Client) QueueStats() QueueStats {
+	c.mu.Lock()
+	pending := len(c.pendingEvents)
+	c.mu.Unlock()
+
+	return QueueStats{
+		PendingEvents:   pending,
+		BatchQueueLen:   len(c.batchQueue),
+		BatchQueueCap:   cap(c.batchQueue),
+		LastFlushTime:   unixNanoTime(c.lastFlushNanos.Load()),
+		LastBatchSentAt: unixNanoTime(c.lastBatchSentNanos.Load()),
+		TotalSent:       c.totalSent.Load(),
+		TotalDropped:    c.totalDropped.Load(),
+	}
+}
+
+// unixNanoTime converts a Unix nanosecond timestamp to a time.Time,
+// returning the zero time for 0.
+func unixNanoTime(n int64) time.Time {
+	if n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, n)
+}
+
 // Health checks the health of the Langfuse API.
 func (c *Client) Health(ctx context.Context) (*HealthStatus, error) {
 	var result HealthStatus
@@ -646,5 +962,16 @@ func (c *Client) Health(ctx context.Context) (*HealthStatus, error) {
 	return &result, nil
 }
 
+// Ingest sends events directly to the ingestion API, bypassing the batch
+// queue, hooks and fallback endpoint, and returns the API's per-event
+// result. It is intended for diagnostics; use QueueEvent for normal delivery.
+func (c *Client) Ingest(ctx context.Context, events []IngestionEvent) (*IngestionResult, error) {
+	var result IngestionResult
+	if err := c.http.post(ctx, endpoints.Ingestion, &IngestionRequest{Batch: events}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // HealthStatus is re-exported from pkg/types for consistency.
 type HealthStatus = pkgtypes.HealthStatus
@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"runtime/debug"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	pkgerrors "github.com/jdziat/langfuse-go/pkg/errors"
 	pkgid "github.com/jdziat/langfuse-go/pkg/id"
 	pkgingestion "github.com/jdziat/langfuse-go/pkg/ingestion"
 )
@@ -19,8 +23,9 @@ var defaultStderrLogger = log.New(os.Stderr, "langfuse: ", log.LstdFlags)
 
 // Client is the main Langfuse client.
 type Client struct {
-	config *Config
-	http   *httpClient
+	config   *Config
+	http     *httpClient
+	fallback *httpClient
 
 	// Lifecycle management
 	lifecycle   *LifecycleManager
@@ -42,16 +47,46 @@ type Client struct {
 	drainSignal   chan struct{}
 	drainComplete chan struct{}
 
+	// processorWG tracks the batchProcessor workers; drainComplete is
+	// closed once all of them have finished
+	processorWG sync.WaitGroup
+
+	// Serializes OnBatchFlushed calls across concurrent senders
+	batchCallbackMu sync.Mutex
+
 	// Backpressure management
 	backpressure *pkgingestion.BackpressureHandler
 
 	// Semaphore to limit concurrent background batch senders
 	backgroundSendSem chan struct{}
 
+	// Limits on in-flight batch requests and flush rate; nil when unlimited
+	flushControl *FlushController
+
 	// Broadcast signaling for queue space availability (for waitForQueueSpace)
 	// Uses close-and-recreate pattern: closing the channel wakes ALL waiters
 	spaceAvailableMu sync.Mutex
 	spaceAvailableCh chan struct{}
+
+	// Delivery statistics (see QueueStats)
+	lastFlushNanos     atomic.Int64
+	lastBatchSentNanos atomic.Int64
+	totalSent          atomic.Int64
+	totalDropped       atomic.Int64
+	batchesSent        atomic.Int64
+
+	// Async error listeners registered with ListenErrors
+	listenersMu sync.Mutex
+	listeners   map[chan error]struct{}
+
+	// Background goroutines are started once, at construction or, with
+	// LazyInitialization, on the first queued event
+	startOnce sync.Once
+	started   atomic.Bool
+
+	// Hooks registered with AddShutdownHook, run once by Shutdown
+	shutdownHooksMu sync.Mutex
+	shutdownHooks   []func()
 }
 
 // batchRequest represents a batch of events to be sent.
@@ -88,6 +123,20 @@ func NewWithConfig(cfg *Config) (*Client, error) {
 		return nil, err
 	}
 
+	var fallbackHTTP *httpClient
+	if cfgCopy.Fallback != nil {
+		fallbackCfg := *cfgCopy.Fallback
+		if fallbackCfg.PublicKey == "" && fallbackCfg.SecretKey == "" {
+			fallbackCfg.PublicKey = cfgCopy.PublicKey
+			fallbackCfg.SecretKey = cfgCopy.SecretKey
+		}
+		fallbackCfg.ApplyDefaults()
+		if err := fallbackCfg.Validate(); err != nil {
+			return nil, fmt.Errorf("langfuse: invalid fallback config: %w", err)
+		}
+		fallbackHTTP = newHTTPClient(&fallbackCfg)
+	}
+
 	httpClient := newHTTPClient(&cfgCopy)
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -127,6 +176,7 @@ func NewWithConfig(cfg *Config) (*Client, error) {
 	c := &Client{
 		config:            &cfgCopy,
 		http:              httpClient,
+		fallback:          fallbackHTTP,
 		lifecycle:         lifecycle,
 		idGenerator:       idGenerator,
 		pendingEvents:     make([]IngestionEvent, 0, cfgCopy.BatchSize),
@@ -138,26 +188,100 @@ func NewWithConfig(cfg *Config) (*Client, error) {
 		drainComplete:     make(chan struct{}),
 		backpressure:      backpressureHandler,
 		backgroundSendSem: make(chan struct{}, cfgCopy.MaxBackgroundSenders),
+		flushControl:      NewFlushController(cfgCopy.MaxConcurrentFlushes, cfgCopy.MaxFlushesPerSecond),
 		spaceAvailableCh:  make(chan struct{}), // Unbuffered - will be closed to broadcast
 	}
 
-	// Start background batch processor
+	if !cfgCopy.LazyInitialization {
+		c.ensureStarted()
+	}
+
+	return c, nil
+}
+
+// ensureStarted starts the background goroutines if they are not running
+// yet. It is safe to call concurrently; the goroutines are started at most
+// once, and never after Shutdown.
+func (c *Client) ensureStarted() {
+	c.startOnce.Do(c.start)
+}
+
+// start starts the batch processors, the flush loop and, with IdleTimeout,
+// the idle shutdown monitor.
+func (c *Client) start() {
+	// Start background batch processors
+	c.wg.Add(c.config.FlushWorkers)
+	c.processorWG.Add(c.config.FlushWorkers)
+	for i := 0; i < c.config.FlushWorkers; i++ {
+		go c.batchProcessor()
+	}
 	c.wg.Add(1)
-	go c.batchProcessor()
+	go func() {
+		defer c.wg.Done()
+		c.processorWG.Wait()
+		close(c.drainComplete) // Signal that every worker has drained
+	}()
 
 	// Start flush timer
 	c.wg.Add(1)
 	go c.flushLoop()
 
-	return c, nil
+	if c.config.IdleTimeout > 0 {
+		go c.idleShutdownMonitor()
+	}
+
+	c.started.Store(true)
+}
+
+// minIdleCheckInterval bounds how often idleShutdownMonitor checks the
+// idle duration.
+const minIdleCheckInterval = 10 * time.Millisecond
+
+// idleShutdownMonitor shuts the client down once it has been idle for
+// IdleTimeout. It is not tracked by wg, since Shutdown waits on wg; it
+// exits when the client context is canceled, which every Shutdown does.
+func (c *Client) idleShutdownMonitor() {
+	timeout := c.config.IdleTimeout
+	ticker := time.NewTicker(max(timeout/4, minIdleCheckInterval))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+			if !c.lifecycle.IsActive() || c.lifecycle.IdleDuration() < timeout {
+				continue
+			}
+
+			c.logInfo("idle timeout reached, shutting down", "idle_timeout", timeout)
+			if c.config.Metrics != nil {
+				c.config.Metrics.IncrementCounter("langfuse.client.idle_shutdown", 1)
+			}
+			if c.config.OnIdleShutdown != nil {
+				c.callOnIdleShutdown()
+			}
+			if err := c.Shutdown(context.Background()); err != nil && err != ErrAlreadyClosed && err != ErrClientClosed {
+				c.handleError(pkgerrors.AsyncOpShutdown, err)
+			}
+			return
+		}
+	}
+}
+
+// IsInitialized reports whether the client's background goroutines have
+// been started. It is always true unless LazyInitialization is enabled and
+// no event has been queued yet.
+func (c *Client) IsInitialized() bool {
+	return c.started.Load()
 }
 
 // handleError handles async errors.
-func (c *Client) handleError(err error) {
-	handled := false
+func (c *Client) handleError(op pkgerrors.AsyncErrorOperation, err error) {
+	handled := c.publishError(pkgerrors.WrapAsyncError(op, err))
 
 	if c.config.ErrorHandler != nil {
-		c.config.ErrorHandler(err)
+		c.callErrorHandler(err)
 		handled = true
 	}
 
@@ -178,6 +302,109 @@ func (c *Client) handleError(err error) {
 	}
 }
 
+// callErrorHandler calls the ErrorHandler. A panic in the handler is only
+// logged, since reporting it would call the handler again.
+func (c *Client) callErrorHandler(err error) {
+	if !c.config.DisablePanicRecovery {
+		defer func() {
+			if r := recover(); r != nil {
+				c.logError("error handler panicked", "panic", r, "error", err)
+			}
+		}()
+	}
+	c.config.ErrorHandler(err)
+}
+
+// callOnIdleShutdown calls the OnIdleShutdown callback.
+func (c *Client) callOnIdleShutdown() {
+	defer c.recoverPanic("OnIdleShutdown callback")
+	c.config.OnIdleShutdown()
+}
+
+// recoverPanic recovers a panic in the calling goroutine and reports it as an
+// AsyncOpInternal error with the panic value and stack trace in its Context,
+// so background processing survives panicking user callbacks. It must be
+// called directly by a deferred call. It does nothing if
+// DisablePanicRecovery is set.
+func (c *Client) recoverPanic(where string) {
+	if c.config.DisablePanicRecovery {
+		return
+	}
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	if c.config.Metrics != nil {
+		c.config.Metrics.IncrementCounter("langfuse.panics.recovered", 1)
+	}
+	err := pkgerrors.NewAsyncError(pkgerrors.AsyncOpInternal, fmt.Errorf("langfuse: panic in %s: %v", where, r)).
+		WithContext("panic", r).
+		WithContext("stack", string(debug.Stack()))
+	c.handleError(pkgerrors.AsyncOpInternal, err)
+}
+
+// ListenErrors returns a channel that receives every async error reported by
+// the client, such as failed batch sends and flushes. Each value is an
+// *AsyncError. The channel is closed when ctx is done, so it can be consumed
+// with range; callers must cancel ctx to release the listener.
+//
+// Each call returns a new channel and every listener receives its own copy of
+// each error. Sends never block: if a listener's buffer of bufferSize errors
+// is full, the error is dropped for that listener.
+//
+// Example:
+//
+//	ctx, cancel := context.WithCancel(context.Background())
+//	defer cancel()
+//	for err := range client.ListenErrors(ctx, 16) {
+//	    log.Printf("langfuse: %v", err)
+//	}
+func (c *Client) ListenErrors(ctx context.Context, bufferSize int) <-chan error {
+	if bufferSize < 0 {
+		bufferSize = 0
+	}
+	ch := make(chan error, bufferSize)
+
+	c.listenersMu.Lock()
+	if c.listeners == nil {
+		c.listeners = make(map[chan error]struct{})
+	}
+	c.listeners[ch] = struct{}{}
+	c.listenersMu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		c.listenersMu.Lock()
+		delete(c.listeners, ch)
+		close(ch)
+		c.listenersMu.Unlock()
+	}()
+
+	return ch
+}
+
+// publishError sends a copy of err to every registered listener.
+// It reports whether at least one listener accepted the error.
+func (c *Client) publishError(err *pkgerrors.AsyncError) bool {
+	c.listenersMu.Lock()
+	defer c.listenersMu.Unlock()
+
+	delivered := false
+	for ch := range c.listeners {
+		errCopy := *err
+		select {
+		case ch <- &errCopy:
+			delivered = true
+		default:
+			if c.config.Metrics != nil {
+				c.config.Metrics.IncrementCounter("langfuse.errors.listener_dropped", 1)
+			}
+		}
+	}
+	return delivered
+}
+
 // log logs a message if logging is enabled.
 func (c *Client) log(format string, v ...any) {
 	if c.config.StructuredLogger != nil {
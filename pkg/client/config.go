@@ -54,6 +54,23 @@ type Logger interface {
 	Printf(format string, v ...any)
 }
 
+// DebugEnabler is an optional interface for Logger and StructuredLogger
+// implementations that can report whether debug output would be written.
+// The SDK uses it to skip expensive debug formatting. Loggers that do not
+// implement it are treated as always enabled.
+type DebugEnabler interface {
+	IsDebugEnabled() bool
+}
+
+// IsDebugEnabled reports whether logger would write debug output.
+// It returns true unless logger implements DebugEnabler and reports false.
+func IsDebugEnabled(logger any) bool {
+	if e, ok := logger.(DebugEnabler); ok {
+		return e.IsDebugEnabled()
+	}
+	return true
+}
+
 // StructuredLogger provides structured logging support.
 type StructuredLogger interface {
 	Debug(msg string, args ...any)
@@ -69,6 +86,21 @@ type Metrics interface {
 	SetGauge(name string, value float64)
 }
 
+// LabeledMetrics is an optional interface for Metrics implementations that
+// accept constant labels, such as those set with WithMetricsLabels. Metrics
+// that do not implement it receive the unlabeled calls instead.
+type LabeledMetrics interface {
+	IncrementCounterWithLabels(name string, value int64, labels map[string]string)
+	RecordDurationWithLabels(name string, duration time.Duration, labels map[string]string)
+	SetGaugeWithLabels(name string, value float64, labels map[string]string)
+}
+
+// PreSendHook transforms a batch of events just before it is sent. The batch
+// is the JSON form of the events, one map per event with "id", "type",
+// "timestamp" and "body" keys. The hook may modify events in place, and any
+// event left out of the returned slice is dropped.
+type PreSendHook func(batch []map[string]any) []map[string]any
+
 // HTTPHook allows customizing HTTP request/response handling.
 // This is an alias to pkghttp.HTTPHook for type compatibility.
 type HTTPHook = pkghttp.HTTPHook
@@ -85,6 +117,10 @@ type Config struct {
 	// SecretKey is the Langfuse secret key (required).
 	SecretKey string
 
+	// ProjectID identifies the Langfuse project the keys belong to. It is
+	// informational and only added to the User-Agent header.
+	ProjectID string
+
 	// BaseURL is the base URL for the Langfuse API.
 	BaseURL string
 
@@ -117,6 +153,13 @@ type Config struct {
 	// Debug enables debug logging.
 	Debug bool
 
+	// DebugPrettyPrint indents JSON request and response bodies in debug logs.
+	DebugPrettyPrint bool
+
+	// DebugMaxBodySize truncates bodies in debug logs to this many bytes.
+	// Zero means no limit.
+	DebugMaxBodySize int
+
 	// ErrorHandler is called when async operations fail.
 	ErrorHandler func(error)
 
@@ -153,15 +196,38 @@ type Config struct {
 	// OnBatchFlushed is called after each batch is sent.
 	OnBatchFlushed func(result BatchResult)
 
+	// OnShutdown is called once at the end of Shutdown with a summary of the
+	// client's delivery over its lifetime.
+	OnShutdown func(summary ShutdownSummary)
+
+	// PreSendHook transforms each serialized batch before it is sent.
+	PreSendHook PreSendHook
+
 	// HTTPHooks are called before and after each HTTP request.
 	HTTPHooks []HTTPHook
 
+	// HTTPMiddleware wraps the HTTP client's transport, the first function
+	// being the outermost.
+	HTTPMiddleware []func(http.RoundTripper) http.RoundTripper
+
 	// ClassifiedHooks are priority-aware HTTP hooks.
 	ClassifiedHooks []ClassifiedHook
 
 	// IdleWarningDuration triggers idle warnings.
 	IdleWarningDuration time.Duration
 
+	// IdleTimeout shuts the client down once no event has been queued for
+	// this long. Zero disables automatic shutdown.
+	IdleTimeout time.Duration
+
+	// OnIdleShutdown is called when IdleTimeout triggers a shutdown.
+	OnIdleShutdown func()
+
+	// DisablePanicRecovery lets panics in user callbacks and background
+	// batch processing crash the process instead of being recovered and
+	// reported as AsyncOpInternal errors.
+	DisablePanicRecovery bool
+
 	// IDGenerationMode controls ID generation behavior.
 	IDGenerationMode IDGenerationMode
 
@@ -180,6 +246,29 @@ type Config struct {
 	// MaxBackgroundSenders limits concurrent background batch senders.
 	// Prevents unbounded goroutine creation under sustained load. Default is 10.
 	MaxBackgroundSenders int
+
+	// FlushWorkers is the number of goroutines sending queued batches
+	// concurrently. Default is 1.
+	FlushWorkers int
+
+	// LazyInitialization defers starting background goroutines until the
+	// first event is queued.
+	LazyInitialization bool
+
+	// MaxConcurrentFlushes limits concurrent in-flight batch requests.
+	// Zero means unlimited.
+	MaxConcurrentFlushes int
+
+	// MaxFlushesPerSecond limits the rate of batch requests.
+	// Zero means unlimited.
+	MaxFlushesPerSecond float64
+
+	// Fallback configures a secondary endpoint for ingestion batches. When a
+	// batch fails against the primary endpoint with a server or network error
+	// after retries are exhausted, or the primary circuit breaker is open, the
+	// batch is resent to the fallback. Missing credentials are inherited from
+	// the primary config. Read operations such as Health never use the fallback.
+	Fallback *Config
 }
 
 // IDGenerationMode controls how IDs are generated.
@@ -200,6 +289,41 @@ type BatchResult struct {
 	Duration   time.Duration
 	Successes  int
 	Errors     int
+
+	// IngestionErrors are the events Langfuse rejected, from
+	// IngestionResult.Errors. It is empty if the request itself failed.
+	IngestionErrors []IngestionError
+}
+
+// FailedEventIDs returns the IDs of the events Langfuse rejected.
+func (r BatchResult) FailedEventIDs() []string {
+	if len(r.IngestionErrors) == 0 {
+		return nil
+	}
+	ids := make([]string, len(r.IngestionErrors))
+	for i, e := range r.IngestionErrors {
+		ids[i] = e.ID
+	}
+	return ids
+}
+
+// ShutdownSummary describes the client's delivery over its lifetime, as
+// reported to the OnShutdown callback.
+type ShutdownSummary struct {
+	// TotalEventsSent is the number of events delivered to the API.
+	TotalEventsSent int64
+	// EventsDropped is the number of events dropped because the queue was
+	// full or a batch could not be sent.
+	EventsDropped int64
+	// BatchesSent is the number of batches delivered to the API.
+	BatchesSent int64
+	// ShutdownDuration is how long Shutdown took.
+	ShutdownDuration time.Duration
+	// DrainedSuccessfully reports whether all pending events were sent
+	// before the shutdown timeout.
+	DrainedSuccessfully bool
+	// Error is the error returned by Shutdown, if any.
+	Error error
 }
 
 // ApplyDefaults sets default values for unset configuration options.
@@ -252,6 +376,10 @@ func (c *Config) ApplyDefaults() {
 		c.MaxBackgroundSenders = DefaultMaxBackgroundSenders
 	}
 
+	if c.FlushWorkers == 0 {
+		c.FlushWorkers = 1
+	}
+
 	if c.Debug && c.Logger == nil {
 		c.Logger = &defaultLogger{
 			logger: log.New(os.Stderr, "langfuse: ", log.LstdFlags),
@@ -320,6 +448,22 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("langfuse: MaxBackgroundSenders cannot be negative, got %d", c.MaxBackgroundSenders)
 	}
 
+	if c.FlushWorkers < 0 {
+		return fmt.Errorf("langfuse: FlushWorkers cannot be negative, got %d", c.FlushWorkers)
+	}
+
+	if c.MaxConcurrentFlushes < 0 {
+		return fmt.Errorf("langfuse: MaxConcurrentFlushes cannot be negative, got %d", c.MaxConcurrentFlushes)
+	}
+
+	if c.MaxFlushesPerSecond < 0 {
+		return fmt.Errorf("langfuse: MaxFlushesPerSecond cannot be negative, got %g", c.MaxFlushesPerSecond)
+	}
+
+	if c.IdleTimeout < 0 {
+		return fmt.Errorf("langfuse: IdleTimeout cannot be negative, got %v", c.IdleTimeout)
+	}
+
 	return nil
 }
 
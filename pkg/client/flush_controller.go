@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// FlushController limits how many batch requests are in flight at once and
+// how often batches are sent. A nil *FlushController imposes no limits.
+type FlushController struct {
+	// sem bounds concurrent in-flight requests; nil means unlimited
+	sem chan struct{}
+
+	// Token bucket for flush rate limiting; rate <= 0 means unlimited
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewFlushController creates a FlushController. maxConcurrent <= 0 disables
+// the concurrency limit and perSecond <= 0 disables rate limiting. It returns
+// nil when both are disabled.
+func NewFlushController(maxConcurrent int, perSecond float64) *FlushController {
+	if maxConcurrent <= 0 && perSecond <= 0 {
+		return nil
+	}
+
+	fc := &FlushController{}
+	if maxConcurrent > 0 {
+		fc.sem = make(chan struct{}, maxConcurrent)
+	}
+	if perSecond > 0 {
+		fc.rate = perSecond
+		fc.burst = math.Max(1, math.Ceil(perSecond))
+		fc.tokens = fc.burst
+		fc.last = time.Now()
+	}
+	return fc
+}
+
+// limitsConcurrency reports whether the controller bounds in-flight requests.
+func (fc *FlushController) limitsConcurrency() bool {
+	return fc != nil && fc.sem != nil
+}
+
+// Acquire blocks until a flush may proceed or ctx is done. It reports whether
+// the caller had to wait on either limit. On success the caller must call
+// release once the request completes.
+func (fc *FlushController) Acquire(ctx context.Context) (release func(), throttled bool, err error) {
+	if fc == nil {
+		return func() {}, false, nil
+	}
+
+	if fc.rate > 0 {
+		for {
+			wait := fc.reserve()
+			if wait <= 0 {
+				break
+			}
+			throttled = true
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, throttled, ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+
+	if fc.sem == nil {
+		return func() {}, throttled, nil
+	}
+
+	select {
+	case fc.sem <- struct{}{}:
+	default:
+		throttled = true
+		select {
+		case fc.sem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, throttled, ctx.Err()
+		}
+	}
+	return func() { <-fc.sem }, throttled, nil
+}
+
+// reserve takes a token if one is available and returns zero, or returns how
+// long to wait before the next token is available.
+func (fc *FlushController) reserve() time.Duration {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	now := time.Now()
+	fc.tokens = math.Min(fc.burst, fc.tokens+now.Sub(fc.last).Seconds()*fc.rate)
+	fc.last = now
+
+	if fc.tokens >= 1 {
+		fc.tokens--
+		return 0
+	}
+	return time.Duration((1 - fc.tokens) / fc.rate * float64(time.Second))
+}
@@ -36,14 +36,40 @@ type httpClient struct {
 	baseURL        string
 	apiPathPrefix  string
 	authHeader     string
+	userAgent      string
 	maxRetries     int
 	retryDelay     time.Duration
 	retryStrategy  pkghttp.RetryStrategy
 	debug          bool
+	prettyPrint    bool
+	maxBodySize    int
+	logger         Logger
+	structLogger   StructuredLogger
 	circuitBreaker *pkghttp.CircuitBreaker
 	hook           HTTPHook
 }
 
+// withHTTPMiddleware returns a copy of client whose transport is wrapped by
+// middleware, the first function being the outermost. client itself is not
+// modified, so a client shared with other code keeps its transport.
+func withHTTPMiddleware(client *http.Client, middleware []func(http.RoundTripper) http.RoundTripper) *http.Client {
+	if len(middleware) == 0 {
+		return client
+	}
+
+	transport := client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for i := len(middleware) - 1; i >= 0; i-- {
+		transport = middleware[i](transport)
+	}
+
+	wrapped := *client
+	wrapped.Transport = transport
+	return &wrapped
+}
+
 // newHTTPClient creates a new HTTP client.
 func newHTTPClient(cfg *Config) *httpClient {
 	auth := base64.StdEncoding.EncodeToString([]byte(cfg.PublicKey + ":" + cfg.SecretKey))
@@ -60,15 +86,25 @@ func newHTTPClient(cfg *Config) *httpClient {
 		}
 	}
 
+	userAgent := "langfuse-go/" + Version
+	if cfg.ProjectID != "" {
+		userAgent += " project/" + cfg.ProjectID
+	}
+
 	h := &httpClient{
-		client:        cfg.HTTPClient,
+		client:        withHTTPMiddleware(cfg.HTTPClient, cfg.HTTPMiddleware),
 		baseURL:       strings.TrimSuffix(cfg.BaseURL, "/"),
 		apiPathPrefix: strings.TrimSuffix(cfg.APIPathPrefix, "/"),
 		authHeader:    "Basic " + auth,
+		userAgent:     userAgent,
 		maxRetries:    cfg.MaxRetries,
 		retryDelay:    cfg.RetryDelay,
 		retryStrategy: retryStrategy,
 		debug:         cfg.Debug,
+		prettyPrint:   cfg.DebugPrettyPrint,
+		maxBodySize:   cfg.DebugMaxBodySize,
+		logger:        cfg.Logger,
+		structLogger:  cfg.StructuredLogger,
 		hook:          combineHooks(cfg.HTTPHooks),
 	}
 
@@ -141,6 +177,14 @@ func (h *httpClient) doOnce(ctx context.Context, req *request) error {
 		u += "?" + req.query.Encode()
 	}
 
+	// Generate request ID for tracing
+	requestID := generateRequestID()
+
+	// Check if context has a request ID override
+	if ctxRequestID, ok := ctx.Value(requestIDContextKey{}).(string); ok && ctxRequestID != "" {
+		requestID = ctxRequestID
+	}
+
 	// Build body
 	var bodyReader io.Reader
 	if req.body != nil {
@@ -153,6 +197,7 @@ func (h *httpClient) doOnce(ctx context.Context, req *request) error {
 				len(bodyBytes), maxRequestBodySize)
 		}
 		bodyReader = bytes.NewReader(bodyBytes)
+		h.logBody(req.method+" "+req.path+" request", requestID, bodyBytes)
 	}
 
 	// Create request
@@ -161,22 +206,13 @@ func (h *httpClient) doOnce(ctx context.Context, req *request) error {
 		return fmt.Errorf("langfuse: failed to create request: %w", err)
 	}
 
-	// Generate request ID for tracing
-	requestID := generateRequestID()
-
 	// Set headers
 	httpReq.Header.Set("Authorization", h.authHeader)
 	httpReq.Header.Set("Content-Type", "application/json")
 	httpReq.Header.Set("Accept", "application/json")
-	httpReq.Header.Set("User-Agent", "langfuse-go/"+Version)
+	httpReq.Header.Set("User-Agent", h.userAgent)
 	httpReq.Header.Set("X-Request-ID", requestID)
 
-	// Check if context has a request ID override
-	if ctxRequestID, ok := ctx.Value(requestIDContextKey{}).(string); ok && ctxRequestID != "" {
-		requestID = ctxRequestID
-		httpReq.Header.Set("X-Request-ID", requestID)
-	}
-
 	// Call BeforeRequest hook
 	if h.hook != nil {
 		if err := h.hook.BeforeRequest(ctx, httpReq); err != nil {
@@ -211,6 +247,7 @@ func (h *httpClient) doOnce(ctx context.Context, req *request) error {
 	if len(respBody) > maxResponseSize {
 		return fmt.Errorf("langfuse: response body exceeded maximum size of %d bytes (request_id=%s)", maxResponseSize, requestID)
 	}
+	h.logBody(fmt.Sprintf("%s %s response (status=%d)", req.method, req.path, resp.StatusCode), requestID, respBody)
 
 	// Check for errors
 	if resp.StatusCode >= 400 {
@@ -246,6 +283,43 @@ func (h *httpClient) doOnce(ctx context.Context, req *request) error {
 	return nil
 }
 
+// logBody logs an HTTP body when debug mode is enabled. Bodies are only
+// formatted if the configured logger would write debug output. Logging never
+// changes the transmitted payload.
+func (h *httpClient) logBody(label, requestID string, body []byte) {
+	if !h.debug || len(body) == 0 {
+		return
+	}
+
+	switch {
+	case h.structLogger != nil:
+		if !IsDebugEnabled(h.structLogger) {
+			return
+		}
+		h.structLogger.Debug("http "+label, "request_id", requestID, "body", formatDebugBody(body, h.prettyPrint, h.maxBodySize))
+	case h.logger != nil:
+		if !IsDebugEnabled(h.logger) {
+			return
+		}
+		h.logger.Printf("http %s (request_id=%s): %s", label, requestID, formatDebugBody(body, h.prettyPrint, h.maxBodySize))
+	}
+}
+
+// formatDebugBody optionally indents a JSON body and truncates it to
+// maxSize bytes. Bodies that are not valid JSON are left unindented.
+func formatDebugBody(body []byte, pretty bool, maxSize int) string {
+	if pretty {
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, body, "", "  "); err == nil {
+			body = buf.Bytes()
+		}
+	}
+	if maxSize > 0 && len(body) > maxSize {
+		return fmt.Sprintf("%s... (truncated, %d bytes total)", body[:maxSize], len(body))
+	}
+	return string(body)
+}
+
 // requestIDContextKey is the context key for request IDs.
 type requestIDContextKey struct{}
 
@@ -314,6 +388,16 @@ func (h *httpClient) delete(ctx context.Context, path string, result any) error
 	})
 }
 
+// patch performs a PATCH request.
+func (h *httpClient) patch(ctx context.Context, path string, body any, result any) error {
+	return h.do(ctx, &request{
+		method: http.MethodPatch,
+		path:   path,
+		body:   body,
+		result: result,
+	})
+}
+
 // Get performs an HTTP GET request (implements http.Doer).
 func (h *httpClient) Get(ctx context.Context, path string, query url.Values, result any) error {
 	return h.get(ctx, path, query, result)
@@ -329,6 +413,11 @@ func (h *httpClient) Delete(ctx context.Context, path string, result any) error
 	return h.delete(ctx, path, result)
 }
 
+// Patch performs an HTTP PATCH request (implements http.PatchDoer).
+func (h *httpClient) Patch(ctx context.Context, path string, body, result any) error {
+	return h.patch(ctx, path, body, result)
+}
+
 // combineHooks combines multiple hooks into one.
 func combineHooks(hooks []HTTPHook) HTTPHook {
 	if len(hooks) == 0 {
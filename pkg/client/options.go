@@ -41,6 +41,14 @@ func WithHTTPClient(client *http.Client) ConfigOption {
 	}
 }
 
+// WithHTTPMiddleware wraps the HTTP transport with fns, the first being the
+// outermost.
+func WithHTTPMiddleware(fns ...func(http.RoundTripper) http.RoundTripper) ConfigOption {
+	return func(c *Config) {
+		c.HTTPMiddleware = append(c.HTTPMiddleware, fns...)
+	}
+}
+
 // WithTimeout sets the request timeout.
 func WithTimeout(timeout time.Duration) ConfigOption {
 	return func(c *Config) {
@@ -146,6 +154,44 @@ func WithOnBatchFlushed(fn func(BatchResult)) ConfigOption {
 	}
 }
 
+// WithOnShutdown sets the callback called at the end of Shutdown.
+func WithOnShutdown(fn func(ShutdownSummary)) ConfigOption {
+	return func(c *Config) {
+		c.OnShutdown = fn
+	}
+}
+
+// WithIdleTimeout sets how long the client may be idle before it shuts
+// itself down.
+func WithIdleTimeout(d time.Duration) ConfigOption {
+	return func(c *Config) {
+		c.IdleTimeout = d
+	}
+}
+
+// WithOnIdleShutdown sets the callback called when the idle timeout
+// triggers a shutdown.
+func WithOnIdleShutdown(fn func()) ConfigOption {
+	return func(c *Config) {
+		c.OnIdleShutdown = fn
+	}
+}
+
+// WithProjectID sets the project ID reported in the User-Agent header.
+func WithProjectID(id string) ConfigOption {
+	return func(c *Config) {
+		c.ProjectID = id
+	}
+}
+
+// WithPanicRecovery sets whether panics in user callbacks and background
+// batch processing are recovered. Enabled by default.
+func WithPanicRecovery(enabled bool) ConfigOption {
+	return func(c *Config) {
+		c.DisablePanicRecovery = !enabled
+	}
+}
+
 // WithIdleWarningDuration sets the idle warning duration.
 func WithIdleWarningDuration(duration time.Duration) ConfigOption {
 	return func(c *Config) {
@@ -180,3 +226,39 @@ func WithMaxBackgroundSenders(n int) ConfigOption {
 		c.MaxBackgroundSenders = n
 	}
 }
+
+// WithPreSendHook sets a hook that transforms each batch before it is sent.
+func WithPreSendHook(fn PreSendHook) ConfigOption {
+	return func(c *Config) {
+		c.PreSendHook = fn
+	}
+}
+
+// WithLazyInitialization defers starting background goroutines until the
+// first event is queued.
+func WithLazyInitialization() ConfigOption {
+	return func(c *Config) {
+		c.LazyInitialization = true
+	}
+}
+
+// WithMaxConcurrentFlushes limits concurrent in-flight batch requests.
+func WithMaxConcurrentFlushes(n int) ConfigOption {
+	return func(c *Config) {
+		c.MaxConcurrentFlushes = n
+	}
+}
+
+// WithMaxFlushesPerSecond limits the rate of batch requests.
+func WithMaxFlushesPerSecond(rps float64) ConfigOption {
+	return func(c *Config) {
+		c.MaxFlushesPerSecond = rps
+	}
+}
+
+// WithConcurrentFlush sets the number of goroutines sending queued batches.
+func WithConcurrentFlush(workers int) ConfigOption {
+	return func(c *Config) {
+		c.FlushWorkers = workers
+	}
+}
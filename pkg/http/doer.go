@@ -3,6 +3,7 @@ package http
 
 import (
 	"context"
+	"errors"
 	"net/url"
 )
 
@@ -19,3 +20,17 @@ type Doer interface {
 	// Delete performs an HTTP DELETE request.
 	Delete(ctx context.Context, path string, result any) error
 }
+
+// PatchDoer is a Doer that can also make HTTP PATCH requests.
+// It is separate from Doer so existing Doer implementations keep compiling;
+// sub-clients that need PATCH type-assert their Doer to PatchDoer.
+type PatchDoer interface {
+	Doer
+
+	// Patch performs an HTTP PATCH request.
+	Patch(ctx context.Context, path string, body, result any) error
+}
+
+// ErrPatchNotSupported is returned by operations that need PATCH when the
+// Doer does not implement PatchDoer.
+var ErrPatchNotSupported = errors.New("langfuse: HTTP doer does not support PATCH requests")
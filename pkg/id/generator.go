@@ -2,6 +2,7 @@ package id
 
 import (
 	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"sync/atomic"
@@ -136,19 +137,84 @@ func (g *IDGenerator) MustGenerate() string {
 	return id
 }
 
+// GenerateBatch creates n unique IDs, reading the random bytes for all of
+// them with a single crypto/rand call. It is cheaper than calling Generate
+// n times when many IDs are needed at once.
+// Returns an error only in IDModeStrict when crypto/rand fails.
+func (g *IDGenerator) GenerateBatch(n int) ([]string, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+
+	b := make([]byte, n*16)
+	_, err := rand.Read(b)
+	if err == nil {
+		ids := make([]string, n)
+		for i := range ids {
+			ids[i] = formatUUID(b[i*16 : (i+1)*16])
+		}
+		if g.metrics != nil {
+			g.metrics.IncrementCounter("langfuse.id.generated", int64(n))
+		}
+		return ids, nil
+	}
+
+	// Track crypto failure
+	failures := cryptoFailures.Add(1)
+	if g.metrics != nil {
+		g.metrics.IncrementCounter("langfuse.id.crypto_failures", 1)
+	}
+
+	switch g.mode {
+	case IDModeStrict:
+		return nil, fmt.Errorf("langfuse: crypto/rand failed (strict mode enabled, %d total failures): %w", failures, err)
+
+	case IDModeFallback:
+		if failures == 1 && g.logger != nil {
+			g.logger.Printf("WARNING: crypto/rand failed, using fallback ID generation: %v", err)
+		}
+
+		ids := make([]string, n)
+		for i := range ids {
+			ids[i] = g.generateFallbackID()
+		}
+		if g.metrics != nil {
+			g.metrics.IncrementCounter("langfuse.id.fallback_used", int64(n))
+		}
+		return ids, nil
+
+	default:
+		return nil, fmt.Errorf("langfuse: unknown ID generation mode: %d", g.mode)
+	}
+}
+
 // generateCryptoUUID generates a UUID v4 using crypto/rand.
 func (g *IDGenerator) generateCryptoUUID() (string, error) {
 	b := make([]byte, 16)
 	if _, err := rand.Read(b); err != nil {
 		return "", err
 	}
+	return formatUUID(b), nil
+}
 
+// formatUUID formats 16 random bytes as a UUID v4, setting the version and
+// variant bits in place.
+func formatUUID(b []byte) string {
 	// Set version (4) and variant bits per RFC 4122
 	b[6] = (b[6] & 0x0f) | 0x40
 	b[8] = (b[8] & 0x3f) | 0x80
 
-	return fmt.Sprintf("%x-%x-%x-%x-%x",
-		b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+	var buf [36]byte
+	hex.Encode(buf[0:8], b[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], b[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], b[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], b[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:36], b[10:16])
+	return string(buf[:])
 }
 
 // generateFallbackID generates a unique ID without crypto/rand.
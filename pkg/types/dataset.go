@@ -44,6 +44,9 @@ type DatasetRun struct {
 	ProjectID string `json:"projectId,omitempty"`
 	CreatedAt Time   `json:"createdAt,omitempty"`
 	UpdatedAt Time   `json:"updatedAt,omitempty"`
+
+	// DatasetRunItems is populated when the run is fetched by name.
+	DatasetRunItems []DatasetRunItem `json:"datasetRunItems,omitempty"`
 }
 
 // DatasetRunItem represents an item in a dataset run.
@@ -48,4 +48,59 @@ type Usage struct {
 	InputCost  float64 `json:"inputCost,omitempty"`
 	OutputCost float64 `json:"outputCost,omitempty"`
 	TotalCost  float64 `json:"totalCost,omitempty"`
+
+	// ReasoningTokens are reasoning or thinking tokens, which some models
+	// report separately from Output.
+	ReasoningTokens int `json:"reasoningTokens,omitempty"`
+
+	// CachedInputTokens are the Input tokens served from the provider's
+	// prompt cache.
+	CachedInputTokens int `json:"cachedInputTokens,omitempty"`
+}
+
+// Usage details keys for the extended usage fields.
+const (
+	UsageDetailsInput           = "input"
+	UsageDetailsOutput          = "output"
+	UsageDetailsTotal           = "total"
+	UsageDetailsReasoningTokens = "output_reasoning_tokens"
+	UsageDetailsCachedTokens    = "input_cached_tokens"
+)
+
+// HasExtendedUsage reports whether reasoning or cached input tokens are set.
+func (u *Usage) HasExtendedUsage() bool {
+	return u != nil && (u.ReasoningTokens > 0 || u.CachedInputTokens > 0)
+}
+
+// Details returns the token counts keyed by usage type, in the form of the
+// ingestion API's usageDetails, or nil if no extended usage is set.
+func (u *Usage) Details() map[string]int {
+	if !u.HasExtendedUsage() {
+		return nil
+	}
+	details := map[string]int{
+		UsageDetailsInput:  u.Input,
+		UsageDetailsOutput: u.Output,
+	}
+	if u.Total > 0 {
+		details[UsageDetailsTotal] = u.Total
+	}
+	if u.ReasoningTokens > 0 {
+		details[UsageDetailsReasoningTokens] = u.ReasoningTokens
+	}
+	if u.CachedInputTokens > 0 {
+		details[UsageDetailsCachedTokens] = u.CachedInputTokens
+	}
+	return details
+}
+
+// EffectiveInputCost returns the cost of the input tokens, charging
+// CachedInputTokens at pricePerCachedToken and the remaining Input tokens at
+// pricePerInputToken.
+func (u *Usage) EffectiveInputCost(pricePerInputToken, pricePerCachedToken float64) float64 {
+	if u == nil {
+		return 0
+	}
+	cached := min(u.CachedInputTokens, u.Input)
+	return float64(u.Input-cached)*pricePerInputToken + float64(cached)*pricePerCachedToken
 }
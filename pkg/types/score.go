@@ -22,3 +22,26 @@ type Score struct {
 	UpdatedAt    Time   `json:"updatedAt,omitempty"`
 	AuthorUserID string `json:"authorUserId,omitempty"`
 }
+
+// ScoreCategory is a category of a categorical score config.
+type ScoreCategory struct {
+	Label string  `json:"label"`
+	Value float64 `json:"value"`
+}
+
+// ScoreConfig defines a named score type and the values it accepts.
+type ScoreConfig struct {
+	ID          string          `json:"id,omitempty"`
+	Name        string          `json:"name"`
+	DataType    ScoreDataType   `json:"dataType"`
+	MinValue    *float64        `json:"minValue,omitempty"`
+	MaxValue    *float64        `json:"maxValue,omitempty"`
+	Categories  []ScoreCategory `json:"categories,omitempty"`
+	Description string          `json:"description,omitempty"`
+	IsArchived  bool            `json:"isArchived,omitempty"`
+
+	// Read-only fields
+	ProjectID string `json:"projectId,omitempty"`
+	CreatedAt Time   `json:"createdAt,omitempty"`
+	UpdatedAt Time   `json:"updatedAt,omitempty"`
+}
@@ -2,6 +2,7 @@ package langfuse
 
 import (
 	"context"
+	"runtime/debug"
 	"time"
 )
 
@@ -190,7 +191,8 @@ func WithSpanMetadata(metadata Metadata) SpanOption {
 	})
 }
 
-// WithLevel sets the observation level.
+// WithLevel sets the observation level of a span. WithSpanLevel is an
+// equivalent that mirrors WithGenerationLevel.
 func WithLevel(level ObservationLevel) SpanOption {
 	return spanOptionFunc(func(c *spanConfig) {
 		c.level = level
@@ -264,6 +266,58 @@ type generationConfig struct {
 	hasCompletionStart  bool
 }
 
+// applyTo sets the options held by c on builder.
+func (c *generationConfig) applyTo(builder *GenerationBuilder) {
+	if c.id != "" {
+		builder.ID(c.id)
+	}
+	if c.model != "" {
+		builder.Model(c.model)
+	}
+	if c.modelParameters != nil {
+		builder.ModelParameters(c.modelParameters)
+	}
+	if c.input != nil {
+		builder.Input(c.input)
+	}
+	if c.output != nil {
+		builder.Output(c.output)
+	}
+	if c.metadata != nil {
+		builder.Metadata(c.metadata)
+	}
+	if c.hasLevel {
+		builder.Level(c.level)
+	}
+	if c.statusMessage != "" {
+		builder.StatusMessage(c.statusMessage)
+	}
+	if c.version != "" {
+		builder.Version(c.version)
+	}
+	if c.environment != "" {
+		builder.Environment(c.environment)
+	}
+	if c.usage != nil {
+		builder.Usage(c.usage)
+	}
+	if c.promptName != "" {
+		builder.PromptName(c.promptName)
+	}
+	if c.hasPromptVersion {
+		builder.PromptVersion(c.promptVersion)
+	}
+	if c.hasStartTime {
+		builder.StartTime(c.startTime)
+	}
+	if c.hasEndTime {
+		builder.EndTime(c.endTime)
+	}
+	if c.hasCompletionStart {
+		builder.CompletionStartTime(c.completionStartTime)
+	}
+}
+
 // GenerationOption configures a generation creation.
 // This interface allows both function-based options and unified observation options.
 type GenerationOption interface {
@@ -676,55 +730,7 @@ func (t *TraceContext) Generation(ctx context.Context, name string, opts ...Gene
 	}
 
 	builder := t.NewGeneration().Name(name)
-
-	if cfg.id != "" {
-		builder.ID(cfg.id)
-	}
-	if cfg.model != "" {
-		builder.Model(cfg.model)
-	}
-	if cfg.modelParameters != nil {
-		builder.ModelParameters(cfg.modelParameters)
-	}
-	if cfg.input != nil {
-		builder.Input(cfg.input)
-	}
-	if cfg.output != nil {
-		builder.Output(cfg.output)
-	}
-	if cfg.metadata != nil {
-		builder.Metadata(cfg.metadata)
-	}
-	if cfg.hasLevel {
-		builder.Level(cfg.level)
-	}
-	if cfg.statusMessage != "" {
-		builder.StatusMessage(cfg.statusMessage)
-	}
-	if cfg.version != "" {
-		builder.Version(cfg.version)
-	}
-	if cfg.environment != "" {
-		builder.Environment(cfg.environment)
-	}
-	if cfg.usage != nil {
-		builder.Usage(cfg.usage)
-	}
-	if cfg.promptName != "" {
-		builder.PromptName(cfg.promptName)
-	}
-	if cfg.hasPromptVersion {
-		builder.PromptVersion(cfg.promptVersion)
-	}
-	if cfg.hasStartTime {
-		builder.StartTime(cfg.startTime)
-	}
-	if cfg.hasEndTime {
-		builder.EndTime(cfg.endTime)
-	}
-	if cfg.hasCompletionStart {
-		builder.CompletionStartTime(cfg.completionStartTime)
-	}
+	cfg.applyTo(builder)
 
 	return builder.Create(ctx)
 }
@@ -928,55 +934,7 @@ func (s *SpanContext) Generation(ctx context.Context, name string, opts ...Gener
 	}
 
 	builder := s.NewGeneration().Name(name)
-
-	if cfg.id != "" {
-		builder.ID(cfg.id)
-	}
-	if cfg.model != "" {
-		builder.Model(cfg.model)
-	}
-	if cfg.modelParameters != nil {
-		builder.ModelParameters(cfg.modelParameters)
-	}
-	if cfg.input != nil {
-		builder.Input(cfg.input)
-	}
-	if cfg.output != nil {
-		builder.Output(cfg.output)
-	}
-	if cfg.metadata != nil {
-		builder.Metadata(cfg.metadata)
-	}
-	if cfg.hasLevel {
-		builder.Level(cfg.level)
-	}
-	if cfg.statusMessage != "" {
-		builder.StatusMessage(cfg.statusMessage)
-	}
-	if cfg.version != "" {
-		builder.Version(cfg.version)
-	}
-	if cfg.environment != "" {
-		builder.Environment(cfg.environment)
-	}
-	if cfg.usage != nil {
-		builder.Usage(cfg.usage)
-	}
-	if cfg.promptName != "" {
-		builder.PromptName(cfg.promptName)
-	}
-	if cfg.hasPromptVersion {
-		builder.PromptVersion(cfg.promptVersion)
-	}
-	if cfg.hasStartTime {
-		builder.StartTime(cfg.startTime)
-	}
-	if cfg.hasEndTime {
-		builder.EndTime(cfg.endTime)
-	}
-	if cfg.hasCompletionStart {
-		builder.CompletionStartTime(cfg.completionStartTime)
-	}
+	cfg.applyTo(builder)
 
 	return builder.Create(ctx)
 }
@@ -1099,55 +1057,7 @@ func (g *GenerationContext) Generation(ctx context.Context, name string, opts ..
 	}
 
 	builder := g.NewGeneration().Name(name)
-
-	if cfg.id != "" {
-		builder.ID(cfg.id)
-	}
-	if cfg.model != "" {
-		builder.Model(cfg.model)
-	}
-	if cfg.modelParameters != nil {
-		builder.ModelParameters(cfg.modelParameters)
-	}
-	if cfg.input != nil {
-		builder.Input(cfg.input)
-	}
-	if cfg.output != nil {
-		builder.Output(cfg.output)
-	}
-	if cfg.metadata != nil {
-		builder.Metadata(cfg.metadata)
-	}
-	if cfg.hasLevel {
-		builder.Level(cfg.level)
-	}
-	if cfg.statusMessage != "" {
-		builder.StatusMessage(cfg.statusMessage)
-	}
-	if cfg.version != "" {
-		builder.Version(cfg.version)
-	}
-	if cfg.environment != "" {
-		builder.Environment(cfg.environment)
-	}
-	if cfg.usage != nil {
-		builder.Usage(cfg.usage)
-	}
-	if cfg.promptName != "" {
-		builder.PromptName(cfg.promptName)
-	}
-	if cfg.hasPromptVersion {
-		builder.PromptVersion(cfg.promptVersion)
-	}
-	if cfg.hasStartTime {
-		builder.StartTime(cfg.startTime)
-	}
-	if cfg.hasEndTime {
-		builder.EndTime(cfg.endTime)
-	}
-	if cfg.hasCompletionStart {
-		builder.CompletionStartTime(cfg.completionStartTime)
-	}
+	cfg.applyTo(builder)
 
 	return builder.Create(ctx)
 }
@@ -1447,6 +1357,97 @@ func WithGeneration(ctx context.Context, trace *TraceContext, model string, inpu
 	return gen, output, nil
 }
 
+// Measure runs fn inside a new span named name and returns its results. If
+// fn returns an error it is recorded on the span with SetStatusError;
+// otherwise a non-nil result is recorded as the span output. The span is
+// ended when fn returns. If fn panics, the panic is recorded with
+// RecordPanic and re-panicked.
+//
+// Example:
+//
+//	docs, err := trace.Measure(ctx, "retrieve", func() (any, error) {
+//	    return store.Search(ctx, query)
+//	}, langfuse.WithSpanInput(query))
+func (t *TraceContext) Measure(ctx context.Context, name string, fn func() (any, error), opts ...SpanOption) (any, error) {
+	span, err := t.Span(ctx, name, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return span.measure(ctx, fn)
+}
+
+// Measure runs fn inside a new child span named name. See
+// TraceContext.Measure.
+func (s *SpanContext) Measure(ctx context.Context, name string, fn func() (any, error), opts ...SpanOption) (any, error) {
+	span, err := s.Span(ctx, name, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return span.measure(ctx, fn)
+}
+
+// measure runs fn and ends the span with its result.
+func (s *SpanContext) measure(ctx context.Context, fn func() (any, error)) (any, error) {
+	defer func() {
+		if r := recover(); r != nil {
+			_ = s.RecordPanic(ctx, r)
+			panic(r)
+		}
+	}()
+
+	result, fnErr := fn()
+	if fnErr != nil {
+		_ = s.SetStatusError(ctx, fnErr)
+		_ = s.End(ctx)
+		return result, fnErr
+	}
+	if result != nil {
+		_ = s.EndWithOutput(ctx, result)
+	} else {
+		_ = s.End(ctx)
+	}
+	return result, nil
+}
+
+// MeasureGeneration runs fn inside a new generation named name and returns
+// its output. The token counts returned by fn are recorded as the
+// generation usage, along with the output or, if fn fails, the error. If fn
+// panics, the panic is recorded as an error and re-panicked.
+//
+// Example:
+//
+//	answer, err := trace.MeasureGeneration(ctx, "answer", func() (string, int, int, error) {
+//	    resp, err := llm.Complete(ctx, prompt)
+//	    if err != nil {
+//	        return "", 0, 0, err
+//	    }
+//	    return resp.Text, resp.Usage.InputTokens, resp.Usage.OutputTokens, nil
+//	}, langfuse.WithModel("gpt-4o"), langfuse.WithGenerationInput(prompt))
+func (t *TraceContext) MeasureGeneration(ctx context.Context, name string, fn func() (output string, inputTokens, outputTokens int, err error), opts ...GenerationOption) (string, error) {
+	gen, err := t.Generation(ctx, name, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			_ = gen.EndWith(ctx,
+				WithError(panicError(r)),
+				WithEndMetadata(Metadata{"panic_stack": string(debug.Stack())}),
+			)
+			panic(r)
+		}
+	}()
+
+	output, inputTokens, outputTokens, fnErr := fn()
+	if fnErr != nil {
+		_ = gen.EndWith(ctx, WithError(fnErr), WithUsage(inputTokens, outputTokens))
+		return output, fnErr
+	}
+	_ = gen.EndWithUsage(ctx, output, inputTokens, outputTokens)
+	return output, nil
+}
+
 // ============================================================================
 // V1 API - Simplified Client Creation
 // ============================================================================
@@ -1958,3 +1959,88 @@ func WithObservation(ctx context.Context, obs Observer) context.Context {
 		return ctx
 	}
 }
+
+// ============================================================================
+// Context Tracer
+// ============================================================================
+
+// ContextTracer starts traces and spans and propagates them through
+// context.Context, in the style of the OpenTelemetry Tracer API. Code that
+// receives the returned context, including other goroutines, can start
+// child spans without having the TraceContext or SpanContext passed in.
+//
+// ContextTracer is safe for concurrent use.
+//
+// Example:
+//
+//	tracer := langfuse.NewContextTracer(client)
+//
+//	ctx, trace, err := tracer.Start(ctx, "handle-request", langfuse.WithUserID(userID))
+//	if err != nil {
+//	    return err
+//	}
+//	defer trace.Update().Output(result).Apply(ctx)
+//
+//	go func(ctx context.Context) {
+//	    ctx, span, _ := tracer.StartSpan(ctx, "background-work")
+//	    defer span.End(ctx)
+//	    // ...
+//	}(ctx)
+type ContextTracer struct {
+	client *Client
+}
+
+// NewContextTracer creates a ContextTracer that creates traces with client.
+func NewContextTracer(client *Client) *ContextTracer {
+	return &ContextTracer{client: client}
+}
+
+// Start creates a new trace and returns a context that carries it. Spans
+// started from the returned context with StartSpan belong to the new trace.
+func (t *ContextTracer) Start(ctx context.Context, name string, opts ...TraceOption) (context.Context, *TraceContext, error) {
+	trace, err := t.client.Trace(ctx, name, opts...)
+	if err != nil {
+		return ctx, nil, err
+	}
+	return ContextWithTrace(ctx, trace), trace, nil
+}
+
+// StartSpan creates a span in the trace carried by ctx and returns a context
+// that carries the new span. If ctx also carries a span of that trace, the
+// new span is its child; otherwise it is attached directly to the trace.
+// It returns a validation error if ctx carries no trace.
+func (t *ContextTracer) StartSpan(ctx context.Context, name string, opts ...SpanOption) (context.Context, *SpanContext, error) {
+	trace, parent, ok := t.Current(ctx)
+	if !ok {
+		return ctx, nil, NewValidationError("ctx", "context does not carry a trace; call Start first")
+	}
+
+	var span *SpanContext
+	var err error
+	if parent != nil {
+		span, err = parent.Span(ctx, name, opts...)
+	} else {
+		span, err = trace.Span(ctx, name, opts...)
+	}
+	if err != nil {
+		return ctx, nil, err
+	}
+	return ContextWithSpan(ctx, span), span, nil
+}
+
+// Current returns the trace and innermost span carried by ctx. The span is
+// nil if no span of the trace has been started in ctx. ok is false if ctx
+// carries no trace.
+func (t *ContextTracer) Current(ctx context.Context) (*TraceContext, *SpanContext, bool) {
+	trace, ok := TraceFromContext(ctx)
+	if !ok || trace == nil {
+		return nil, nil, false
+	}
+	span, _ := SpanFromContext(ctx)
+	if span != nil && span.traceID != trace.traceID {
+		// The span was started in a different trace than the one most
+		// recently stored in ctx.
+		span = nil
+	}
+	return trace, span, true
+}
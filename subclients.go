@@ -1,9 +1,15 @@
 package langfuse
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/url"
+	"sort"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/jdziat/langfuse-go/pkg/api/datasets"
 	"github.com/jdziat/langfuse-go/pkg/api/models"
@@ -20,13 +26,15 @@ import (
 
 // TracesClient handles trace-related API operations.
 type TracesClient struct {
-	impl *traces.Client
+	impl   *traces.Client
+	client *Client
 }
 
 // newTracesClient creates a new TracesClient.
 func newTracesClient(client *Client) *TracesClient {
 	return &TracesClient{
-		impl: traces.New(client.HTTP()),
+		impl:   traces.New(client.HTTP()),
+		client: client,
 	}
 }
 
@@ -34,6 +42,37 @@ func newTracesClient(client *Client) *TracesClient {
 type TracesListParams struct {
 	PaginationParams
 	FilterParams
+	Order OrderParams
+
+	// inputSchemaVersion is set by InputSchemaVersion
+	inputSchemaVersion *int
+}
+
+// InputSchemaVersion keeps only traces whose input was recorded with
+// TraceBuilder.VersionedInput and schema version v. The API cannot filter
+// on metadata, so the filter is applied to each returned page; pages may
+// hold fewer than Limit traces.
+//
+// Example:
+//
+//	params := (&langfuse.TracesListParams{}).InputSchemaVersion(2)
+//	traces, err := client.Traces().List(ctx, params)
+func (p *TracesListParams) InputSchemaVersion(v int) *TracesListParams {
+	p.inputSchemaVersion = &v
+	return p
+}
+
+// OrderBy sorts the listed traces by field, such as TraceOrderByTimestamp,
+// in descending order if desc is true. The sortable fields depend on the
+// Langfuse server version; the API rejects fields it cannot sort by.
+//
+// Example:
+//
+//	params := (&langfuse.TracesListParams{}).OrderBy(langfuse.TraceOrderByTimestamp, true)
+//	traces, err := client.Traces().List(ctx, params)
+func (p *TracesListParams) OrderBy(field string, desc bool) *TracesListParams {
+	p.Order = OrderParams{Field: field, Desc: desc}
+	return p
 }
 
 // TracesListResponse represents the response from listing traces.
@@ -46,16 +85,62 @@ type TracesListResponse struct {
 func (c *TracesClient) List(ctx context.Context, params *TracesListParams) (*TracesListResponse, error) {
 	var query = make(map[string][]string)
 	if params != nil {
-		query = mergeQuery(params.PaginationParams.ToQuery(), params.FilterParams.ToQuery())
+		query = mergeQuery(params.PaginationParams.ToQuery(), params.FilterParams.ToQuery(), params.Order.ToQuery())
 	}
 
 	var result TracesListResponse
 	if err := c.impl.List(ctx, query, &result); err != nil {
 		return nil, err
 	}
+	if params != nil && params.inputSchemaVersion != nil {
+		result.Data = filterByInputSchemaVersion(result.Data, *params.inputSchemaVersion)
+	}
 	return &result, nil
 }
 
+// filterByInputSchemaVersion returns the traces recorded with input schema
+// version v. Metadata decoded from JSON holds the version as a float64.
+func filterByInputSchemaVersion(traces []Trace, v int) []Trace {
+	filtered := traces[:0]
+	for _, t := range traces {
+		if version, ok := t.Metadata[InputSchemaVersionMetadataKey].(float64); ok && version == float64(v) {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered
+}
+
+// ListByUserID lists the traces of a user. page may be nil to use the
+// API's default page and limit.
+//
+// Example:
+//
+//	traces, err := client.Traces().ListByUserID(ctx, "user-123", &langfuse.PaginationParams{Limit: 20})
+func (c *TracesClient) ListByUserID(ctx context.Context, userID string, page *PaginationParams) (*TracesListResponse, error) {
+	if userID == "" {
+		return nil, NewValidationError("userId", "user ID is required")
+	}
+	return c.List(ctx, tracesListParams(FilterParams{UserID: userID}, page))
+}
+
+// ListBySessionID lists the traces of a session. page may be nil to use
+// the API's default page and limit.
+func (c *TracesClient) ListBySessionID(ctx context.Context, sessionID string, page *PaginationParams) (*TracesListResponse, error) {
+	if sessionID == "" {
+		return nil, NewValidationError("sessionId", "session ID is required")
+	}
+	return c.List(ctx, tracesListParams(FilterParams{SessionID: sessionID}, page))
+}
+
+// tracesListParams combines a filter with optional pagination.
+func tracesListParams(filter FilterParams, page *PaginationParams) *TracesListParams {
+	params := &TracesListParams{FilterParams: filter}
+	if page != nil {
+		params.PaginationParams = *page
+	}
+	return params
+}
+
 // Get retrieves a single trace by ID.
 func (c *TracesClient) Get(ctx context.Context, traceID string) (*Trace, error) {
 	var result Trace
@@ -70,6 +155,323 @@ func (c *TracesClient) Delete(ctx context.Context, traceID string) error {
 	return c.impl.Delete(ctx, traceID)
 }
 
+// Trace statuses set by TracesClient.Archive and TracesClient.Restore.
+const (
+	TraceStatusActive   = "active"
+	TraceStatusArchived = "archived"
+)
+
+// archiveBatchSize is the page size used by BulkArchive.
+const archiveBatchSize = 100
+
+// ArchiveResult summarizes a TracesClient.BulkArchive call.
+type ArchiveResult struct {
+	// ArchivedCount is the number of traces archived
+	ArchivedCount int
+
+	// FailedCount is the number of traces that could not be archived
+	FailedCount int
+
+	// FailedIDs are the IDs of the traces that could not be archived
+	FailedIDs []string
+}
+
+// traceStatusUpdate is the request body for changing a trace's status.
+type traceStatusUpdate struct {
+	Status string `json:"status"`
+}
+
+// Archive marks a trace as archived, e.g. because it is resolved or out of
+// scope for evaluation. Archived traces are kept and can be restored with
+// Restore. It sends PATCH /api/public/traces/{traceId} with status
+// "archived"; see the Langfuse API reference at
+// https://api.reference.langfuse.com.
+func (c *TracesClient) Archive(ctx context.Context, traceID string) error {
+	return c.impl.Update(ctx, traceID, &traceStatusUpdate{Status: TraceStatusArchived}, nil)
+}
+
+// Restore marks an archived trace as active again. Like Archive, it sends
+// PATCH /api/public/traces/{traceId}, with status "active".
+func (c *TracesClient) Restore(ctx context.Context, traceID string) error {
+	return c.impl.Update(ctx, traceID, &traceStatusUpdate{Status: TraceStatusActive}, nil)
+}
+
+// BulkArchive archives every trace matching filter. Matching traces are
+// fetched in pages of 100 and then archived one by one; pagination fields in
+// filter are ignored. Traces that fail to archive are reported in the result rather
+// than stopping the operation. An error is returned, together with the
+// result so far, if listing traces fails or ctx is cancelled.
+//
+// Example:
+//
+//	result, err := client.Traces().BulkArchive(ctx, langfuse.TracesListParams{
+//	    FilterParams: langfuse.FilterParams{Tags: []string{"resolved"}},
+//	})
+func (c *TracesClient) BulkArchive(ctx context.Context, filter TracesListParams) (*ArchiveResult, error) {
+	var ids []string
+	params := filter
+	params.Cursor = ""
+	params.Limit = archiveBatchSize
+	for page := 1; ; page++ {
+		params.Page = page
+		resp, err := c.List(ctx, &params)
+		if err != nil {
+			return &ArchiveResult{}, fmt.Errorf("langfuse: list traces: %w", err)
+		}
+		for _, t := range resp.Data {
+			ids = append(ids, t.ID)
+		}
+		if len(resp.Data) == 0 || !resp.Meta.HasMore() {
+			break
+		}
+	}
+
+	// Archiving starts only after every page is listed, so archived traces
+	// cannot shift the pages of a filter that excludes them.
+	result := &ArchiveResult{}
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if err := c.Archive(ctx, id); err != nil {
+			result.FailedCount++
+			result.FailedIDs = append(result.FailedIDs, id)
+			continue
+		}
+		result.ArchivedCount++
+	}
+	return result, nil
+}
+
+// Filter returns the traces matching params for which fn returns true. It
+// pages through every trace matching params, so it makes one List call per
+// page of results; prefer server-side filtering through params, such as
+// tags, user, session or time range, and use Filter only for conditions the
+// API cannot express, such as metadata values. Pages are fetched starting at
+// params.Page with params.Limit traces each, or 100 if unset. An error is
+// returned if listing fails or ctx is cancelled.
+//
+// Example:
+//
+//	flagged, err := client.Traces().Filter(ctx, &langfuse.TracesListParams{
+//	    FilterParams: langfuse.FilterParams{Tags: []string{"support"}},
+//	}, func(t *langfuse.Trace) bool {
+//	    return t.Metadata["escalated"] == true
+//	})
+func (c *TracesClient) Filter(ctx context.Context, params *TracesListParams, fn func(*Trace) bool) ([]*Trace, error) {
+	return c.filter(ctx, params, fn, 0)
+}
+
+// FilterFirst is like Filter but stops fetching pages once n matching
+// traces are found, and returns at most n traces. It returns nil if n is not
+// positive.
+func (c *TracesClient) FilterFirst(ctx context.Context, params *TracesListParams, fn func(*Trace) bool, n int) ([]*Trace, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+	return c.filter(ctx, params, fn, n)
+}
+
+// filter pages through the traces matching params and collects those for
+// which fn returns true, stopping after limit matches if limit is positive.
+func (c *TracesClient) filter(ctx context.Context, params *TracesListParams, fn func(*Trace) bool, limit int) ([]*Trace, error) {
+	var p TracesListParams
+	if params != nil {
+		p = *params
+	}
+	p.Cursor = ""
+	if p.Limit <= 0 {
+		p.Limit = traceFetchPageSize
+	}
+	if p.Page <= 0 {
+		p.Page = 1
+	}
+
+	var matched []*Trace
+	for ; ; p.Page++ {
+		if err := ctx.Err(); err != nil {
+			return matched, err
+		}
+		resp, err := c.List(ctx, &p)
+		if err != nil {
+			return matched, fmt.Errorf("langfuse: list traces: %w", err)
+		}
+		for i := range resp.Data {
+			trace := &resp.Data[i]
+			if !fn(trace) {
+				continue
+			}
+			matched = append(matched, trace)
+			if limit > 0 && len(matched) == limit {
+				return matched, nil
+			}
+		}
+		if len(resp.Data) == 0 || !resp.Meta.HasMore() {
+			return matched, nil
+		}
+	}
+}
+
+// GetTraceOption configures TracesClient.GetWithObservations.
+type GetTraceOption func(*getTraceConfig)
+
+type getTraceConfig struct {
+	fetchScores bool
+}
+
+// WithFetchScores sets whether GetWithObservations also fetches the trace
+// scores, which costs an additional API call. Defaults to false.
+func WithFetchScores(fetch bool) GetTraceOption {
+	return func(c *getTraceConfig) {
+		c.fetchScores = fetch
+	}
+}
+
+// traceFetchPageSize is the page size used when fetching all observations
+// or scores of a trace.
+const traceFetchPageSize = 100
+
+// TraceWithObservations is a trace together with its observations and,
+// optionally, its scores.
+type TraceWithObservations struct {
+	Trace        *Trace
+	Observations []Observation
+	Scores       []Score
+}
+
+// ObservationNode is a node of the observation tree of a trace.
+type ObservationNode struct {
+	// Observation is nil for the root node, which stands for the trace
+	Observation *Observation
+	Children    []*ObservationNode
+}
+
+// ObservationTree reconstructs the parent-child tree of the observations
+// from their ParentObservationID fields. The returned root stands for the
+// trace itself and has the top-level observations as children. Observations
+// whose parent is not part of the trace are attached to the root. Children
+// are ordered by start time.
+func (t *TraceWithObservations) ObservationTree() *ObservationNode {
+	root := &ObservationNode{}
+	nodes := make(map[string]*ObservationNode, len(t.Observations))
+	for i := range t.Observations {
+		nodes[t.Observations[i].ID] = &ObservationNode{Observation: &t.Observations[i]}
+	}
+
+	for i := range t.Observations {
+		obs := &t.Observations[i]
+		parent, ok := nodes[obs.ParentObservationID]
+		if !ok || obs.ParentObservationID == obs.ID {
+			parent = root
+		}
+		parent.Children = append(parent.Children, nodes[obs.ID])
+	}
+
+	var sortChildren func(n *ObservationNode)
+	sortChildren = func(n *ObservationNode) {
+		sort.SliceStable(n.Children, func(i, j int) bool {
+			return n.Children[i].Observation.StartTime.Before(n.Children[j].Observation.StartTime.Time)
+		})
+		for _, child := range n.Children {
+			sortChildren(child)
+		}
+	}
+	sortChildren(root)
+	return root
+}
+
+// GetWithObservations retrieves a trace and all of its observations in
+// parallel. Pass WithFetchScores(true) to also fetch its scores.
+//
+// Example:
+//
+//	full, err := client.Traces().GetWithObservations(ctx, traceID, langfuse.WithFetchScores(true))
+//	if err != nil {
+//	    return err
+//	}
+//	for _, node := range full.ObservationTree().Children {
+//	    fmt.Println(node.Observation.Name, len(node.Children))
+//	}
+func (c *TracesClient) GetWithObservations(ctx context.Context, traceID string, opts ...GetTraceOption) (*TraceWithObservations, error) {
+	if traceID == "" {
+		return nil, NewValidationError("traceID", "trace ID is required")
+	}
+	cfg := &getTraceConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	var (
+		wg       sync.WaitGroup
+		result   TraceWithObservations
+		traceErr error
+		obsErr   error
+		scoreErr error
+	)
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		result.Trace, traceErr = c.Get(ctx, traceID)
+	}()
+	go func() {
+		defer wg.Done()
+		result.Observations, obsErr = c.listObservations(ctx, traceID)
+	}()
+	if cfg.fetchScores {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result.Scores, scoreErr = c.listScores(ctx, traceID)
+		}()
+	}
+	wg.Wait()
+
+	if traceErr != nil {
+		return nil, traceErr
+	}
+	if obsErr != nil {
+		return nil, fmt.Errorf("langfuse: list observations: %w", obsErr)
+	}
+	if scoreErr != nil {
+		return nil, fmt.Errorf("langfuse: list scores: %w", scoreErr)
+	}
+	return &result, nil
+}
+
+// listObservations fetches every observation of the trace.
+func (c *TracesClient) listObservations(ctx context.Context, traceID string) ([]Observation, error) {
+	var all []Observation
+	for page := 1; ; page++ {
+		resp, err := c.client.Observations().ListByTrace(ctx, traceID, &PaginationParams{Page: page, Limit: traceFetchPageSize})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, resp.Data...)
+		if len(resp.Data) == 0 || !resp.Meta.HasMore() {
+			return all, nil
+		}
+	}
+}
+
+// listScores fetches every score of the trace.
+func (c *TracesClient) listScores(ctx context.Context, traceID string) ([]Score, error) {
+	var all []Score
+	for page := 1; ; page++ {
+		resp, err := c.client.Scores().List(ctx, &ScoresListParams{
+			PaginationParams: PaginationParams{Page: page, Limit: traceFetchPageSize},
+			TraceID:          traceID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, resp.Data...)
+		if len(resp.Data) == 0 || !resp.Meta.HasMore() {
+			return all, nil
+		}
+	}
+}
+
 // ============================================================================
 // Observations Client
 // ============================================================================
@@ -91,6 +493,22 @@ type ObservationsListParams struct {
 	PaginationParams
 	FilterParams
 	ParentObservationID string
+	Order               OrderParams
+
+	// LLMProvider keeps only observations whose metadata records this
+	// provider, as set by GenerationBuilder.LLMProvider. The API cannot
+	// filter on metadata, so the filter is applied to each returned page;
+	// pages may hold fewer than Limit observations.
+	LLMProvider string
+}
+
+// OrderBy sorts the listed observations by field, in descending order if
+// desc is true. Note that not all Langfuse API versions support ordering
+// observations; servers that do not ignore the parameter and return
+// observations in their default order.
+func (p *ObservationsListParams) OrderBy(field string, desc bool) *ObservationsListParams {
+	p.Order = OrderParams{Field: field, Desc: desc}
+	return p
 }
 
 // ObservationsListResponse represents the response from listing observations.
@@ -103,7 +521,7 @@ type ObservationsListResponse struct {
 func (c *ObservationsClient) List(ctx context.Context, params *ObservationsListParams) (*ObservationsListResponse, error) {
 	query := url.Values{}
 	if params != nil {
-		query = mergeQuery(params.PaginationParams.ToQuery(), params.FilterParams.ToQuery())
+		query = mergeQuery(params.PaginationParams.ToQuery(), params.FilterParams.ToQuery(), params.Order.ToQuery())
 		if params.ParentObservationID != "" {
 			query.Set("parentObservationId", params.ParentObservationID)
 		}
@@ -113,9 +531,23 @@ func (c *ObservationsClient) List(ctx context.Context, params *ObservationsListP
 	if err := c.impl.List(ctx, query, &result); err != nil {
 		return nil, err
 	}
+	if params != nil && params.LLMProvider != "" {
+		result.Data = filterByLLMProvider(result.Data, params.LLMProvider)
+	}
 	return &result, nil
 }
 
+// filterByLLMProvider returns the observations recorded with provider.
+func filterByLLMProvider(observations []Observation, provider string) []Observation {
+	filtered := observations[:0]
+	for _, o := range observations {
+		if p, _ := o.Metadata[LLMProviderMetadataKey].(string); p == provider {
+			filtered = append(filtered, o)
+		}
+	}
+	return filtered
+}
+
 // Get retrieves a single observation by ID.
 func (c *ObservationsClient) Get(ctx context.Context, observationID string) (*Observation, error) {
 	var result Observation
@@ -149,7 +581,8 @@ func (c *ObservationsClient) ListSpans(ctx context.Context, params *Observations
 	return c.List(ctx, params)
 }
 
-// ListGenerations retrieves all generations.
+// ListGenerations retrieves all generations. Set params.LLMProvider to list
+// only the generations of one provider.
 func (c *ObservationsClient) ListGenerations(ctx context.Context, params *ObservationsListParams) (*ObservationsListResponse, error) {
 	if params == nil {
 		params = &ObservationsListParams{}
@@ -194,6 +627,16 @@ type ScoresListParams struct {
 	DataType      ScoreDataType
 	Source        ScoreSource
 	Environment   string
+	Order         OrderParams
+}
+
+// OrderBy sorts the listed scores by field, in descending order if desc is
+// true. Note that not all Langfuse API versions support ordering scores;
+// servers that do not ignore the parameter and return scores in their
+// default order.
+func (p *ScoresListParams) OrderBy(field string, desc bool) *ScoresListParams {
+	p.Order = OrderParams{Field: field, Desc: desc}
+	return p
 }
 
 // ScoresListResponse represents the response from listing scores.
@@ -206,7 +649,7 @@ type ScoresListResponse struct {
 func (c *ScoresClient) List(ctx context.Context, params *ScoresListParams) (*ScoresListResponse, error) {
 	query := url.Values{}
 	if params != nil {
-		query = params.PaginationParams.ToQuery()
+		query = mergeQuery(params.PaginationParams.ToQuery(), params.Order.ToQuery())
 		if params.Name != "" {
 			query.Set("name", params.Name)
 		}
@@ -309,6 +752,150 @@ func (c *ScoresClient) ListByObservation(ctx context.Context, observationID stri
 	return c.List(ctx, p)
 }
 
+// ScoreConfigClient handles score config API operations. Score configs
+// define named score types with their data type and accepted values; scores
+// reference them by ID via ScoreBuilder.ConfigID.
+type ScoreConfigClient struct {
+	impl *scores.Client
+}
+
+// Configs returns a client for managing score configs.
+//
+// Example:
+//
+//	minValue, maxValue := 0.0, 1.0
+//	cfg, err := client.Scores().Configs().Create(ctx, &langfuse.CreateScoreConfigRequest{
+//	    Name:     "accuracy",
+//	    DataType: langfuse.ScoreDataTypeNumeric,
+//	    MinValue: &minValue,
+//	    MaxValue: &maxValue,
+//	})
+//	client.NewScore().TraceID(traceID).Name("accuracy").NumericValue(0.9).ConfigID(cfg.ID).Create(ctx)
+func (c *ScoresClient) Configs() *ScoreConfigClient {
+	return &ScoreConfigClient{impl: c.impl}
+}
+
+// ScoreConfigsListParams represents parameters for listing score configs.
+type ScoreConfigsListParams struct {
+	PaginationParams
+}
+
+// ScoreConfigsListResponse represents the response from listing score configs.
+type ScoreConfigsListResponse struct {
+	Data []ScoreConfig `json:"data"`
+	Meta MetaResponse  `json:"meta"`
+}
+
+// List retrieves a list of score configs.
+func (c *ScoreConfigClient) List(ctx context.Context, params *ScoreConfigsListParams) (*ScoreConfigsListResponse, error) {
+	query := url.Values{}
+	if params != nil {
+		query = params.PaginationParams.ToQuery()
+	}
+
+	var result ScoreConfigsListResponse
+	if err := c.impl.ListConfigs(ctx, query, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Get retrieves a score config by ID.
+func (c *ScoreConfigClient) Get(ctx context.Context, configID string) (*ScoreConfig, error) {
+	if configID == "" {
+		return nil, NewValidationError("configId", "score config ID is required")
+	}
+
+	var result ScoreConfig
+	if err := c.impl.GetConfig(ctx, configID, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateScoreConfigRequest represents a request to create a score config.
+type CreateScoreConfigRequest struct {
+	Name        string          `json:"name"`
+	DataType    ScoreDataType   `json:"dataType"`
+	MinValue    *float64        `json:"minValue,omitempty"`
+	MaxValue    *float64        `json:"maxValue,omitempty"`
+	Categories  []ScoreCategory `json:"categories,omitempty"`
+	Description string          `json:"description,omitempty"`
+}
+
+// Create creates a new score config. Categorical configs require at least
+// one category.
+func (c *ScoreConfigClient) Create(ctx context.Context, req *CreateScoreConfigRequest) (*ScoreConfig, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.Name == "" {
+		return nil, NewValidationError("name", "score config name is required")
+	}
+	if req.DataType == "" {
+		return nil, NewValidationError("dataType", "score config data type is required")
+	}
+	if req.DataType == ScoreDataTypeCategorical && len(req.Categories) == 0 {
+		return nil, NewValidationError("categories", "categorical score configs require at least one category")
+	}
+	if err := validateScoreConfigRange(req.MinValue, req.MaxValue); err != nil {
+		return nil, err
+	}
+
+	var result ScoreConfig
+	if err := c.impl.CreateConfig(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateScoreConfigRequest represents a request to update a score config.
+// Only the fields that are set are changed.
+type UpdateScoreConfigRequest struct {
+	Name        string          `json:"name,omitempty"`
+	MinValue    *float64        `json:"minValue,omitempty"`
+	MaxValue    *float64        `json:"maxValue,omitempty"`
+	Categories  []ScoreCategory `json:"categories,omitempty"`
+	Description string          `json:"description,omitempty"`
+	IsArchived  *bool           `json:"isArchived,omitempty"`
+}
+
+// Update updates a score config.
+func (c *ScoreConfigClient) Update(ctx context.Context, configID string, req *UpdateScoreConfigRequest) (*ScoreConfig, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if configID == "" {
+		return nil, NewValidationError("configId", "score config ID is required")
+	}
+	if err := validateScoreConfigRange(req.MinValue, req.MaxValue); err != nil {
+		return nil, err
+	}
+
+	var result ScoreConfig
+	if err := c.impl.UpdateConfig(ctx, configID, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Delete deletes a score config by ID. Langfuse versions that only support
+// archiving configs reject the request; use Update with IsArchived instead.
+func (c *ScoreConfigClient) Delete(ctx context.Context, configID string) error {
+	if configID == "" {
+		return NewValidationError("configId", "score config ID is required")
+	}
+	return c.impl.DeleteConfig(ctx, configID)
+}
+
+// validateScoreConfigRange checks that minValue does not exceed maxValue.
+func validateScoreConfigRange(minValue, maxValue *float64) error {
+	if minValue != nil && maxValue != nil && *minValue > *maxValue {
+		return NewValidationError("minValue", fmt.Sprintf("minimum value %v exceeds maximum value %v", *minValue, *maxValue))
+	}
+	return nil
+}
+
 // ============================================================================
 // Score Builder (for ingestion API)
 // ============================================================================
@@ -761,6 +1348,91 @@ func (c *PromptsClient) Create(ctx context.Context, req *CreatePromptRequest) (*
 	return &result, nil
 }
 
+// GetOrCreate returns the latest version of the prompt named req.Name if its
+// content matches req.Prompt, and creates a new version otherwise. Contents
+// are compared by their JSON serialization; chat prompts are compared message
+// by message. This makes it safe to run prompt provisioning on every deploy.
+//
+// Example:
+//
+//	prompt, err := client.Prompts().GetOrCreate(ctx, &langfuse.CreatePromptRequest{
+//	    Name:   "greeting",
+//	    Prompt: "Hello {{name}}!",
+//	    Type:   "text",
+//	    Labels: []string{"production"},
+//	})
+func (c *PromptsClient) GetOrCreate(ctx context.Context, req *CreatePromptRequest) (*Prompt, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.Name == "" {
+		return nil, NewValidationError("name", "prompt name is required")
+	}
+
+	existing, err := c.GetLatest(ctx, req.Name)
+	if err != nil {
+		if apiErr, ok := AsAPIError(err); !ok || !apiErr.IsNotFound() {
+			return nil, err
+		}
+		return c.Create(ctx, req)
+	}
+
+	if (req.Type == "" || req.Type == existing.Type) && promptContentEqual(existing.Prompt, req.Prompt) {
+		return existing, nil
+	}
+	return c.Create(ctx, req)
+}
+
+// promptContentEqual reports whether two prompt contents have the same JSON
+// serialization. Both sides are normalized first so that, for example, a
+// []ChatMessage and the decoded API response compare equal. Chat prompts are
+// compared message by message.
+func promptContentEqual(a, b any) bool {
+	na, err := normalizeJSON(a)
+	if err != nil {
+		return false
+	}
+	nb, err := normalizeJSON(b)
+	if err != nil {
+		return false
+	}
+
+	aMessages, aIsChat := na.([]any)
+	bMessages, bIsChat := nb.([]any)
+	if aIsChat || bIsChat {
+		if !aIsChat || !bIsChat || len(aMessages) != len(bMessages) {
+			return false
+		}
+		for i := range aMessages {
+			if !jsonEqual(aMessages[i], bMessages[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return jsonEqual(na, nb)
+}
+
+// normalizeJSON round-trips v through JSON into generic values.
+func normalizeJSON(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// jsonEqual compares the JSON serializations of two normalized values.
+func jsonEqual(a, b any) bool {
+	aData, aErr := json.Marshal(a)
+	bData, bErr := json.Marshal(b)
+	return aErr == nil && bErr == nil && bytes.Equal(aData, bData)
+}
+
 // CreateTextPrompt creates a new text prompt.
 func (c *PromptsClient) CreateTextPrompt(ctx context.Context, name string, prompt string, labels []string) (*Prompt, error) {
 	return c.Create(ctx, &CreatePromptRequest{
@@ -998,3 +1670,338 @@ func (c *DatasetsClient) CreateRunItem(ctx context.Context, req *CreateDatasetRu
 	}
 	return &result, nil
 }
+
+// benchmarkPageSize is the number of dataset items fetched per page by
+// RunBenchmark.
+const benchmarkPageSize = 50
+
+// BenchmarkItem is the outcome of running a single dataset item in a
+// benchmark.
+type BenchmarkItem struct {
+	// TraceID is the trace produced for the item
+	TraceID string
+
+	// LatencyMs is the item latency. If zero, the wall-clock time of the
+	// benchmark function is used.
+	LatencyMs int64
+
+	// Score is the item score, averaged into BenchmarkResult.MeanScore
+	Score float64
+
+	// Error marks the item as failed
+	Error error
+}
+
+// BenchmarkProgress is sent on BenchmarkResult.Progress as each item
+// completes.
+type BenchmarkProgress struct {
+	ItemID    string
+	Item      *BenchmarkItem
+	Completed int
+	Total     int
+}
+
+// BenchmarkResult aggregates a benchmark run. The summary fields are set
+// once Wait returns.
+type BenchmarkResult struct {
+	TotalItems      int
+	SuccessfulItems int
+	FailedItems     int
+
+	// Latency percentiles of the successful items
+	P50LatencyMs int64
+	P95LatencyMs int64
+	P99LatencyMs int64
+
+	// MeanScore is the mean score of the successful items
+	MeanScore float64
+
+	// FailedIDs holds the dataset item IDs that failed, in completion order
+	FailedIDs []string
+
+	// Progress receives one update per completed item and is closed when
+	// the benchmark finishes. It is buffered for every item, so it does not
+	// need to be drained.
+	Progress <-chan BenchmarkProgress
+
+	done chan struct{}
+}
+
+// Wait blocks until every item has completed and returns r.
+func (r *BenchmarkResult) Wait() *BenchmarkResult {
+	<-r.done
+	return r
+}
+
+// RunBenchmark runs fn over every item of the dataset using a pool of
+// concurrency workers. It lists the dataset items, starts the workers and
+// returns immediately; read r.Progress to follow the run and call Wait for
+// the summary. An item fails if fn returns an error or sets
+// BenchmarkItem.Error. Items not started before ctx is cancelled fail with
+// the context error.
+//
+// RunBenchmark returns an error only if the dataset items cannot be listed.
+// A concurrency below 1 is treated as 1.
+//
+// Example:
+//
+//	result, err := client.Datasets().RunBenchmark(ctx, "qa-golden",
+//	    func(ctx context.Context, item *langfuse.DatasetItem) (*langfuse.BenchmarkItem, error) {
+//	        trace, _ := client.NewTrace().Name("qa").Input(item.Input).Create(ctx)
+//	        return &langfuse.BenchmarkItem{TraceID: trace.ID(), Score: grade(item)}, nil
+//	    }, 8)
+//	if err != nil {
+//	    return err
+//	}
+//	for p := range result.Progress {
+//	    log.Printf("%d/%d done", p.Completed, p.Total)
+//	}
+//	log.Printf("p95=%dms mean=%.2f", result.Wait().P95LatencyMs, result.MeanScore)
+func (c *DatasetsClient) RunBenchmark(ctx context.Context, datasetName string, fn func(ctx context.Context, item *DatasetItem) (*BenchmarkItem, error), concurrency int) (*BenchmarkResult, error) {
+	if datasetName == "" {
+		return nil, NewValidationError("datasetName", "dataset name is required")
+	}
+	if fn == nil {
+		return nil, NewValidationError("fn", "benchmark function is required")
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var items []DatasetItem
+	for page := 1; ; page++ {
+		resp, err := c.ListItems(ctx, &DatasetItemsListParams{
+			PaginationParams: PaginationParams{Page: page, Limit: benchmarkPageSize},
+			DatasetName:      datasetName,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("langfuse: list dataset items: %w", err)
+		}
+		items = append(items, resp.Data...)
+		if len(resp.Data) == 0 || !resp.Meta.HasMore() {
+			break
+		}
+	}
+
+	progress := make(chan BenchmarkProgress, len(items))
+	result := &BenchmarkResult{
+		TotalItems: len(items),
+		Progress:   progress,
+		done:       make(chan struct{}),
+	}
+
+	work := make(chan *DatasetItem)
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		completed int
+		latencies []int64
+		scoreSum  float64
+	)
+	record := func(item *DatasetItem, outcome *BenchmarkItem) {
+		mu.Lock()
+		defer mu.Unlock()
+		completed++
+		if outcome.Error != nil {
+			result.FailedItems++
+			result.FailedIDs = append(result.FailedIDs, item.ID)
+		} else {
+			result.SuccessfulItems++
+			latencies = append(latencies, outcome.LatencyMs)
+			scoreSum += outcome.Score
+		}
+		progress <- BenchmarkProgress{ItemID: item.ID, Item: outcome, Completed: completed, Total: len(items)}
+	}
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range work {
+				record(item, runBenchmarkItem(ctx, item, fn))
+			}
+		}()
+	}
+
+	go func() {
+		for i := range items {
+			if ctx.Err() != nil {
+				record(&items[i], &BenchmarkItem{Error: ctx.Err()})
+				continue
+			}
+			select {
+			case work <- &items[i]:
+			case <-ctx.Done():
+				record(&items[i], &BenchmarkItem{Error: ctx.Err()})
+			}
+		}
+		close(work)
+		wg.Wait()
+
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		result.P50LatencyMs = latencyPercentile(latencies, 50)
+		result.P95LatencyMs = latencyPercentile(latencies, 95)
+		result.P99LatencyMs = latencyPercentile(latencies, 99)
+		if result.SuccessfulItems > 0 {
+			result.MeanScore = scoreSum / float64(result.SuccessfulItems)
+		}
+		close(progress)
+		close(result.done)
+	}()
+
+	return result, nil
+}
+
+// runBenchmarkItem calls fn for a single item and normalizes its outcome.
+func runBenchmarkItem(ctx context.Context, item *DatasetItem, fn func(ctx context.Context, item *DatasetItem) (*BenchmarkItem, error)) *BenchmarkItem {
+	start := time.Now()
+	outcome, err := fn(ctx, item)
+	elapsed := time.Since(start).Milliseconds()
+
+	if outcome == nil {
+		outcome = &BenchmarkItem{}
+	} else {
+		cp := *outcome
+		outcome = &cp
+	}
+	if err != nil && outcome.Error == nil {
+		outcome.Error = err
+	}
+	if outcome.LatencyMs == 0 {
+		outcome.LatencyMs = elapsed
+	}
+	return outcome
+}
+
+// latencyPercentile returns the nearest-rank percentile p of sorted.
+func latencyPercentile(sorted []int64, p int) int64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// CloneOption configures DatasetsClient.CloneDataset.
+type CloneOption func(*cloneConfig)
+
+type cloneConfig struct {
+	descriptionPrefix string
+	metadataTags      Metadata
+}
+
+// WithDescriptionPrefix prepends prefix to the source dataset's description
+// to form the description of the cloned dataset.
+func WithDescriptionPrefix(prefix string) CloneOption {
+	return func(c *cloneConfig) {
+		c.descriptionPrefix = prefix
+	}
+}
+
+// WithMetadataTag adds key and value to the metadata of the cloned dataset
+// and of every cloned item. It may be given more than once.
+func WithMetadataTag(key, value string) CloneOption {
+	return func(c *cloneConfig) {
+		if c.metadataTags == nil {
+			c.metadataTags = Metadata{}
+		}
+		c.metadataTags[key] = value
+	}
+}
+
+// CloneDataset creates a dataset named destName holding a copy of every item
+// of sourceName for which filter returns true; a nil filter copies all
+// items. Cloned items get new IDs but keep their input, expected output,
+// metadata, status and source trace and observation. The source dataset's
+// metadata is copied as well.
+//
+// If creating an item fails, CloneDataset returns the error; the items
+// created so far remain in the new dataset.
+//
+// Example:
+//
+//	subset, err := client.Datasets().CloneDataset(ctx, "qa-golden", "qa-golden-billing",
+//	    func(item *langfuse.DatasetItem) bool {
+//	        return item.Metadata["topic"] == "billing"
+//	    },
+//	    langfuse.WithDescriptionPrefix("Billing subset: "),
+//	    langfuse.WithMetadataTag("subset", "billing"),
+//	)
+func (c *DatasetsClient) CloneDataset(ctx context.Context, sourceName, destName string, filter func(*DatasetItem) bool, opts ...CloneOption) (*Dataset, error) {
+	if sourceName == "" {
+		return nil, NewValidationError("sourceName", "source dataset name is required")
+	}
+	if destName == "" {
+		return nil, NewValidationError("destName", "destination dataset name is required")
+	}
+
+	cfg := &cloneConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	source, err := c.Get(ctx, sourceName)
+	if err != nil {
+		return nil, fmt.Errorf("langfuse: get dataset %s: %w", sourceName, err)
+	}
+
+	dest, err := c.Create(ctx, &CreateDatasetRequest{
+		Name:        destName,
+		Description: cfg.descriptionPrefix + source.Description,
+		Metadata:    withMetadataTags(source.Metadata, cfg.metadataTags),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("langfuse: create dataset %s: %w", destName, err)
+	}
+
+	for page := 1; ; page++ {
+		resp, err := c.ListItems(ctx, &DatasetItemsListParams{
+			PaginationParams: PaginationParams{Page: page, Limit: benchmarkPageSize},
+			DatasetName:      sourceName,
+		})
+		if err != nil {
+			return dest, fmt.Errorf("langfuse: list dataset items: %w", err)
+		}
+
+		for i := range resp.Data {
+			item := &resp.Data[i]
+			if filter != nil && !filter(item) {
+				continue
+			}
+			if _, err := c.CreateItem(ctx, &CreateDatasetItemRequest{
+				DatasetName:         destName,
+				Input:               item.Input,
+				ExpectedOutput:      item.ExpectedOutput,
+				Metadata:            withMetadataTags(item.Metadata, cfg.metadataTags),
+				SourceTraceID:       item.SourceTraceID,
+				SourceObservationID: item.SourceObservationID,
+				Status:              item.Status,
+			}); err != nil {
+				return dest, fmt.Errorf("langfuse: clone dataset item %s: %w", item.ID, err)
+			}
+		}
+
+		if len(resp.Data) == 0 || !resp.Meta.HasMore() {
+			return dest, nil
+		}
+	}
+}
+
+// withMetadataTags returns a copy of metadata with tags added, or metadata
+// itself if there are no tags.
+func withMetadataTags(metadata, tags Metadata) Metadata {
+	if len(tags) == 0 {
+		return metadata
+	}
+	merged := make(Metadata, len(metadata)+len(tags))
+	for k, v := range metadata {
+		merged[k] = v
+	}
+	for k, v := range tags {
+		merged[k] = v
+	}
+	return merged
+}
@@ -3,9 +3,14 @@ package langfuse_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/jdziat/langfuse-go"
 )
@@ -167,6 +172,60 @@ func BenchmarkFlush(b *testing.B) {
 	}
 }
 
+// BenchmarkCompressedPayload measures the ingestion payload sent for traces
+// with a 100KB input, with and without WithCompressMetadata.
+func BenchmarkCompressedPayload(b *testing.B) {
+	words := []string{"retrieval", "context", "model", "answer", "token", "latency", "prompt", "the", "of", "and"}
+	var doc strings.Builder
+	for i := 0; doc.Len() < 100*1024; i++ {
+		doc.WriteString(words[(i*7+i/3)%len(words)])
+		doc.WriteByte(' ')
+	}
+	input := map[string]any{"document": doc.String()}
+
+	for _, tc := range []struct {
+		name string
+		opts []langfuse.ConfigOption
+	}{
+		{"uncompressed", nil},
+		{"compressed", []langfuse.ConfigOption{langfuse.WithCompressMetadata(10 * 1024)}},
+	} {
+		b.Run(tc.name, func(b *testing.B) {
+			var payloadBytes atomic.Int64
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n, _ := io.Copy(io.Discard, r.Body)
+				payloadBytes.Add(n)
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(langfuse.IngestionResult{})
+			}))
+			defer server.Close()
+
+			opts := append([]langfuse.ConfigOption{
+				langfuse.WithBaseURL(server.URL),
+				langfuse.WithBatchSize(1000),
+				langfuse.WithFlushInterval(60 * time.Second),
+			}, tc.opts...)
+			client, err := langfuse.New("pk-lf-test-key", "sk-lf-test-key", opts...)
+			if err != nil {
+				b.Fatalf("Failed to create test client: %v", err)
+			}
+			defer client.Shutdown(context.Background())
+
+			ctx := context.Background()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := client.NewTrace().Name("large-input").Input(input).Create(ctx); err != nil {
+					b.Fatal(err)
+				}
+			}
+			client.Shutdown(ctx)
+			b.StopTimer()
+
+			b.ReportMetric(float64(payloadBytes.Load())/float64(b.N), "payload-bytes/op")
+		})
+	}
+}
+
 // Note: BenchmarkJSONMarshaling was removed because it uses unexported type createTraceEvent
 
 // Note: BenchmarkIDGeneration was removed because it uses unexported function generateID
@@ -232,3 +291,42 @@ func BenchmarkPromptCompileLarge(b *testing.B) {
 		}
 	}
 }
+
+// BenchmarkConcurrentFlush compares batch throughput of one and four flush
+// workers against a server that takes 50ms per request.
+func BenchmarkConcurrentFlush(b *testing.B) {
+	for _, workers := range []int{1, 4} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				io.Copy(io.Discard, r.Body)
+				time.Sleep(50 * time.Millisecond)
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(langfuse.IngestionResult{})
+			}))
+			defer server.Close()
+
+			client, err := langfuse.New("pk-lf-test-key", "sk-lf-test-key",
+				langfuse.WithBaseURL(server.URL),
+				langfuse.WithBatchSize(1),
+				langfuse.WithBatchQueueSize(b.N+1),
+				langfuse.WithFlushInterval(time.Hour),
+				langfuse.WithShutdownTimeout(time.Minute),
+				langfuse.WithConcurrentFlush(workers),
+			)
+			if err != nil {
+				b.Fatalf("Failed to create test client: %v", err)
+			}
+
+			ctx := context.Background()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := client.NewTrace().Name("benchmark").Create(ctx); err != nil {
+					b.Fatal(err)
+				}
+			}
+			if err := client.Shutdown(ctx); err != nil {
+				b.Fatal(err)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -125,6 +126,57 @@ func TestTagsBuilder(t *testing.T) {
 			t.Errorf("tags[1] = %s, want version:1.2.3", tags[1])
 		}
 	})
+
+	t.Run("from labels", func(t *testing.T) {
+		b := langfuse.NewTags().FromLabels(map[string]string{
+			"version": "v2",
+			"app":     "chatbot",
+			"env":     "prod",
+		})
+		tags := b.Build()
+
+		want := []string{"app:chatbot", "env:prod", "version:v2"}
+		if len(tags) != len(want) {
+			t.Fatalf("tags = %v, want %v", tags, want)
+		}
+		for i := range want {
+			if tags[i] != want[i] {
+				t.Errorf("tags[%d] = %s, want %s", i, tags[i], want[i])
+			}
+		}
+		if b.HasErrors() {
+			t.Errorf("unexpected errors: %v", b.Errors())
+		}
+	})
+
+	t.Run("from labels rejects invalid entries", func(t *testing.T) {
+		b := langfuse.NewTags().FromLabels(map[string]string{
+			"bad key": "x",
+			"team":    "search ops",
+			"long":    strings.Repeat("a", 64),
+			"ok":      "yes",
+		})
+		tags := b.Build()
+
+		if len(tags) != 1 || tags[0] != "ok:yes" {
+			t.Errorf("tags = %v, want [ok:yes]", tags)
+		}
+		if len(b.Errors()) != 3 {
+			t.Errorf("len(Errors()) = %d, want 3", len(b.Errors()))
+		}
+	})
+
+	t.Run("from annotations", func(t *testing.T) {
+		tags := langfuse.NewTags().FromAnnotations(map[string]string{
+			"langfuse.com/team":                 "search",
+			"langfuse.com/tier":                 "gold",
+			"kubectl.kubernetes.io/restartedAt": "now",
+		}, "langfuse.com/").Build()
+
+		if len(tags) != 2 || tags[0] != "team:search" || tags[1] != "tier:gold" {
+			t.Errorf("tags = %v, want [team:search tier:gold]", tags)
+		}
+	})
 }
 
 func TestUsageBuilder(t *testing.T) {
@@ -0,0 +1,85 @@
+package langfuse_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	langfuse "github.com/jdziat/langfuse-go"
+	"github.com/jdziat/langfuse-go/langfusetest"
+)
+
+func TestContextTracer(t *testing.T) {
+	client, server := langfusetest.NewTestClient(t)
+	tracer := langfuse.NewContextTracer(client)
+	ctx := context.Background()
+
+	if _, _, ok := tracer.Current(ctx); ok {
+		t.Error("Current should report no trace for an empty context")
+	}
+	if _, _, err := tracer.StartSpan(ctx, "orphan"); err == nil {
+		t.Error("StartSpan without a trace should fail")
+	}
+
+	ctx, trace, err := tracer.Start(ctx, "request", langfuse.WithUserID("user-1"))
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if got, span, ok := tracer.Current(ctx); !ok || got != trace || span != nil {
+		t.Errorf("Current = (%v, %v, %v), want the trace and no span", got, span, ok)
+	}
+
+	spanCtx, parent, err := tracer.StartSpan(ctx, "parent")
+	if err != nil {
+		t.Fatalf("StartSpan failed: %v", err)
+	}
+	if _, span, _ := tracer.Current(spanCtx); span != parent {
+		t.Error("Current should return the span stored by StartSpan")
+	}
+
+	var wg sync.WaitGroup
+	children := make([]*langfuse.SpanContext, 3)
+	for i := range children {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, child, err := tracer.StartSpan(spanCtx, "child")
+			if err != nil {
+				t.Errorf("StartSpan in goroutine failed: %v", err)
+				return
+			}
+			children[i] = child
+		}(i)
+	}
+	wg.Wait()
+
+	// A new trace started from a context carrying a span must not inherit it.
+	otherCtx, other, err := tracer.Start(spanCtx, "other")
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if got, span, _ := tracer.Current(otherCtx); got != other || span != nil {
+		t.Error("Current should not return a span from a different trace")
+	}
+
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	spans := make(map[string]map[string]any)
+	for _, e := range server.RequestsByType("span-create") {
+		spans[e.Body["id"].(string)] = e.Body
+	}
+	if body := spans[parent.ID()]; body == nil || body["traceId"] != trace.ID() || body["parentObservationId"] != nil {
+		t.Errorf("parent span body = %v, want root span of trace %s", body, trace.ID())
+	}
+	for i, child := range children {
+		if child == nil {
+			continue
+		}
+		body := spans[child.ID()]
+		if body == nil || body["parentObservationId"] != parent.ID() || body["traceId"] != trace.ID() {
+			t.Errorf("child %d body = %v, want parent %s", i, body, parent.ID())
+		}
+	}
+}
@@ -3,9 +3,13 @@ package langfuse_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"sort"
+	"sync"
 	"testing"
+	"time"
 
 	langfuse "github.com/jdziat/langfuse-go"
 )
@@ -424,3 +428,187 @@ func TestDatasetsClientCreateRunItemValidation(t *testing.T) {
 		t.Error("Expected validation error for missing runName")
 	}
 }
+
+func TestDatasetsClientRunBenchmark(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		resp := langfuse.DatasetItemsListResponse{
+			Meta: langfuse.MetaResponse{Page: 1, TotalPages: 2, TotalItems: 4},
+		}
+		if page == "1" {
+			resp.Data = []langfuse.DatasetItem{{ID: "item-1"}, {ID: "item-2"}}
+		} else {
+			resp.Data = []langfuse.DatasetItem{{ID: "item-3"}, {ID: "item-4"}}
+			resp.Meta.Page = 2
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client, _ := langfuse.New("pk-lf-test-key", "sk-lf-test-key", langfuse.WithBaseURL(server.URL))
+	defer client.Shutdown(context.Background())
+
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	result, err := client.Datasets().RunBenchmark(context.Background(), "my-dataset",
+		func(ctx context.Context, item *langfuse.DatasetItem) (*langfuse.BenchmarkItem, error) {
+			mu.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mu.Unlock()
+			defer func() {
+				mu.Lock()
+				running--
+				mu.Unlock()
+			}()
+
+			switch item.ID {
+			case "item-2":
+				return nil, errors.New("model timeout")
+			case "item-4":
+				return &langfuse.BenchmarkItem{Error: errors.New("bad output")}, nil
+			}
+			time.Sleep(5 * time.Millisecond)
+			latency := map[string]int64{"item-1": 100, "item-3": 300}[item.ID]
+			return &langfuse.BenchmarkItem{TraceID: "trace-" + item.ID, LatencyMs: latency, Score: float64(latency) / 400}, nil
+		}, 2)
+	if err != nil {
+		t.Fatalf("RunBenchmark failed: %v", err)
+	}
+
+	var updates []langfuse.BenchmarkProgress
+	for p := range result.Progress {
+		updates = append(updates, p)
+	}
+	result.Wait()
+
+	if len(updates) != 4 || updates[3].Completed != 4 || updates[3].Total != 4 {
+		t.Errorf("unexpected progress updates: %+v", updates)
+	}
+	if maxRunning > 2 {
+		t.Errorf("ran %d items at once, want at most 2", maxRunning)
+	}
+	if result.TotalItems != 4 || result.SuccessfulItems != 2 || result.FailedItems != 2 {
+		t.Errorf("totals = %d/%d/%d, want 4/2/2", result.TotalItems, result.SuccessfulItems, result.FailedItems)
+	}
+	sort.Strings(result.FailedIDs)
+	if len(result.FailedIDs) != 2 || result.FailedIDs[0] != "item-2" || result.FailedIDs[1] != "item-4" {
+		t.Errorf("FailedIDs = %v, want [item-2 item-4]", result.FailedIDs)
+	}
+	if result.P50LatencyMs != 100 || result.P95LatencyMs != 300 || result.P99LatencyMs != 300 {
+		t.Errorf("latencies = %d/%d/%d, want 100/300/300", result.P50LatencyMs, result.P95LatencyMs, result.P99LatencyMs)
+	}
+	if result.MeanScore != 0.5 {
+		t.Errorf("MeanScore = %v, want 0.5", result.MeanScore)
+	}
+}
+
+func TestDatasetsClientRunBenchmarkValidation(t *testing.T) {
+	client, _ := langfuse.New("pk-lf-test-key", "sk-lf-test-key")
+	defer client.Shutdown(context.Background())
+
+	fn := func(ctx context.Context, item *langfuse.DatasetItem) (*langfuse.BenchmarkItem, error) {
+		return nil, nil
+	}
+	if _, err := client.Datasets().RunBenchmark(context.Background(), "", fn, 1); err == nil {
+		t.Error("Expected validation error for missing dataset name")
+	}
+	if _, err := client.Datasets().RunBenchmark(context.Background(), "my-dataset", nil, 1); err == nil {
+		t.Error("Expected validation error for missing function")
+	}
+}
+
+func TestDatasetsClientCloneDataset(t *testing.T) {
+	var mu sync.Mutex
+	var created *langfuse.CreateDatasetRequest
+	var items []langfuse.CreateDatasetItemRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/api/public/v2/datasets/source" && r.Method == http.MethodGet:
+			json.NewEncoder(w).Encode(langfuse.Dataset{
+				Name:        "source",
+				Description: "QA pairs",
+				Metadata:    langfuse.Metadata{"owner": "qa"},
+			})
+		case r.URL.Path == "/api/public/v2/datasets" && r.Method == http.MethodPost:
+			created = &langfuse.CreateDatasetRequest{}
+			json.NewDecoder(r.Body).Decode(created)
+			json.NewEncoder(w).Encode(langfuse.Dataset{ID: "ds-2", Name: created.Name, Description: created.Description})
+		case r.URL.Path == "/api/public/dataset-items" && r.Method == http.MethodGet:
+			if r.URL.Query().Get("datasetName") != "source" {
+				t.Errorf("listed items of %q, want source", r.URL.Query().Get("datasetName"))
+			}
+			resp := langfuse.DatasetItemsListResponse{Meta: langfuse.MetaResponse{Page: 1, TotalPages: 2}}
+			if r.URL.Query().Get("page") == "1" {
+				resp.Data = []langfuse.DatasetItem{
+					{ID: "item-1", Input: "refund?", Metadata: langfuse.Metadata{"topic": "billing"}, SourceTraceID: "trace-1"},
+					{ID: "item-2", Input: "login?", Metadata: langfuse.Metadata{"topic": "auth"}},
+				}
+			} else {
+				resp.Meta.Page = 2
+				resp.Data = []langfuse.DatasetItem{
+					{ID: "item-3", Input: "invoice?", Metadata: langfuse.Metadata{"topic": "billing"}},
+				}
+			}
+			json.NewEncoder(w).Encode(resp)
+		case r.URL.Path == "/api/public/dataset-items" && r.Method == http.MethodPost:
+			var req langfuse.CreateDatasetItemRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			items = append(items, req)
+			json.NewEncoder(w).Encode(langfuse.DatasetItem{ID: "new-" + req.SourceTraceID})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := langfuse.New("pk-lf-test-key", "sk-lf-test-key", langfuse.WithBaseURL(server.URL))
+	defer client.Shutdown(context.Background())
+
+	dataset, err := client.Datasets().CloneDataset(context.Background(), "source", "billing",
+		func(item *langfuse.DatasetItem) bool { return item.Metadata["topic"] == "billing" },
+		langfuse.WithDescriptionPrefix("Billing subset of "),
+		langfuse.WithMetadataTag("subset", "billing"),
+	)
+	if err != nil {
+		t.Fatalf("CloneDataset failed: %v", err)
+	}
+	if dataset.Name != "billing" {
+		t.Errorf("dataset name = %q, want billing", dataset.Name)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if created.Description != "Billing subset of QA pairs" {
+		t.Errorf("description = %q", created.Description)
+	}
+	if created.Metadata["owner"] != "qa" || created.Metadata["subset"] != "billing" {
+		t.Errorf("dataset metadata = %v", created.Metadata)
+	}
+	if len(items) != 2 {
+		t.Fatalf("cloned %d items, want 2", len(items))
+	}
+	if items[0].DatasetName != "billing" || items[0].Input != "refund?" || items[0].SourceTraceID != "trace-1" {
+		t.Errorf("first item = %+v", items[0])
+	}
+	if items[1].SourceTraceID != "" || items[1].Metadata["subset"] != "billing" || items[1].Metadata["topic"] != "billing" {
+		t.Errorf("second item = %+v", items[1])
+	}
+}
+
+func TestDatasetsClientCloneDatasetValidation(t *testing.T) {
+	client, _ := langfuse.New("pk-lf-test-key", "sk-lf-test-key")
+	defer client.Shutdown(context.Background())
+
+	if _, err := client.Datasets().CloneDataset(context.Background(), "source", "", nil); err == nil {
+		t.Error("CloneDataset should require a destination name")
+	}
+}
@@ -1,13 +1,16 @@
 package langfuse_test
 
 import (
+	"context"
 	"errors"
+	"reflect"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	langfuse "github.com/jdziat/langfuse-go"
+	"github.com/jdziat/langfuse-go/langfusetest"
 )
 
 // testMetrics is defined in backpressure_test.go
@@ -853,6 +856,56 @@ func TestIngestionResultFirstError(t *testing.T) {
 	})
 }
 
+func TestBatchResultFailedEventIDs(t *testing.T) {
+	server := langfusetest.NewMockServer()
+	defer server.Close()
+	server.RespondWithPartialSuccess([]string{"event-1"}, []string{"event-2", "event-3"})
+
+	var mu sync.Mutex
+	var results []langfuse.BatchResult
+	client, err := langfuse.New("pk-lf-test-key", "sk-lf-test-key",
+		langfuse.WithBaseURL(server.URL),
+		langfuse.WithFlushInterval(1*time.Hour),
+		langfuse.WithOnBatchFlushed(func(result langfuse.BatchResult) {
+			mu.Lock()
+			results = append(results, result)
+			mu.Unlock()
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	if _, err := client.NewTrace().Name("partial").Create(ctx); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(results) != 1 {
+		t.Fatalf("OnBatchFlushed called %d times, want 1", len(results))
+	}
+	result := results[0]
+	if !result.Success || result.Successes != 1 || result.Errors != 2 {
+		t.Errorf("result = %+v, want 1 success and 2 errors", result)
+	}
+	if got, want := result.FailedEventIDs(), []string{"event-2", "event-3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FailedEventIDs() = %v, want %v", got, want)
+	}
+	if result.IngestionErrors[0].Message != "Validation failed" {
+		t.Errorf("IngestionErrors[0] = %+v, want the server's error", result.IngestionErrors[0])
+	}
+
+	if ids := (langfuse.BatchResult{}).FailedEventIDs(); ids != nil {
+		t.Errorf("FailedEventIDs() of a batch without errors = %v, want nil", ids)
+	}
+}
+
 func TestNewValidationErrorWithCause(t *testing.T) {
 	cause := errors.New("underlying cause")
 	err := langfuse.NewValidationErrorWithCause("field_name", "validation message", cause)
@@ -1,6 +1,8 @@
 package langfuse_test
 
 import (
+	"fmt"
+	"strings"
 	"sync"
 	"testing"
 
@@ -48,6 +50,35 @@ func TestIDGenerator_GenerateUnique(t *testing.T) {
 	}
 }
 
+func TestIDGenerator_GenerateBatch(t *testing.T) {
+	gen := langfuse.NewIDGenerator(&langfuse.IDGeneratorConfig{
+		Mode: langfuse.IDModeStrict,
+	})
+
+	ids, err := gen.GenerateBatch(500)
+	if err != nil {
+		t.Fatalf("GenerateBatch() error = %v", err)
+	}
+	if len(ids) != 500 {
+		t.Fatalf("GenerateBatch() returned %d IDs, want 500", len(ids))
+	}
+
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if len(id) != 36 || id[14] != '4' || !strings.ContainsRune("89ab", rune(id[19])) {
+			t.Errorf("GenerateBatch() ID %q is not a UUID v4", id)
+		}
+		if seen[id] {
+			t.Errorf("GenerateBatch() duplicate ID %q", id)
+		}
+		seen[id] = true
+	}
+
+	if ids, err := gen.GenerateBatch(0); err != nil || len(ids) != 0 {
+		t.Errorf("GenerateBatch(0) = %v, %v, want empty", ids, err)
+	}
+}
+
 func TestIDGenerator_MustGenerate(t *testing.T) {
 	gen := langfuse.NewIDGenerator(&langfuse.IDGeneratorConfig{
 		Mode: langfuse.IDModeFallback,
@@ -266,3 +297,24 @@ func BenchmarkIDGenerator_GenerateConcurrent(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkIDGenerator_SingleVsBatch(b *testing.B) {
+	gen := langfuse.NewIDGenerator(&langfuse.IDGeneratorConfig{
+		Mode: langfuse.IDModeFallback,
+	})
+
+	for _, n := range []int{10, 100, 1000} {
+		b.Run(fmt.Sprintf("single/n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < n; j++ {
+					_, _ = gen.Generate()
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("batch/n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = gen.GenerateBatch(n)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/jdziat/langfuse-go"
+	"github.com/jdziat/langfuse-go/langfusetest"
 	"go.uber.org/goleak"
 )
 
@@ -246,3 +247,35 @@ func TestMultipleClients_NoLeaks(t *testing.T) {
 	// Give goroutines time to exit
 	time.Sleep(100 * time.Millisecond)
 }
+
+// TestContextTracer_NoLeaks verifies with langfusetest.CheckForGoroutineLeaks
+// that a client used through the Simple API leaves no SDK goroutines behind
+// once it is shut down.
+func TestContextTracer_NoLeaks(t *testing.T) {
+	server := langfusetest.NewMockServer()
+	defer server.Close()
+
+	langfusetest.CheckForGoroutineLeaks(t, func() {
+		client, err := langfuse.New("pk-lf-test-key", "sk-lf-test-key",
+			langfuse.WithBaseURL(server.URL),
+			langfuse.WithFlushInterval(100*time.Millisecond),
+		)
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		defer client.Shutdown(context.Background())
+
+		tracer := langfuse.NewContextTracer(client)
+		ctx, _, err := tracer.Start(context.Background(), "leak-check")
+		if err != nil {
+			t.Fatalf("Start failed: %v", err)
+		}
+		for i := 0; i < 10; i++ {
+			_, span, err := tracer.StartSpan(ctx, "work")
+			if err != nil {
+				t.Fatalf("StartSpan failed: %v", err)
+			}
+			span.End(ctx)
+		}
+	})
+}
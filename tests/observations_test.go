@@ -207,6 +207,34 @@ func TestObservationsClientListGenerations(t *testing.T) {
 	}
 }
 
+func TestObservationsClientListGenerationsByProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(langfuse.ObservationsListResponse{
+			Data: []langfuse.Observation{
+				{ID: "gen-1", Metadata: langfuse.Metadata{langfuse.LLMProviderMetadataKey: langfuse.ProviderOpenAI}},
+				{ID: "gen-2", Metadata: langfuse.Metadata{langfuse.LLMProviderMetadataKey: langfuse.ProviderMistral}},
+				{ID: "gen-3"},
+			},
+			Meta: langfuse.MetaResponse{TotalItems: 3},
+		})
+	}))
+	defer server.Close()
+
+	client, _ := langfuse.New("pk-lf-test-key", "sk-lf-test-key", langfuse.WithBaseURL(server.URL))
+	defer client.Shutdown(context.Background())
+
+	result, err := client.Observations().ListGenerations(context.Background(), &langfuse.ObservationsListParams{
+		LLMProvider: langfuse.ProviderMistral,
+	})
+	if err != nil {
+		t.Fatalf("ListGenerations failed: %v", err)
+	}
+	if len(result.Data) != 1 || result.Data[0].ID != "gen-2" {
+		t.Errorf("Data = %+v, want only gen-2", result.Data)
+	}
+}
+
 func TestObservationsClientListEvents(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		query := r.URL.Query()
@@ -236,3 +264,22 @@ func TestObservationsClientListEvents(t *testing.T) {
 		t.Errorf("Expected 1 event, got %d", len(result.Data))
 	}
 }
+
+func TestObservationsClientListOrderBy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("orderBy"); got != "startTime.desc" {
+			t.Errorf("Expected orderBy=startTime.desc, got %s", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(langfuse.ObservationsListResponse{})
+	}))
+	defer server.Close()
+
+	client, _ := langfuse.New("pk-lf-test-key", "sk-lf-test-key", langfuse.WithBaseURL(server.URL))
+	defer client.Shutdown(context.Background())
+
+	params := (&langfuse.ObservationsListParams{}).OrderBy("startTime", true)
+	if _, err := client.Observations().List(context.Background(), params); err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+}
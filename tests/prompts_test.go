@@ -590,3 +590,118 @@ func TestStringsIndex(t *testing.T) {
 		})
 	}
 }
+
+func TestPromptsClientGetOrCreate(t *testing.T) {
+	tests := []struct {
+		name        string
+		existing    *langfuse.Prompt
+		req         *langfuse.CreatePromptRequest
+		wantCreate  bool
+		wantVersion int
+	}{
+		{
+			name:        "not found creates prompt",
+			req:         &langfuse.CreatePromptRequest{Name: "greeting", Prompt: "Hello {{name}}!", Type: "text"},
+			wantCreate:  true,
+			wantVersion: 1,
+		},
+		{
+			name:        "matching text prompt is reused",
+			existing:    &langfuse.Prompt{Name: "greeting", Version: 3, Type: "text", Prompt: "Hello {{name}}!"},
+			req:         &langfuse.CreatePromptRequest{Name: "greeting", Prompt: "Hello {{name}}!", Type: "text"},
+			wantVersion: 3,
+		},
+		{
+			name:        "changed text prompt creates version",
+			existing:    &langfuse.Prompt{Name: "greeting", Version: 3, Type: "text", Prompt: "Hi {{name}}!"},
+			req:         &langfuse.CreatePromptRequest{Name: "greeting", Prompt: "Hello {{name}}!", Type: "text"},
+			wantCreate:  true,
+			wantVersion: 4,
+		},
+		{
+			name: "matching chat prompt is reused",
+			existing: &langfuse.Prompt{Name: "chat", Version: 2, Type: "chat", Prompt: []langfuse.ChatMessage{
+				{Role: "system", Content: "Be brief."},
+				{Role: "user", Content: "{{question}}"},
+			}},
+			req: &langfuse.CreatePromptRequest{Name: "chat", Type: "chat", Prompt: []langfuse.ChatMessage{
+				{Role: "system", Content: "Be brief."},
+				{Role: "user", Content: "{{question}}"},
+			}},
+			wantVersion: 2,
+		},
+		{
+			name: "changed chat message creates version",
+			existing: &langfuse.Prompt{Name: "chat", Version: 2, Type: "chat", Prompt: []langfuse.ChatMessage{
+				{Role: "system", Content: "Be brief."},
+				{Role: "user", Content: "{{question}}"},
+			}},
+			req: &langfuse.CreatePromptRequest{Name: "chat", Type: "chat", Prompt: []langfuse.ChatMessage{
+				{Role: "system", Content: "Be thorough."},
+				{Role: "user", Content: "{{question}}"},
+			}},
+			wantCreate:  true,
+			wantVersion: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.Method {
+				case http.MethodGet:
+					if tt.existing == nil {
+						w.WriteHeader(http.StatusNotFound)
+						json.NewEncoder(w).Encode(map[string]string{"message": "prompt not found"})
+						return
+					}
+					json.NewEncoder(w).Encode(tt.existing)
+				case http.MethodPost:
+					created = true
+					var req langfuse.CreatePromptRequest
+					json.NewDecoder(r.Body).Decode(&req)
+					version := 1
+					if tt.existing != nil {
+						version = tt.existing.Version + 1
+					}
+					json.NewEncoder(w).Encode(langfuse.Prompt{Name: req.Name, Version: version, Prompt: req.Prompt})
+				}
+			}))
+			defer server.Close()
+
+			client, _ := langfuse.New("pk-lf-test-key", "sk-lf-test-key", langfuse.WithBaseURL(server.URL))
+			defer client.Shutdown(context.Background())
+
+			prompt, err := client.Prompts().GetOrCreate(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("GetOrCreate failed: %v", err)
+			}
+			if created != tt.wantCreate {
+				t.Errorf("created = %v, want %v", created, tt.wantCreate)
+			}
+			if prompt.Version != tt.wantVersion {
+				t.Errorf("Version = %d, want %d", prompt.Version, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestPromptsClientGetOrCreateServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			t.Error("Create should not be called when GetLatest fails with a non-404 error")
+		}
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client, _ := langfuse.New("pk-lf-test-key", "sk-lf-test-key", langfuse.WithBaseURL(server.URL), langfuse.WithMaxRetries(0))
+	defer client.Shutdown(context.Background())
+
+	_, err := client.Prompts().GetOrCreate(context.Background(), &langfuse.CreatePromptRequest{Name: "greeting", Prompt: "Hello"})
+	if err == nil {
+		t.Fatal("expected error for forbidden response")
+	}
+}
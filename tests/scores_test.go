@@ -268,3 +268,129 @@ func TestScoresClientListByObservation(t *testing.T) {
 		t.Errorf("Expected 1 score, got %d", len(result.Data))
 	}
 }
+
+func TestScoresClientListOrderBy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if got := query.Get("orderBy"); got != "timestamp.asc" {
+			t.Errorf("Expected orderBy=timestamp.asc, got %s", got)
+		}
+		if got := query.Get("name"); got != "quality" {
+			t.Errorf("Expected name=quality, got %s", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(langfuse.ScoresListResponse{})
+	}))
+	defer server.Close()
+
+	client, _ := langfuse.New("pk-lf-test-key", "sk-lf-test-key", langfuse.WithBaseURL(server.URL))
+	defer client.Shutdown(context.Background())
+
+	params := (&langfuse.ScoresListParams{Name: "quality"}).OrderBy("timestamp", false)
+	if _, err := client.Scores().List(context.Background(), params); err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+}
+
+func TestScoreConfigClient(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPost, http.MethodPatch:
+			var cfg langfuse.ScoreConfig
+			json.NewDecoder(r.Body).Decode(&cfg)
+			cfg.ID = "config-1"
+			cfg.DataType = langfuse.ScoreDataTypeCategorical
+			json.NewEncoder(w).Encode(cfg)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			cfg := langfuse.ScoreConfig{ID: "config-1", Name: "tone", DataType: langfuse.ScoreDataTypeCategorical}
+			if r.URL.Path == "/api/public/score-configs" {
+				json.NewEncoder(w).Encode(langfuse.ScoreConfigsListResponse{Data: []langfuse.ScoreConfig{cfg}})
+				return
+			}
+			json.NewEncoder(w).Encode(cfg)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := langfuse.New("pk-lf-test-key", "sk-lf-test-key", langfuse.WithBaseURL(server.URL))
+	defer client.Shutdown(context.Background())
+	configs := client.Scores().Configs()
+	ctx := context.Background()
+
+	created, err := configs.Create(ctx, &langfuse.CreateScoreConfigRequest{
+		Name:       "tone",
+		DataType:   langfuse.ScoreDataTypeCategorical,
+		Categories: []langfuse.ScoreCategory{{Label: "polite", Value: 1}, {Label: "rude", Value: 0}},
+	})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if created.ID != "config-1" || len(created.Categories) != 2 || created.Categories[0].Label != "polite" {
+		t.Errorf("Create() = %+v", created)
+	}
+
+	if got, err := configs.Get(ctx, "config-1"); err != nil || got.Name != "tone" {
+		t.Errorf("Get() = %+v, %v", got, err)
+	}
+	if list, err := configs.List(ctx, nil); err != nil || len(list.Data) != 1 {
+		t.Errorf("List() = %+v, %v", list, err)
+	}
+
+	archived := true
+	updated, err := configs.Update(ctx, "config-1", &langfuse.UpdateScoreConfigRequest{Description: "Tone of voice", IsArchived: &archived})
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if updated.Description != "Tone of voice" || !updated.IsArchived {
+		t.Errorf("Update() = %+v", updated)
+	}
+
+	if err := configs.Delete(ctx, "config-1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	want := []string{
+		"POST /api/public/score-configs",
+		"GET /api/public/score-configs/config-1",
+		"GET /api/public/score-configs",
+		"PATCH /api/public/score-configs/config-1",
+		"DELETE /api/public/score-configs/config-1",
+	}
+	if len(requests) != len(want) {
+		t.Fatalf("requests = %v, want %v", requests, want)
+	}
+	for i := range want {
+		if requests[i] != want[i] {
+			t.Errorf("request %d = %q, want %q", i, requests[i], want[i])
+		}
+	}
+}
+
+func TestScoreConfigClientValidation(t *testing.T) {
+	client, _ := langfuse.New("pk-lf-test-key", "sk-lf-test-key")
+	defer client.Shutdown(context.Background())
+	configs := client.Scores().Configs()
+	ctx := context.Background()
+
+	if _, err := configs.Create(ctx, nil); err != langfuse.ErrNilRequest {
+		t.Errorf("Expected ErrNilRequest, got %v", err)
+	}
+	if _, err := configs.Create(ctx, &langfuse.CreateScoreConfigRequest{DataType: langfuse.ScoreDataTypeNumeric}); err == nil {
+		t.Error("Expected validation error for missing name")
+	}
+	if _, err := configs.Create(ctx, &langfuse.CreateScoreConfigRequest{Name: "tone", DataType: langfuse.ScoreDataTypeCategorical}); err == nil {
+		t.Error("Expected validation error for categorical config without categories")
+	}
+	minValue, maxValue := 1.0, 0.0
+	if _, err := configs.Update(ctx, "config-1", &langfuse.UpdateScoreConfigRequest{MinValue: &minValue, MaxValue: &maxValue}); err == nil {
+		t.Error("Expected validation error for minValue above maxValue")
+	}
+	if err := configs.Delete(ctx, ""); err == nil {
+		t.Error("Expected validation error for missing config ID")
+	}
+}
@@ -15,10 +15,15 @@ package langfuse_test
 // 3. Testing behavior through the public API instead
 
 import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/jdziat/langfuse-go"
+	"github.com/jdziat/langfuse-go/langfusetest"
 )
 
 // TestPromptsOptionTypes verifies that prompts options are valid function types.
@@ -29,6 +34,143 @@ func TestPromptsOptionTypes(t *testing.T) {
 	var _ langfuse.PromptsOption = langfuse.WithPromptCaching(5 * time.Minute)
 }
 
+func TestConfiguredPromptsClientCaching(t *testing.T) {
+	server := langfusetest.NewMockLangfuseServer()
+	defer server.Close()
+	server.AddPrompt(&langfuse.Prompt{Name: "greeting", Version: 1, Prompt: "Hi", Labels: []string{"production"}})
+	server.AddPrompt(&langfuse.Prompt{Name: "greeting", Version: 2, Prompt: "Hello", Labels: []string{"staging"}})
+	server.AddPrompt(&langfuse.Prompt{Name: "farewell", Version: 1, Prompt: "Bye", Labels: []string{"production"}})
+
+	client, err := langfuse.New("pk-lf-test-key", "sk-lf-test-key", langfuse.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	var queryMu sync.Mutex
+	var lastQuery url.Values
+	respond := server.ResponseFunc
+	server.SetResponseFunc(func(r *http.Request) (int, any) {
+		queryMu.Lock()
+		lastQuery = r.URL.Query()
+		queryMu.Unlock()
+		return respond(r)
+	})
+	query := func() url.Values {
+		queryMu.Lock()
+		defer queryMu.Unlock()
+		return lastQuery
+	}
+
+	ctx := context.Background()
+	fetches := func(name string) int {
+		return len(server.RequestsWithPath("/api/public/v2/prompts/" + name))
+	}
+	get := func(fn func() (*langfuse.Prompt, error)) *langfuse.Prompt {
+		t.Helper()
+		prompt, err := fn()
+		if err != nil {
+			t.Fatalf("get prompt failed: %v", err)
+		}
+		return prompt
+	}
+
+	t.Run("cache hits skip HTTP", func(t *testing.T) {
+		server.Reset()
+		prompts := client.PromptsWithOptions(langfuse.WithPromptCaching(time.Hour))
+		for i := 0; i < 3; i++ {
+			get(func() (*langfuse.Prompt, error) { return prompts.GetLatest(ctx, "greeting") })
+		}
+		if n := fetches("greeting"); n != 1 {
+			t.Errorf("GetLatest made %d requests, want 1", n)
+		}
+
+		// Version and label are part of the cache key.
+		if p := get(func() (*langfuse.Prompt, error) { return prompts.GetByVersion(ctx, "greeting", 2) }); p.Version != 2 {
+			t.Errorf("GetByVersion returned version %d, want 2", p.Version)
+		}
+		get(func() (*langfuse.Prompt, error) { return prompts.GetByLabel(ctx, "greeting", "staging") })
+		get(func() (*langfuse.Prompt, error) { return prompts.GetByVersion(ctx, "greeting", 2) })
+		if n := fetches("greeting"); n != 3 {
+			t.Errorf("got %d requests, want 3", n)
+		}
+		if prompts.CacheSize() != 3 {
+			t.Errorf("CacheSize() = %d, want 3", prompts.CacheSize())
+		}
+	})
+
+	t.Run("expiry causes refetch", func(t *testing.T) {
+		server.Reset()
+		prompts := client.PromptsWithOptions(langfuse.WithPromptCaching(20 * time.Millisecond))
+		get(func() (*langfuse.Prompt, error) { return prompts.GetLatest(ctx, "greeting") })
+		get(func() (*langfuse.Prompt, error) { return prompts.GetLatest(ctx, "greeting") })
+		time.Sleep(40 * time.Millisecond)
+		get(func() (*langfuse.Prompt, error) { return prompts.GetLatest(ctx, "greeting") })
+		if n := fetches("greeting"); n != 2 {
+			t.Errorf("got %d requests, want 2", n)
+		}
+	})
+
+	t.Run("invalidation", func(t *testing.T) {
+		server.Reset()
+		prompts := client.PromptsWithOptions(langfuse.WithPromptCaching(time.Hour))
+		get(func() (*langfuse.Prompt, error) { return prompts.GetLatest(ctx, "greeting") })
+		get(func() (*langfuse.Prompt, error) { return prompts.GetByVersion(ctx, "greeting", 2) })
+		get(func() (*langfuse.Prompt, error) { return prompts.GetLatest(ctx, "farewell") })
+
+		prompts.Invalidate("greeting")
+		if prompts.CacheSize() != 1 {
+			t.Errorf("CacheSize() after Invalidate = %d, want 1", prompts.CacheSize())
+		}
+		get(func() (*langfuse.Prompt, error) { return prompts.GetLatest(ctx, "greeting") })
+		get(func() (*langfuse.Prompt, error) { return prompts.GetLatest(ctx, "farewell") })
+		if g, f := fetches("greeting"), fetches("farewell"); g != 3 || f != 1 {
+			t.Errorf("got %d greeting and %d farewell requests, want 3 and 1", g, f)
+		}
+
+		prompts.InvalidateAll()
+		if prompts.CacheSize() != 0 {
+			t.Errorf("CacheSize() after InvalidateAll = %d, want 0", prompts.CacheSize())
+		}
+	})
+
+	t.Run("defaults do not modify params", func(t *testing.T) {
+		prompts := client.PromptsWithOptions(langfuse.WithDefaultLabel("staging"))
+		params := &langfuse.GetPromptParams{}
+		if p := get(func() (*langfuse.Prompt, error) { return prompts.Get(ctx, "greeting", params) }); p.Version != 2 {
+			t.Errorf("Get returned version %d, want the staging version 2", p.Version)
+		}
+		if params.Label != "" {
+			t.Errorf("params.Label = %q, want it left unset", params.Label)
+		}
+	})
+
+	t.Run("default label does not apply to GetByVersion", func(t *testing.T) {
+		server.Reset()
+		prompts := client.PromptsWithOptions(langfuse.WithDefaultLabel("staging"))
+		if p := get(func() (*langfuse.Prompt, error) { return prompts.GetByVersion(ctx, "greeting", 1) }); p.Version != 1 {
+			t.Errorf("GetByVersion returned version %d, want 1", p.Version)
+		}
+		if q := query(); q.Get("label") != "" || q.Get("version") != "1" {
+			t.Errorf("GetByVersion query = %v, want only version=1", q)
+		}
+	})
+
+	t.Run("default version does not apply to GetLatest", func(t *testing.T) {
+		server.Reset()
+		prompts := client.PromptsWithOptions(langfuse.WithDefaultVersion(2))
+		if p := get(func() (*langfuse.Prompt, error) { return prompts.GetLatest(ctx, "greeting") }); p.Version != 1 {
+			t.Errorf("GetLatest returned version %d, want the production version 1", p.Version)
+		}
+		if q := query(); q.Get("version") != "" {
+			t.Errorf("GetLatest query = %v, want no version", q)
+		}
+		if p := get(func() (*langfuse.Prompt, error) { return prompts.Get(ctx, "greeting", nil) }); p.Version != 2 {
+			t.Errorf("Get returned version %d, want the default version 2", p.Version)
+		}
+	})
+}
+
 // TestTracesOptionTypes verifies that traces options are valid function types.
 func TestTracesOptionTypes(t *testing.T) {
 	// These should compile without errors - verifying option functions exist
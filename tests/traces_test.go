@@ -3,11 +3,17 @@ package langfuse_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	langfuse "github.com/jdziat/langfuse-go"
+	"github.com/jdziat/langfuse-go/langfusetest"
 )
 
 func TestTracesClientList(t *testing.T) {
@@ -102,6 +108,51 @@ func TestTracesClientListWithFilters(t *testing.T) {
 	}
 }
 
+func TestTracesClientListByUserAndSession(t *testing.T) {
+	var queries []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(langfuse.TracesListResponse{
+			Data: []langfuse.Trace{{ID: "trace-1"}},
+			Meta: langfuse.MetaResponse{TotalItems: 1},
+		})
+	}))
+	defer server.Close()
+
+	client, _ := langfuse.New("pk-lf-test-key", "sk-lf-test-key", langfuse.WithBaseURL(server.URL))
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	if _, err := client.Traces().ListByUserID(ctx, "user-123", &langfuse.PaginationParams{Page: 2, Limit: 10}); err != nil {
+		t.Fatalf("ListByUserID failed: %v", err)
+	}
+	if _, err := client.TracesWithOptions().ListBySessionID(ctx, "session-456", nil); err != nil {
+		t.Fatalf("ListBySessionID failed: %v", err)
+	}
+
+	if len(queries) != 2 {
+		t.Fatalf("got %d requests, want 2", len(queries))
+	}
+	if q := queries[0]; q.Get("userId") != "user-123" || q.Get("page") != "2" || q.Get("limit") != "10" {
+		t.Errorf("ListByUserID query = %v", q)
+	}
+	if q := queries[1]; q.Get("sessionId") != "session-456" || q.Get("userId") != "" || q.Get("page") != "" {
+		t.Errorf("ListBySessionID query = %v", q)
+	}
+
+	var validationErr *langfuse.ValidationError
+	if _, err := client.Traces().ListByUserID(ctx, "", nil); !errors.As(err, &validationErr) {
+		t.Errorf("ListByUserID with an empty user ID error = %v, want a ValidationError", err)
+	}
+	if _, err := client.Traces().ListBySessionID(ctx, "", nil); !errors.As(err, &validationErr) {
+		t.Errorf("ListBySessionID with an empty session ID error = %v, want a ValidationError", err)
+	}
+	if len(queries) != 2 {
+		t.Errorf("got %d requests, want no requests for empty IDs", len(queries))
+	}
+}
+
 func TestTracesClientListNilParams(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -209,3 +260,535 @@ func TestTracesClientDelete(t *testing.T) {
 		t.Fatalf("Delete failed: %v", err)
 	}
 }
+
+func TestTracesClientGetWithObservations(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var mu sync.Mutex
+	paths := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths[r.URL.Path]++
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/public/traces/trace-123":
+			json.NewEncoder(w).Encode(langfuse.Trace{ID: "trace-123", Name: "Test Trace"})
+		case "/api/public/observations":
+			if r.URL.Query().Get("traceId") != "trace-123" {
+				t.Errorf("Expected traceId=trace-123, got %s", r.URL.Query().Get("traceId"))
+			}
+			json.NewEncoder(w).Encode(langfuse.ObservationsListResponse{
+				Data: []langfuse.Observation{
+					{ID: "gen-1", ParentObservationID: "span-1", StartTime: langfuse.Time{Time: base.Add(2 * time.Second)}},
+					{ID: "span-2", StartTime: langfuse.Time{Time: base.Add(3 * time.Second)}},
+					{ID: "span-1", StartTime: langfuse.Time{Time: base}},
+					{ID: "event-1", ParentObservationID: "span-1", StartTime: langfuse.Time{Time: base.Add(time.Second)}},
+					{ID: "orphan", ParentObservationID: "missing", StartTime: langfuse.Time{Time: base.Add(4 * time.Second)}},
+				},
+			})
+		case "/api/public/scores":
+			json.NewEncoder(w).Encode(langfuse.ScoresListResponse{
+				Data: []langfuse.Score{{ID: "score-1", TraceID: "trace-123", Name: "quality"}},
+			})
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := langfuse.New("pk-lf-test-key", "sk-lf-test-key", langfuse.WithBaseURL(server.URL))
+	defer client.Shutdown(context.Background())
+
+	full, err := client.Traces().GetWithObservations(context.Background(), "trace-123")
+	if err != nil {
+		t.Fatalf("GetWithObservations failed: %v", err)
+	}
+	if full.Trace.ID != "trace-123" || len(full.Observations) != 5 {
+		t.Errorf("unexpected result: trace=%s observations=%d", full.Trace.ID, len(full.Observations))
+	}
+	if full.Scores != nil || paths["/api/public/scores"] != 0 {
+		t.Error("scores should not be fetched by default")
+	}
+
+	root := full.ObservationTree()
+	if root.Observation != nil {
+		t.Error("root node should stand for the trace")
+	}
+	var top []string
+	for _, n := range root.Children {
+		top = append(top, n.Observation.ID)
+	}
+	if strings.Join(top, ",") != "span-1,span-2,orphan" {
+		t.Errorf("top-level observations = %v, want [span-1 span-2 orphan]", top)
+	}
+	span1 := root.Children[0]
+	if len(span1.Children) != 2 || span1.Children[0].Observation.ID != "event-1" || span1.Children[1].Observation.ID != "gen-1" {
+		t.Errorf("span-1 children not ordered by start time")
+	}
+
+	full, err = client.Traces().GetWithObservations(context.Background(), "trace-123", langfuse.WithFetchScores(true))
+	if err != nil {
+		t.Fatalf("GetWithObservations with scores failed: %v", err)
+	}
+	if len(full.Scores) != 1 || full.Scores[0].Name != "quality" {
+		t.Errorf("unexpected scores: %+v", full.Scores)
+	}
+}
+
+func TestTracesClientGetWithObservationsNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/public/observations" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(langfuse.ObservationsListResponse{})
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"message": "Trace not found"})
+	}))
+	defer server.Close()
+
+	client, _ := langfuse.New("pk-lf-test-key", "sk-lf-test-key", langfuse.WithBaseURL(server.URL))
+	defer client.Shutdown(context.Background())
+
+	if _, err := client.Traces().GetWithObservations(context.Background(), "missing"); err == nil {
+		t.Error("Expected error for missing trace")
+	}
+	if _, err := client.Traces().GetWithObservations(context.Background(), ""); err == nil {
+		t.Error("Expected validation error for empty trace ID")
+	}
+}
+
+func TestTracesClientArchiveAndRestore(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+body["status"].(string))
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, _ := langfuse.New("pk-lf-test-key", "sk-lf-test-key", langfuse.WithBaseURL(server.URL))
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	if err := client.Traces().Archive(ctx, "trace-1"); err != nil {
+		t.Fatalf("Archive failed: %v", err)
+	}
+	if err := client.Traces().Restore(ctx, "trace-1"); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{
+		"PATCH /api/public/traces/trace-1 archived",
+		"PATCH /api/public/traces/trace-1 active",
+	}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests = %q, want %q", requests, want)
+	}
+}
+
+func TestTracesClientBulkArchive(t *testing.T) {
+	var mu sync.Mutex
+	var archived []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			query := r.URL.Query()
+			if query.Get("tags") != "resolved" || query.Get("limit") != "100" {
+				t.Errorf("unexpected list query: %s", r.URL.RawQuery)
+			}
+			page := query.Get("page")
+			resp := langfuse.TracesListResponse{Meta: langfuse.MetaResponse{Limit: 100, TotalPages: 2}}
+			if page == "1" {
+				resp.Meta.Page = 1
+				resp.Data = []langfuse.Trace{{ID: "trace-1"}, {ID: "trace-2"}}
+			} else {
+				resp.Meta.Page = 2
+				resp.Data = []langfuse.Trace{{ID: "trace-3"}}
+			}
+			json.NewEncoder(w).Encode(resp)
+		case http.MethodPatch:
+			id := strings.TrimPrefix(r.URL.Path, "/api/public/traces/")
+			if id == "trace-2" {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"message":"not found"}`))
+				return
+			}
+			mu.Lock()
+			archived = append(archived, id)
+			mu.Unlock()
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	client, _ := langfuse.New("pk-lf-test-key", "sk-lf-test-key", langfuse.WithBaseURL(server.URL))
+	defer client.Shutdown(context.Background())
+
+	result, err := client.Traces().BulkArchive(context.Background(), langfuse.TracesListParams{
+		PaginationParams: langfuse.PaginationParams{Page: 7, Limit: 5},
+		FilterParams:     langfuse.FilterParams{Tags: []string{"resolved"}},
+	})
+	if err != nil {
+		t.Fatalf("BulkArchive failed: %v", err)
+	}
+	if result.ArchivedCount != 2 || result.FailedCount != 1 {
+		t.Errorf("ArchivedCount = %d, FailedCount = %d, want 2 and 1", result.ArchivedCount, result.FailedCount)
+	}
+	if len(result.FailedIDs) != 1 || result.FailedIDs[0] != "trace-2" {
+		t.Errorf("FailedIDs = %v, want [trace-2]", result.FailedIDs)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if strings.Join(archived, ",") != "trace-1,trace-3" {
+		t.Errorf("archived = %v, want [trace-1 trace-3]", archived)
+	}
+}
+
+func TestTracesClientFilter(t *testing.T) {
+	var mu sync.Mutex
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		query := r.URL.Query()
+		if query.Get("tags") != "support" || query.Get("limit") != "2" {
+			t.Errorf("unexpected list query: %s", r.URL.RawQuery)
+		}
+		mu.Lock()
+		pages = append(pages, query.Get("page"))
+		mu.Unlock()
+
+		resp := langfuse.TracesListResponse{Meta: langfuse.MetaResponse{Limit: 2, TotalPages: 3}}
+		switch query.Get("page") {
+		case "1":
+			resp.Meta.Page = 1
+			resp.Data = []langfuse.Trace{{ID: "trace-1", Metadata: map[string]any{"escalated": true}}, {ID: "trace-2"}}
+		case "2":
+			resp.Meta.Page = 2
+			resp.Data = []langfuse.Trace{{ID: "trace-3"}, {ID: "trace-4", Metadata: map[string]any{"escalated": true}}}
+		default:
+			resp.Meta.Page = 3
+			resp.Data = []langfuse.Trace{{ID: "trace-5", Metadata: map[string]any{"escalated": true}}}
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client, _ := langfuse.New("pk-lf-test-key", "sk-lf-test-key", langfuse.WithBaseURL(server.URL))
+	defer client.Shutdown(context.Background())
+
+	params := &langfuse.TracesListParams{
+		PaginationParams: langfuse.PaginationParams{Limit: 2},
+		FilterParams:     langfuse.FilterParams{Tags: []string{"support"}},
+	}
+	escalated := func(trace *langfuse.Trace) bool {
+		return trace.Metadata["escalated"] == true
+	}
+	ids := func(traces []*langfuse.Trace) string {
+		var out []string
+		for _, trace := range traces {
+			out = append(out, trace.ID)
+		}
+		return strings.Join(out, ",")
+	}
+
+	all, err := client.Traces().Filter(context.Background(), params, escalated)
+	if err != nil {
+		t.Fatalf("Filter failed: %v", err)
+	}
+	if got := ids(all); got != "trace-1,trace-4,trace-5" {
+		t.Errorf("Filter() = %s, want trace-1,trace-4,trace-5", got)
+	}
+
+	mu.Lock()
+	pages = nil
+	mu.Unlock()
+
+	first, err := client.Traces().FilterFirst(context.Background(), params, escalated, 2)
+	if err != nil {
+		t.Fatalf("FilterFirst failed: %v", err)
+	}
+	if got := ids(first); got != "trace-1,trace-4" {
+		t.Errorf("FilterFirst() = %s, want trace-1,trace-4", got)
+	}
+	mu.Lock()
+	if strings.Join(pages, ",") != "1,2" {
+		t.Errorf("FilterFirst fetched pages %v, want [1 2]", pages)
+	}
+	mu.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.Traces().Filter(ctx, params, escalated); !errors.Is(err, context.Canceled) {
+		t.Errorf("Filter() with cancelled context error = %v, want context.Canceled", err)
+	}
+}
+
+func TestTracesClientListOrderBy(t *testing.T) {
+	tests := []struct {
+		field string
+		desc  bool
+		want  string
+	}{
+		{langfuse.TraceOrderByTimestamp, true, "timestamp.desc"},
+		{langfuse.TraceOrderByName, false, "name.asc"},
+		{langfuse.TraceOrderByLatency, true, "latency.desc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				query := r.URL.Query()
+				if query.Get("orderBy") != tt.want {
+					t.Errorf("Expected orderBy=%s, got %s", tt.want, query.Get("orderBy"))
+				}
+				if query.Get("userId") != "user-123" {
+					t.Errorf("Expected userId=user-123, got %s", query.Get("userId"))
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(langfuse.TracesListResponse{})
+			}))
+			defer server.Close()
+
+			client, _ := langfuse.New("pk-lf-test-key", "sk-lf-test-key", langfuse.WithBaseURL(server.URL))
+			defer client.Shutdown(context.Background())
+
+			params := &langfuse.TracesListParams{
+				FilterParams: langfuse.FilterParams{UserID: "user-123"},
+			}
+			if params.OrderBy(tt.field, tt.desc) != params {
+				t.Error("OrderBy should return the same params")
+			}
+			if _, err := client.Traces().List(context.Background(), params); err != nil {
+				t.Fatalf("List failed: %v", err)
+			}
+		})
+	}
+}
+
+func TestTracesClientListWithoutOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("orderBy") {
+			t.Errorf("orderBy should not be sent, got %s", r.URL.Query().Get("orderBy"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(langfuse.TracesListResponse{})
+	}))
+	defer server.Close()
+
+	client, _ := langfuse.New("pk-lf-test-key", "sk-lf-test-key", langfuse.WithBaseURL(server.URL))
+	defer client.Shutdown(context.Background())
+
+	if _, err := client.Traces().List(context.Background(), &langfuse.TracesListParams{}); err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+}
+
+func TestTraceBuilderVersionedInput(t *testing.T) {
+	server := langfusetest.NewMockLangfuseServer()
+	defer server.Close()
+
+	client, err := langfuse.New("pk-lf-test-key", "sk-lf-test-key",
+		langfuse.WithBaseURL(server.URL),
+		langfuse.WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	v1, err := client.NewTrace().Name("v1").VersionedInput(1, map[string]any{"query": "q"}).Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	// Metadata set after VersionedInput must not drop the schema version.
+	v2, err := client.NewTrace().Name("v2").
+		VersionedInput(2, map[string]any{"query": "q", "context": []string{"doc"}}).
+		Metadata(langfuse.Metadata{"team": "search"}).
+		Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	plain, err := client.NewTrace().Name("plain").Input("q").Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if v1.InputSchemaVersion() != 1 || v2.InputSchemaVersion() != 2 || plain.InputSchemaVersion() != 0 {
+		t.Errorf("InputSchemaVersion() = %d, %d, %d, want 1, 2, 0",
+			v1.InputSchemaVersion(), v2.InputSchemaVersion(), plain.InputSchemaVersion())
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	body := server.TracesCreated()[1]
+	input, _ := body["input"].(map[string]any)
+	if input["schema_version"] != 2.0 || input["data"] == nil {
+		t.Errorf("input = %v, want the versioned wrapper", body["input"])
+	}
+	if metadata, _ := body["metadata"].(map[string]any); metadata["team"] != "search" {
+		t.Errorf("metadata = %v, want team kept", metadata)
+	}
+
+	result, err := client.Traces().List(ctx, (&langfuse.TracesListParams{}).InputSchemaVersion(2))
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(result.Data) != 1 || result.Data[0].ID != v2.ID() {
+		t.Errorf("Data = %+v, want only the v2 trace", result.Data)
+	}
+}
+
+func TestTraceBuilderAutoEnd(t *testing.T) {
+	server := langfusetest.NewMockLangfuseServer()
+	defer server.Close()
+
+	client, err := langfuse.New("pk-lf-test-key", "sk-lf-test-key",
+		langfuse.WithBaseURL(server.URL),
+		langfuse.WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	ctx := context.Background()
+	timeout := 20 * time.Millisecond
+	forgotten, err := client.NewTrace().Name("forgotten").AutoEnd(timeout).Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	ended, err := client.NewTrace().Name("ended").AutoEnd(timeout).Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := ended.Update().Output("done").Apply(ctx); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	cancelled, err := client.NewTrace().Name("cancelled").AutoEnd(timeout).Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	cancelled.CancelAutoEnd()
+	if _, err := client.NewTrace().Name("pending").AutoEnd(time.Hour).Create(ctx); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	time.Sleep(10 * timeout)
+
+	// Shutdown must not wait for the pending hour-long timeout.
+	done := make(chan error, 1)
+	go func() { done <- client.Shutdown(ctx) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Shutdown failed: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Shutdown blocked on a pending auto-end timeout")
+	}
+
+	autoEnded := make(map[string]bool)
+	for _, body := range server.TracesCreated() {
+		if metadata, _ := body["metadata"].(map[string]any); metadata[langfuse.StatusMessageMetadataKey] == langfuse.AutoEndStatusMessage {
+			autoEnded[body["id"].(string)] = true
+		}
+	}
+	if len(autoEnded) != 1 || !autoEnded[forgotten.ID()] {
+		t.Errorf("auto-ended traces = %v, want only %s", autoEnded, forgotten.ID())
+	}
+}
+
+func TestClientReplay(t *testing.T) {
+	server := langfusetest.NewMockLangfuseServer()
+	defer server.Close()
+
+	client, err := langfuse.New("pk-lf-test-key", "sk-lf-test-key",
+		langfuse.WithBaseURL(server.URL),
+		langfuse.WithFlushInterval(1*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	original, err := client.NewTrace().
+		Name("offline-eval").
+		Input(map[string]any{"query": "q"}).
+		Output(map[string]any{"output": "a"}).
+		UserID("user-1").
+		SessionID("session-1").
+		Tags([]string{"prod"}).
+		Metadata(langfuse.Metadata{"dataset": "v1"}).
+		Create(ctx)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := original.NewSpan().Name("step").Create(ctx); err != nil {
+		t.Fatalf("NewSpan failed: %v", err)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	replay, err := client.Replay(ctx, original.ID(),
+		langfuse.WithReplayTags("eval-v2"),
+		langfuse.WithReplayUserID("evaluator"),
+	)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if replay.ID() == original.ID() {
+		t.Fatal("replayed trace should have a new ID")
+	}
+	if err := replay.ScoreNumeric(ctx, "faithfulness", 0.8); err != nil {
+		t.Fatalf("ScoreNumeric failed: %v", err)
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	full, err := client.Traces().GetWithObservations(ctx, replay.ID(), langfuse.WithFetchScores(true))
+	if err != nil {
+		t.Fatalf("GetWithObservations failed: %v", err)
+	}
+	got := full.Trace
+	if got.Name != "offline-eval" || got.SessionID != "session-1" || got.UserID != "evaluator" {
+		t.Errorf("replayed trace = %+v", got)
+	}
+	if len(got.Tags) != 1 || got.Tags[0] != "eval-v2" {
+		t.Errorf("tags = %v, want [eval-v2]", got.Tags)
+	}
+	if input, _ := got.Input.(map[string]any); input["query"] != "q" {
+		t.Errorf("input = %v", got.Input)
+	}
+	if output, _ := got.Output.(map[string]any); output["output"] != "a" {
+		t.Errorf("output = %v", got.Output)
+	}
+	if got.Metadata[langfuse.ReplayMetadataKey] != original.ID() || got.Metadata["dataset"] != "v1" {
+		t.Errorf("metadata = %v", got.Metadata)
+	}
+	if len(full.Observations) != 0 {
+		t.Errorf("got %d observations, want none replayed", len(full.Observations))
+	}
+	if len(full.Scores) != 1 || full.Scores[0].Name != "faithfulness" {
+		t.Errorf("scores = %+v, want the faithfulness score", full.Scores)
+	}
+
+	var apiErr *langfuse.APIError
+	if _, err := client.Replay(ctx, "missing"); !errors.As(err, &apiErr) {
+		t.Errorf("Replay of an unknown trace error = %v, want an APIError", err)
+	}
+}
@@ -3,12 +3,14 @@ package langfuse_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	langfuse "github.com/jdziat/langfuse-go"
+	"github.com/jdziat/langfuse-go/langfusetest"
 )
 
 func TestNewClientV1(t *testing.T) {
@@ -406,6 +408,94 @@ func TestClientStats(t *testing.T) {
 	})
 }
 
+func TestClientStatsHistory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(langfuse.IngestionResult{})
+	}))
+	defer server.Close()
+
+	t.Run("disabled by default", func(t *testing.T) {
+		client, err := langfuse.New("pk-lf-test-key", "sk-lf-test-key", langfuse.WithBaseURL(server.URL))
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		defer client.Shutdown(context.Background())
+
+		if history := client.StatsHistory(0); history != nil {
+			t.Errorf("StatsHistory() = %v, want nil", history)
+		}
+		if delta := client.StatsDelta(time.Time{}, time.Now()); delta != (langfuse.ClientStatsDelta{}) {
+			t.Errorf("StatsDelta() = %+v, want zero", delta)
+		}
+	})
+
+	t.Run("delta over sent events", func(t *testing.T) {
+		client, err := langfuse.New("pk-lf-test-key", "sk-lf-test-key",
+			langfuse.WithBaseURL(server.URL),
+			langfuse.WithFlushInterval(time.Hour),
+			langfuse.WithStatsHistoryConfig(langfuse.StatsHistoryConfig{Interval: 10 * time.Millisecond}),
+		)
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		defer client.Shutdown(context.Background())
+
+		ctx := context.Background()
+		time.Sleep(30 * time.Millisecond)
+		for i := 0; i < 5; i++ {
+			if _, err := client.NewTrace().Name("trace").Create(ctx); err != nil {
+				t.Fatalf("Create failed: %v", err)
+			}
+		}
+		if err := client.Flush(ctx); err != nil {
+			t.Fatalf("Flush failed: %v", err)
+		}
+		time.Sleep(50 * time.Millisecond)
+
+		history := client.StatsHistory(0)
+		if len(history) < 2 {
+			t.Fatalf("got %d snapshots, want at least 2", len(history))
+		}
+		for i := 1; i < len(history); i++ {
+			if history[i].Timestamp.Before(history[i-1].Timestamp) {
+				t.Fatal("snapshots should be ordered oldest first")
+			}
+		}
+		if recent := client.StatsHistory(25 * time.Millisecond); len(recent) == 0 || len(recent) >= len(history) {
+			t.Errorf("StatsHistory(25ms) returned %d of %d snapshots", len(recent), len(history))
+		}
+
+		delta := client.StatsDelta(time.Time{}, time.Now())
+		if delta.EventsSent != 5 {
+			t.Errorf("EventsSent = %d, want 5", delta.EventsSent)
+		}
+		if delta.Duration <= 0 || delta.EventsPerSecond <= 0 {
+			t.Errorf("Duration = %v, EventsPerSecond = %v, want positive", delta.Duration, delta.EventsPerSecond)
+		}
+	})
+
+	t.Run("ring buffer keeps newest entries", func(t *testing.T) {
+		client, err := langfuse.New("pk-lf-test-key", "sk-lf-test-key",
+			langfuse.WithBaseURL(server.URL),
+			langfuse.WithStatsHistoryConfig(langfuse.StatsHistoryConfig{Interval: 5 * time.Millisecond, MaxEntries: 3}),
+		)
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		defer client.Shutdown(context.Background())
+
+		time.Sleep(60 * time.Millisecond)
+		history := client.StatsHistory(0)
+		if len(history) != 3 {
+			t.Fatalf("got %d snapshots, want 3", len(history))
+		}
+		if time.Since(history[0].Timestamp) > 50*time.Millisecond {
+			t.Error("oldest snapshots should have been overwritten")
+		}
+	})
+}
+
 // NOTE: TestV1EndOptions and TestV1UpdateOptions tests were removed because they test
 // unexported types (endConfig, spanConfig, updateConfig) that are not accessible
 // from external test packages.
@@ -472,6 +562,115 @@ func TestWithObservation(t *testing.T) {
 	})
 }
 
+func TestChildContext(t *testing.T) {
+	client, _ := langfusetest.NewTestClient(t)
+	ctx := context.Background()
+
+	trace, _ := client.NewTrace().Name("request").Create(ctx)
+	span, _ := trace.NewSpan().Name("retrieve").Create(ctx)
+
+	spanCtx := span.ChildContext(ctx)
+	if got, ok := langfuse.CurrentSpan(spanCtx); !ok || got != span {
+		t.Errorf("CurrentSpan() = %v, %v, want the span", got, ok)
+	}
+	if got, ok := langfuse.CurrentTrace(spanCtx); !ok || got.ID() != trace.ID() {
+		t.Errorf("CurrentTrace() = %v, %v, want the span's trace", got, ok)
+	}
+
+	traceCtx := trace.ChildContext(spanCtx)
+	if _, ok := langfuse.CurrentSpan(traceCtx); ok {
+		t.Error("TraceContext.ChildContext should clear the current span")
+	}
+	if got, ok := langfuse.TraceFromContext(traceCtx); !ok || got != trace {
+		t.Errorf("TraceFromContext() = %v, %v, want the trace", got, ok)
+	}
+
+	// ContextWithTrace and ContextWithSpan keep the other value.
+	mixed := langfuse.ContextWithTrace(langfuse.ContextWithSpan(ctx, span), trace)
+	if _, ok := langfuse.SpanFromContext(mixed); !ok {
+		t.Error("ContextWithTrace should keep the current span")
+	}
+	if _, ok := langfuse.CurrentTrace(langfuse.ContextWithSpan(ctx, span)); ok {
+		t.Error("ContextWithSpan should not set the current trace")
+	}
+
+	if _, ok := langfuse.CurrentTrace(ctx); ok {
+		t.Error("CurrentTrace should report false for an empty context")
+	}
+}
+
+func TestMeasure(t *testing.T) {
+	client, server := langfusetest.NewTestClient(t)
+	ctx := context.Background()
+	trace, _ := client.NewTrace().Name("request").Create(ctx)
+
+	result, err := trace.Measure(ctx, "retrieve", func() (any, error) {
+		return []string{"doc-1"}, nil
+	})
+	if err != nil || len(result.([]string)) != 1 {
+		t.Fatalf("Measure() = %v, %v", result, err)
+	}
+
+	parent, _ := trace.Span(ctx, "pipeline")
+	failure := errors.New("rerank failed")
+	if _, err := parent.Measure(ctx, "rerank", func() (any, error) { return nil, failure }); err != failure {
+		t.Errorf("Measure() error = %v, want %v", err, failure)
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("recovered %v, want the panic to be re-raised", r)
+			}
+		}()
+		trace.Measure(ctx, "explode", func() (any, error) { panic("boom") })
+	}()
+
+	answer, err := trace.MeasureGeneration(ctx, "answer", func() (string, int, int, error) {
+		return "42", 10, 2, nil
+	}, langfuse.WithModel("gpt-4o"))
+	if err != nil || answer != "42" {
+		t.Fatalf("MeasureGeneration() = %q, %v", answer, err)
+	}
+
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	updates := make(map[string]map[string]any)
+	names := make(map[string]string)
+	for _, e := range server.RequestsByType("span-create") {
+		names[e.Body["id"].(string)] = e.Body["name"].(string)
+	}
+	for _, e := range server.RequestsByType("span-update") {
+		name := names[e.Body["id"].(string)]
+		if updates[name] == nil {
+			updates[name] = make(map[string]any)
+		}
+		for k, v := range e.Body {
+			updates[name][k] = v
+		}
+	}
+	if out, _ := updates["retrieve"]["output"].([]any); len(out) != 1 || updates["retrieve"]["endTime"] == nil {
+		t.Errorf("retrieve span update = %v, want output and end time", updates["retrieve"])
+	}
+	if updates["rerank"]["statusMessage"] != "rerank failed" || updates["rerank"]["level"] != "ERROR" || updates["rerank"]["endTime"] == nil {
+		t.Errorf("rerank span update = %v, want the error and end time", updates["rerank"])
+	}
+	if updates["explode"]["statusMessage"] != "panic: boom" {
+		t.Errorf("explode span update = %v, want the panic recorded", updates["explode"])
+	}
+
+	gens := server.RequestsByType("generation-update")
+	if len(gens) != 1 {
+		t.Fatalf("got %d generation updates, want 1", len(gens))
+	}
+	usage, _ := gens[0].Body["usage"].(map[string]any)
+	if gens[0].Body["output"] != "42" || usage["input"] != float64(10) || usage["output"] != float64(2) {
+		t.Errorf("generation update = %v, want output and usage", gens[0].Body)
+	}
+}
+
 func TestV1FullWorkflow(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == "/api/public/ingestion" {
@@ -114,6 +114,50 @@ func TestBuildResultOk(t *testing.T) {
 	}
 }
 
+func TestBuildResult_OKAndError(t *testing.T) {
+	testErr := errors.New("test error")
+
+	ok := langfuse.NewBuildResult("value", nil)
+	if !ok.OK() || ok.Error() != nil {
+		t.Errorf("OK() = %v, Error() = %v, want true, nil", ok.OK(), ok.Error())
+	}
+
+	failed := langfuse.NewBuildResult("", testErr)
+	if failed.OK() || failed.Error() != testErr {
+		t.Errorf("OK() = %v, Error() = %v, want false, testErr", failed.OK(), failed.Error())
+	}
+}
+
+func TestBuilders_TryCreate(t *testing.T) {
+	client := createValidatedTestClient(t)
+	defer client.Shutdown(context.Background())
+	ctx := context.Background()
+
+	traceResult := client.NewTrace().Name("try-trace").TryCreate(ctx)
+	if !traceResult.OK() {
+		t.Fatalf("TraceBuilder.TryCreate() error = %v", traceResult.Error())
+	}
+	trace := traceResult.Value()
+
+	spanResult := trace.NewSpan().Name("try-span").TryCreate(ctx)
+	if !spanResult.OK() || spanResult.Value() == nil {
+		t.Errorf("SpanBuilder.TryCreate() error = %v", spanResult.Error())
+	}
+
+	generation, err := trace.NewGeneration().Name("try-generation").TryCreate(ctx).Unwrap()
+	if err != nil || generation == nil {
+		t.Errorf("GenerationBuilder.TryCreate() error = %v", err)
+	}
+
+	invalid := trace.NewSpan().ID("").TryCreate(ctx)
+	if invalid.OK() {
+		t.Error("TryCreate() without an ID should fail")
+	}
+	if invalid.Value() != nil {
+		t.Error("Value() should be nil on failure")
+	}
+}
+
 func TestValidatedTraceBuilder_ErrorAccumulation(t *testing.T) {
 	client := createValidatedTestClient(t)
 	defer client.Shutdown(context.Background())
@@ -86,8 +86,12 @@ func TestValidator(t *testing.T) {
 		combined := v.CombinedError()
 		msg := combined.Error()
 
-		if !strings.Contains(msg, "multiple validation errors") {
-			t.Error("combined error should mention multiple errors")
+		compErr, ok := langfuse.AsCompilationError(combined)
+		if !ok {
+			t.Fatalf("CombinedError should return CompilationError for multiple errors, got %T", combined)
+		}
+		if len(compErr.Errors) != 2 {
+			t.Errorf("CompilationError has %d errors, want 2", len(compErr.Errors))
 		}
 		if !strings.Contains(msg, "name") {
 			t.Error("combined error should contain first error")
@@ -96,6 +100,40 @@ func TestValidator(t *testing.T) {
 			t.Error("combined error should contain second error")
 		}
 	})
+
+	t.Run("methods chain", func(t *testing.T) {
+		v := &langfuse.Validator{}
+		n := 0
+		v.AddError(langfuse.ErrNilRequest).
+			AddFieldError("name", "too long").
+			AddFieldErrorf("count", "must be >= %d, got %d", 1, n)
+
+		if len(v.Errors()) != 3 {
+			t.Fatalf("should have 3 errors, got %d", len(v.Errors()))
+		}
+		if v.ClearErrors().HasErrors() {
+			t.Error("ClearErrors should return the cleared validator")
+		}
+	})
+
+	t.Run("FieldErrors groups by field", func(t *testing.T) {
+		v := &langfuse.Validator{}
+		v.AddFieldError("name", "is required").
+			AddFieldErrorf("name", "exceeds %d characters", 10).
+			AddFieldError("value", "must be positive").
+			AddError(langfuse.ErrNilRequest)
+
+		fields := v.FieldErrors()
+		if got := fields["name"]; len(got) != 2 || got[1] != "exceeds 10 characters" {
+			t.Errorf("FieldErrors()[name] = %v", got)
+		}
+		if got := fields["value"]; len(got) != 1 || got[0] != "must be positive" {
+			t.Errorf("FieldErrors()[value] = %v", got)
+		}
+		if got := fields[""]; len(got) != 1 {
+			t.Errorf("FieldErrors()[\"\"] = %v, want the non-field error", got)
+		}
+	})
 }
 
 func TestValidateID(t *testing.T) {
@@ -62,6 +62,12 @@ type (
 	// Score represents a score attached to a trace or observation.
 	Score = types.Score
 
+	// ScoreConfig defines a named score type and the values it accepts.
+	ScoreConfig = types.ScoreConfig
+
+	// ScoreCategory is a category of a categorical score config.
+	ScoreCategory = types.ScoreCategory
+
 	// Prompt represents a prompt in Langfuse.
 	Prompt = types.Prompt
 
@@ -130,6 +136,20 @@ const (
 	ScoreDataTypeBoolean     = types.ScoreDataTypeBoolean
 )
 
+// ============================================================================
+// Usage Details Constants
+// ============================================================================
+
+// Keys of the usageDetails recorded for Usage with reasoning or cached input
+// tokens.
+const (
+	UsageDetailsInput           = types.UsageDetailsInput
+	UsageDetailsOutput          = types.UsageDetailsOutput
+	UsageDetailsTotal           = types.UsageDetailsTotal
+	UsageDetailsReasoningTokens = types.UsageDetailsReasoningTokens
+	UsageDetailsCachedTokens    = types.UsageDetailsCachedTokens
+)
+
 // ============================================================================
 // Score Source Constants
 // ============================================================================